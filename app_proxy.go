@@ -0,0 +1,95 @@
+package shopline
+
+import (
+	"crypto/hmac"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// appProxyMaxAge rejects proxy requests whose timestamp is older than this,
+// limiting replay of captured signed URLs.
+const appProxyMaxAge = 5 * time.Minute
+
+// AppProxyRequest holds the identity parameters Shopline adds to app proxy
+// requests.
+type AppProxyRequest struct {
+	// Handle is the store handle (e.g. "open001").
+	Handle string
+	// CustomerID is the logged-in storefront customer, or 0 for guests.
+	CustomerID int64
+	// PathPrefix is the proxy path configured for the app (e.g. "/apps/myapp").
+	PathPrefix string
+	// Timestamp is when Shopline signed the request.
+	Timestamp time.Time
+}
+
+// VerifyAppProxyRequest verifies the signature Shopline adds to storefront
+// app proxy requests.
+//
+// The signature is an HMAC-SHA256 over the sorted query parameters (excluding
+// "sign"), computed the same way as GenerateSignature; repeated parameters are
+// joined with ",". Requests without a valid timestamp, or with one older
+// than 5 minutes, are rejected.
+func (app App) VerifyAppProxyRequest(r *http.Request) bool {
+	query := r.URL.Query()
+	sign := query.Get("sign")
+	if sign == "" {
+		return false
+	}
+
+	params := make(map[string]string, len(query))
+	for k, v := range query {
+		if k == "sign" {
+			continue
+		}
+		vals := append([]string(nil), v...)
+		sort.Strings(vals)
+		params[k] = strings.Join(vals, ",")
+	}
+
+	expected := app.GenerateSignature(params)
+	if !hmac.Equal([]byte(sign), []byte(expected)) {
+		return false
+	}
+
+	// Without a timestamp a captured URL would verify forever.
+	ts := parseProxyTimestamp(query.Get("timestamp"))
+	return !ts.IsZero() && timeNow().Sub(ts) <= appProxyMaxAge
+}
+
+// ParseAppProxyRequest extracts the store handle and logged-in customer from
+// an app proxy request. Call VerifyAppProxyRequest first; the values are only
+// trustworthy on a verified request.
+func ParseAppProxyRequest(r *http.Request) AppProxyRequest {
+	query := r.URL.Query()
+
+	handle := query.Get("handle")
+	if handle == "" {
+		// "shop" carries the full domain, e.g. open001.myshopline.com;
+		// the handle is its first label whatever the domain suffix.
+		handle, _, _ = strings.Cut(query.Get("shop"), ".")
+	}
+	customerID, _ := strconv.ParseInt(query.Get("logged_in_customer_id"), 10, 64)
+
+	return AppProxyRequest{
+		Handle:     handle,
+		CustomerID: customerID,
+		PathPrefix: query.Get("path_prefix"),
+		Timestamp:  parseProxyTimestamp(query.Get("timestamp")),
+	}
+}
+
+// parseProxyTimestamp parses a Unix timestamp in seconds or milliseconds.
+func parseProxyTimestamp(s string) time.Time {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	if n > 1e12 {
+		return time.UnixMilli(n)
+	}
+	return time.Unix(n, 0)
+}
@@ -14,6 +14,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/imokyou/slshop/scopes"
 )
 
 // authHTTPClient is a dedicated HTTP client for auth endpoints with
@@ -49,23 +51,49 @@ type TokenResponse struct {
 //   - state: A random nonce for CSRF protection (passed as customField)
 //
 // The merchant should be redirected to this URL to authorize the app.
+// The scope is normalized with scopes.Normalize (lowercase, no blanks or
+// duplicates) but not validated; use AuthorizeURLChecked, or call
+// ValidateScope at startup, so a typo fails here rather than on the
+// merchant's consent page.
 func (app App) AuthorizeURL(handle, state string) string {
 	params := url.Values{
 		"appKey":       {app.AppKey},
 		"responseType": {"code"},
-		"scope":        {app.Scope},
+		"scope":        {scopes.Normalize(app.Scope)},
 		"redirectUri":  {app.RedirectURL},
 	}
 	if state != "" {
 		params.Set("customField", state)
 	}
 	return fmt.Sprintf(
-		"https://%s.myshopline.com/admin/oauth-web/#/oauth/authorize?%s",
-		handle,
+		"https://%s/admin/oauth-web/#/oauth/authorize?%s",
+		app.storeHost(handle),
 		params.Encode(),
 	)
 }
 
+// AuthorizeURLChecked is like AuthorizeURL but first validates app.Scope
+// with ValidateScope, returning its error instead of a URL the merchant
+// could not approve.
+func (app App) AuthorizeURLChecked(handle, state string) (string, error) {
+	if err := app.ValidateScope(); err != nil {
+		return "", err
+	}
+	return app.AuthorizeURL(handle, state), nil
+}
+
+// ValidateScope reports unknown entries in app.Scope, such as typos that
+// would otherwise only surface as permission errors after install.
+func (app App) ValidateScope() error {
+	if strings.TrimSpace(app.Scope) == "" {
+		return fmt.Errorf("shopline: app scope is empty")
+	}
+	if _, err := scopes.Parse(app.Scope); err != nil {
+		return fmt.Errorf("shopline: invalid app scope: %w", err)
+	}
+	return nil
+}
+
 // GenerateSignature generates an HMAC-SHA256 signature for API requests.
 //
 // The signature is computed by:
@@ -117,7 +145,7 @@ func (app App) VerifySignature(query url.Values) bool {
 // GetAccessToken exchanges an authorization code for an access token.
 //
 // This corresponds to Step 4 of the Shopline OAuth flow.
-// POST https://{handle}.myshopline.com/admin/oauth/token/create
+// POST https://{handle}.{DomainSuffix}/admin/oauth/token/create
 func (app App) GetAccessToken(ctx context.Context, handle, code string) (*TokenResponse, error) {
 	bodyJSON, err := json.Marshal(map[string]string{"code": code})
 	if err != nil {
@@ -129,18 +157,56 @@ func (app App) GetAccessToken(ctx context.Context, handle, code string) (*TokenR
 // RefreshAccessToken refreshes the access token before it expires (10-hour validity).
 //
 // This corresponds to Step 6 of the Shopline OAuth flow.
-// POST https://{handle}.myshopline.com/admin/oauth/token/refresh
+// POST https://{handle}.{DomainSuffix}/admin/oauth/token/refresh
 func (app App) RefreshAccessToken(ctx context.Context, handle string) (*TokenResponse, error) {
 	return app.doAuthRequest(ctx, handle, "refresh", nil)
 }
 
-// doAuthRequest is the shared implementation for token create/refresh requests.
+// RevokeAccessToken revokes an access token, e.g. when a merchant offboards.
+// Requests made with the token fail afterwards.
+//
+// POST https://{handle}.{DomainSuffix}/admin/oauth/token/revoke
+func (app App) RevokeAccessToken(ctx context.Context, handle, token string) error {
+	if token == "" {
+		return fmt.Errorf("shopline: token must not be empty")
+	}
+	bodyJSON, err := json.Marshal(map[string]string{"accessToken": token})
+	if err != nil {
+		return fmt.Errorf("shopline: failed to marshal body: %w", err)
+	}
+	_, err = app.doAuthRequest(ctx, handle, "revoke", bytes.NewReader(bodyJSON))
+	return err
+}
+
+// doAuthRequest is the shared implementation for token create, refresh and
+// revoke requests.
 // It handles signature generation, header setting, request execution, and
 // response parsing in a single place to eliminate code duplication.
 func (app App) doAuthRequest(ctx context.Context, handle, endpoint string, body io.Reader) (*TokenResponse, error) {
+	var tokenResp TokenResponse
+	if decoded, err := app.doAuthRequestInto(ctx, handle, endpoint, body, &tokenResp); err != nil {
+		if decoded {
+			return &tokenResp, err
+		}
+		return nil, err
+	}
+	return &tokenResp, nil
+}
+
+// authResult is implemented by the token response types.
+type authResult interface {
+	status() (code int, message, traceID string)
+}
+
+func (r *TokenResponse) status() (int, string, string) { return r.Code, r.Message, r.TraceID }
+
+// doAuthRequestInto sends a token request and decodes the response into
+// result, failing if the response code is not 200. decoded reports whether
+// result holds the response, which callers return along with such a failure.
+func (app App) doAuthRequestInto(ctx context.Context, handle, endpoint string, body io.Reader, result authResult) (decoded bool, err error) {
 	// P1-5: Validate handle to prevent empty or malicious URL construction
 	if handle == "" {
-		return nil, fmt.Errorf("shopline: handle must not be empty")
+		return false, fmt.Errorf("shopline: handle must not be empty")
 	}
 
 	timestamp := fmt.Sprintf("%d", currentTimeMillis())
@@ -149,11 +215,11 @@ func (app App) doAuthRequest(ctx context.Context, handle, endpoint string, body
 		"timestamp": timestamp,
 	})
 
-	apiURL := fmt.Sprintf("https://%s.myshopline.com/admin/oauth/token/%s", handle, endpoint)
+	apiURL := fmt.Sprintf("https://%s/admin/oauth/token/%s", app.storeHost(handle), endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, body)
 	if err != nil {
-		return nil, fmt.Errorf("shopline: failed to create request: %w", err)
+		return false, fmt.Errorf("shopline: failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -164,27 +230,26 @@ func (app App) doAuthRequest(ctx context.Context, handle, endpoint string, body
 	// P0-1: Use dedicated client with timeout instead of http.DefaultClient
 	resp, err := authHTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("shopline: %s token request failed: %w", endpoint, err)
+		return false, fmt.Errorf("shopline: %s token request failed: %w", endpoint, err)
 	}
 	defer resp.Body.Close()
 
 	// P1-3: Limit response body size to prevent OOM
 	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
 	if err != nil {
-		return nil, fmt.Errorf("shopline: failed to read %s response: %w", endpoint, err)
+		return false, fmt.Errorf("shopline: failed to read %s response: %w", endpoint, err)
 	}
 
-	var tokenResp TokenResponse
-	if err := json.Unmarshal(respBody, &tokenResp); err != nil {
-		return nil, fmt.Errorf("shopline: failed to parse %s response: %w (body: %s)", endpoint, err, string(respBody))
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return false, fmt.Errorf("shopline: failed to parse %s response: %w (body: %s)", endpoint, err, string(respBody))
 	}
 
-	if tokenResp.Code != 200 {
-		return &tokenResp, fmt.Errorf("shopline: %s token request failed: %s (code: %d, traceId: %s)",
-			endpoint, tokenResp.Message, tokenResp.Code, tokenResp.TraceID)
+	if code, message, traceID := result.status(); code != 200 {
+		return true, fmt.Errorf("shopline: %s token request failed: %s (code: %d, traceId: %s)",
+			endpoint, message, code, traceID)
 	}
 
-	return &tokenResp, nil
+	return true, nil
 }
 
 // VerifyWebhookRequest verifies the HMAC signature of a Shopline webhook request.
@@ -193,16 +258,74 @@ func (app App) doAuthRequest(ctx context.Context, handle, endpoint string, body
 // The signature is computed over the raw request body using AppSecret.
 //
 // After verification, the request body is restored so downstream handlers
-// can still read it.
+// can still read it. Use VerifyWebhookRequestDetailed to learn why a
+// request was rejected.
 func (app App) VerifyWebhookRequest(r *http.Request) bool {
+	return app.VerifyWebhookRequestDetailed(r).Valid
+}
+
+// WebhookFailure identifies why a webhook signature check failed.
+type WebhookFailure string
+
+const (
+	WebhookMissingSignature WebhookFailure = "missing_signature"
+	WebhookBodyUnreadable   WebhookFailure = "body_unreadable"
+	WebhookDigestMismatch   WebhookFailure = "digest_mismatch"
+)
+
+// webhookDigestPrefixLen is how much of each digest a WebhookVerification
+// reveals: enough to tell secrets apart, too little to forge a signature.
+const webhookDigestPrefixLen = 8
+
+// WebhookVerification is the outcome of VerifyWebhookRequestDetailed.
+// It is meant for server-side logs; do not echo it back to the caller.
+type WebhookVerification struct {
+	Valid   bool
+	Failure WebhookFailure // empty when Valid
+	Err     error          // body read error for WebhookBodyUnreadable
+
+	// For WebhookDigestMismatch: leading hex characters of the signature
+	// header and of the digest computed with AppSecret, and the body size
+	// it was computed over. A mismatch on every request usually means the
+	// wrong AppSecret; a mismatch on some means the body was altered
+	// (e.g. re-encoded by a proxy) before verification.
+	ProvidedPrefix string
+	ComputedPrefix string
+	BodySize       int
+}
+
+// String describes the result in one line, e.g. for logging.
+func (v WebhookVerification) String() string {
+	switch v.Failure {
+	case "":
+		return "webhook signature valid"
+	case WebhookBodyUnreadable:
+		return fmt.Sprintf("webhook signature invalid: %s: %v", v.Failure, v.Err)
+	case WebhookDigestMismatch:
+		return fmt.Sprintf("webhook signature invalid: %s (provided %s..., computed %s... over %d bytes)",
+			v.Failure, v.ProvidedPrefix, v.ComputedPrefix, v.BodySize)
+	default:
+		return fmt.Sprintf("webhook signature invalid: %s", v.Failure)
+	}
+}
+
+// VerifyWebhookRequestDetailed is VerifyWebhookRequest returning the reason
+// for a failure instead of a bool:
+//
+//	if v := app.VerifyWebhookRequestDetailed(r); !v.Valid {
+//	    log.Println(v)
+//	    http.Error(w, "invalid signature", http.StatusUnauthorized)
+//	    return
+//	}
+func (app App) VerifyWebhookRequestDetailed(r *http.Request) WebhookVerification {
 	signature := r.Header.Get("X-Shopline-Hmac-SHA256")
 	if signature == "" {
-		return false
+		return WebhookVerification{Failure: WebhookMissingSignature}
 	}
 
 	body, err := io.ReadAll(io.LimitReader(r.Body, maxResponseBodySize))
 	if err != nil {
-		return false
+		return WebhookVerification{Failure: WebhookBodyUnreadable, Err: err}
 	}
 	// P0-2: Restore the body so downstream handlers can read it.
 	// Without this, any handler after verification gets an empty body.
@@ -212,7 +335,22 @@ func (app App) VerifyWebhookRequest(r *http.Request) bool {
 	mac.Write(body)
 	expected := hex.EncodeToString(mac.Sum(nil))
 
-	return hmac.Equal([]byte(signature), []byte(expected))
+	if hmac.Equal([]byte(signature), []byte(expected)) {
+		return WebhookVerification{Valid: true, BodySize: len(body)}
+	}
+	return WebhookVerification{
+		Failure:        WebhookDigestMismatch,
+		ProvidedPrefix: digestPrefix(signature),
+		ComputedPrefix: digestPrefix(expected),
+		BodySize:       len(body),
+	}
+}
+
+func digestPrefix(s string) string {
+	if len(s) > webhookDigestPrefixLen {
+		return s[:webhookDigestPrefixLen]
+	}
+	return s
 }
 
 // currentTimeMillis returns the current time in milliseconds.
@@ -0,0 +1,222 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+// Webhook topics for shop plan and app subscription changes.
+const (
+	TopicShopUpdate             = "shop/update"
+	TopicAppSubscriptionsUpdate = "app_subscriptions/update"
+)
+
+// Status is the state of a shop's subscription to the app.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusActive    Status = "active"
+	StatusFrozen    Status = "frozen" // shop stopped paying its own plan
+	StatusCancelled Status = "cancelled"
+	StatusDeclined  Status = "declined"
+	StatusExpired   Status = "expired"
+)
+
+// Active reports whether the shop may use paid features.
+func (s Status) Active() bool {
+	return s == StatusActive
+}
+
+// =====================================================================
+// Handler
+// =====================================================================
+
+// Handler reacts to billing-related webhooks after the StatusStore has been
+// updated. Returning an error responds with HTTP 500 so Shopline redelivers
+// the webhook.
+type Handler interface {
+	// AppSubscriptionUpdated is called when the app subscription is
+	// activated, frozen, cancelled, etc.
+	AppSubscriptionUpdated(ctx context.Context, shopDomain string, p AppSubscriptionPayload) error
+
+	// ShopPlanChanged is called when the shop's own Shopline plan changes.
+	ShopPlanChanged(ctx context.Context, shopDomain string, p ShopPlanPayload) error
+}
+
+// Register wires the billing topics into d. Subscription status changes are
+// recorded in store before h is called; h may be nil. A delivery older than
+// the recorded status, e.g. a redelivery arriving after a later change, is
+// acknowledged without recording it or calling h, so it cannot reactivate a
+// cancelled shop. Deliveries without a shop domain or status are rejected.
+//
+//	d := webhook.NewDispatcher(webhook.WithVerifier(app.VerifyWebhookRequest))
+//	billing.Register(d, statusStore, myBillingHandler)
+//	http.Handle("/webhooks", d)
+func Register(d *webhook.Dispatcher, store StatusStore, h Handler) {
+	d.Handle(TopicAppSubscriptionsUpdate, func(ctx context.Context, del webhook.Delivery) error {
+		var p AppSubscriptionPayload
+		if err := decode(del, &p); err != nil {
+			return err
+		}
+		sub := p.AppSubscription
+		shop := shopDomain(del, sub.ShopDomain)
+		if shop == "" || sub.Status == "" {
+			return fmt.Errorf("billing: %s delivery %s has no shop domain or status", del.Topic, del.WebhookID)
+		}
+		var updatedAt time.Time
+		if sub.UpdatedAt != nil {
+			updatedAt = *sub.UpdatedAt
+		}
+		recorded, err := store.SetStatus(ctx, shop, sub.Status, updatedAt)
+		if err != nil {
+			return fmt.Errorf("billing: failed to record status for %s: %w", shop, err)
+		}
+		if !recorded || h == nil {
+			return nil
+		}
+		return h.AppSubscriptionUpdated(ctx, shop, p)
+	})
+	d.Handle(TopicShopUpdate, func(ctx context.Context, del webhook.Delivery) error {
+		if h == nil {
+			return nil
+		}
+		var p ShopPlanPayload
+		if err := decode(del, &p); err != nil {
+			return err
+		}
+		return h.ShopPlanChanged(ctx, shopDomain(del, p.MyshoplineDomain), p)
+	})
+}
+
+func decode(del webhook.Delivery, v interface{}) error {
+	if err := json.Unmarshal(del.Body, v); err != nil {
+		return fmt.Errorf("billing: failed to decode %s payload: %w", del.Topic, err)
+	}
+	return nil
+}
+
+// shopDomain prefers the delivery header and falls back to the payload.
+func shopDomain(del webhook.Delivery, fromPayload string) string {
+	if del.ShopDomain != "" {
+		return del.ShopDomain
+	}
+	return fromPayload
+}
+
+// =====================================================================
+// Status Store
+// =====================================================================
+
+// StatusStore persists the latest app subscription status per shop domain.
+type StatusStore interface {
+	// Status returns the recorded status, or "" if none is known.
+	Status(ctx context.Context, shopDomain string) (Status, error)
+
+	// SetStatus records s as the status at updatedAt, unless the recorded
+	// status is from a later time, and reports whether it recorded s. The
+	// comparison and write must be atomic. A zero updatedAt always records.
+	SetStatus(ctx context.Context, shopDomain string, s Status, updatedAt time.Time) (bool, error)
+}
+
+// MemoryStatusStore is an in-process StatusStore for tests and
+// single-instance apps. It is safe for concurrent use.
+type MemoryStatusStore struct {
+	mu       sync.RWMutex
+	statuses map[string]statusRecord
+}
+
+type statusRecord struct {
+	status    Status
+	updatedAt time.Time
+}
+
+// NewMemoryStatusStore creates an empty MemoryStatusStore.
+func NewMemoryStatusStore() *MemoryStatusStore {
+	return &MemoryStatusStore{statuses: make(map[string]statusRecord)}
+}
+
+func (m *MemoryStatusStore) Status(_ context.Context, shopDomain string) (Status, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.statuses[shopDomain].status, nil
+}
+
+func (m *MemoryStatusStore) SetStatus(_ context.Context, shopDomain string, s Status, updatedAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cur, ok := m.statuses[shopDomain]; ok && !updatedAt.IsZero() && cur.updatedAt.After(updatedAt) {
+		return false, nil
+	}
+	m.statuses[shopDomain] = statusRecord{status: s, updatedAt: updatedAt}
+	return true, nil
+}
+
+// =====================================================================
+// Guard Middleware
+// =====================================================================
+
+// Guard blocks requests from shops whose app subscription is not active,
+// responding with HTTP 402 Payment Required. shopDomain extracts the shop
+// from the request, e.g. from the session or an app proxy query parameter;
+// requests with no shop are rejected too.
+//
+//	paid := billing.Guard(statusStore, sessionShop, reportsHandler)
+//	http.Handle("/reports", paid)
+func Guard(store StatusStore, shopDomain func(r *http.Request) string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		shop := shopDomain(r)
+		if shop == "" {
+			http.Error(w, "unknown shop", http.StatusPaymentRequired)
+			return
+		}
+		status, err := store.Status(r.Context(), shop)
+		if err != nil {
+			http.Error(w, "billing status unavailable", http.StatusInternalServerError)
+			return
+		}
+		if !status.Active() {
+			http.Error(w, "app subscription inactive", http.StatusPaymentRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// AppSubscription is the app's recurring charge on a shop.
+type AppSubscription struct {
+	ID               int64      `json:"id,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	Status           Status     `json:"status,omitempty"`
+	ShopDomain       string     `json:"shop_domain,omitempty"`
+	Price            string     `json:"price,omitempty"`
+	Currency         string     `json:"currency,omitempty"`
+	Test             bool       `json:"test,omitempty"`
+	CurrentPeriodEnd *time.Time `json:"current_period_end,omitempty"`
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// AppSubscriptionPayload is the body of an app_subscriptions/update webhook.
+type AppSubscriptionPayload struct {
+	AppSubscription AppSubscription `json:"app_subscription"`
+}
+
+// ShopPlanPayload is the plan-related part of a shop/update webhook body.
+type ShopPlanPayload struct {
+	ID               int64      `json:"id,omitempty"`
+	MyshoplineDomain string     `json:"myshopline_domain,omitempty"`
+	PlanName         string     `json:"plan_name,omitempty"`
+	PlanDisplayName  string     `json:"plan_display_name,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,108 @@
+package billing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+type recordingHandler struct {
+	subscription *AppSubscriptionPayload
+	plan         *ShopPlanPayload
+}
+
+func (h *recordingHandler) AppSubscriptionUpdated(_ context.Context, _ string, p AppSubscriptionPayload) error {
+	h.subscription = &p
+	return nil
+}
+func (h *recordingHandler) ShopPlanChanged(_ context.Context, _ string, p ShopPlanPayload) error {
+	h.plan = &p
+	return nil
+}
+
+func deliver(t *testing.T, d *webhook.Dispatcher, topic, body string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(webhook.HeaderTopic, topic)
+	req.Header.Set(webhook.HeaderShopDomain, "a.myshopline.com")
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRegister(t *testing.T) {
+	h := &recordingHandler{}
+	store := NewMemoryStatusStore()
+	d := webhook.NewDispatcher()
+	Register(d, store, h)
+
+	if code := deliver(t, d, TopicAppSubscriptionsUpdate,
+		`{"app_subscription":{"id":5,"name":"Pro","status":"frozen"}}`); code != http.StatusOK {
+		t.Fatalf("app_subscriptions/update: expected 200, got %d", code)
+	}
+	if h.subscription == nil || h.subscription.AppSubscription.Status != StatusFrozen {
+		t.Errorf("unexpected subscription payload: %+v", h.subscription)
+	}
+	if s, _ := store.Status(context.Background(), "a.myshopline.com"); s != StatusFrozen {
+		t.Errorf("expected stored status frozen, got %q", s)
+	}
+
+	if code := deliver(t, d, TopicShopUpdate, `{"id":1,"plan_name":"advanced"}`); code != http.StatusOK {
+		t.Fatalf("shop/update: expected 200, got %d", code)
+	}
+	if h.plan == nil || h.plan.PlanName != "advanced" {
+		t.Errorf("unexpected plan payload: %+v", h.plan)
+	}
+}
+
+func TestRegister_StaleDelivery(t *testing.T) {
+	h := &recordingHandler{}
+	store := NewMemoryStatusStore()
+	d := webhook.NewDispatcher()
+	Register(d, store, h)
+
+	deliver(t, d, TopicAppSubscriptionsUpdate,
+		`{"app_subscription":{"id":5,"status":"cancelled","updated_at":"2026-03-02T00:00:00Z"}}`)
+	h.subscription = nil
+	if code := deliver(t, d, TopicAppSubscriptionsUpdate,
+		`{"app_subscription":{"id":5,"status":"active","updated_at":"2026-03-01T00:00:00Z"}}`); code != http.StatusOK {
+		t.Fatalf("stale delivery: expected 200, got %d", code)
+	}
+	if s, _ := store.Status(context.Background(), "a.myshopline.com"); s != StatusCancelled {
+		t.Errorf("stale delivery reactivated the shop: %q", s)
+	}
+	if h.subscription != nil {
+		t.Error("handler called for a stale delivery")
+	}
+
+	if code := deliver(t, d, TopicAppSubscriptionsUpdate, `{"app_subscription":{"id":5}}`); code == http.StatusOK {
+		t.Error("expected delivery without status to be rejected")
+	}
+}
+
+func TestGuard(t *testing.T) {
+	store := NewMemoryStatusStore()
+	store.SetStatus(context.Background(), "paid.myshopline.com", StatusActive, time.Time{})
+	store.SetStatus(context.Background(), "frozen.myshopline.com", StatusFrozen, time.Time{})
+	guarded := Guard(store, func(r *http.Request) string { return r.URL.Query().Get("shop") },
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }))
+
+	cases := map[string]int{
+		"paid.myshopline.com":    http.StatusOK,
+		"frozen.myshopline.com":  http.StatusPaymentRequired,
+		"unknown.myshopline.com": http.StatusPaymentRequired,
+		"":                       http.StatusPaymentRequired,
+	}
+	for shop, want := range cases {
+		rec := httptest.NewRecorder()
+		guarded.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/reports?shop="+shop, nil))
+		if rec.Code != want {
+			t.Errorf("%q: expected %d, got %d", shop, want, rec.Code)
+		}
+	}
+}
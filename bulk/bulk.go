@@ -67,7 +67,7 @@ func (s *serviceOp) GetCurrent(ctx context.Context, opType string) (*BulkOperati
 		Type string `url:"type,omitempty"`
 	}{Type: opType}
 	err := s.client.Get(ctx, s.client.CreatePath("current_bulk_operation.json"), r, &opts)
-	return r.Data, err
+	return core.Found(r.Data, err)
 }
 
 // POST bulk_operations.json (query)
@@ -0,0 +1,135 @@
+package bulk
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// =====================================================================
+// Query Builder
+// =====================================================================
+
+// QueryBuilder assembles the query string for CreateQuery and checks it
+// before submission, so a typo fails immediately instead of after a
+// long-running job:
+//
+//	req, err := bulk.NewQuery("products").
+//		Fields("id", "title", "updated_at").
+//		Connection("variants", "id", "sku", "price").
+//		Filter("status", "active").
+//		Build()
+//
+// Errors are collected while chaining and reported by Build.
+type QueryBuilder struct {
+	resource    string
+	fields      []string
+	connections []connection
+	filters     []string
+	errs        []string
+}
+
+type connection struct {
+	name   string
+	fields []string
+}
+
+var identifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewQuery starts a bulk query over the given root connection, e.g. "products".
+func NewQuery(resource string) *QueryBuilder {
+	b := &QueryBuilder{resource: resource}
+	b.checkName("resource", resource)
+	return b
+}
+
+// Fields adds scalar fields selected on each root node.
+func (b *QueryBuilder) Fields(fields ...string) *QueryBuilder {
+	b.fields = b.addFields(b.resource, b.fields, fields)
+	return b
+}
+
+// Connection adds a nested connection (e.g. a product's "variants") with the
+// fields selected on each of its nodes.
+func (b *QueryBuilder) Connection(name string, fields ...string) *QueryBuilder {
+	b.checkName("connection", name)
+	for _, c := range b.connections {
+		if c.name == name {
+			b.errs = append(b.errs, fmt.Sprintf("duplicate connection %q", name))
+			return b
+		}
+	}
+	if len(fields) == 0 {
+		b.errs = append(b.errs, fmt.Sprintf("connection %q selects no fields", name))
+	}
+	b.connections = append(b.connections, connection{name: name, fields: b.addFields(name, nil, fields)})
+	return b
+}
+
+// Filter restricts the root nodes to those whose key matches value. Multiple
+// filters are combined with AND.
+func (b *QueryBuilder) Filter(key, value string) *QueryBuilder {
+	b.checkName("filter key", key)
+	if value == "" {
+		b.errs = append(b.errs, fmt.Sprintf("filter %q has an empty value", key))
+	}
+	b.filters = append(b.filters, key+":"+filterValue(value))
+	return b
+}
+
+// Build validates the query and returns the request accepted by
+// Service.CreateQuery.
+func (b *QueryBuilder) Build() (BulkQueryRequest, error) {
+	errs := b.errs
+	if len(b.fields) == 0 && len(b.connections) == 0 {
+		errs = append(errs, "no fields selected")
+	}
+	if len(errs) > 0 {
+		return BulkQueryRequest{}, fmt.Errorf("bulk: invalid query: %s", strings.Join(errs, "; "))
+	}
+	return BulkQueryRequest{Query: b.String()}, nil
+}
+
+// String renders the query without validating it.
+func (b *QueryBuilder) String() string {
+	var sb strings.Builder
+	sb.WriteString("{ ")
+	sb.WriteString(b.resource)
+	if len(b.filters) > 0 {
+		fmt.Fprintf(&sb, "(query: %q)", strings.Join(b.filters, " AND "))
+	}
+	selection := append([]string(nil), b.fields...)
+	for _, c := range b.connections {
+		selection = append(selection, fmt.Sprintf("%s { edges { node { %s } } }", c.name, strings.Join(c.fields, " ")))
+	}
+	fmt.Fprintf(&sb, " { edges { node { %s } } } }", strings.Join(selection, " "))
+	return sb.String()
+}
+
+func (b *QueryBuilder) checkName(kind, name string) {
+	if !identifierRe.MatchString(name) {
+		b.errs = append(b.errs, fmt.Sprintf("invalid %s name %q", kind, name))
+	}
+}
+
+func (b *QueryBuilder) addFields(owner string, dst, fields []string) []string {
+	for _, f := range fields {
+		b.checkName("field", f)
+		for _, existing := range dst {
+			if existing == f {
+				b.errs = append(b.errs, fmt.Sprintf("duplicate field %q on %s", f, owner))
+			}
+		}
+		dst = append(dst, f)
+	}
+	return dst
+}
+
+// filterValue single-quotes values containing spaces or quotes so they are
+// matched as one term.
+func filterValue(v string) string {
+	if !strings.ContainsAny(v, " \t'\":") {
+		return v
+	}
+	return "'" + strings.ReplaceAll(v, "'", `\'`) + "'"
+}
@@ -0,0 +1,38 @@
+package bulk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	req, err := NewQuery("products").
+		Fields("id", "title").
+		Connection("variants", "id", "sku").
+		Filter("status", "active").
+		Filter("vendor", "Acme Co").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{ products(query: "status:active AND vendor:'Acme Co'") { edges { node { id title variants { edges { node { id sku } } } } } } }`
+	if req.Query != want {
+		t.Errorf("unexpected query:\n got %s\nwant %s", req.Query, want)
+	}
+}
+
+func TestQueryBuilder_Invalid(t *testing.T) {
+	cases := map[string]*QueryBuilder{
+		"bad resource":    NewQuery("prod ucts").Fields("id"),
+		"no fields":       NewQuery("products"),
+		"bad field":       NewQuery("products").Fields("id", "ti-tle"),
+		"duplicate field": NewQuery("products").Fields("id", "id"),
+		"empty conn":      NewQuery("products").Fields("id").Connection("variants"),
+		"empty filter":    NewQuery("products").Fields("id").Filter("status", ""),
+	}
+	for name, b := range cases {
+		if _, err := b.Build(); err == nil || !strings.HasPrefix(err.Error(), "bulk: invalid query") {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/scopes"
+)
+
+// capabilitiesTTL is how long Capabilities reuses a detection result.
+const capabilitiesTTL = time.Hour
+
+// Feature is an optional store feature an app may depend on.
+type Feature string
+
+const (
+	FeatureShoplinePayments Feature = "shopline_payments"
+	FeatureSubscriptions    Feature = "subscriptions"
+	FeatureMarkets          Feature = "markets"
+	FeatureGiftCards        Feature = "gift_cards"
+	FeaturePublications     Feature = "publications"
+)
+
+// featureProbe is the cheapest read that only succeeds when a feature is
+// available, and the scope that read needs.
+type featureProbe struct {
+	feature Feature
+	path    string
+	scope   scopes.Scope
+}
+
+var featureProbes = []featureProbe{
+	{FeatureShoplinePayments, "payments/store/balance.json", scopes.Read(scopes.Payments)},
+	{FeatureSubscriptions, "subscription_contracts.json", scopes.Read(scopes.Orders)},
+	{FeatureMarkets, "markets.json", scopes.Read(scopes.Markets)},
+	{FeatureGiftCards, "gift_cards.json", scopes.Read(scopes.GiftCards)},
+	{FeaturePublications, "publications.json", scopes.Read(scopes.Publications)},
+}
+
+// =====================================================================
+// Capabilities
+// =====================================================================
+
+// Capabilities describes what a store and the app's token can do.
+type Capabilities struct {
+	PlanName        string
+	PlanDisplayName string
+	// Scopes granted to the token, as recorded by the TokenManager. Empty
+	// when the client uses a static token or the scope is unknown.
+	Scopes    []scopes.Scope
+	Features  map[Feature]bool
+	CheckedAt time.Time
+}
+
+// Has reports whether feature f is available.
+func (c *Capabilities) Has(f Feature) bool {
+	return c != nil && c.Features[f]
+}
+
+// HasScope reports whether the token was granted s. A write scope grants the
+// matching read scope. Without known scopes it reports true, leaving the
+// answer to the API.
+func (c *Capabilities) HasScope(s scopes.Scope) bool {
+	if c == nil || len(c.Scopes) == 0 {
+		return true
+	}
+	for _, granted := range c.Scopes {
+		if granted == s || (strings.HasPrefix(string(s), "read_") && granted == "write_"+s[len("read_"):]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities detects which optional features the store offers, so an app
+// can hide what is unavailable instead of showing merchants a 403:
+//
+//	caps, err := client.Capabilities(ctx)
+//	if err == nil && !caps.Has(shopline.FeatureSubscriptions) {
+//	    // hide the subscriptions tab
+//	}
+//
+// It reads the shop's plan and then probes each feature with a one-item read
+// without retries; a feature whose scope the token lacks is not probed. 401,
+// 403 and 404 answers mark a feature unavailable, any other failure is
+// returned as an error. Results are cached per API version for an hour, and
+// concurrent calls share one detection; call InvalidateCapabilities after
+// the merchant changes plan or re-authorizes.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	cache, version := c.caps, c.apiVersion
+	cache.mu.Lock()
+	if caps := cache.results[version]; caps != nil && time.Since(caps.CheckedAt) < capabilitiesTTL {
+		cache.mu.Unlock()
+		return caps, nil
+	}
+	call, running := cache.calls[version]
+	if !running {
+		call = &capabilitiesCall{done: make(chan struct{})}
+		cache.calls[version] = call
+	}
+	cache.mu.Unlock()
+
+	if running {
+		select {
+		case <-call.done:
+			return call.caps, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	call.caps, call.err = c.detectCapabilities(ctx)
+	cache.mu.Lock()
+	delete(cache.calls, version)
+	if call.err == nil {
+		cache.results[version] = call.caps
+	}
+	cache.mu.Unlock()
+	close(call.done)
+	return call.caps, call.err
+}
+
+func (c *Client) detectCapabilities(ctx context.Context) (*Capabilities, error) {
+	ctx = core.WithNoRetry(ctx)
+	var r struct {
+		Shop *struct {
+			PlanName        string `json:"plan_name"`
+			PlanDisplayName string `json:"plan_display_name"`
+		} `json:"shop"`
+	}
+	if err := c.Get(core.WithFields(ctx, "plan_name", "plan_display_name"), c.CreatePath("shop.json"), &r, nil); err != nil {
+		return nil, fmt.Errorf("shopline: failed to read shop plan: %w", err)
+	}
+	caps := &Capabilities{Features: make(map[Feature]bool, len(featureProbes))}
+	if r.Shop != nil {
+		caps.PlanName = r.Shop.PlanName
+		caps.PlanDisplayName = r.Shop.PlanDisplayName
+	}
+	if c.tokenManager != nil {
+		// Keep scopes the catalog does not know yet: they were granted.
+		for _, f := range strings.Split(scopes.Normalize(c.tokenManager.grantedScope()), ",") {
+			if f != "" {
+				caps.Scopes = append(caps.Scopes, scopes.Scope(f))
+			}
+		}
+	}
+
+	for _, p := range featureProbes {
+		if !caps.HasScope(p.scope) {
+			caps.Features[p.feature] = false
+			continue
+		}
+		ok, err := c.probe(ctx, p.path)
+		if err != nil {
+			return nil, fmt.Errorf("shopline: failed to detect %s: %w", p.feature, err)
+		}
+		caps.Features[p.feature] = ok
+	}
+	caps.CheckedAt = time.Now()
+	return caps, nil
+}
+
+// capabilitiesCache holds a client's last Capabilities result per API
+// version, and the detections in progress.
+type capabilitiesCache struct {
+	mu      sync.Mutex
+	results map[string]*Capabilities
+	calls   map[string]*capabilitiesCall
+}
+
+func newCapabilitiesCache() *capabilitiesCache {
+	return &capabilitiesCache{
+		results: make(map[string]*Capabilities),
+		calls:   make(map[string]*capabilitiesCall),
+	}
+}
+
+// capabilitiesCall is a detection other callers wait for.
+type capabilitiesCall struct {
+	done chan struct{}
+	caps *Capabilities
+	err  error
+}
+
+// InvalidateCapabilities drops the cached Capabilities results of every API
+// version.
+func (c *Client) InvalidateCapabilities() {
+	c.caps.mu.Lock()
+	clear(c.caps.results)
+	c.caps.mu.Unlock()
+}
+
+// probe reads a single item from path and reports whether the API allowed it.
+func (c *Client) probe(ctx context.Context, path string) (bool, error) {
+	var raw map[string]interface{}
+	err := c.Get(core.WithFields(ctx, "id"), c.CreatePath(path), &raw, &core.ListOptions{Limit: 1})
+	if err == nil {
+		return true, nil
+	}
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.Status {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			return false, nil
+		}
+	}
+	return false, err
+}
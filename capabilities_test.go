@@ -0,0 +1,180 @@
+package shopline
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/scopes"
+)
+
+// ============================================================
+// Capabilities Tests
+// ============================================================
+
+func TestCapabilities(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/shop.json"):
+			w.Write([]byte(`{"shop":{"plan_name":"advanced","plan_display_name":"Advanced"}}`))
+		case strings.HasSuffix(r.URL.Path, "/payments/store/balance.json"):
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":"forbidden"}`))
+		case strings.HasSuffix(r.URL.Path, "/subscription_contracts.json"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":"not found"}`))
+		default:
+			if r.URL.Query().Get("limit") != "1" {
+				t.Errorf("probe %s: limit = %q, want 1", r.URL.Path, r.URL.Query().Get("limit"))
+			}
+			w.Write([]byte(`{}`))
+		}
+	})
+	defer server.Close()
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if caps.PlanName != "advanced" || caps.PlanDisplayName != "Advanced" {
+		t.Errorf("plan = %q/%q", caps.PlanName, caps.PlanDisplayName)
+	}
+	want := map[Feature]bool{
+		FeatureShoplinePayments: false,
+		FeatureSubscriptions:    false,
+		FeatureMarkets:          true,
+		FeatureGiftCards:        true,
+		FeaturePublications:     true,
+	}
+	for f, ok := range want {
+		if caps.Has(f) != ok {
+			t.Errorf("Has(%s) = %v, want %v", f, caps.Has(f), ok)
+		}
+	}
+
+	n := atomic.LoadInt32(&calls)
+	if _, err := client.Capabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&calls); got != n {
+		t.Errorf("cached call made %d requests", got-n)
+	}
+	client.InvalidateCapabilities()
+	if _, err := client.Capabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2*n {
+		t.Errorf("after invalidate: %d requests, want %d", got-n, n)
+	}
+}
+
+func TestCapabilities_ServerErrorNotCached(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/markets.json") && fail.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errors":"boom"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+	WithRetry(3)(client)
+
+	if _, err := client.Capabilities(context.Background()); err == nil {
+		t.Fatal("expected error for a 500 probe")
+	}
+	fail.Store(false)
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if !caps.Has(FeatureMarkets) {
+		t.Error("markets not detected after recovery")
+	}
+}
+
+func TestCapabilities_SkipsProbesWithoutScope(t *testing.T) {
+	var probed []string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/shop.json") {
+			probed = append(probed, r.URL.Path)
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+	WithTokenManager(nil)(client)
+	if err := client.TokenManager().SetInitialToken(context.Background(), "tok", time.Now().Add(time.Hour), "read_products,write_markets"); err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if len(probed) != 1 || !strings.HasSuffix(probed[0], "/markets.json") {
+		t.Errorf("probed %v, want only markets.json", probed)
+	}
+	if !caps.Has(FeatureMarkets) || caps.Has(FeatureGiftCards) {
+		t.Errorf("features = %v", caps.Features)
+	}
+	if !caps.HasScope(scopes.Read(scopes.Markets)) || caps.HasScope(scopes.Write(scopes.Products)) {
+		t.Errorf("HasScope wrong for scopes %v", caps.Scopes)
+	}
+}
+
+func TestCapabilities_PerAPIVersion(t *testing.T) {
+	versions := map[string]int{}
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		version := strings.Split(r.URL.Path, "/")[3]
+		if strings.HasSuffix(r.URL.Path, "/shop.json") {
+			versions[version]++
+		}
+		if strings.HasSuffix(r.URL.Path, "/markets.json") && version == "v20260301" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil || !caps.Has(FeatureMarkets) {
+		t.Fatalf("default version: %v, %+v", err, caps)
+	}
+	v2 := client.WithAPIVersion("v20260301")
+	caps, err = v2.Capabilities(context.Background())
+	if err != nil || caps.Has(FeatureMarkets) {
+		t.Fatalf("v20260301 reused another version's result: %v, %+v", err, caps)
+	}
+	client.Capabilities(context.Background())
+	if len(versions) != 2 || versions["v20260301"] != 1 || versions[client.GetAPIVersion()] != 1 {
+		t.Errorf("detections per version = %v", versions)
+	}
+}
+
+func TestCapabilities_UnknownGrantedScope(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+	WithTokenManager(nil)(client)
+	if err := client.TokenManager().SetInitialToken(context.Background(), "tok", time.Now().Add(time.Hour), "read_products,read_loyalty_points"); err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := client.Capabilities(context.Background())
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if !caps.HasScope("read_loyalty_points") || !caps.HasScope(scopes.Read(scopes.Products)) {
+		t.Errorf("granted scopes dropped: %v", caps.Scopes)
+	}
+}
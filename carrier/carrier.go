@@ -0,0 +1,239 @@
+// Package carrier implements the callback side of carrier services: the
+// endpoint Shopline calls at checkout to fetch live shipping rates from a
+// CarrierService registered with order.CarrierServiceService.
+package carrier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultTimeout bounds how long the provider may take. Shopline stops
+// waiting after a few seconds and falls back to backup rates, so there is no
+// point in computing an answer beyond that.
+const defaultTimeout = 10 * time.Second
+
+// maxRequestBodySize limits rate request reads to 1MB.
+const maxRequestBodySize = 1 << 20
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// Address is the origin or destination of a shipment.
+type Address struct {
+	Country     string `json:"country,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
+	Province    string `json:"province,omitempty"`
+	City        string `json:"city,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Address1    string `json:"address1,omitempty"`
+	Address2    string `json:"address2,omitempty"`
+	Address3    string `json:"address3,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Fax         string `json:"fax,omitempty"`
+	Email       string `json:"email,omitempty"`
+	AddressType string `json:"address_type,omitempty"`
+	CompanyName string `json:"company_name,omitempty"`
+}
+
+// Item is a cart line to be shipped. Price is in the currency's minor unit
+// (e.g. cents).
+type Item struct {
+	Name               string            `json:"name,omitempty"`
+	SKU                string            `json:"sku,omitempty"`
+	Quantity           int               `json:"quantity,omitempty"`
+	Grams              int               `json:"grams,omitempty"`
+	Price              int64             `json:"price,omitempty"`
+	Vendor             string            `json:"vendor,omitempty"`
+	RequiresShipping   bool              `json:"requires_shipping,omitempty"`
+	Taxable            bool              `json:"taxable,omitempty"`
+	FulfillmentService string            `json:"fulfillment_service,omitempty"`
+	Properties         map[string]string `json:"properties,omitempty"`
+	ProductID          int64             `json:"product_id,omitempty"`
+	VariantID          int64             `json:"variant_id,omitempty"`
+}
+
+// RateRequest is a checkout's request for shipping rates.
+type RateRequest struct {
+	Origin      Address `json:"origin"`
+	Destination Address `json:"destination"`
+	Items       []Item  `json:"items"`
+	Currency    string  `json:"currency,omitempty"`
+	Locale      string  `json:"locale,omitempty"`
+}
+
+// TotalGrams returns the weight of all items that require shipping.
+func (r *RateRequest) TotalGrams() int {
+	total := 0
+	for _, it := range r.Items {
+		if it.RequiresShipping {
+			total += it.Grams * it.Quantity
+		}
+	}
+	return total
+}
+
+// Rate is a shipping option offered at checkout. TotalPrice is in the
+// currency's minor unit (e.g. 1295 for 12.95 USD).
+type Rate struct {
+	ServiceName     string
+	ServiceCode     string
+	Description     string
+	Currency        string
+	TotalPrice      int64
+	PhoneRequired   bool
+	MinDeliveryDate *time.Time
+	MaxDeliveryDate *time.Time
+}
+
+// MarshalJSON encodes the rate in the callback wire format, where
+// total_price is a string.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rateWire{
+		ServiceName:     r.ServiceName,
+		ServiceCode:     r.ServiceCode,
+		Description:     r.Description,
+		Currency:        r.Currency,
+		TotalPrice:      strconv.FormatInt(r.TotalPrice, 10),
+		PhoneRequired:   r.PhoneRequired,
+		MinDeliveryDate: r.MinDeliveryDate,
+		MaxDeliveryDate: r.MaxDeliveryDate,
+	})
+}
+
+type rateWire struct {
+	ServiceName     string     `json:"service_name"`
+	ServiceCode     string     `json:"service_code"`
+	Description     string     `json:"description,omitempty"`
+	Currency        string     `json:"currency"`
+	TotalPrice      string     `json:"total_price"`
+	PhoneRequired   bool       `json:"phone_required,omitempty"`
+	MinDeliveryDate *time.Time `json:"min_delivery_date,omitempty"`
+	MaxDeliveryDate *time.Time `json:"max_delivery_date,omitempty"`
+}
+
+type rateRequestResource struct {
+	Rate *RateRequest `json:"rate"`
+}
+type ratesResource struct {
+	Rates []Rate `json:"rates"`
+}
+
+// =====================================================================
+// Rate Server
+// =====================================================================
+
+// RateProvider computes shipping rates for a checkout. Returning no rates
+// hides the carrier at checkout; returning an error makes Shopline fall back
+// to the store's backup rates.
+type RateProvider interface {
+	Rates(ctx context.Context, req *RateRequest) ([]Rate, error)
+}
+
+// RateProviderFunc adapts a function to RateProvider.
+type RateProviderFunc func(ctx context.Context, req *RateRequest) ([]Rate, error)
+
+// Rates calls f.
+func (f RateProviderFunc) Rates(ctx context.Context, req *RateRequest) ([]Rate, error) {
+	return f(ctx, req)
+}
+
+// ServerOption configures a RateServer.
+type ServerOption func(*RateServer)
+
+// WithVerifier sets the signature check run before the provider. Pass
+// App.VerifyWebhookRequest from the root package, since rate callbacks are
+// signed like webhooks. Requests failing verification are rejected with
+// HTTP 401.
+func WithVerifier(verify func(r *http.Request) bool) ServerOption {
+	return func(s *RateServer) { s.verify = verify }
+}
+
+// WithTimeout bounds the provider's context (default 10s).
+func WithTimeout(d time.Duration) ServerOption {
+	return func(s *RateServer) { s.timeout = d }
+}
+
+// WithErrorHandler sets a callback for provider errors, which are otherwise
+// only reported to Shopline as HTTP 500.
+func WithErrorHandler(fn func(req *RateRequest, err error)) ServerOption {
+	return func(s *RateServer) { s.onError = fn }
+}
+
+// RateServer is an http.Handler serving a carrier service's callback URL.
+//
+//	srv := carrier.NewRateServer(myRates, carrier.WithVerifier(app.VerifyWebhookRequest))
+//	http.Handle("/carrier/rates", srv)
+//	client.CarrierService.Create(ctx, order.CarrierService{
+//	    Name: "Express", CallbackURL: "https://app.example.com/carrier/rates",
+//	    ServiceDiscovery: true,
+//	})
+type RateServer struct {
+	provider RateProvider
+	verify   func(r *http.Request) bool
+	timeout  time.Duration
+	onError  func(req *RateRequest, err error)
+}
+
+// NewRateServer creates a RateServer answering with provider.
+func NewRateServer(provider RateProvider, opts ...ServerOption) *RateServer {
+	s := &RateServer{provider: provider, timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *RateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.verify != nil && !s.verify(r) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	in := &rateRequestResource{}
+	if err := json.Unmarshal(body, in); err != nil || in.Rate == nil {
+		http.Error(w, "invalid rate request", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	rates, err := s.provider.Rates(ctx, in.Rate)
+	if err != nil {
+		if s.onError != nil {
+			s.onError(in.Rate, err)
+		}
+		http.Error(w, "rate calculation failed", http.StatusInternalServerError)
+		return
+	}
+	for i := range rates {
+		if rates[i].Currency == "" {
+			rates[i].Currency = in.Rate.Currency
+		}
+	}
+	if rates == nil {
+		rates = []Rate{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ratesResource{Rates: rates})
+}
@@ -0,0 +1,92 @@
+package carrier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const rateRequestBody = `{"rate":{
+	"origin":{"country":"US","postal_code":"10001"},
+	"destination":{"country":"CA","postal_code":"K2P1L4","province":"ON"},
+	"items":[
+		{"name":"Tee","sku":"TEE-S","quantity":2,"grams":200,"price":1999,"requires_shipping":true},
+		{"name":"Gift card","quantity":1,"price":5000,"requires_shipping":false}
+	],
+	"currency":"USD","locale":"en"}}`
+
+func TestRateServer(t *testing.T) {
+	var got *RateRequest
+	srv := NewRateServer(RateProviderFunc(func(ctx context.Context, req *RateRequest) ([]Rate, error) {
+		got = req
+		return []Rate{{ServiceName: "Express", ServiceCode: "EXP", TotalPrice: 1295}}, nil
+	}))
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rates", strings.NewReader(rateRequestBody)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if got.Destination.Province != "ON" || len(got.Items) != 2 || got.Items[0].Price != 1999 {
+		t.Errorf("unexpected decoded request: %+v", got)
+	}
+	if grams := got.TotalGrams(); grams != 400 {
+		t.Errorf("expected 400 grams, got %d", grams)
+	}
+	var resp map[string][]map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	rate := resp["rates"][0]
+	if rate["total_price"] != "1295" || rate["currency"] != "USD" || rate["service_code"] != "EXP" {
+		t.Errorf("unexpected rate: %v", rate)
+	}
+}
+
+func TestRateServer_Errors(t *testing.T) {
+	var reported error
+	failing := RateProviderFunc(func(ctx context.Context, req *RateRequest) ([]Rate, error) {
+		return nil, errors.New("carrier API down")
+	})
+	srv := NewRateServer(failing, WithErrorHandler(func(req *RateRequest, err error) { reported = err }))
+	rejecting := NewRateServer(failing, WithVerifier(func(r *http.Request) bool { return false }))
+
+	tests := []struct {
+		name   string
+		srv    *RateServer
+		method string
+		body   string
+		want   int
+	}{
+		{"method", srv, http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"signature", rejecting, http.MethodPost, rateRequestBody, http.StatusUnauthorized},
+		{"malformed", srv, http.MethodPost, `{"rates":[]}`, http.StatusBadRequest},
+		{"provider", srv, http.MethodPost, rateRequestBody, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		tt.srv.ServeHTTP(rec, httptest.NewRequest(tt.method, "/rates", strings.NewReader(tt.body)))
+		if rec.Code != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, rec.Code)
+		}
+	}
+	if reported == nil {
+		t.Error("expected provider error to be reported")
+	}
+}
+
+func TestRateServer_NoRates(t *testing.T) {
+	srv := NewRateServer(RateProviderFunc(func(ctx context.Context, req *RateRequest) ([]Rate, error) {
+		return nil, nil
+	}))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/rates", strings.NewReader(rateRequestBody)))
+	if body := strings.TrimSpace(rec.Body.String()); body != `{"rates":[]}` {
+		t.Errorf("expected empty rates array, got %s", body)
+	}
+}
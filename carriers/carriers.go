@@ -0,0 +1,161 @@
+// Package carriers maps shipping carriers to the URL of their tracking
+// page, so a tracking link can be shown for fulfillments the API returns
+// with only a tracking number and company name.
+//
+//	url, ok := carriers.TrackingURL("UPS", "1Z999AA10123456784")
+//	// https://www.ups.com/track?tracknum=1Z999AA10123456784
+//
+// Carriers are looked up by code or by any of their names, ignoring case,
+// spaces and punctuation, so "DHL Express", "dhl-express" and "dhl_express"
+// are the same carrier. Register adds or replaces carriers.
+package carriers
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Placeholder marks where the tracking number goes in a URL template.
+const Placeholder = "{number}"
+
+// Carrier is a shipping carrier and its tracking page.
+type Carrier struct {
+	// Code identifies the carrier, e.g. "ups".
+	Code string
+	// Name is the display name, e.g. "UPS".
+	Name string
+	// Aliases are other names the carrier goes by in tracking_company,
+	// e.g. "United Parcel Service".
+	Aliases []string
+	// URLTemplate is the tracking page URL with Placeholder for the number.
+	URLTemplate string
+}
+
+// TrackingURL returns the tracking page URL for number. Whitespace in the
+// number is dropped, as carriers print numbers in groups.
+func (c Carrier) TrackingURL(number string) string {
+	number = strings.Join(strings.Fields(number), "")
+	return strings.ReplaceAll(c.URLTemplate, Placeholder, url.QueryEscape(number))
+}
+
+var defaults = []Carrier{
+	{Code: "ups", Name: "UPS", Aliases: []string{"United Parcel Service"}, URLTemplate: "https://www.ups.com/track?tracknum={number}"},
+	{Code: "usps", Name: "USPS", Aliases: []string{"United States Postal Service"}, URLTemplate: "https://tools.usps.com/go/TrackConfirmAction?tLabels={number}"},
+	{Code: "fedex", Name: "FedEx", Aliases: []string{"Federal Express"}, URLTemplate: "https://www.fedex.com/fedextrack/?trknbr={number}"},
+	{Code: "dhl_express", Name: "DHL Express", Aliases: []string{"DHL"}, URLTemplate: "https://www.dhl.com/en/express/tracking.html?AWB={number}"},
+	{Code: "dhl_ecommerce", Name: "DHL eCommerce", URLTemplate: "https://webtrack.dhlglobalmail.com/?trackingnumber={number}"},
+	{Code: "tnt", Name: "TNT", URLTemplate: "https://www.tnt.com/express/en_us/site/shipping-tools/tracking.html?searchType=con&cons={number}"},
+	{Code: "dpd", Name: "DPD", URLTemplate: "https://tracking.dpd.de/status/en_US/parcel/{number}"},
+	{Code: "gls", Name: "GLS", URLTemplate: "https://gls-group.com/track/{number}"},
+	{Code: "postnl", Name: "PostNL", URLTemplate: "https://postnl.nl/tracktrace/?B={number}"},
+	{Code: "royal_mail", Name: "Royal Mail", URLTemplate: "https://www.royalmail.com/track-your-item#/tracking-results/{number}"},
+	{Code: "canada_post", Name: "Canada Post", Aliases: []string{"Postes Canada"}, URLTemplate: "https://www.canadapost-postescanada.ca/track-reperage/en#/search?searchFor={number}"},
+	{Code: "australia_post", Name: "Australia Post", Aliases: []string{"AusPost"}, URLTemplate: "https://auspost.com.au/mypost/track/#/details/{number}"},
+	{Code: "japan_post", Name: "Japan Post", URLTemplate: "https://trackings.post.japanpost.jp/services/srv/search/?requestNo1={number}&locale=en"},
+	{Code: "aramex", Name: "Aramex", URLTemplate: "https://www.aramex.com/track/results?ShipmentNumber={number}"},
+	{Code: "sf_express", Name: "SF Express", Aliases: []string{"顺丰", "顺丰速运", "SF"}, URLTemplate: "https://www.sf-express.com/chn/en/dynamic_function/waybill/#search/bill-number/{number}"},
+	{Code: "yunexpress", Name: "YunExpress", Aliases: []string{"云途", "云途物流"}, URLTemplate: "https://www.yuntrack.com/parcelTracking?id={number}"},
+	{Code: "4px", Name: "4PX", Aliases: []string{"递四方"}, URLTemplate: "https://track.4px.com/#/result/0/{number}"},
+	{Code: "yanwen", Name: "Yanwen", Aliases: []string{"燕文", "燕文物流"}, URLTemplate: "https://track.yw56.com.cn/en/querydel?nums={number}"},
+	{Code: "cainiao", Name: "Cainiao", Aliases: []string{"菜鸟", "AliExpress Standard Shipping"}, URLTemplate: "https://global.cainiao.com/detail.htm?mailNoList={number}"},
+}
+
+var (
+	mu    sync.RWMutex
+	table = map[string]Carrier{} // normalized code and names → carrier
+)
+
+func init() {
+	for _, c := range defaults {
+		add(c)
+	}
+}
+
+// Register adds c to the table, replacing the carrier with the same code,
+// so apps can cover their local carriers or point a carrier at another
+// tracking page. A name already used by another carrier then refers to c.
+// It is safe for concurrent use.
+func Register(c Carrier) error {
+	if normalize(c.Code) == "" {
+		return fmt.Errorf("carriers: carrier %q has no code", c.Name)
+	}
+	if !strings.Contains(c.URLTemplate, Placeholder) {
+		return fmt.Errorf("carriers: template of %q has no %s placeholder", c.Code, Placeholder)
+	}
+	if _, err := url.Parse(strings.ReplaceAll(c.URLTemplate, Placeholder, "0")); err != nil {
+		return fmt.Errorf("carriers: invalid template of %q: %w", c.Code, err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	add(c)
+	return nil
+}
+
+func add(c Carrier) {
+	code := normalize(c.Code)
+	for key, old := range table {
+		if normalize(old.Code) == code {
+			delete(table, key)
+		}
+	}
+	for _, name := range append([]string{c.Code, c.Name}, c.Aliases...) {
+		if key := normalize(name); key != "" {
+			table[key] = c
+		}
+	}
+}
+
+// Lookup returns the carrier with the code or name nameOrCode.
+func Lookup(nameOrCode string) (Carrier, bool) {
+	key := normalize(nameOrCode)
+	if key == "" {
+		return Carrier{}, false
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := table[key]
+	return c, ok
+}
+
+// TrackingURL returns the tracking page URL of number at the carrier named
+// company, and false if the carrier is unknown or number is empty.
+func TrackingURL(company, number string) (string, bool) {
+	if strings.TrimSpace(number) == "" {
+		return "", false
+	}
+	c, ok := Lookup(company)
+	if !ok {
+		return "", false
+	}
+	return c.TrackingURL(number), true
+}
+
+// All returns every carrier in the table, ordered by code.
+func All() []Carrier {
+	mu.RLock()
+	defer mu.RUnlock()
+	byCode := make(map[string]Carrier)
+	for _, c := range table {
+		byCode[c.Code] = c
+	}
+	all := make([]Carrier, 0, len(byCode))
+	for _, c := range byCode {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Code < all[j].Code })
+	return all
+}
+
+// normalize folds case and drops spaces and punctuation.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
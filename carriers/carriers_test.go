@@ -0,0 +1,70 @@
+package carriers
+
+import "testing"
+
+func TestTrackingURL(t *testing.T) {
+	tests := []struct {
+		company, number, want string
+	}{
+		{"UPS", "1Z999AA10123456784", "https://www.ups.com/track?tracknum=1Z999AA10123456784"},
+		{"united parcel service", " 1Z 999 AA1 ", "https://www.ups.com/track?tracknum=1Z999AA1"},
+		{"DHL-Express", "123", "https://www.dhl.com/en/express/tracking.html?AWB=123"},
+		{"顺丰速运", "SF123", "https://www.sf-express.com/chn/en/dynamic_function/waybill/#search/bill-number/SF123"},
+		{"Japan Post", "A&B", "https://trackings.post.japanpost.jp/services/srv/search/?requestNo1=A%26B&locale=en"},
+		{"Acme Couriers", "123", ""},
+		{"UPS", " ", ""},
+		{"", "123", ""},
+	}
+	for _, tt := range tests {
+		got, ok := TrackingURL(tt.company, tt.number)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("TrackingURL(%q, %q) = %q, %v, want %q", tt.company, tt.number, got, ok, tt.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register(Carrier{Code: "acme", URLTemplate: "https://acme.example.com/track"}); err == nil {
+		t.Error("expected error for a template without placeholder")
+	}
+	if err := Register(Carrier{Name: "Acme", URLTemplate: "https://acme.example.com/{number}"}); err == nil {
+		t.Error("expected error for a carrier without code")
+	}
+
+	if err := Register(Carrier{Code: "acme", Name: "Acme Couriers", URLTemplate: "https://acme.example.com/t/{number}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := TrackingURL("ACME couriers", "42"); got != "https://acme.example.com/t/42" {
+		t.Errorf("registered carrier: got %q", got)
+	}
+
+	ups, _ := Lookup("ups")
+	defer Register(ups)
+	if err := Register(Carrier{Code: "ups", Name: "UPS", URLTemplate: "https://track.example.com/ups/{number}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := Lookup("United Parcel Service"); ok {
+		t.Error("replaced carrier's aliases should be gone")
+	}
+	n := 0
+	for _, c := range All() {
+		if c.Code == "ups" {
+			n++
+			if c.URLTemplate != "https://track.example.com/ups/{number}" {
+				t.Errorf("All returned the replaced carrier: %+v", c)
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("All returned %d UPS carriers", n)
+	}
+
+	fedex, _ := Lookup("fedex")
+	defer Register(fedex)
+	if err := Register(Carrier{Code: "FedEx", Name: "FedEx", URLTemplate: "https://track.example.com/fedex/{number}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := Lookup("Federal Express"); ok {
+		t.Error("carrier replaced under a differently cased code should lose its aliases")
+	}
+}
@@ -0,0 +1,673 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// versionPrefix matches the versioned API root that core.Requester.CreatePath
+// adds back, e.g. "/admin/openapi/v20251201/" or "/admin/openapi/{version}/".
+var versionPrefix = regexp.MustCompile(`^/?admin/openapi/[^/]+/`)
+
+// listOptionParams are the query parameters covered by core.ListOptions.
+var listOptionParams = map[string]bool{
+	"page": true, "limit": true, "since_id": true, "fields": true,
+	"created_at_min": true, "created_at_max": true,
+	"updated_at_min": true, "updated_at_max": true,
+	"sort_by": true, "order": true,
+}
+
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "html": "HTML", "sku": "SKU",
+	"api": "API", "ip": "IP", "uid": "UID", "json": "JSON", "http": "HTTP",
+}
+
+// generator turns a Spec into Go source for one package in the repository
+// layout: per-tag Service interfaces with New*Service constructors and
+// unexported *Op implementations, option structs, models and JSON wrappers.
+type generator struct {
+	spec   *Spec
+	pkg    string
+	source string
+	tags   map[string]bool // nil = all tags
+
+	services map[string][]*method
+	options  []string
+	wrappers map[string]wrapper
+	models   map[string]bool
+	warnings []string
+	usesFmt  bool
+	usesTime bool
+}
+
+type method struct {
+	name    string
+	summary string
+	params  []string // declarations after ctx
+	results string
+	body    []string
+}
+
+type wrapper struct {
+	key, field, typ string
+}
+
+func newGenerator(spec *Spec, pkg, source string, tags []string) *generator {
+	g := &generator{
+		spec:     spec,
+		pkg:      pkg,
+		source:   source,
+		services: map[string][]*method{},
+		wrappers: map[string]wrapper{},
+		models:   map[string]bool{},
+	}
+	if len(tags) > 0 {
+		g.tags = map[string]bool{}
+		for _, t := range tags {
+			g.tags[t] = true
+		}
+	}
+	return g
+}
+
+// Generate returns the gofmt-ed source. Operations that cannot be expressed
+// through core.Requester are skipped and reported in g.warnings.
+func (g *generator) Generate() ([]byte, error) {
+	paths := make([]string, 0, len(g.spec.Paths))
+	for p := range g.spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		item := g.spec.Paths[p]
+		verbs := make([]string, 0, len(item))
+		for v := range item {
+			verbs = append(verbs, v)
+		}
+		sort.Strings(verbs)
+		for _, verb := range verbs {
+			g.addOperation(p, verb, item[verb])
+		}
+	}
+	if len(g.services) == 0 {
+		return nil, fmt.Errorf("slshop-gen: no operations selected")
+	}
+
+	src := g.render()
+	out, err := format.Source(src)
+	if err != nil {
+		return src, fmt.Errorf("slshop-gen: generated invalid Go: %w", err)
+	}
+	return out, nil
+}
+
+func (g *generator) warnf(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+// =====================================================================
+// Operations
+// =====================================================================
+
+func (g *generator) addOperation(path, verb string, op *Operation) {
+	service := g.pkg
+	if len(op.Tags) > 0 {
+		service = op.Tags[0]
+	}
+	if g.tags != nil && !g.tags[service] {
+		return
+	}
+	if verb == "patch" {
+		g.warnf("%s %s: PATCH is not supported by core.Requester, skipped", strings.ToUpper(verb), path)
+		return
+	}
+
+	m := &method{name: g.operationName(path, verb, op), summary: op.Summary}
+	pathExpr := g.pathExpr(path, op, m)
+
+	opts := "nil"
+	if q := queryParams(op); len(q) > 0 {
+		if verb == "get" {
+			opts = "opts"
+			m.params = append(m.params, "opts *"+m.name+"Options")
+			g.addOptions(m.name+"Options", q)
+		} else {
+			g.warnf("%s %s: query parameters on %s are not supported, ignored", strings.ToUpper(verb), path, verb)
+		}
+	}
+
+	body := "nil"
+	if verb == "post" || verb == "put" {
+		body = g.requestBody(op.RequestBody.schema(), m)
+	}
+
+	// core.Requester.Delete returns no body.
+	result := resultShape{results: "error"}
+	if verb != "delete" {
+		result = g.responseResult(op)
+	}
+	call := pascal(verb)
+	var args string
+	switch verb {
+	case "get":
+		args = fmt.Sprintf("ctx, %s, %s, %s", pathExpr, result.target, opts)
+	case "delete":
+		args = fmt.Sprintf("ctx, %s", pathExpr)
+	default:
+		args = fmt.Sprintf("ctx, %s, %s, %s", pathExpr, body, result.target)
+	}
+
+	m.results = result.results
+	if result.decl == "" {
+		m.body = []string{fmt.Sprintf("return s.client.%s(%s)", call, args)}
+	} else {
+		ret := result.ret
+		if verb == "get" && result.found {
+			ret = fmt.Sprintf("core.Found(%s, err)", result.ret)
+		} else {
+			ret += ", err"
+		}
+		m.body = []string{
+			result.decl,
+			fmt.Sprintf("err := s.client.%s(%s)", call, args),
+			"return " + ret,
+		}
+	}
+	g.services[service] = append(g.services[service], m)
+}
+
+func (g *generator) operationName(path, verb string, op *Operation) string {
+	if op.OperationID != "" {
+		return pascal(op.OperationID)
+	}
+	var parts []string
+	for _, seg := range strings.Split(versionPrefix.ReplaceAllString(path, ""), "/") {
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		parts = append(parts, strings.TrimSuffix(seg, ".json"))
+	}
+	return pascal(verb) + pascal(strings.Join(parts, "_"))
+}
+
+// pathExpr converts "/admin/openapi/{version}/products/{id}.json" into a
+// CreatePath expression and adds the path parameters to m.
+func (g *generator) pathExpr(path string, op *Operation, m *method) string {
+	rel := strings.TrimPrefix(versionPrefix.ReplaceAllString(path, ""), "/")
+	types := map[string]string{}
+	for _, p := range op.Parameters {
+		if p.In == "path" && p.Schema != nil && p.Schema.Type == "integer" {
+			types[p.Name] = "int64"
+		}
+	}
+
+	var formatStr strings.Builder
+	var args []string
+	for rest := rel; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			formatStr.WriteString(strings.ReplaceAll(rest, "%", "%%"))
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			formatStr.WriteString(rest)
+			break
+		}
+		formatStr.WriteString(strings.ReplaceAll(rest[:open], "%", "%%"))
+		name := rest[open+1 : open+end]
+		arg := argName(name)
+		typ := types[name]
+		if typ == "" {
+			typ = "string"
+		}
+		if typ == "int64" {
+			formatStr.WriteString("%d")
+		} else {
+			formatStr.WriteString("%s")
+		}
+		m.params = append(m.params, arg+" "+typ)
+		args = append(args, arg)
+		rest = rest[open+end+1:]
+	}
+
+	if len(args) == 0 {
+		return fmt.Sprintf("s.client.CreatePath(%q)", formatStr.String())
+	}
+	g.usesFmt = true
+	return fmt.Sprintf("s.client.CreatePath(fmt.Sprintf(%q, %s))", formatStr.String(), strings.Join(args, ", "))
+}
+
+func queryParams(op *Operation) []Parameter {
+	var q []Parameter
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			q = append(q, p)
+		}
+	}
+	return q
+}
+
+func (g *generator) addOptions(name string, params []Parameter) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	embedded := false
+	for _, p := range params {
+		if listOptionParams[p.Name] {
+			embedded = true
+		}
+	}
+	if embedded {
+		b.WriteString("core.ListOptions\n")
+	}
+	sort.Slice(params, func(i, j int) bool { return params[i].Name < params[j].Name })
+	for _, p := range params {
+		if embedded && listOptionParams[p.Name] {
+			continue
+		}
+		typ := "string"
+		if p.Schema != nil {
+			switch p.Schema.Type {
+			case "integer":
+				typ = "int64"
+			case "boolean":
+				typ = "bool"
+			case "array":
+				typ = "[]string"
+			}
+		}
+		fmt.Fprintf(&b, "%s %s `url:\"%s,omitempty\"`\n", pascal(p.Name), typ, p.Name)
+	}
+	b.WriteString("}\n")
+	g.options = append(g.options, b.String())
+}
+
+// requestBody returns the body expression and adds the body parameter to m.
+func (g *generator) requestBody(s *Schema, m *method) string {
+	if s == nil {
+		return "nil"
+	}
+	if model := s.refName(); model != "" {
+		g.useModel(model)
+		m.params = append(m.params, "v "+pascal(model))
+		return "v"
+	}
+	if key, prop, ok := singleProperty(s); ok {
+		if model := prop.refName(); model != "" {
+			g.useModel(model)
+			w := g.wrapper(key, "*"+pascal(model))
+			m.params = append(m.params, "v "+pascal(model))
+			return fmt.Sprintf("%s{%s: &v}", w, pascal(key))
+		}
+	}
+	m.params = append(m.params, "body map[string]interface{}")
+	return "body"
+}
+
+type resultShape struct {
+	results string // result list of the method
+	decl    string // declaration of r, "" if the call returns only an error
+	target  string // result argument passed to the client
+	ret     string // first returned value
+	found   bool   // wrap with core.Found on GET
+}
+
+func (g *generator) responseResult(op *Operation) resultShape {
+	s := successSchema(op)
+	if s == nil {
+		return resultShape{results: "error", target: "nil"}
+	}
+	if model := s.refName(); model != "" {
+		g.useModel(model)
+		typ := pascal(model)
+		return resultShape{
+			results: fmt.Sprintf("(*%s, error)", typ),
+			decl:    fmt.Sprintf("r := &%s{}", typ), target: "r", ret: "r",
+		}
+	}
+	if key, prop, ok := singleProperty(s); ok {
+		field := pascal(key)
+		switch {
+		case prop.refName() != "":
+			model := pascal(prop.refName())
+			g.useModel(prop.refName())
+			w := g.wrapper(key, "*"+model)
+			return resultShape{
+				results: fmt.Sprintf("(*%s, error)", model),
+				decl:    fmt.Sprintf("r := &%s{}", w), target: "r", ret: "r." + field, found: true,
+			}
+		case prop.Type == "array" && prop.Items.refName() != "":
+			model := pascal(prop.Items.refName())
+			g.useModel(prop.Items.refName())
+			w := g.wrapper(key, "[]"+model)
+			return resultShape{
+				results: fmt.Sprintf("([]%s, error)", model),
+				decl:    fmt.Sprintf("r := &%s{}", w), target: "r", ret: "r." + field,
+			}
+		case prop.Type == "integer" && key == "count":
+			g.wrapper("count", "int")
+			return resultShape{
+				results: "(int, error)",
+				decl:    "r := &countResource{}", target: "r", ret: "r.Count",
+			}
+		}
+	}
+	return resultShape{
+		results: "(map[string]interface{}, error)",
+		decl:    "r := map[string]interface{}{}", target: "&r", ret: "r",
+	}
+}
+
+// successSchema returns the JSON schema of the first 2xx response.
+func successSchema(op *Operation) *Schema {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if s := op.Responses[code].schema(); s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+func singleProperty(s *Schema) (string, *Schema, bool) {
+	if s == nil || len(s.Properties) != 1 {
+		return "", nil, false
+	}
+	for key, prop := range s.Properties {
+		return key, prop, prop != nil
+	}
+	return "", nil, false
+}
+
+// wrapper registers the JSON envelope {key: typ} and returns its type name.
+func (g *generator) wrapper(key, typ string) string {
+	name := lowerFirst(pascal(key)) + "Resource"
+	if w, ok := g.wrappers[name]; ok && w.typ != typ {
+		name = lowerFirst(pascal(key)) + strings.TrimLeft(typ, "*[]") + "Resource"
+	}
+	g.wrappers[name] = wrapper{key: key, field: pascal(key), typ: typ}
+	return name
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// useModel marks a component schema, and every schema it references, for
+// generation.
+func (g *generator) useModel(name string) {
+	if g.models[name] {
+		return
+	}
+	s, ok := g.spec.Components.Schemas[name]
+	if !ok {
+		g.warnf("schema %q is referenced but not defined", name)
+		return
+	}
+	g.models[name] = true
+	var walk func(*Schema)
+	walk = func(s *Schema) {
+		if s == nil {
+			return
+		}
+		if ref := s.refName(); ref != "" {
+			g.useModel(ref)
+			return
+		}
+		for _, p := range s.Properties {
+			walk(p)
+		}
+		walk(s.Items)
+	}
+	walk(s)
+}
+
+// goType maps a schema to a Go type. Object references are pointers in
+// struct fields and values in slices, as in the hand-written models.
+func (g *generator) goType(s *Schema, inSlice bool) string {
+	if s == nil {
+		return "interface{}"
+	}
+	if ref := s.refName(); ref != "" {
+		// Non-object components (enums etc.) are named value types.
+		target := g.spec.Components.Schemas[ref]
+		if inSlice || (target != nil && target.Type != "" && target.Type != "object") {
+			return pascal(ref)
+		}
+		return "*" + pascal(ref)
+	}
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			g.usesTime = true
+			return "*time.Time"
+		}
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int"
+		}
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + g.goType(s.Items, true)
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+func (g *generator) renderModel(b *bytes.Buffer, name string) {
+	s := g.spec.Components.Schemas[name]
+	if s.Type != "" && s.Type != "object" {
+		writeComment(b, s.Description)
+		fmt.Fprintf(b, "type %s %s\n\n", pascal(name), g.goType(s, false))
+		return
+	}
+	props := make([]string, 0, len(s.Properties))
+	for p := range s.Properties {
+		props = append(props, p)
+	}
+	sort.Slice(props, func(i, j int) bool {
+		// id first, then alphabetical, to resemble the hand-written models.
+		if (props[i] == "id") != (props[j] == "id") {
+			return props[i] == "id"
+		}
+		return props[i] < props[j]
+	})
+	writeComment(b, s.Description)
+	fmt.Fprintf(b, "type %s struct {\n", pascal(name))
+	for _, p := range props {
+		fmt.Fprintf(b, "%s %s `json:\"%s,omitempty\"`\n", pascal(p), g.goType(s.Properties[p], false), p)
+	}
+	b.WriteString("}\n\n")
+}
+
+// =====================================================================
+// Rendering
+// =====================================================================
+
+func (g *generator) render() []byte {
+	var body bytes.Buffer
+
+	services := make([]string, 0, len(g.services))
+	for s := range g.services {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+	for _, svc := range services {
+		g.renderService(&body, svc, g.services[svc])
+	}
+
+	if len(g.options) > 0 {
+		banner(&body, "Query Options")
+		for _, o := range g.options {
+			body.WriteString(o + "\n")
+		}
+	}
+
+	models := make([]string, 0, len(g.models))
+	for m := range g.models {
+		models = append(models, m)
+	}
+	sort.Strings(models)
+	var modelBuf bytes.Buffer
+	for _, m := range models {
+		g.renderModel(&modelBuf, m)
+	}
+	if modelBuf.Len() > 0 {
+		banner(&body, "Models")
+		body.Write(modelBuf.Bytes())
+	}
+
+	if len(g.wrappers) > 0 {
+		banner(&body, "JSON Wrappers")
+		names := make([]string, 0, len(g.wrappers))
+		for n := range g.wrappers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			w := g.wrappers[n]
+			fmt.Fprintf(&body, "type %s struct {\n%s %s `json:\"%s\"`\n}\n", n, w.field, w.typ, w.key)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by slshop-gen from %s. DO NOT EDIT.\n\n", g.source)
+	fmt.Fprintf(&out, "package %s\n\nimport (\n\"context\"\n", g.pkg)
+	if g.usesFmt {
+		out.WriteString("\"fmt\"\n")
+	}
+	if g.usesTime {
+		out.WriteString("\"time\"\n")
+	}
+	out.WriteString("\n\"github.com/imokyou/slshop/core\"\n)\n\n")
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func (g *generator) renderService(b *bytes.Buffer, name string, methods []*method) {
+	iface := pascal(name) + "Service"
+	op := lowerFirst(pascal(name)) + "Op"
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+	banner(b, pascal(name))
+	fmt.Fprintf(b, "type %s interface {\n", iface)
+	for _, m := range methods {
+		writeComment(b, m.summary)
+		fmt.Fprintf(b, "%s(%s) %s\n", m.name, m.signature(), m.results)
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "func New%s(client core.Requester) %s {\nreturn &%s{client: client}\n}\n\n", iface, iface, op)
+	fmt.Fprintf(b, "type %s struct{ client core.Requester }\n\n", op)
+	for _, m := range methods {
+		fmt.Fprintf(b, "func (s *%s) %s(%s) %s {\n%s\n}\n\n", op, m.name, m.signature(), m.results, strings.Join(m.body, "\n"))
+	}
+}
+
+func (m *method) signature() string {
+	return strings.Join(append([]string{"ctx context.Context"}, m.params...), ", ")
+}
+
+func banner(b *bytes.Buffer, title string) {
+	line := "// " + strings.Repeat("=", 69) + "\n"
+	fmt.Fprintf(b, "%s// %s\n%s\n", line, title, line)
+}
+
+func writeComment(b *bytes.Buffer, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// =====================================================================
+// Naming
+// =====================================================================
+
+// pascal converts snake_case, kebab-case, dotted and camelCase names to an
+// exported Go identifier with the usual initialisms: "product_id" and
+// "productId" both become "ProductID".
+func pascal(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		if up, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(up)
+			continue
+		}
+		r := []rune(word)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	return b.String()
+}
+
+func splitWords(s string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	for i, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && len(cur) > 0 && !unicode.IsUpper(cur[len(cur)-1]):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// lowerFirst unexports an identifier, lowering a leading initialism as a
+// whole: "Product" → "product", "URLPath" → "urlPath", "IDs" → "ids".
+func lowerFirst(s string) string {
+	r := []rune(s)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	switch {
+	case n == 0:
+		return s
+	case n > 1 && n < len(r) && unicode.IsLower(r[n]) && r[n] != 's':
+		n-- // the last capital starts the next word
+	case n > 1 && n < len(r) && r[n] == 's':
+		n++ // plural initialism such as "IDs"
+	}
+	return strings.ToLower(string(r[:n])) + string(r[n:])
+}
+
+// argName turns a path parameter into an unexported identifier.
+func argName(s string) string {
+	name := lowerFirst(pascal(s))
+	if token.IsKeyword(name) {
+		name += "Param"
+	}
+	return name
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata golden files")
+
+func TestGenerate_Golden(t *testing.T) {
+	spec, err := loadSpec("testdata/widgets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGenerator(spec, "widget", "testdata/widgets.json", nil)
+	got, err := g.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, got)
+	}
+	if len(g.warnings) != 1 || !strings.Contains(g.warnings[0], "PATCH") {
+		t.Errorf("expected a PATCH warning, got %v", g.warnings)
+	}
+
+	const golden = "testdata/widgets.golden"
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("output differs from %s; run go test -update to accept\n%s", golden, got)
+	}
+}
+
+func TestGenerate_TagFilter(t *testing.T) {
+	spec, err := loadSpec("testdata/widgets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := newGenerator(spec, "widget", "widgets.json", []string{"Gadget"}).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(got), "WidgetService") || !strings.Contains(string(got), "GadgetService") {
+		t.Errorf("expected only the Gadget service:\n%s", got)
+	}
+	if _, err := newGenerator(spec, "widget", "widgets.json", []string{"Nope"}).Generate(); err == nil {
+		t.Error("expected error when no operation is selected")
+	}
+}
+
+func TestNaming(t *testing.T) {
+	cases := map[string]string{
+		"product_id":   "ProductID",
+		"productId":    "ProductID",
+		"getProduct":   "GetProduct",
+		"body_html":    "BodyHTML",
+		"variant_ids":  "VariantIDs",
+		"orders.count": "OrdersCount",
+	}
+	for in, want := range cases {
+		if got := pascal(in); got != want {
+			t.Errorf("pascal(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for in, want := range map[string]string{"Product": "product", "URLPath": "urlPath", "IDs": "ids", "ID": "id", "type": "typeParam"} {
+		got := lowerFirst(in)
+		if in == "type" {
+			got = argName(in)
+		}
+		if got != want {
+			t.Errorf("lowerFirst(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Command slshop-gen generates service interfaces, models and JSON wrapper
+// structs from a Shopline OpenAPI document, in the same layout as the
+// hand-written packages of this SDK.
+//
+// Usage:
+//
+//	go run ./cmd/slshop-gen -spec openapi.json -pkg product -tags Product,Variant \
+//	    -o product/zz_generated.go
+//
+// Each OpenAPI tag becomes a <Tag>Service interface with a New<Tag>Service
+// constructor. Paths are made relative to the versioned API root, so the
+// generated code works with any API version passed to shopline.WithVersion.
+// Only JSON documents are read; convert YAML specs first. Operations that
+// cannot be expressed through core.Requester (e.g. PATCH) are skipped with a
+// warning on stderr.
+//
+// Review the output before committing it: names come from operationIds and
+// may need a hand-written wrapper to match existing method names.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path to the OpenAPI JSON document (required)")
+	pkg := flag.String("pkg", "", "Go package name of the generated file (required)")
+	tags := flag.String("tags", "", "comma-separated OpenAPI tags to generate (default all)")
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	if *specPath == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(*specPath, *pkg, *tags, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, pkg, tags, out string) error {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+	var tagList []string
+	if tags != "" {
+		tagList = strings.Split(tags, ",")
+	}
+
+	g := newGenerator(spec, pkg, specPath, tagList)
+	src, err := g.Generate()
+	for _, w := range g.warnings {
+		fmt.Fprintln(os.Stderr, "slshop-gen: warning:", w)
+	}
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3 document the generator reads.
+type Spec struct {
+	Paths      map[string]PathItem `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// PathItem maps lower-case HTTP methods to operations. Non-method keys
+// (parameters, summary, ...) are dropped by UnmarshalJSON.
+type PathItem map[string]*Operation
+
+var httpMethods = map[string]bool{"get": true, "post": true, "put": true, "delete": true, "patch": true}
+
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*p = PathItem{}
+	for key, value := range raw {
+		if !httpMethods[key] {
+			continue
+		}
+		op := &Operation{}
+		if err := json.Unmarshal(value, op); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		(*p)[key] = op
+	}
+	return nil
+}
+
+type Operation struct {
+	OperationID string           `json:"operationId"`
+	Summary     string           `json:"summary"`
+	Tags        []string         `json:"tags"`
+	Parameters  []Parameter      `json:"parameters"`
+	RequestBody *Body            `json:"requestBody"`
+	Responses   map[string]*Body `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query, header
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Body is a request body or response; only application/json is read.
+type Body struct {
+	Content map[string]struct {
+		Schema *Schema `json:"schema"`
+	} `json:"content"`
+}
+
+func (b *Body) schema() *Schema {
+	if b == nil {
+		return nil
+	}
+	for mime, c := range b.Content {
+		if strings.HasPrefix(mime, "application/json") {
+			return c.Schema
+		}
+	}
+	return nil
+}
+
+type Schema struct {
+	Ref         string             `json:"$ref"`
+	Type        schemaType         `json:"type"`
+	Format      string             `json:"format"`
+	Description string             `json:"description"`
+	Properties  map[string]*Schema `json:"properties"`
+	Items       *Schema            `json:"items"`
+}
+
+// refName returns the component name of a "#/components/schemas/X" ref.
+func (s *Schema) refName() string {
+	if s == nil || s.Ref == "" {
+		return ""
+	}
+	return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
+}
+
+// schemaType accepts both OpenAPI 3.0 ("string") and 3.1 (["string",
+// "null"]) type declarations.
+type schemaType string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaType(single)
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return err
+	}
+	for _, s := range multi {
+		if s != "null" {
+			*t = schemaType(s)
+			break
+		}
+	}
+	return nil
+}
+
+// loadSpec reads a JSON OpenAPI document.
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("slshop-gen: %s is not a JSON OpenAPI document: %w", path, err)
+	}
+	return spec, nil
+}
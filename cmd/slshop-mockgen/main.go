@@ -0,0 +1,249 @@
+// Command slshop-mockgen generates the shoplinemock package: a mock for the
+// interface of every service field of shopline.Client, with one func field
+// per method, plus a Mocks struct installing them all on a Client.
+//
+// Usage, from the repository root:
+//
+//	go run ./cmd/slshop-mockgen -o shoplinemock/zz_generated.go
+//
+// Rerun it (or go generate ./shoplinemock) after adding a service or
+// changing a service interface; until then shoplinemock does not compile,
+// and this command's test reports the stale file.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+)
+
+const clientPkg = "github.com/imokyou/slshop"
+
+func main() {
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	src, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// service is an interface-typed field of shopline.Client.
+type service struct {
+	field string
+	named *types.Named
+	iface *types.Interface
+}
+
+func generate() ([]byte, error) {
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(clientPkg)
+	if err != nil {
+		return nil, fmt.Errorf("slshop-mockgen: failed to load %s: %w", clientPkg, err)
+	}
+	obj := pkg.Scope().Lookup("Client")
+	if obj == nil {
+		return nil, fmt.Errorf("slshop-mockgen: %s has no Client type", clientPkg)
+	}
+	st := obj.Type().Underlying().(*types.Struct)
+
+	var services []service
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if !f.Exported() {
+			continue
+		}
+		named, ok := f.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		iface, ok := named.Underlying().(*types.Interface)
+		if !ok {
+			return nil, fmt.Errorf("slshop-mockgen: Client.%s is %s, not an interface", f.Name(), named)
+		}
+		services = append(services, service{field: f.Name(), named: named, iface: iface})
+	}
+
+	g := &generator{imports: map[string]string{clientPkg: "shopline"}}
+	var body bytes.Buffer
+	for _, s := range services {
+		g.writeMock(&body, s)
+	}
+	g.writeMocks(&body, services)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by slshop-mockgen. DO NOT EDIT.\n\npackage shoplinemock\n\nimport (\n")
+	paths := make([]string, 0, len(g.imports))
+	for p := range g.imports {
+		paths = append(paths, p)
+	}
+	// Standard library first, then the SDK's packages.
+	sort.Slice(paths, func(i, j int) bool {
+		si, sj := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], ".")
+		if si != sj {
+			return si
+		}
+		return paths[i] < paths[j]
+	})
+	for i, p := range paths {
+		if i > 0 && strings.Contains(p, ".") && !strings.Contains(paths[i-1], ".") {
+			buf.WriteString("\n")
+		}
+		name := g.imports[p]
+		if name == p[strings.LastIndex(p, "/")+1:] {
+			fmt.Fprintf(&buf, "\t%q\n", p)
+		} else {
+			fmt.Fprintf(&buf, "\t%s %q\n", name, p)
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("slshop-mockgen: generated invalid code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	imports map[string]string // path → name used in the generated file
+}
+
+// qualifier names packages by their declared name, as the SDK's own
+// packages are imported.
+func (g *generator) qualifier(p *types.Package) string {
+	g.imports[p.Path()] = p.Name()
+	return p.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) writeMock(w *bytes.Buffer, s service) {
+	typeName := s.field + "Service"
+	ifaceName := g.typeString(s.named)
+	fmt.Fprintf(w, "\n// %s mocks %s. Methods whose func field is nil return\n// ErrNotMocked.\ntype %s struct {\n\trecorder\n", typeName, ifaceName, typeName)
+	for i := 0; i < s.iface.NumMethods(); i++ {
+		m := s.iface.Method(i)
+		sig := m.Type().(*types.Signature)
+		fmt.Fprintf(w, "\t%sFunc %s\n", m.Name(), g.typeString(sig))
+	}
+	fmt.Fprintf(w, "}\n\nvar _ %s = (*%s)(nil)\n", ifaceName, typeName)
+
+	for i := 0; i < s.iface.NumMethods(); i++ {
+		m := s.iface.Method(i)
+		sig := m.Type().(*types.Signature)
+		params, args := g.params(sig)
+		results := g.results(sig)
+		fmt.Fprintf(w, "\n// %s implements %s.\nfunc (m *%s) %s(%s) %s {\n", m.Name(), ifaceName, typeName, m.Name(), params, results)
+		fmt.Fprintf(w, "\tm.record(%q)\n", m.Name())
+		fmt.Fprintf(w, "\tif m.%sFunc != nil {\n\t\treturn m.%sFunc(%s)\n\t}\n", m.Name(), m.Name(), args)
+		w.WriteString(g.zeroReturn(sig, s.field+"."+m.Name()))
+		w.WriteString("}\n")
+	}
+}
+
+func (g *generator) params(sig *types.Signature) (decl, call string) {
+	var ds, cs []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		p := sig.Params().At(i)
+		name := fmt.Sprintf("p%d", i)
+		t := g.typeString(p.Type())
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			ds = append(ds, name+" ..."+g.typeString(p.Type().(*types.Slice).Elem()))
+			cs = append(cs, name+"...")
+			continue
+		}
+		ds = append(ds, name+" "+t)
+		cs = append(cs, name)
+	}
+	return strings.Join(ds, ", "), strings.Join(cs, ", ")
+}
+
+func (g *generator) results(sig *types.Signature) string {
+	r := sig.Results()
+	switch r.Len() {
+	case 0:
+		return ""
+	case 1:
+		return g.typeString(r.At(0).Type())
+	}
+	parts := make([]string, r.Len())
+	for i := range parts {
+		parts[i] = g.typeString(r.At(i).Type())
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func (g *generator) zeroReturn(sig *types.Signature, method string) string {
+	r := sig.Results()
+	if r.Len() == 0 {
+		return "\treturn\n"
+	}
+	vals := make([]string, r.Len())
+	for i := range vals {
+		t := r.At(i).Type()
+		if types.Identical(t, types.Universe.Lookup("error").Type()) {
+			vals[i] = fmt.Sprintf("notMocked(%q)", method)
+			continue
+		}
+		vals[i] = g.zeroValue(t)
+	}
+	return "\treturn " + strings.Join(vals, ", ") + "\n"
+}
+
+func (g *generator) zeroValue(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsString != 0:
+			if _, named := t.(*types.Named); named {
+				return g.typeString(t) + `("")`
+			}
+			return `""`
+		case u.Info()&types.IsNumeric != 0:
+			if _, named := t.(*types.Named); named {
+				return g.typeString(t) + "(0)"
+			}
+			return "0"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil"
+	}
+	return g.typeString(t) + "{}"
+}
+
+func (g *generator) writeMocks(w *bytes.Buffer, services []service) {
+	w.WriteString("\n// Mocks holds a mock for every service of a shopline.Client.\ntype Mocks struct {\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\t%s *%sService\n", s.field, s.field)
+	}
+	w.WriteString("}\n\n// newMocks creates empty mocks.\nfunc newMocks() *Mocks {\n\treturn &Mocks{\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\t\t%s: &%sService{},\n", s.field, s.field)
+	}
+	w.WriteString("\t}\n}\n\n// install replaces every service of c with its mock.\nfunc (m *Mocks) install(c *shopline.Client) {\n")
+	for _, s := range services {
+		fmt.Fprintf(w, "\tc.%s = m.%s\n", s.field, s.field)
+	}
+	w.WriteString("}\n")
+}
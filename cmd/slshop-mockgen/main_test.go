@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestGenerate_UpToDate(t *testing.T) {
+	got, err := generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("../../shoplinemock/zz_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("shoplinemock/zz_generated.go is stale; run go generate ./shoplinemock")
+	}
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	shopline "github.com/imokyou/slshop"
+	"github.com/imokyou/slshop/bulk"
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/webhook"
+)
+
+// errUsage reports invalid arguments; the flag package has already printed
+// the details.
+var errUsage = errors.New("invalid usage")
+
+// exportPageSize is the page size of products export, the API maximum.
+const exportPageSize = 250
+
+type command func(ctx context.Context, env *cliEnv, args []string) error
+
+var commands = map[string]command{
+	"auth login":        authLogin,
+	"products list":     productsList,
+	"products export":   productsExport,
+	"orders get":        ordersGet,
+	"webhooks list":     webhooksList,
+	"webhooks register": webhooksRegister,
+	"bulk run":          bulkRun,
+}
+
+// =====================================================================
+// Setup
+// =====================================================================
+
+// newFlagSet creates a flag set for a command with the flags shared by all
+// commands.
+func newFlagSet(env *cliEnv, name string) (*flag.FlagSet, *string, *string) {
+	fs := flag.NewFlagSet("slshop "+name, flag.ContinueOnError)
+	fs.SetOutput(env.stderr)
+	handle := fs.String("handle", env.getenv("SHOPLINE_HANDLE"), "store handle, e.g. open001")
+	baseURL := fs.String("base-url", env.getenv("SHOPLINE_BASE_URL"), "API base URL override")
+	return fs, handle, baseURL
+}
+
+// parse parses args, mapping flag errors to errUsage.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
+
+func (env *cliEnv) app() shopline.App {
+	return shopline.App{
+		AppKey:      env.getenv("SHOPLINE_APP_KEY"),
+		AppSecret:   env.getenv("SHOPLINE_APP_SECRET"),
+		RedirectURL: env.getenv("SHOPLINE_REDIRECT_URL"),
+		Scope:       env.getenv("SHOPLINE_SCOPE"),
+	}
+}
+
+// tokenStore returns the store auth login saves tokens to.
+func (env *cliEnv) tokenStore() (*shopline.FileTokenStore, error) {
+	dir := env.getenv("SHOPLINE_TOKEN_DIR")
+	if dir == "" {
+		config, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("cannot locate token directory, set SHOPLINE_TOKEN_DIR: %w", err)
+		}
+		dir = filepath.Join(config, "slshop", "tokens")
+	}
+	return shopline.NewFileTokenStore(dir), nil
+}
+
+// client creates a client for handle using SHOPLINE_TOKEN, or the token
+// saved by auth login.
+func (env *cliEnv) client(handle, baseURL string) (*shopline.Client, error) {
+	if handle == "" {
+		return nil, errors.New("no store handle, set -handle or SHOPLINE_HANDLE")
+	}
+	var opts []shopline.Option
+	if baseURL != "" {
+		opts = append(opts, shopline.WithBaseURL(baseURL))
+	}
+	token := env.getenv("SHOPLINE_TOKEN")
+	if token == "" {
+		store, err := env.tokenStore()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, shopline.WithTokenManager(store))
+	}
+	return shopline.NewClient(env.app(), handle, token, opts...)
+}
+
+// printJSON writes v as indented JSON.
+func (env *cliEnv) printJSON(v interface{}) error {
+	enc := json.NewEncoder(env.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// =====================================================================
+// Commands
+// =====================================================================
+
+func authLogin(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, _ := newFlagSet(env, "auth login")
+	code := fs.String("code", "", "authorization code from the OAuth callback")
+	state := fs.String("state", "", "state passed through the authorization URL")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *handle == "" {
+		return errors.New("no store handle, set -handle or SHOPLINE_HANDLE")
+	}
+	app := env.app()
+	if *code == "" {
+		if err := app.ValidateScope(); err != nil {
+			fmt.Fprintln(env.stderr, "warning:", err)
+		}
+		fmt.Fprintln(env.stdout, "Open this URL, approve the app and rerun with -code from the callback:")
+		fmt.Fprintln(env.stdout, app.AuthorizeURL(*handle, *state))
+		return nil
+	}
+
+	resp, err := app.GetAccessToken(ctx, *handle, *code)
+	if err != nil {
+		return err
+	}
+	expireAt, err := time.Parse(time.RFC3339, resp.Data.ExpireTime)
+	if err != nil {
+		expireAt = time.Now().Add(10 * time.Hour)
+	}
+	store, err := env.tokenStore()
+	if err != nil {
+		return err
+	}
+	tm := shopline.NewTokenManager(app, *handle, store)
+	if err := tm.SetInitialToken(ctx, resp.Data.AccessToken, expireAt, resp.Data.Scope); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Saved token for %s, expires %s\n", *handle, expireAt.Format(time.RFC3339))
+	return nil
+}
+
+func productsList(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "products list")
+	limit := fs.Int("limit", 50, "products per page (max 250)")
+	page := fs.Int("page", 0, "page number")
+	sinceID := fs.Int64("since-id", 0, "only products with a greater ID")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+	products, err := client.Product.List(ctx, &core.ListOptions{Limit: *limit, Page: *page, SinceID: *sinceID})
+	if err != nil {
+		return err
+	}
+	return env.printJSON(products)
+}
+
+func productsExport(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "products export")
+	out := fs.String("o", "", "output file (default stdout)")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+
+	dst := env.stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		dst = f
+	}
+	w := bufio.NewWriter(dst)
+	enc := json.NewEncoder(w)
+
+	var sinceID int64
+	total := 0
+	for {
+		products, err := client.Product.List(ctx, &core.ListOptions{Limit: exportPageSize, SinceID: sinceID})
+		if err != nil {
+			return fmt.Errorf("export stopped after %d products: %w", total, err)
+		}
+		for _, p := range products {
+			if err := enc.Encode(p); err != nil {
+				return err
+			}
+			sinceID = p.ID
+		}
+		total += len(products)
+		if len(products) < exportPageSize {
+			break
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stderr, "exported %d products\n", total)
+	return nil
+}
+
+func ordersGet(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "orders get")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(env.stderr, "usage: slshop orders get [flags] ID")
+		return errUsage
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid order ID %q", fs.Arg(0))
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+	o, err := client.Order.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return env.printJSON(o)
+}
+
+func webhooksList(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "webhooks list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+	subs, err := client.Webhook.List(ctx, nil)
+	if err != nil {
+		return err
+	}
+	return env.printJSON(subs)
+}
+
+func webhooksRegister(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "webhooks register")
+	topic := fs.String("topic", "", "webhook topic, e.g. orders/paid (required)")
+	address := fs.String("address", "", "HTTPS URL receiving deliveries (required)")
+	fields := fs.String("fields", "", "comma-separated fields to include")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *topic == "" || *address == "" {
+		fmt.Fprintln(env.stderr, "-topic and -address are required")
+		return errUsage
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+	sub := webhook.Subscription{Topic: *topic, Address: *address, Format: "json"}
+	if *fields != "" {
+		sub.Fields = strings.Split(*fields, ",")
+	}
+	created, err := client.Webhook.Create(ctx, sub)
+	if err != nil {
+		return err
+	}
+	return env.printJSON(created)
+}
+
+// bulkTerminalStatuses are the statuses after which a bulk operation no
+// longer changes.
+var bulkTerminalStatuses = map[string]bool{
+	"COMPLETED": true, "FAILED": true, "CANCELED": true, "CANCELLED": true, "EXPIRED": true,
+}
+
+func bulkRun(ctx context.Context, env *cliEnv, args []string) error {
+	fs, handle, baseURL := newFlagSet(env, "bulk run")
+	query := fs.String("query", "", "bulk query (required)")
+	poll := fs.Duration("poll", 5*time.Second, "status polling interval")
+	noWait := fs.Bool("no-wait", false, "print the created operation without waiting")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *query == "" {
+		fmt.Fprintln(env.stderr, "-query is required")
+		return errUsage
+	}
+	client, err := env.client(*handle, *baseURL)
+	if err != nil {
+		return err
+	}
+
+	op, err := client.BulkOperation.CreateQuery(ctx, bulk.BulkQueryRequest{Query: *query})
+	if err != nil {
+		return err
+	}
+	if op == nil {
+		return fmt.Errorf("bulk query created no operation")
+	}
+	for !*noWait && !bulkTerminalStatuses[strings.ToUpper(op.Status)] {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(*poll):
+		}
+		current, err := client.BulkOperation.GetCurrent(ctx, "QUERY")
+		if errors.Is(err, core.ErrNotFound) {
+			env.printJSON(op)
+			return fmt.Errorf("bulk operation %s is no longer the current operation; last status %s", op.ID, op.Status)
+		}
+		if err != nil {
+			return err
+		}
+		op = current
+		fmt.Fprintf(env.stderr, "bulk operation %s: %s (%d objects)\n", op.ID, op.Status, op.ObjectCount)
+	}
+	if err := env.printJSON(op); err != nil {
+		return err
+	}
+	if !*noWait && !strings.EqualFold(op.Status, "COMPLETED") {
+		return fmt.Errorf("bulk operation %s ended with status %s", op.ID, op.Status)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testEnv returns a cliEnv talking to server with a static token.
+func testEnv(server *httptest.Server) (*cliEnv, *bytes.Buffer) {
+	var out bytes.Buffer
+	vars := map[string]string{
+		"SHOPLINE_HANDLE":   "testshop",
+		"SHOPLINE_TOKEN":    "test-token",
+		"SHOPLINE_BASE_URL": server.URL,
+	}
+	env := &cliEnv{stdout: &out, stderr: &bytes.Buffer{}, getenv: func(k string) string { return vars[k] }}
+	return env, &out
+}
+
+func TestProductsExport(t *testing.T) {
+	var sinceIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since := r.URL.Query().Get("since_id")
+		sinceIDs = append(sinceIDs, since)
+		n := exportPageSize
+		if since != "" {
+			n = 2
+		}
+		start := 1
+		if since != "" {
+			fmt.Sscan(since, &start)
+			start++
+		}
+		products := make([]map[string]int, n)
+		for i := range products {
+			products[i] = map[string]int{"id": start + i}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"products": products})
+	}))
+	defer server.Close()
+	env, out := testEnv(server)
+
+	if err := run(context.Background(), env, []string{"products", "export"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != exportPageSize+2 {
+		t.Errorf("expected %d NDJSON lines, got %d", exportPageSize+2, len(lines))
+	}
+	if len(sinceIDs) != 2 || sinceIDs[1] != "250" {
+		t.Errorf("expected second page after ID 250, got since_id %v", sinceIDs)
+	}
+}
+
+func TestOrdersGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/orders/1234.json") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"order":{"id":1234,"name":"#1001"}}`))
+	}))
+	defer server.Close()
+	env, out := testEnv(server)
+
+	if err := run(context.Background(), env, []string{"orders", "get", "1234"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"name": "#1001"`) {
+		t.Errorf("unexpected output: %s", out)
+	}
+	if err := run(context.Background(), env, []string{"orders", "get"}); err != errUsage {
+		t.Errorf("expected errUsage without an ID, got %v", err)
+	}
+}
+
+func TestWebhooksRegister(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"webhook":{"id":5,"topic":"orders/paid"}}`))
+	}))
+	defer server.Close()
+	env, _ := testEnv(server)
+
+	err := run(context.Background(), env, []string{"webhooks", "register",
+		"-topic", "orders/paid", "-address", "https://app.example.com/hooks"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["webhook"]["topic"] != "orders/paid" || body["webhook"]["address"] != "https://app.example.com/hooks" {
+		t.Errorf("unexpected request body: %v", body)
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	env := &cliEnv{stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}, getenv: func(string) string { return "" }}
+	if err := run(context.Background(), env, []string{"products", "delete-all"}); err != errUsage {
+		t.Errorf("expected errUsage, got %v", err)
+	}
+}
+
+func TestBulkRun_NoCurrentOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/bulk_operations.json") {
+			w.Write([]byte(`{"data":{"id":"op-1","status":"RUNNING"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors":"not found"}`))
+	}))
+	defer server.Close()
+	env, out := testEnv(server)
+
+	err := run(context.Background(), env, []string{"bulk", "run", "-query", "{ products { id } }", "-poll", "1ms"})
+	if err == nil || !strings.Contains(err.Error(), "no longer the current operation") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), `"op-1"`) {
+		t.Errorf("expected the last known operation, got %s", out)
+	}
+}
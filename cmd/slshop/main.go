@@ -0,0 +1,76 @@
+// Command slshop runs common Shopline Admin API operations from the shell,
+// for support tasks and scripts that do not warrant a Go program.
+//
+// Usage:
+//
+//	slshop auth login -handle open001                 # print the authorization URL
+//	slshop auth login -handle open001 -code CODE      # exchange the code and save the token
+//	slshop products list -limit 50
+//	slshop products export -o products.ndjson
+//	slshop orders get 1234
+//	slshop webhooks list
+//	slshop webhooks register -topic orders/paid -address https://app.example.com/hooks
+//	slshop bulk run -query '{ products { edges { node { id } } } }'
+//
+// Credentials come from the environment: SHOPLINE_APP_KEY, SHOPLINE_APP_SECRET,
+// SHOPLINE_REDIRECT_URL and SHOPLINE_SCOPE for auth login, and SHOPLINE_HANDLE
+// plus either SHOPLINE_TOKEN or a token saved by auth login for the other
+// commands. Tokens are saved under SHOPLINE_TOKEN_DIR (default
+// ~/.config/slshop/tokens). Results are printed as JSON.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+const usage = `usage: slshop <command> <subcommand> [flags]
+
+commands:
+  auth login          authorize the app and save the store's token
+  products list       list one page of products
+  products export     write all products as NDJSON
+  orders get ID       show an order
+  webhooks list       list webhook subscriptions
+  webhooks register   subscribe to a topic
+  bulk run            run a bulk query and print the result URL
+
+run "slshop <command> <subcommand> -h" for flags
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	env := &cliEnv{stdout: os.Stdout, stderr: os.Stderr, getenv: os.Getenv}
+	if err := run(ctx, env, os.Args[1:]); err != nil {
+		if err != errUsage {
+			fmt.Fprintln(os.Stderr, "slshop:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// cliEnv is the process environment a command runs in, replaced in tests.
+type cliEnv struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// run dispatches args to a command.
+func run(ctx context.Context, env *cliEnv, args []string) error {
+	if len(args) < 2 {
+		fmt.Fprint(env.stderr, usage)
+		return errUsage
+	}
+	cmd, ok := commands[args[0]+" "+args[1]]
+	if !ok {
+		fmt.Fprintf(env.stderr, "unknown command %q\n\n%s", args[0]+" "+args[1], usage)
+		return errUsage
+	}
+	return cmd(ctx, env, args[2:])
+}
@@ -0,0 +1,90 @@
+package shopline
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+)
+
+// maxPooledBufferSize keeps buffers grown by unusually large responses out
+// of the pool, so one bulk export does not pin megabytes of memory.
+const maxPooledBufferSize = 4 * 1024 * 1024
+
+// MarshalFunc encodes a request body, with the signature of json.Marshal.
+type MarshalFunc func(v interface{}) ([]byte, error)
+
+// UnmarshalFunc decodes a response body, with the signature of
+// json.Unmarshal.
+type UnmarshalFunc func(data []byte, v interface{}) error
+
+// jsonCodec is a JSON implementation set with WithJSONCodec.
+type jsonCodec struct {
+	marshal   MarshalFunc
+	unmarshal UnmarshalFunc
+}
+
+// WithJSONCodec replaces encoding/json for request and response bodies,
+// e.g. with a faster drop-in implementation:
+//
+//	shopline.WithJSONCodec(sonic.Marshal, sonic.Unmarshal)
+//	shopline.WithJSONCodec(jsoniter.ConfigCompatibleWithStandardLibrary.Marshal,
+//	    jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal)
+//
+// The codec must honour `json` struct tags and json.Marshaler/Unmarshaler.
+// Strict decoding and drift detection still use encoding/json, since they
+// rely on its unknown-field errors. A nil function keeps encoding/json for
+// that direction.
+func WithJSONCodec(marshal MarshalFunc, unmarshal UnmarshalFunc) Option {
+	return func(c *Client) {
+		if marshal == nil {
+			marshal = json.Marshal
+		}
+		if unmarshal == nil {
+			unmarshal = json.Unmarshal
+		}
+		c.codec = &jsonCodec{marshal: marshal, unmarshal: unmarshal}
+	}
+}
+
+func (c *Client) marshalJSON(v interface{}) ([]byte, error) {
+	if c.codec != nil {
+		return c.codec.marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if c.codec != nil {
+		return c.codec.unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// =====================================================================
+// Buffer Pool
+// =====================================================================
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// pooledBuffers reports whether response bodies may be read into pooled
+// buffers. encoding/json copies everything it keeps from its input, but
+// other codecs may return strings that alias the buffer, so pooling is
+// limited to the default codec.
+func (c *Client) pooledBuffers() bool {
+	return c.codec == nil
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,130 @@
+package shopline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/imokyou/slshop/product"
+)
+
+// ============================================================
+// JSON Codec Tests
+// ============================================================
+
+func TestWithJSONCodec(t *testing.T) {
+	var marshals, unmarshals int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"product":{"id":7,"title":"Tee"}}`))
+	})
+	defer server.Close()
+	WithJSONCodec(
+		func(v interface{}) ([]byte, error) { atomic.AddInt32(&marshals, 1); return json.Marshal(v) },
+		func(data []byte, v interface{}) error {
+			atomic.AddInt32(&unmarshals, 1)
+			return json.Unmarshal(data, v)
+		},
+	)(client)
+
+	p, err := client.Product.Create(context.Background(), product.Product{Title: "Tee"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ID != 7 {
+		t.Errorf("expected ID 7, got %d", p.ID)
+	}
+	if marshals != 1 || unmarshals != 1 {
+		t.Errorf("expected codec to be used once each way, got %d marshals and %d unmarshals", marshals, unmarshals)
+	}
+}
+
+func TestDo_ErrorBodySurvivesBufferReuse(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"message":"title can't be blank"}`))
+			return
+		}
+		w.Write([]byte(`{"product":{"id":1,"title":"` + strings.Repeat("x", 64) + `"}}`))
+	})
+	defer server.Close()
+
+	_, err := client.Product.Create(context.Background(), product.Product{})
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		t.Fatalf("expected ResponseError, got %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := client.Product.Get(context.Background(), 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := string(respErr.RawBody); got != `{"message":"title can't be blank"}` {
+		t.Errorf("error body was overwritten: %s", got)
+	}
+}
+
+// ============================================================
+// Benchmarks
+// ============================================================
+
+// productPage builds a products.json response of n products with variants.
+func productPage(n int) []byte {
+	var sb strings.Builder
+	sb.WriteString(`{"products":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprintf(&sb, `{"id":%d,"title":"Product %d","handle":"product-%d","body_html":"<p>%s</p>","variants":[`,
+			i+1, i, i, strings.Repeat("description ", 20))
+		for v := 0; v < 5; v++ {
+			if v > 0 {
+				sb.WriteByte(',')
+			}
+			fmt.Fprintf(&sb, `{"id":%d,"product_id":%d,"title":"Size %d","price":"19.99","sku":"SKU-%d-%d"}`, i*10+v, i+1, v, i, v)
+		}
+		sb.WriteString(`]}`)
+	}
+	sb.WriteString(`]}`)
+	return []byte(sb.String())
+}
+
+func benchmarkProductList(b *testing.B, opts ...Option) {
+	page := productPage(250)
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(page)
+	})
+	defer server.Close()
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	ctx := context.Background()
+	b.SetBytes(int64(len(page)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.Product.List(ctx, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkProductList measures a 250-product page with the default codec
+// and pooled response buffers.
+func BenchmarkProductList(b *testing.B) {
+	benchmarkProductList(b)
+}
+
+// BenchmarkProductList_CustomCodec is the baseline for comparing codecs
+// passed to WithJSONCodec; response buffers are not pooled.
+func BenchmarkProductList_CustomCodec(b *testing.B) {
+	benchmarkProductList(b, WithJSONCodec(json.Marshal, json.Unmarshal))
+}
@@ -0,0 +1,80 @@
+package shopline
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultCompressionThreshold is the smallest request body gzipped by
+// WithCompression; compressing smaller bodies costs more than it saves.
+const DefaultCompressionThreshold = 1024
+
+// WithCompression gzips request bodies of at least
+// DefaultCompressionThreshold bytes, such as bulk metafield sets or products
+// with many variants, and asks for gzipped responses. Compressed responses
+// are decompressed before decoding; the 10MB response limit applies to the
+// decompressed size.
+func WithCompression() Option {
+	return func(c *Client) {
+		c.compression = true
+	}
+}
+
+// compressBody gzips body when compression is enabled and body is large
+// enough, returning the new body and whether it was compressed.
+func (c *Client) compressBody(body []byte) ([]byte, bool) {
+	if !c.compression || len(body) < DefaultCompressionThreshold {
+		return body, false
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return body, false
+	}
+	if err := zw.Close(); err != nil {
+		return body, false
+	}
+	return buf.Bytes(), true
+}
+
+// decompressResponse replaces a gzipped response body with its decompressed
+// stream. The transport only does this itself when it added Accept-Encoding,
+// which NewRequest does explicitly under WithCompression.
+func decompressResponse(resp *http.Response) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipReader{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipReader decompresses body, opening the gzip stream on first read so an
+// empty body is not an error.
+type gzipReader struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (g *gzipReader) Read(p []byte) (int, error) {
+	if g.err != nil {
+		return 0, g.err
+	}
+	if g.zr == nil {
+		g.zr, g.err = gzip.NewReader(g.body)
+		if g.err != nil {
+			return 0, g.err
+		}
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gzipReader) Close() error {
+	return g.body.Close()
+}
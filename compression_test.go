@@ -0,0 +1,74 @@
+package shopline
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/product"
+)
+
+// ============================================================
+// Compression Tests
+// ============================================================
+
+func TestWithCompression(t *testing.T) {
+	var encoding, accept, title string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		encoding, accept = r.Header.Get("Content-Encoding"), r.Header.Get("Accept-Encoding")
+		body := r.Body
+		if encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("invalid gzip body: %v", err)
+				return
+			}
+			body = zr
+		}
+		b, _ := io.ReadAll(body)
+		if strings.Contains(string(b), `"title":"`) {
+			title = "sent"
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(`{"product":{"id":9,"title":"Tee"}}`))
+		zw.Close()
+	})
+	defer server.Close()
+	WithCompression()(client)
+
+	p, err := client.Product.Create(context.Background(), product.Product{
+		Title:    "Tee",
+		BodyHTML: strings.Repeat("<p>long description</p>", 100),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoding != "gzip" || accept != "gzip" || title != "sent" {
+		t.Errorf("expected gzipped request, got Content-Encoding %q, Accept-Encoding %q", encoding, accept)
+	}
+	if p.ID != 9 || p.Title != "Tee" {
+		t.Errorf("unexpected product: %+v", p)
+	}
+}
+
+func TestWithCompression_SmallBody(t *testing.T) {
+	var encoding string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		w.Write([]byte(`{"product":{"id":9}}`))
+	})
+	defer server.Close()
+	WithCompression()(client)
+
+	if _, err := client.Product.Create(context.Background(), product.Product{Title: "Tee"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if encoding != "" {
+		t.Errorf("expected small body to be sent uncompressed, got %q", encoding)
+	}
+}
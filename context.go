@@ -0,0 +1,87 @@
+package shopline
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is an unexported type for context keys defined in this package.
+type contextKey int
+
+const (
+	headersKey contextKey = iota
+	requestInfoKey
+)
+
+// ContextWithHeaders returns a context whose requests carry h in addition to
+// the SDK's headers, e.g. correlation IDs, partner attribution or an
+// IdempotencyKeyHeader. It works through every sub-package service:
+//
+//	ctx = shopline.ContextWithHeaders(ctx, http.Header{"X-Request-Id": {reqID}})
+//	o, err := client.Order.Get(ctx, id)
+//
+// Headers already on ctx are kept unless h sets the same key. A value for
+// Content-Type, Accept or User-Agent replaces the SDK's; Authorization is
+// ignored, since the client always sends its own token.
+func ContextWithHeaders(ctx context.Context, h http.Header) context.Context {
+	merged := HeadersFromContext(ctx)
+	if merged == nil {
+		merged = make(http.Header, len(h))
+	}
+	for k, v := range h {
+		merged[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	return context.WithValue(ctx, headersKey, merged)
+}
+
+// HeadersFromContext returns a copy of the headers attached to ctx with
+// ContextWithHeaders, or nil.
+func HeadersFromContext(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headersKey).(http.Header)
+	return h.Clone()
+}
+
+// setContextHeaders copies the headers attached to ctx onto req.
+func setContextHeaders(ctx context.Context, req *http.Request) {
+	h, _ := ctx.Value(headersKey).(http.Header)
+	for k, v := range h {
+		if k == "Authorization" {
+			continue
+		}
+		req.Header[k] = append([]string(nil), v...)
+	}
+}
+
+// RequestTrace is the last attempt of a request made with a context from
+// WithRequestInfo, and the response it got.
+type RequestTrace struct {
+	Request    *http.Request
+	Attempt    int // 1 for the first attempt
+	StatusCode int
+	TraceID    string
+}
+
+// WithRequestInfo returns a context that records the last attempt of each
+// request made with it into the returned RequestTrace, including the status
+// and the trace ID Shopline support asks for, which successful responses
+// carry too. It works through every sub-package service:
+//
+//	ctx, info := shopline.WithRequestInfo(ctx)
+//	o, err := client.Order.Get(ctx, id)
+//	log.Printf("order %d fetched (traceId: %s)", id, info.TraceID)
+//
+// The trace is overwritten without synchronization, so use the context for
+// one request at a time. Helpers that send concurrent requests with the
+// context they are given, such as DraftOrder.BatchCreate, race on it; give
+// them a context without one. For list metadata, see core.WithPageInfo.
+func WithRequestInfo(ctx context.Context) (context.Context, *RequestTrace) {
+	info := &RequestTrace{}
+	return context.WithValue(ctx, requestInfoKey, info), info
+}
+
+// RequestInfoFromContext returns the RequestTrace set on ctx with
+// WithRequestInfo, or nil.
+func RequestInfoFromContext(ctx context.Context) *RequestTrace {
+	info, _ := ctx.Value(requestInfoKey).(*RequestTrace)
+	return info
+}
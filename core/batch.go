@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// =====================================================================
+// Batch Helpers
+// =====================================================================
+
+// NewBatchID returns a random ID for the idempotency keys of one batch, so
+// that every retry of an item reuses its key while separate batches of the
+// same items never collide:
+//
+//	ictx := core.WithIdempotencyKey(ctx, fmt.Sprintf("product-%s-%d", batchID, i))
+func NewBatchID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// RunWorkers calls fn for each of 0..n-1 with up to workers concurrent
+// calls. It stops handing out work when ctx is done, and returns once every
+// call it started has returned, with the number of calls started: work
+// 0..started-1 was handed out, the rest was not.
+func RunWorkers(ctx context.Context, n, workers int, fn func(i int)) (started int) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(workers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+feed:
+	for ; started < n; started++ {
+		select {
+		case jobs <- started:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return started
+}
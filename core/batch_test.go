@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewBatchID(t *testing.T) {
+	a, err := NewBatchID()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := NewBatchID()
+	if len(a) != 16 || a == b {
+		t.Errorf("expected distinct 16-character IDs, got %q and %q", a, b)
+	}
+}
+
+func TestRunWorkers(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	var running, peak atomic.Int32
+	started := RunWorkers(context.Background(), 20, 3, func(i int) {
+		if n := running.Add(1); n > peak.Load() {
+			peak.Store(n)
+		}
+		mu.Lock()
+		seen[i] = true
+		mu.Unlock()
+		running.Add(-1)
+	})
+	if started != 20 || len(seen) != 20 {
+		t.Errorf("expected all 20 items run, started %d, saw %d", started, len(seen))
+	}
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent calls, got %d", peak.Load())
+	}
+}
+
+func TestRunWorkers_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	started := RunWorkers(ctx, 10, 1, func(i int) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+	})
+	if started >= 10 || int(calls.Load()) != started {
+		t.Errorf("expected the run to stop early with every started call run, started %d, calls %d", started, calls.Load())
+	}
+}
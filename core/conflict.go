@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// defaultConflictAttempts bounds UpdateOnConflict when MaxAttempts is unset.
+const defaultConflictAttempts = 3
+
+// ErrConflict matches, with errors.Is, API errors reporting that an update
+// was based on a stale copy of the resource (409 Conflict or 412
+// Precondition Failed), typically because someone else saved it since it
+// was read.
+var ErrConflict = errors.New("shopline: update conflicted with a concurrent change")
+
+// ConflictPolicy tells UpdateOnConflict how to recover from a conflict.
+type ConflictPolicy[T any] struct {
+	// Refetch reads the resource's current state.
+	Refetch func(ctx context.Context) (*T, error)
+
+	// Merge reapplies the caller's change to current and returns the value
+	// to send next. Keep current's UpdatedAt so the retry is not rejected
+	// as stale again. Returning an error stops the retries with it.
+	Merge func(current *T, desired T) (T, error)
+
+	// MaxAttempts bounds the number of updates sent (default 3).
+	MaxAttempts int
+}
+
+// UpdateOnConflict calls update with desired and, when it fails with
+// ErrConflict, refetches the resource, merges desired into it and tries
+// again, so concurrent admin edits do not fail a sync job:
+//
+//	p, err := core.UpdateOnConflict(ctx, desired, client.Product.Update, core.ConflictPolicy[product.Product]{
+//	    Refetch: func(ctx context.Context) (*product.Product, error) { return client.Product.Get(ctx, desired.ID) },
+//	    Merge: func(cur *product.Product, want product.Product) (product.Product, error) {
+//	        merged := *cur
+//	        merged.Tags = want.Tags
+//	        return merged, nil
+//	    },
+//	})
+//
+// Other errors are returned at once, and so is a conflict when Refetch or
+// Merge is nil. When every attempt conflicts, the error wraps the last
+// conflict.
+func UpdateOnConflict[T any](ctx context.Context, desired T, update func(ctx context.Context, v T) (*T, error), p ConflictPolicy[T]) (*T, error) {
+	attempts := p.MaxAttempts
+	if attempts <= 0 {
+		attempts = defaultConflictAttempts
+	}
+	next := desired
+	for attempt := 1; ; attempt++ {
+		v, err := update(ctx, next)
+		if err == nil || !errors.Is(err, ErrConflict) {
+			return v, err
+		}
+		if attempt == attempts {
+			return nil, fmt.Errorf("core: update still conflicted after %d attempts: %w", attempts, err)
+		}
+		if p.Refetch == nil || p.Merge == nil {
+			return nil, err
+		}
+		current, ferr := p.Refetch(ctx)
+		if ferr != nil {
+			return nil, fmt.Errorf("core: failed to refetch after conflict: %w", ferr)
+		}
+		if next, err = p.Merge(current, desired); err != nil {
+			return nil, err
+		}
+	}
+}
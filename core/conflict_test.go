@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type conflictDoc struct {
+	Version int
+	Title   string
+	Tags    string
+}
+
+func TestUpdateOnConflict(t *testing.T) {
+	stored := conflictDoc{Version: 2, Title: "edited by admin"}
+	var sent []conflictDoc
+	update := func(_ context.Context, d conflictDoc) (*conflictDoc, error) {
+		sent = append(sent, d)
+		if d.Version != stored.Version {
+			return nil, fmt.Errorf("shopline: 409: %w", ErrConflict)
+		}
+		stored = d
+		stored.Version++
+		return &stored, nil
+	}
+	policy := ConflictPolicy[conflictDoc]{
+		Refetch: func(context.Context) (*conflictDoc, error) { cur := stored; return &cur, nil },
+		Merge: func(cur *conflictDoc, want conflictDoc) (conflictDoc, error) {
+			merged := *cur
+			merged.Tags = want.Tags
+			return merged, nil
+		},
+	}
+
+	got, err := UpdateOnConflict(context.Background(), conflictDoc{Version: 1, Tags: "synced"}, update, policy)
+	if err != nil {
+		t.Fatalf("UpdateOnConflict: %v", err)
+	}
+	if got.Title != "edited by admin" || got.Tags != "synced" || got.Version != 3 {
+		t.Errorf("got %+v", got)
+	}
+	if len(sent) != 2 {
+		t.Errorf("sent %d updates, want 2", len(sent))
+	}
+}
+
+func TestUpdateOnConflict_GivesUp(t *testing.T) {
+	calls := 0
+	update := func(context.Context, conflictDoc) (*conflictDoc, error) {
+		calls++
+		return nil, ErrConflict
+	}
+	policy := ConflictPolicy[conflictDoc]{
+		Refetch:     func(context.Context) (*conflictDoc, error) { return &conflictDoc{}, nil },
+		Merge:       func(cur *conflictDoc, _ conflictDoc) (conflictDoc, error) { return *cur, nil },
+		MaxAttempts: 2,
+	}
+	_, err := UpdateOnConflict(context.Background(), conflictDoc{}, update, policy)
+	if !errors.Is(err, ErrConflict) || calls != 2 {
+		t.Errorf("err = %v after %d calls, want ErrConflict after 2", err, calls)
+	}
+
+	other := errors.New("boom")
+	calls = 0
+	_, err = UpdateOnConflict(context.Background(), conflictDoc{}, func(context.Context, conflictDoc) (*conflictDoc, error) {
+		calls++
+		return nil, other
+	}, policy)
+	if err != other || calls != 1 {
+		t.Errorf("err = %v after %d calls, want the error unchanged after 1", err, calls)
+	}
+}
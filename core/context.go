@@ -0,0 +1,45 @@
+package core
+
+import "context"
+
+// contextKey is an unexported type for context keys defined in this package,
+// preventing collisions with keys from other packages.
+type contextKey int
+
+const (
+	noRetryKey contextKey = iota
+	fieldsKey
+	paginationKey
+	pageInfoKey
+	idempotencyKey
+)
+
+// WithNoRetry returns a context that disables automatic retries for requests
+// made with it, regardless of the client's WithRetry setting.
+//
+// Use it for latency-sensitive calls (e.g. interactive UI endpoints) where
+// failing fast is better than waiting out a backoff:
+//
+//	p, err := client.Product.Get(core.WithNoRetry(ctx), id)
+func WithNoRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey, true)
+}
+
+// NoRetry reports whether retries were disabled on ctx with WithNoRetry.
+func NoRetry(ctx context.Context) bool {
+	v, _ := ctx.Value(noRetryKey).(bool)
+	return v
+}
+
+// WithIdempotencyKey returns a context whose requests carry key in the
+// Idempotency-Key header, so the client may retry a POST made with it without
+// risking a duplicate. Use a fresh key per logical write.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey, key)
+}
+
+// IdempotencyKey returns the key set on ctx with WithIdempotencyKey, or "".
+func IdempotencyKey(ctx context.Context) string {
+	v, _ := ctx.Value(idempotencyKey).(string)
+	return v
+}
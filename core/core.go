@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"net/url"
 	"time"
 )
 
@@ -16,10 +17,27 @@ type Requester interface {
 	CreatePath(resource string) string
 }
 
+// QueryEncoder is implemented by option field types that encode themselves
+// into the query string instead of using the default `url` tag rules. The
+// signature matches github.com/google/go-querystring's Encoder, so types
+// written for it work unchanged.
+type QueryEncoder interface {
+	EncodeValues(key string, v *url.Values) error
+}
+
 // =====================================================================
 // 共享类型 — 多个子包引用的通用模型
 // =====================================================================
 
+// RateLimit holds the rate limit state reported by the API on a response.
+// Zero values mean the header was absent.
+type RateLimit struct {
+	Limit     int           // requests allowed in the current window
+	Remaining int           // requests left in the current window
+	Reset     time.Duration // time until the window resets
+	Bucket    string        // rate limit bucket the request was counted against
+}
+
 // ListOptions specifies the optional parameters to various List methods.
 type ListOptions struct {
 	Page         int    `url:"page,omitempty"`
@@ -30,8 +48,25 @@ type ListOptions struct {
 	UpdatedAtMin string `url:"updated_at_min,omitempty"`
 	UpdatedAtMax string `url:"updated_at_max,omitempty"`
 	Fields       string `url:"fields,omitempty"`
+
+	// SortBy and Order request server-side sorting, e.g. SortBy "updated_at"
+	// with Order SortAsc. A fixed order is needed for reliable incremental sync.
+	SortBy string        `url:"sort_by,omitempty"`
+	Order  SortDirection `url:"order,omitempty"`
+
+	// PageInfo is a cursor from Pagination for endpoints with cursor-based
+	// pagination. Only Limit and Fields may be combined with it.
+	PageInfo string `url:"page_info,omitempty"`
 }
 
+// SortDirection is the direction of ListOptions.Order.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
 // CountOptions specifies the optional parameters for Count methods.
 type CountOptions struct {
 	CreatedAtMin string `url:"created_at_min,omitempty"`
@@ -0,0 +1,109 @@
+package core
+
+import "time"
+
+// now is a function variable for testing.
+var now = time.Now
+
+// =====================================================================
+// Date Ranges
+// =====================================================================
+
+// DateRange is an inclusive time range expressed in a shop's timezone.
+//
+// Reports such as "orders today" must use the shop's day boundaries, not the
+// server's. Build ranges from the shop's IANA timezone (store.Info.Location)
+// and apply them to List/Count options:
+//
+//	loc, _ := info.Location()
+//	opts := &order.ListOptions{}
+//	core.LastDays(loc, 7).SetCreated(&opts.ListOptions)
+type DateRange struct {
+	Min time.Time
+	Max time.Time
+}
+
+// startOfDay returns midnight of t's day in loc.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// dayRange covers whole days from start up to (not including) end.
+func dayRange(start, end time.Time) DateRange {
+	return DateRange{Min: start, Max: end.Add(-time.Second)}
+}
+
+// Today covers the current calendar day in loc. A nil loc means UTC.
+func Today(loc *time.Location) DateRange {
+	loc = orUTC(loc)
+	start := startOfDay(now(), loc)
+	return dayRange(start, start.AddDate(0, 0, 1))
+}
+
+// Yesterday covers the previous calendar day in loc.
+func Yesterday(loc *time.Location) DateRange {
+	loc = orUTC(loc)
+	start := startOfDay(now(), loc)
+	return dayRange(start.AddDate(0, 0, -1), start)
+}
+
+// LastDays covers the last n calendar days in loc, including today.
+// LastDays(loc, 7) is "last 7 days".
+func LastDays(loc *time.Location, n int) DateRange {
+	loc = orUTC(loc)
+	if n < 1 {
+		n = 1
+	}
+	end := startOfDay(now(), loc).AddDate(0, 0, 1)
+	return dayRange(end.AddDate(0, 0, -n), end)
+}
+
+// ThisMonth covers the current calendar month in loc.
+func ThisMonth(loc *time.Location) DateRange {
+	loc = orUTC(loc)
+	t := now().In(loc)
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	return dayRange(start, start.AddDate(0, 1, 0))
+}
+
+// LastMonth covers the previous calendar month in loc.
+func LastMonth(loc *time.Location) DateRange {
+	loc = orUTC(loc)
+	t := now().In(loc)
+	end := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	return dayRange(end.AddDate(0, -1, 0), end)
+}
+
+func orUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Format returns Min and Max as RFC 3339 strings with the shop's UTC offset,
+// the format expected by *_at_min / *_at_max query parameters.
+func (r DateRange) Format() (min, max string) {
+	return r.Min.Format(time.RFC3339), r.Max.Format(time.RFC3339)
+}
+
+// SetCreated sets CreatedAtMin/CreatedAtMax on o.
+func (r DateRange) SetCreated(o *ListOptions) {
+	o.CreatedAtMin, o.CreatedAtMax = r.Format()
+}
+
+// SetUpdated sets UpdatedAtMin/UpdatedAtMax on o.
+func (r DateRange) SetUpdated(o *ListOptions) {
+	o.UpdatedAtMin, o.UpdatedAtMax = r.Format()
+}
+
+// SetCreatedCount sets CreatedAtMin/CreatedAtMax on o.
+func (r DateRange) SetCreatedCount(o *CountOptions) {
+	o.CreatedAtMin, o.CreatedAtMax = r.Format()
+}
+
+// SetUpdatedCount sets UpdatedAtMin/UpdatedAtMax on o.
+func (r DateRange) SetUpdatedCount(o *CountOptions) {
+	o.UpdatedAtMin, o.UpdatedAtMax = r.Format()
+}
@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRanges_ShopTimezone(t *testing.T) {
+	// 2026-03-01 01:30 in Shanghai is still 2026-02-28 in UTC.
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	old := now
+	now = func() time.Time { return time.Date(2026, 2, 28, 17, 30, 0, 0, time.UTC) }
+	defer func() { now = old }()
+
+	tests := []struct {
+		name     string
+		r        DateRange
+		min, max string
+	}{
+		{"today", Today(loc), "2026-03-01T00:00:00+08:00", "2026-03-01T23:59:59+08:00"},
+		{"yesterday", Yesterday(loc), "2026-02-28T00:00:00+08:00", "2026-02-28T23:59:59+08:00"},
+		{"last 7 days", LastDays(loc, 7), "2026-02-23T00:00:00+08:00", "2026-03-01T23:59:59+08:00"},
+		{"this month", ThisMonth(loc), "2026-03-01T00:00:00+08:00", "2026-03-31T23:59:59+08:00"},
+		{"last month", LastMonth(loc), "2026-02-01T00:00:00+08:00", "2026-02-28T23:59:59+08:00"},
+	}
+	for _, tt := range tests {
+		min, max := tt.r.Format()
+		if min != tt.min || max != tt.max {
+			t.Errorf("%s: got [%s, %s], want [%s, %s]", tt.name, min, max, tt.min, tt.max)
+		}
+	}
+
+	var opts ListOptions
+	Today(loc).SetCreated(&opts)
+	if opts.CreatedAtMin != "2026-03-01T00:00:00+08:00" {
+		t.Errorf("SetCreated: unexpected CreatedAtMin %q", opts.CreatedAtMin)
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is reported by Get methods when the requested resource does
+// not exist, whether the API answered 404 or the response carried no
+// resource. Check it with errors.Is; a 404 keeps its trace ID and other
+// details in the underlying error:
+//
+//	p, err := client.Product.Get(ctx, id)
+//	if errors.Is(err, core.ErrNotFound) {
+//	    // deleted or never existed
+//	}
+var ErrNotFound = errors.New("shopline: resource not found")
+
+// Found normalizes the result of a single-resource Get so that a missing
+// resource is always (nil, ErrNotFound) and never a nil or empty value with
+// a nil error.
+func Found[T any](v *T, err error) (*T, error) {
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+// IsRetryable reports whether a request that failed with err may succeed
+// when repeated. Errors that do not say, such as transport errors, are
+// retryable; context cancellation and deadlines are not, as the caller has
+// given up.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var r interface{ IsRetryable() bool }
+	if errors.As(err, &r) {
+		return r.IsRetryable()
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type retryableError bool
+
+func (e retryableError) Error() string     { return "status error" }
+func (e retryableError) IsRetryable() bool { return bool(e) }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("connection reset"), true},
+		{fmt.Errorf("wrapped: %w", retryableError(true)), true},
+		{fmt.Errorf("wrapped: %w", retryableError(false)), false},
+		{context.Canceled, false},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// =====================================================================
+// Partial Field Selection
+// =====================================================================
+
+// WithFields returns a context that asks for only the given top-level fields
+// in the responses of GET requests made with it, including single-resource
+// Gets that have no options of their own:
+//
+//	p, err := client.Product.Get(core.WithFields(ctx, "id", "title", "variants"), id)
+//
+// An explicit fields query parameter (e.g. ListOptions.Fields) takes
+// precedence. The client checks the names against the JSON tags of the
+// resource each response decodes into, and fails the request on an unknown
+// one; see ValidateResultFields.
+//
+// Helpers that make several requests and need whole objects, such as
+// order.Service.RefundAll, ignore the selection.
+func WithFields(ctx context.Context, fields ...string) context.Context {
+	return context.WithValue(ctx, fieldsKey, append([]string(nil), fields...))
+}
+
+// WithoutFields returns a context that drops any selection made with
+// WithFields, for requests whose full responses the caller relies on.
+func WithoutFields(ctx context.Context) context.Context {
+	if SelectedFields(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, fieldsKey, []string(nil))
+}
+
+// SelectedFields returns the fields set on ctx with WithFields, or nil.
+func SelectedFields(ctx context.Context) []string {
+	v, _ := ctx.Value(fieldsKey).([]string)
+	return v
+}
+
+// ValidateFields checks that every name is the JSON name of a top-level
+// field of T, so a typo does not silently return objects with the field
+// missing. T may be a struct or a pointer to one.
+//
+//	err := core.ValidateFields[product.Product]("id", "title", "variants")
+func ValidateFields[T any](fields ...string) error {
+	known := jsonFieldNames(reflect.TypeOf((*T)(nil)).Elem())
+	var unknown []string
+	for _, f := range fields {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("core: unknown fields for %s: %s", reflect.TypeOf((*T)(nil)).Elem(), strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// SelectFields validates fields against T and joins them for
+// ListOptions.Fields:
+//
+//	opts.Fields, err = core.SelectFields[product.Product]("id", "updated_at")
+func SelectFields[T any](fields ...string) (string, error) {
+	if err := ValidateFields[T](fields...); err != nil {
+		return "", err
+	}
+	return strings.Join(fields, ","), nil
+}
+
+// ValidateResultFields checks fields against the resource that result, the
+// response a request decodes into, wraps: the element of its single JSON
+// field, as in struct{ Product *Product `json:"product"` } or
+// struct{ Products []Product `json:"products"` }. Results of any other shape
+// are not checked.
+func ValidateResultFields(result interface{}, fields ...string) error {
+	t := resourceType(reflect.TypeOf(result))
+	if t == nil || len(fields) == 0 {
+		return nil
+	}
+	known := jsonFieldNames(t)
+	var unknown []string
+	for _, f := range fields {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("core: unknown fields for %s: %s", t, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// resourceType returns the struct type wrapped by the response type t, or
+// nil if t is not a wrapper of one.
+func resourceType(t reflect.Type) reflect.Type {
+	t = indirect(t)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var field *reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" {
+			continue
+		}
+		if field != nil {
+			return nil
+		}
+		field = &f
+	}
+	if field == nil {
+		return nil
+	}
+	elem := indirect(field.Type)
+	if elem != nil && elem.Kind() == reflect.Slice {
+		elem = indirect(elem.Elem())
+	}
+	if elem == nil || elem.Kind() != reflect.Struct {
+		return nil
+	}
+	return elem
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// jsonFieldNames returns the JSON names of t's fields, including those
+// promoted from embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := make(map[string]bool)
+	if t.Kind() != reflect.Struct {
+		return names
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			for n := range jsonFieldNames(f.Type) {
+				names[n] = true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type fieldsModel struct {
+	ListOptions
+	ID       int64  `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Secret   string `json:"-"`
+	Untagged string
+	hidden   string
+}
+
+func TestValidateFields(t *testing.T) {
+	if err := ValidateFields[fieldsModel]("id", "title", "Untagged"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateFields[*fieldsModel]("id"); err != nil {
+		t.Errorf("unexpected error for pointer type: %v", err)
+	}
+	err := ValidateFields[fieldsModel]("id", "titel", "Secret", "hidden")
+	if err == nil || !strings.Contains(err.Error(), "titel, Secret, hidden") {
+		t.Errorf("expected unknown fields error, got %v", err)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	got, err := SelectFields[Customer]("id", "email", "updated_at")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "id,email,updated_at" {
+		t.Errorf("expected joined fields, got %q", got)
+	}
+	if _, err := SelectFields[Customer]("emial"); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	ctx := context.Background()
+	if SelectedFields(ctx) != nil {
+		t.Error("expected no fields on a plain context")
+	}
+	fields := []string{"id", "title"}
+	ctx = WithFields(ctx, fields...)
+	fields[0] = "changed"
+	if got := SelectedFields(ctx); len(got) != 2 || got[0] != "id" {
+		t.Errorf("unexpected fields %v", got)
+	}
+}
+
+func TestWithoutFields(t *testing.T) {
+	ctx := WithoutFields(WithFields(context.Background(), "id"))
+	if got := SelectedFields(ctx); got != nil {
+		t.Errorf("expected selection dropped, got %v", got)
+	}
+}
+
+func TestValidateResultFields(t *testing.T) {
+	var one struct {
+		Model *fieldsModel `json:"model"`
+	}
+	var many struct {
+		Models []fieldsModel `json:"models"`
+	}
+	if err := ValidateResultFields(&one, "id", "title"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateResultFields(&many, "titel"); err == nil || !strings.Contains(err.Error(), "titel") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+	var raw map[string]interface{}
+	if err := ValidateResultFields(&raw, "anything"); err != nil {
+		t.Errorf("expected unchecked result for a map, got %v", err)
+	}
+}
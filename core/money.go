@@ -0,0 +1,411 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// =====================================================================
+// Decimal
+// =====================================================================
+
+// Decimal is an exact fixed-point decimal number, used for monetary amounts.
+//
+// The API sends amounts as strings ("199.00"); Decimal marshals back to the
+// same representation and also accepts bare JSON numbers when decoding.
+// The zero value is 0.
+type Decimal struct {
+	units int64 // value = units / 10^scale
+	scale int32
+}
+
+// maxDecimalScale bounds the number of fractional digits accepted.
+const maxDecimalScale = 9
+
+// maxDecimalExponent is the largest power of ten that fits in an int64, and
+// so the largest positive exponent a non-zero decimal can be scaled by.
+const maxDecimalExponent = 18
+
+// ErrDecimalOverflow is returned when a decimal value or the result of
+// decimal arithmetic does not fit in 64 bits at its scale.
+var ErrDecimalOverflow = errors.New("core: decimal overflow")
+
+// NewDecimal returns units × 10^-scale, e.g. NewDecimal(19900, 2) is 199.00.
+func NewDecimal(units int64, scale int32) Decimal {
+	return Decimal{units: units, scale: scale}
+}
+
+// ParseDecimal parses a decimal string such as "199.00", "-3.5", "12" or,
+// as JSON numbers may be written, "1.5e2". An empty string parses as zero.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, nil
+	}
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[i+1:], 10, 32); err != nil {
+			return Decimal{}, fmt.Errorf("core: invalid decimal %q", s)
+		}
+		mantissa = s[:i]
+	}
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+	if strings.ContainsAny(fracPart, "+-") || (intPart == "" && fracPart == "") {
+		return Decimal{}, fmt.Errorf("core: invalid decimal %q", s)
+	}
+	if intPart == "" || intPart == "-" || intPart == "+" {
+		intPart += "0"
+	}
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Decimal{}, fmt.Errorf("core: invalid decimal %q: %w", s, ErrDecimalOverflow)
+	}
+	if err != nil {
+		return Decimal{}, fmt.Errorf("core: invalid decimal %q: %w", s, err)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("core: invalid decimal %q: more than %d fractional digits", s, maxDecimalScale)
+	}
+	d := Decimal{units: units}
+	if scale < 0 {
+		if units == 0 {
+			return Decimal{}, nil
+		}
+		if -scale > maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("core: invalid decimal %q: %w", s, ErrDecimalOverflow)
+		}
+		if d, err = d.rescale(int32(-scale)); err != nil {
+			return Decimal{}, fmt.Errorf("core: invalid decimal %q: %w", s, err)
+		}
+		d.scale = 0
+		return d, nil
+	}
+	d.scale = int32(scale)
+	return d, nil
+}
+
+// MustParseDecimal is like ParseDecimal but panics on error.
+// Intended for constants and tests.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// rescale returns d expressed with the given (larger or equal) scale.
+// It fails with ErrDecimalOverflow if the units no longer fit.
+func (d Decimal) rescale(scale int32) (Decimal, error) {
+	if d.units == 0 {
+		if d.scale < scale {
+			d.scale = scale
+		}
+		return d, nil
+	}
+	for d.scale < scale {
+		if d.units > math.MaxInt64/10 || d.units < math.MinInt64/10 {
+			return Decimal{}, ErrDecimalOverflow
+		}
+		d.units *= 10
+		d.scale++
+	}
+	return d, nil
+}
+
+// align returns a and b expressed with the same scale.
+func align(a, b Decimal) (Decimal, Decimal, error) {
+	var err error
+	if a.scale < b.scale {
+		a, err = a.rescale(b.scale)
+	} else {
+		b, err = b.rescale(a.scale)
+	}
+	return a, b, err
+}
+
+// Add returns d + o, or ErrDecimalOverflow if the sum does not fit.
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	a, b, err := align(d, o)
+	if err != nil {
+		return Decimal{}, err
+	}
+	sum := a.units + b.units
+	if (b.units > 0 && sum < a.units) || (b.units < 0 && sum > a.units) {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: sum, scale: a.scale}, nil
+}
+
+// Sub returns d - o, or ErrDecimalOverflow if the difference does not fit.
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	if o.units == math.MinInt64 {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return d.Add(o.Neg())
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: -d.units, scale: d.scale}
+}
+
+// Mul returns d × n (e.g. unit price × quantity), or ErrDecimalOverflow if
+// the product does not fit.
+func (d Decimal) Mul(n int64) (Decimal, error) {
+	if d.units == 0 || n == 0 {
+		return Decimal{scale: d.scale}, nil
+	}
+	units := d.units * n
+	if units/n != d.units || (d.units == -1 && n == math.MinInt64) || (n == -1 && d.units == math.MinInt64) {
+		return Decimal{}, ErrDecimalOverflow
+	}
+	return Decimal{units: units, scale: d.scale}, nil
+}
+
+// Cmp compares d and o and returns -1, 0 or +1.
+func (d Decimal) Cmp(o Decimal) int {
+	if a, b, err := align(d, o); err == nil {
+		switch {
+		case a.units < b.units:
+			return -1
+		case a.units > b.units:
+			return 1
+		}
+		return 0
+	}
+	return d.rat().Cmp(o.rat())
+}
+
+// rat returns d as an exact rational, for comparisons that overflow int64.
+func (d Decimal) rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(d.units), denom)
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	switch {
+	case d.units < 0:
+		return -1
+	case d.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether d is zero.
+func (d Decimal) IsZero() bool {
+	return d.units == 0
+}
+
+// StringFixed formats d with exactly places fractional digits, rounding
+// half away from zero when digits are dropped.
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		s := d.String()
+		if d.scale == 0 && places > 0 {
+			s += "."
+		}
+		return s + strings.Repeat("0", int(places-d.scale))
+	}
+	units := d.units
+	for s := d.scale; s > places+1; s-- {
+		units /= 10
+	}
+	last := units % 10
+	units /= 10
+	if last >= 5 {
+		units++
+	} else if last <= -5 {
+		units--
+	}
+	return Decimal{units: units, scale: places}.String()
+}
+
+// Float64 returns the nearest float64. Use only for display or statistics.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats d keeping its scale, e.g. "199.00".
+func (d Decimal) String() string {
+	neg := d.units < 0
+	u := d.units
+	if neg {
+		u = -u
+	}
+	digits := strconv.FormatInt(u, 10)
+	if d.scale > 0 {
+		if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		cut := len(digits) - int(d.scale)
+		digits = digits[:cut] + "." + digits[cut:]
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON encodes d as a JSON string, matching the API representation.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts a JSON string ("199.00"), a JSON number, including
+// exponent notation such as 1.5e2, or null.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if bytes.Equal(b, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	s := string(b)
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+	}
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// =====================================================================
+// Money
+// =====================================================================
+
+// Currencies whose minor unit is not a hundredth (ISO 4217).
+var (
+	zeroDecimalCurrencies = map[string]bool{
+		"BIF": true, "CLP": true, "DJF": true, "GNF": true, "ISK": true,
+		"JPY": true, "KMF": true, "KRW": true, "PYG": true, "RWF": true,
+		"UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+	}
+	threeDecimalCurrencies = map[string]bool{
+		"BHD": true, "IQD": true, "JOD": true, "KWD": true, "LYD": true, "OMR": true, "TND": true,
+	}
+)
+
+// CurrencyDecimals returns the number of fractional digits amounts in the
+// currency are given with: 0 for currencies without a minor unit such as
+// JPY and KRW, 3 for BHD, KWD and the like, and 2 otherwise.
+func CurrencyDecimals(currency string) int32 {
+	switch currency = strings.ToUpper(currency); {
+	case zeroDecimalCurrencies[currency]:
+		return 0
+	case threeDecimalCurrencies[currency]:
+		return 3
+	}
+	return 2
+}
+
+// Money is an amount in a specific currency.
+//
+// The API carries the currency in a separate field, so Money marshals to and
+// from the bare amount string; Currency must be filled in by the caller (the
+// Money accessors on Order, Refund and Transaction do this).
+type Money struct {
+	Amount   Decimal
+	Currency string
+}
+
+// NewMoney parses amount and pairs it with currency.
+func NewMoney(amount, currency string) (Money, error) {
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: d, Currency: currency}, nil
+}
+
+// checkCurrency returns an error if m and o are in different currencies.
+// An empty currency is treated as compatible with any currency.
+func (m Money) checkCurrency(o Money) (string, error) {
+	switch {
+	case m.Currency == "":
+		return o.Currency, nil
+	case o.Currency == "" || strings.EqualFold(m.Currency, o.Currency):
+		return m.Currency, nil
+	}
+	return "", fmt.Errorf("core: currency mismatch: %s vs %s", m.Currency, o.Currency)
+}
+
+// Add returns m + o. It fails if the currencies differ or the sum
+// overflows.
+func (m Money) Add(o Money) (Money, error) {
+	cur, err := m.checkCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	sum, err := m.Amount.Add(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: sum, Currency: cur}, nil
+}
+
+// Sub returns m - o. It fails if the currencies differ or the difference
+// overflows.
+func (m Money) Sub(o Money) (Money, error) {
+	cur, err := m.checkCurrency(o)
+	if err != nil {
+		return Money{}, err
+	}
+	diff, err := m.Amount.Sub(o.Amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: diff, Currency: cur}, nil
+}
+
+// Cmp compares m and o. It fails if the currencies differ.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	return m.Amount.Cmp(o.Amount), nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String formats m as "199.00 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+	return m.Amount.String() + " " + m.Currency
+}
+
+// MarshalJSON encodes the amount only, matching the API representation.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return m.Amount.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the amount; Currency is left unchanged.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	return m.Amount.UnmarshalJSON(b)
+}
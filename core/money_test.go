@@ -0,0 +1,134 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{"199.00", "199.00", false},
+		{"-3.5", "-3.5", false},
+		{"12", "12", false},
+		{".5", "0.5", false},
+		{"0.05", "0.05", false},
+		{"", "0", false},
+		{"abc", "", true},
+		{"1.2.3", "", true},
+		{"1e5", "100000", false},
+		{"1.25E-1", "0.125", false},
+		{"-2.50e+1", "-25.0", false},
+		{"1e-10", "", true},
+		{"1e", "", true},
+		{"e5", "", true},
+		{"99999999999999999999", "", true},
+		{"1e19", "", true},
+		{"0e2000000000", "0", false},
+		{"5e2000000000", "", true},
+		{"1e18", "1000000000000000000", false},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseDecimal(%q) error = %v, wantErr %v", tt.in, err, tt.err)
+			continue
+		}
+		if !tt.err && d.String() != tt.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", tt.in, d, tt.want)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("0.10")
+	b := MustParseDecimal("0.2")
+	if got, err := a.Add(b); err != nil || got.String() != "0.30" {
+		t.Errorf("0.10 + 0.2 = %s, %v, want 0.30", got, err)
+	}
+	if got, err := a.Sub(b); err != nil || got.String() != "-0.10" {
+		t.Errorf("0.10 - 0.2 = %s, %v, want -0.10", got, err)
+	}
+	if got, err := MustParseDecimal("19.99").Mul(3); err != nil || got.String() != "59.97" {
+		t.Errorf("19.99 * 3 = %s, %v, want 59.97", got, err)
+	}
+	if a.Cmp(b) != -1 || b.Cmp(a) != 1 || a.Cmp(MustParseDecimal("0.1")) != 0 {
+		t.Error("unexpected Cmp result")
+	}
+	if got := MustParseDecimal("1.235").StringFixed(2); got != "1.24" {
+		t.Errorf("StringFixed rounding = %s, want 1.24", got)
+	}
+	if got := MustParseDecimal("5").StringFixed(2); got != "5.00" {
+		t.Errorf("StringFixed padding = %s, want 5.00", got)
+	}
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	max := NewDecimal(math.MaxInt64, 0)
+	if _, err := max.Add(NewDecimal(1, 0)); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("MaxInt64 + 1: expected ErrDecimalOverflow, got %v", err)
+	}
+	if _, err := max.Neg().Sub(NewDecimal(2, 0)); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("-MaxInt64 - 2: expected ErrDecimalOverflow, got %v", err)
+	}
+	if _, err := max.Add(NewDecimal(1, 2)); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("rescaling MaxInt64: expected ErrDecimalOverflow, got %v", err)
+	}
+	if _, err := MustParseDecimal("4611686018427387904").Mul(2); !errors.Is(err, ErrDecimalOverflow) {
+		t.Errorf("2^62 * 2: expected ErrDecimalOverflow, got %v", err)
+	}
+	if got, err := NewDecimal(0, 0).Add(NewDecimal(0, 2000000000)); err != nil || !got.IsZero() {
+		t.Errorf("adding zeros at a huge scale: zero=%v, err=%v", got.IsZero(), err)
+	}
+	if max.Cmp(NewDecimal(1, 2)) != 1 || NewDecimal(1, 2).Cmp(max) != -1 {
+		t.Error("Cmp should not overflow when aligning scales")
+	}
+}
+
+func TestCurrencyDecimals(t *testing.T) {
+	for currency, want := range map[string]int32{"USD": 2, "jpy": 0, "KRW": 0, "KWD": 3, "": 2} {
+		if got := CurrencyDecimals(currency); got != want {
+			t.Errorf("CurrencyDecimals(%q) = %d, want %d", currency, got, want)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+		C Decimal `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"199.00","b":12.5,"c":null}`), &v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.A.String() != "199.00" || v.B.String() != "12.5" || !v.C.IsZero() {
+		t.Errorf("unexpected decode: %s %s %s", v.A, v.B, v.C)
+	}
+	out, _ := json.Marshal(v)
+	if string(out) != `{"a":"199.00","b":"12.5","c":"0"}` {
+		t.Errorf("unexpected encode: %s", out)
+	}
+
+	var n Decimal
+	if err := json.Unmarshal([]byte(`1.5e2`), &n); err != nil || n.String() != "150" {
+		t.Errorf("exponent number decoded as %s, %v; want 150", n, err)
+	}
+}
+
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	usd, _ := NewMoney("10.00", "USD")
+	eur, _ := NewMoney("5.00", "EUR")
+	if _, err := usd.Add(eur); err == nil {
+		t.Error("expected currency mismatch error")
+	}
+	sum, err := usd.Add(Money{Amount: MustParseDecimal("2.50")})
+	if err != nil || sum.String() != "12.50 USD" {
+		t.Errorf("expected 12.50 USD, got %s (%v)", sum, err)
+	}
+}
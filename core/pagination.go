@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// =====================================================================
+// Cursor Pagination
+// =====================================================================
+
+// Pagination holds the page_info cursors of a list response's Link header.
+// Cursors reach beyond the depth limit of page-number pagination.
+type Pagination struct {
+	NextPageInfo     string
+	PreviousPageInfo string
+}
+
+// HasNext reports whether there is a next page.
+func (p *Pagination) HasNext() bool {
+	return p != nil && p.NextPageInfo != ""
+}
+
+// NextOptions returns the options for the next page, keeping limit and
+// fields from opts, which may be nil. It returns nil on the last page.
+func (p *Pagination) NextOptions(opts *ListOptions) *ListOptions {
+	if !p.HasNext() {
+		return nil
+	}
+	next := &ListOptions{PageInfo: p.NextPageInfo}
+	if opts != nil {
+		next.Limit, next.Fields = opts.Limit, opts.Fields
+	}
+	return next
+}
+
+// WithPagination returns a context that captures the pagination of list
+// requests made with it into the returned Pagination, which is overwritten
+// by each response:
+//
+//	ctx, page := core.WithPagination(ctx)
+//	opts := &order.ListOptions{ListOptions: core.ListOptions{Limit: 250}}
+//	for {
+//	    orders, err := client.Order.List(ctx, opts)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    process(orders)
+//	    next := page.NextOptions(&opts.ListOptions)
+//	    if next == nil {
+//	        break
+//	    }
+//	    opts = &order.ListOptions{ListOptions: *next}
+//	}
+func WithPagination(ctx context.Context) (context.Context, *Pagination) {
+	p := &Pagination{}
+	return context.WithValue(ctx, paginationKey, p), p
+}
+
+// PaginationFrom returns the Pagination set on ctx with WithPagination, or
+// nil.
+func PaginationFrom(ctx context.Context) *Pagination {
+	p, _ := ctx.Value(paginationKey).(*Pagination)
+	return p
+}
+
+// ParseLinkHeader extracts the page_info cursors from a Link header such as
+//
+//	<https://shop.myshopline.com/admin/openapi/v20251201/orders.json?limit=50&page_info=abc>; rel="next"
+func ParseLinkHeader(header string) Pagination {
+	var p Pagination
+	for _, link := range splitLinks(header) {
+		parts := strings.Split(link, ";")
+		target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		u, err := url.Parse(target)
+		if err != nil {
+			continue
+		}
+		pageInfo := u.Query().Get("page_info")
+		for _, param := range parts[1:] {
+			switch strings.ReplaceAll(strings.TrimSpace(param), " ", "") {
+			case `rel="next"`, "rel=next":
+				p.NextPageInfo = pageInfo
+			case `rel="previous"`, "rel=previous", `rel="prev"`, "rel=prev":
+				p.PreviousPageInfo = pageInfo
+			}
+		}
+	}
+	return p
+}
+
+// splitLinks splits a Link header into its links. Only commas outside the
+// <...> targets separate links: a target's query may contain unescaped
+// commas, as in fields=id,title.
+func splitLinks(header string) []string {
+	var links []string
+	start, inTarget := 0, false
+	for i := 0; i < len(header); i++ {
+		switch header[i] {
+		case '<':
+			inTarget = true
+		case '>':
+			inTarget = false
+		case ',':
+			if !inTarget {
+				links = append(links, header[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(links, header[start:])
+}
+
+// =====================================================================
+// Response Metadata
+// =====================================================================
+
+// PageInfo is the metadata of a list response that the []T returned by List
+// methods leaves out: pagination cursors, the trace ID for support requests
+// and the rate limit state after the call.
+type PageInfo struct {
+	Pagination
+	StatusCode int
+	TraceID    string
+	RateLimit  RateLimit
+}
+
+// WithPageInfo returns a context that captures the metadata of responses to
+// requests made with it into the returned PageInfo, which is overwritten by
+// each response, including failed ones. See ListWithInfo for the common case.
+func WithPageInfo(ctx context.Context) (context.Context, *PageInfo) {
+	info := &PageInfo{}
+	return context.WithValue(ctx, pageInfoKey, info), info
+}
+
+// PageInfoFrom returns the PageInfo set on ctx with WithPageInfo, or nil.
+func PageInfoFrom(ctx context.Context) *PageInfo {
+	info, _ := ctx.Value(pageInfoKey).(*PageInfo)
+	return info
+}
+
+// ListWithInfo calls a List method and returns the response metadata along
+// with the items:
+//
+//	orders, info, err := core.ListWithInfo(ctx, func(ctx context.Context) ([]order.Order, error) {
+//	    return client.Order.List(ctx, opts)
+//	})
+//	log.Printf("trace %s, %d requests left", info.TraceID, info.RateLimit.Remaining)
+//	next := info.NextOptions(&opts.ListOptions)
+//
+// The PageInfo is non-nil even when err is not, so the trace ID of a failed
+// call is available.
+func ListWithInfo[T any](ctx context.Context, list func(ctx context.Context) ([]T, error)) ([]T, *PageInfo, error) {
+	ctx, info := WithPageInfo(ctx)
+	items, err := list(ctx)
+	return items, info, err
+}
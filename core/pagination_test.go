@@ -0,0 +1,48 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://s.myshopline.com/admin/openapi/v1/orders.json?limit=2&page_info=prev1>; rel="previous", ` +
+		`<https://s.myshopline.com/admin/openapi/v1/orders.json?limit=2&page_info=next1>; rel="next"`
+	p := ParseLinkHeader(header)
+	if p.NextPageInfo != "next1" || p.PreviousPageInfo != "prev1" {
+		t.Errorf("unexpected pagination: %+v", p)
+	}
+	header = `<https://s.myshopline.com/admin/openapi/v1/orders.json?fields=id,title&page_info=next2&limit=2>; rel="next"`
+	if p := ParseLinkHeader(header); p.NextPageInfo != "next2" {
+		t.Errorf("expected the cursor after a comma in the query, got %+v", p)
+	}
+	if empty := ParseLinkHeader(""); empty.HasNext() {
+		t.Errorf("expected no next page, got %+v", empty)
+	}
+}
+
+func TestPagination_NextOptions(t *testing.T) {
+	ctx, p := WithPagination(context.Background())
+	if PaginationFrom(ctx) != p {
+		t.Fatal("expected PaginationFrom to return the captured Pagination")
+	}
+	if p.NextOptions(nil) != nil {
+		t.Error("expected nil options on the last page")
+	}
+	p.NextPageInfo = "abc"
+	next := p.NextOptions(&ListOptions{Limit: 50, Fields: "id", SinceID: 9})
+	if next.PageInfo != "abc" || next.Limit != 50 || next.Fields != "id" || next.SinceID != 0 {
+		t.Errorf("unexpected next options: %+v", next)
+	}
+}
+
+func TestListWithInfo(t *testing.T) {
+	items, info, err := ListWithInfo(context.Background(), func(ctx context.Context) ([]int, error) {
+		// Stands in for the client filling the PageInfo from the response.
+		PageInfoFrom(ctx).TraceID = "trace-1"
+		return []int{1, 2}, nil
+	})
+	if err != nil || len(items) != 2 || info.TraceID != "trace-1" {
+		t.Errorf("unexpected result: %v, %+v, %v", items, info, err)
+	}
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Resource describes a REST resource with the standard endpoint layout:
+//
+//	GET    {Path}.json          list, wrapped in ListKey
+//	GET    {Path}/count.json    count
+//	GET    {Path}/{id}.json     get, wrapped in Key
+//	POST   {Path}.json          create
+//	PUT    {Path}/{id}.json     update
+//	DELETE {Path}/{id}.json     delete
+type Resource[T any] struct {
+	Path    string         // e.g. "script_tags"
+	Key     string         // e.g. "script_tag"
+	ListKey string         // e.g. "script_tags"
+	ID      func(*T) int64 // ID used by Update; required only if Update is used
+}
+
+// ResourceService implements List/Get/Create/Update/Delete/Count for a
+// Resource. Its methods have the same signatures as the hand-written
+// services, so a package can return it directly from its constructor:
+//
+//	func NewScriptTagService(client core.Requester) ScriptTagService {
+//		return core.NewResourceService(client, core.Resource[ScriptTag]{
+//			Path: "script_tags", Key: "script_tag", ListKey: "script_tags",
+//		})
+//	}
+//
+// Resources with extra endpoints or non-standard paths keep their own
+// implementation.
+type ResourceService[T any] struct {
+	client   Requester
+	res      Resource[T]
+	single   reflect.Type // struct{ V *T `json:"<Key>"` }
+	multiple reflect.Type // struct{ V []T `json:"<ListKey>"` }
+}
+
+// NewResourceService creates a ResourceService for res.
+func NewResourceService[T any](client Requester, res Resource[T]) *ResourceService[T] {
+	return &ResourceService[T]{
+		client:   client,
+		res:      res,
+		single:   wrapperType(res.Key, reflect.TypeOf((*T)(nil))),
+		multiple: wrapperType(res.ListKey, reflect.TypeOf([]T(nil))),
+	}
+}
+
+// wrapperType builds the JSON envelope struct for key at runtime, so the
+// response is decoded into a real struct and strict decoding still sees
+// unknown fields of T.
+func wrapperType(key string, typ reflect.Type) reflect.Type {
+	return reflect.StructOf([]reflect.StructField{{
+		Name: "V",
+		Type: typ,
+		Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, key)),
+	}})
+}
+
+func (s *ResourceService[T]) List(ctx context.Context, opts *ListOptions) ([]T, error) {
+	r := reflect.New(s.multiple)
+	err := s.client.Get(ctx, s.client.CreatePath(s.res.Path+".json"), r.Interface(), opts)
+	return r.Elem().Field(0).Interface().([]T), err
+}
+
+func (s *ResourceService[T]) Get(ctx context.Context, id int64) (*T, error) {
+	r := reflect.New(s.single)
+	err := s.client.Get(ctx, s.itemPath(id), r.Interface(), nil)
+	return Found(r.Elem().Field(0).Interface().(*T), err)
+}
+
+func (s *ResourceService[T]) Create(ctx context.Context, v T) (*T, error) {
+	r := reflect.New(s.single)
+	err := s.client.Post(ctx, s.client.CreatePath(s.res.Path+".json"), s.body(&v), r.Interface())
+	return r.Elem().Field(0).Interface().(*T), err
+}
+
+func (s *ResourceService[T]) Update(ctx context.Context, v T) (*T, error) {
+	if s.res.ID == nil {
+		return nil, fmt.Errorf("core: %s does not support Update", s.res.Path)
+	}
+	r := reflect.New(s.single)
+	err := s.client.Put(ctx, s.itemPath(s.res.ID(&v)), s.body(&v), r.Interface())
+	return r.Elem().Field(0).Interface().(*T), err
+}
+
+func (s *ResourceService[T]) Delete(ctx context.Context, id int64) error {
+	return s.client.Delete(ctx, s.itemPath(id))
+}
+
+func (s *ResourceService[T]) Count(ctx context.Context) (int, error) {
+	r := &struct {
+		Count int `json:"count"`
+	}{}
+	err := s.client.Get(ctx, s.client.CreatePath(s.res.Path+"/count.json"), r, nil)
+	return r.Count, err
+}
+
+func (s *ResourceService[T]) itemPath(id int64) string {
+	return s.client.CreatePath(fmt.Sprintf("%s/%d.json", s.res.Path, id))
+}
+
+func (s *ResourceService[T]) body(v *T) interface{} {
+	b := reflect.New(s.single).Elem()
+	b.Field(0).Set(reflect.ValueOf(v))
+	return b.Interface()
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type widget struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// fakeRequester answers each call with the canned body for method+path and
+// records the last request body.
+type fakeRequester struct {
+	responses map[string]string
+	lastBody  string
+}
+
+func (f *fakeRequester) CreatePath(resource string) string { return "/api/" + resource }
+func (f *fakeRequester) Get(_ context.Context, path string, result, _ interface{}) error {
+	return f.do(http.MethodGet, path, nil, result)
+}
+func (f *fakeRequester) Post(_ context.Context, path string, body, result interface{}) error {
+	return f.do(http.MethodPost, path, body, result)
+}
+func (f *fakeRequester) Put(_ context.Context, path string, body, result interface{}) error {
+	return f.do(http.MethodPut, path, body, result)
+}
+func (f *fakeRequester) Delete(_ context.Context, path string) error {
+	return f.do(http.MethodDelete, path, nil, nil)
+}
+func (f *fakeRequester) do(method, path string, body, result interface{}) error {
+	if body != nil {
+		b, _ := json.Marshal(body)
+		f.lastBody = string(b)
+	}
+	resp, ok := f.responses[method+" "+path]
+	if !ok {
+		return ErrNotFound
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(strings.NewReader(resp)).Decode(result)
+}
+
+func TestResourceService(t *testing.T) {
+	f := &fakeRequester{responses: map[string]string{
+		"GET /api/widgets.json":       `{"widgets":[{"id":1,"name":"a"},{"id":2,"name":"b"}]}`,
+		"GET /api/widgets/count.json": `{"count":2}`,
+		"GET /api/widgets/1.json":     `{"widget":{"id":1,"name":"a"}}`,
+		"GET /api/widgets/3.json":     `{}`,
+		"PUT /api/widgets/1.json":     `{"widget":{"id":1,"name":"renamed"}}`,
+		"DELETE /api/widgets/1.json":  ``,
+	}}
+	svc := NewResourceService(f, Resource[widget]{
+		Path: "widgets", Key: "widget", ListKey: "widgets",
+		ID: func(w *widget) int64 { return w.ID },
+	})
+	ctx := context.Background()
+
+	list, err := svc.List(ctx, nil)
+	if err != nil || len(list) != 2 || list[1].Name != "b" {
+		t.Errorf("List: got %+v (%v)", list, err)
+	}
+	if n, err := svc.Count(ctx); err != nil || n != 2 {
+		t.Errorf("Count: got %d (%v)", n, err)
+	}
+	if w, err := svc.Get(ctx, 1); err != nil || w.Name != "a" {
+		t.Errorf("Get: got %+v (%v)", w, err)
+	}
+	if _, err := svc.Get(ctx, 3); err != ErrNotFound {
+		t.Errorf("Get of missing widget: expected ErrNotFound, got %v", err)
+	}
+	w, err := svc.Update(ctx, widget{ID: 1, Name: "renamed"})
+	if err != nil || w.Name != "renamed" {
+		t.Errorf("Update: got %+v (%v)", w, err)
+	}
+	if f.lastBody != `{"widget":{"id":1,"name":"renamed"}}` {
+		t.Errorf("Update: unexpected body %s", f.lastBody)
+	}
+	if err := svc.Delete(ctx, 1); err != nil {
+		t.Errorf("Delete: %v", err)
+	}
+
+	noID := NewResourceService(f, Resource[widget]{Path: "widgets", Key: "widget", ListKey: "widgets"})
+	if _, err := noID.Update(ctx, widget{ID: 1}); err == nil {
+		t.Error("expected Update without ID func to fail")
+	}
+}
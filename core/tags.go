@@ -0,0 +1,67 @@
+package core
+
+import "strings"
+
+// Tags is a list of resource tags.
+//
+// The API represents tags as a single comma-separated string
+// ("vip, wholesale"); Tags converts between that form and a slice.
+type Tags []string
+
+// ParseTags splits a comma-separated tag string, trimming whitespace and
+// dropping empty entries.
+func ParseTags(s string) Tags {
+	var tags Tags
+	for _, part := range strings.Split(s, ",") {
+		if tag := strings.TrimSpace(part); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// String joins the tags into the API's comma-separated form.
+func (t Tags) String() string {
+	return strings.Join(t, ", ")
+}
+
+// Contains reports whether tag is present.
+func (t Tags) Contains(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	for _, existing := range t {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns a new Tags with tags appended, skipping blanks and duplicates.
+// The original order is preserved.
+func (t Tags) Add(tags ...string) Tags {
+	out := make(Tags, 0, len(t)+len(tags))
+	for _, tag := range append(append([]string{}, t...), tags...) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !out.Contains(tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// Remove returns a new Tags without any of the given tags.
+func (t Tags) Remove(tags ...string) Tags {
+	drop := Tags(tags)
+	out := make(Tags, 0, len(t))
+	for _, tag := range t {
+		if !drop.Contains(tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// TagList returns the customer's tags parsed from the comma-separated Tags field.
+func (c *Customer) TagList() Tags {
+	return ParseTags(c.Tags)
+}
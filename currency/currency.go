@@ -0,0 +1,182 @@
+// Package currency converts amounts between a shop's enabled currencies
+// using the rates from store.Service.GetSettlementCurrency.
+//
+// A currency's RateToDefault is taken as the number of its units one unit of
+// the shop's default (primary) currency is worth, e.g. "0.92" for EUR in a
+// USD shop.
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/store"
+)
+
+const defaultTTL = time.Hour
+
+// ErrUnsupportedCurrency is returned when converting from or to a currency
+// the shop has not enabled.
+var ErrUnsupportedCurrency = errors.New("currency: currency not enabled for shop")
+
+// Source loads the shop's currencies. store.Service implements it.
+type Source interface {
+	GetSettlementCurrency(ctx context.Context) ([]store.Currency, error)
+}
+
+// Option configures a Converter.
+type Option func(*Converter)
+
+// WithTTL sets how long loaded rates are used before they are fetched again
+// (default 1h).
+func WithTTL(d time.Duration) Option {
+	return func(c *Converter) { c.ttl = d }
+}
+
+// Converter converts Money between the shop's enabled currencies. Rates are
+// loaded on first use and refreshed once they are older than the TTL. It is
+// safe for concurrent use.
+//
+//	conv := currency.NewConverter(client.Store)
+//	total, _ := o.TotalPriceMoney()
+//	eur, err := conv.Convert(ctx, total, "EUR")
+type Converter struct {
+	source Source
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	primary   string
+	rates     map[string]*big.Rat // units of the currency per unit of primary
+	fetchedAt time.Time
+}
+
+// NewConverter creates a Converter reading rates from source.
+func NewConverter(source Source, opts ...Option) *Converter {
+	c := &Converter{source: source, ttl: defaultTTL, now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Primary returns the shop's default currency.
+func (c *Converter) Primary(ctx context.Context) (string, error) {
+	primary, _, err := c.load(ctx)
+	return primary, err
+}
+
+// Rate returns how many units of to one unit of from is worth.
+func (c *Converter) Rate(ctx context.Context, from, to string) (core.Decimal, error) {
+	primary, rates, err := c.load(ctx)
+	if err != nil {
+		return core.Decimal{}, err
+	}
+	r, err := rate(primary, rates, from, to)
+	if err != nil {
+		return core.Decimal{}, err
+	}
+	return core.ParseDecimal(r.FloatString(6))
+}
+
+// Convert returns m in currency to, rounded half away from zero to the
+// currency's minor unit. An empty m.Currency is taken to be the shop's
+// default currency.
+func (c *Converter) Convert(ctx context.Context, m core.Money, to string) (core.Money, error) {
+	primary, rates, err := c.load(ctx)
+	if err != nil {
+		return core.Money{}, err
+	}
+	from := m.Currency
+	if from == "" {
+		from = primary
+	}
+	r, err := rate(primary, rates, from, to)
+	if err != nil {
+		return core.Money{}, err
+	}
+	amount, ok := new(big.Rat).SetString(m.Amount.String())
+	if !ok {
+		return core.Money{}, fmt.Errorf("currency: invalid amount %s", m.Amount)
+	}
+	to = strings.ToUpper(to)
+	places := int(core.CurrencyDecimals(to))
+	d, err := core.ParseDecimal(amount.Mul(amount, r).FloatString(places))
+	if err != nil {
+		return core.Money{}, err
+	}
+	return core.Money{Amount: d, Currency: to}, nil
+}
+
+// Refresh reloads the rates now, regardless of the TTL.
+func (c *Converter) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch(ctx)
+}
+
+// load returns the cached rates, fetching them if missing or expired.
+func (c *Converter) load(ctx context.Context) (string, map[string]*big.Rat, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rates == nil || c.now().Sub(c.fetchedAt) >= c.ttl {
+		if err := c.fetch(ctx); err != nil {
+			return "", nil, err
+		}
+	}
+	return c.primary, c.rates, nil
+}
+
+// fetch loads the rates; c.mu must be held.
+func (c *Converter) fetch(ctx context.Context) error {
+	currencies, err := c.source.GetSettlementCurrency(ctx)
+	if err != nil {
+		return fmt.Errorf("currency: failed to load shop currencies: %w", err)
+	}
+	primary := ""
+	rates := make(map[string]*big.Rat, len(currencies))
+	for _, cur := range currencies {
+		code := strings.ToUpper(cur.Code)
+		if cur.Primary {
+			primary = code
+			rates[code] = big.NewRat(1, 1)
+			continue
+		}
+		if !cur.Enabled || cur.RateToDefault == "" {
+			continue
+		}
+		r, ok := new(big.Rat).SetString(cur.RateToDefault)
+		if !ok || r.Sign() <= 0 {
+			return fmt.Errorf("currency: invalid rate %q for %s", cur.RateToDefault, code)
+		}
+		rates[code] = r
+	}
+	if primary == "" {
+		return errors.New("currency: shop has no primary currency")
+	}
+	c.primary, c.rates, c.fetchedAt = primary, rates, c.now()
+	return nil
+}
+
+// rate returns the factor converting from into to.
+func rate(primary string, rates map[string]*big.Rat, from, to string) (*big.Rat, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == "" {
+		from = primary
+	}
+	fromRate, ok := rates[from]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := rates[to]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, to)
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
@@ -0,0 +1,84 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/store"
+)
+
+type fakeSource struct {
+	calls      int
+	currencies []store.Currency
+}
+
+func (f *fakeSource) GetSettlementCurrency(ctx context.Context) ([]store.Currency, error) {
+	f.calls++
+	return f.currencies, nil
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{currencies: []store.Currency{
+		{Code: "USD", Primary: true, Enabled: true},
+		{Code: "EUR", Enabled: true, RateToDefault: "0.92"},
+		{Code: "JPY", Enabled: true, RateToDefault: "150.5"},
+		{Code: "GBP", Enabled: false, RateToDefault: "0.79"},
+	}}
+}
+
+func TestConvert(t *testing.T) {
+	conv := NewConverter(newFakeSource())
+	ctx := context.Background()
+
+	tests := []struct {
+		in   core.Money
+		to   string
+		want string
+	}{
+		{core.Money{Amount: core.MustParseDecimal("100.00"), Currency: "USD"}, "EUR", "92.00 EUR"},
+		{core.Money{Amount: core.MustParseDecimal("10"), Currency: "EUR"}, "usd", "10.87 USD"},
+		{core.Money{Amount: core.MustParseDecimal("9.99")}, "JPY", "1503 JPY"},
+		{core.Money{Amount: core.MustParseDecimal("1505"), Currency: "JPY"}, "EUR", "9.20 EUR"},
+	}
+	for _, tt := range tests {
+		got, err := conv.Convert(ctx, tt.in, tt.to)
+		if err != nil {
+			t.Fatalf("Convert(%s, %s): %v", tt.in, tt.to, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("Convert(%s, %s) = %s, want %s", tt.in, tt.to, got, tt.want)
+		}
+	}
+
+	_, err := conv.Convert(ctx, core.Money{Amount: core.MustParseDecimal("1"), Currency: "USD"}, "GBP")
+	if !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency for a disabled currency, got %v", err)
+	}
+	if r, _ := conv.Rate(ctx, "EUR", "JPY"); r.String() != "163.586957" {
+		t.Errorf("unexpected EUR/JPY rate %s", r)
+	}
+}
+
+func TestConverter_TTL(t *testing.T) {
+	src := newFakeSource()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	conv := NewConverter(src, WithTTL(time.Minute))
+	conv.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		conv.Rate(ctx, "USD", "EUR")
+	}
+	if src.calls != 1 {
+		t.Fatalf("expected rates to be cached, got %d loads", src.calls)
+	}
+
+	src.currencies[1].RateToDefault = "0.95"
+	now = now.Add(time.Minute)
+	if r, _ := conv.Rate(ctx, "USD", "EUR"); r.String() != "0.950000" || src.calls != 2 {
+		t.Errorf("expected refreshed rate after TTL, got %s after %d loads", r, src.calls)
+	}
+}
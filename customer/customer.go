@@ -30,8 +30,12 @@ type Service interface {
 	BatchMarketingStates(ctx context.Context, opts *MarketingOptions) ([]MarketingState, error)
 
 	DeleteTag(ctx context.Context, customerID int64, tag string) error
+	AddTags(ctx context.Context, customerID int64, tags []string) (*core.Customer, error)
+	SetTags(ctx context.Context, customerID int64, tags []string) (*core.Customer, error)
 	AddToBlacklist(ctx context.Context, id int64) error
 	RemoveFromBlacklist(ctx context.Context, id int64) error
+	Merge(ctx context.Context, primaryID, duplicateID int64) (*core.Customer, error)
+	FindDuplicates(ctx context.Context, by DuplicateField) ([]DuplicateGroup, error)
 
 	ListGroups(ctx context.Context, opts *core.ListOptions) ([]Group, error)
 	GetGroup(ctx context.Context, groupID int64) (*Group, error)
@@ -175,7 +179,7 @@ func (s *serviceOp) List(ctx context.Context, opts *ListOptions) ([]core.Custome
 func (s *serviceOp) Get(ctx context.Context, id int64) (*core.Customer, error) {
 	r := &customerResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", basePath, id)), r, nil)
-	return r.Customer, err
+	return core.Found(r.Customer, err)
 }
 func (s *serviceOp) Count(ctx context.Context, opts *core.CountOptions) (int, error) {
 	r := &countResource{}
@@ -235,6 +239,33 @@ func (s *serviceOp) BatchMarketingStates(ctx context.Context, opts *MarketingOpt
 func (s *serviceOp) DeleteTag(ctx context.Context, customerID int64, tag string) error {
 	return s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d/tags/%s.json", basePath, customerID, tag)), nil, nil)
 }
+
+// AddTags merges tags into the customer's existing tags.
+// The customer is read first so existing tags are preserved; tags already
+// present are not duplicated.
+func (s *serviceOp) AddTags(ctx context.Context, customerID int64, tags []string) (*core.Customer, error) {
+	c, err := s.Get(core.WithoutFields(ctx), customerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.SetTags(ctx, customerID, c.TagList().Add(tags...))
+}
+
+// SetTags replaces all of the customer's tags. An empty slice clears them.
+func (s *serviceOp) SetTags(ctx context.Context, customerID int64, tags []string) (*core.Customer, error) {
+	// Sent as a map because core.Customer.Tags is omitempty and would drop
+	// the empty string needed to clear all tags.
+	body := map[string]interface{}{
+		"customer": map[string]interface{}{
+			"id":   customerID,
+			"tags": core.Tags(nil).Add(tags...).String(),
+		},
+	}
+	r := &customerResource{}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", basePath, customerID)), body, r)
+	return r.Customer, err
+}
+
 func (s *serviceOp) AddToBlacklist(ctx context.Context, id int64) error {
 	return s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d/blacklist.json", basePath, id)), nil, nil)
 }
@@ -254,7 +285,7 @@ func (s *serviceOp) ListGroups(ctx context.Context, opts *core.ListOptions) ([]G
 func (s *serviceOp) GetGroup(ctx context.Context, groupID int64) (*Group, error) {
 	r := &groupResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/groups/%d.json", basePath, groupID)), r, nil)
-	return r.CustomerGroup, err
+	return core.Found(r.CustomerGroup, err)
 }
 func (s *serviceOp) CreateGroup(ctx context.Context, g Group) (*Group, error) {
 	r := &groupResource{}
@@ -300,7 +331,7 @@ func (s *serviceOp) DeleteAddress(ctx context.Context, customerID, addressID int
 func (s *serviceOp) GetAddress(ctx context.Context, customerID, addressID int64) (*core.Address, error) {
 	r := &addressResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d/addresses/%d.json", basePath, customerID, addressID)), r, nil)
-	return r.Address, err
+	return core.Found(r.Address, err)
 }
 func (s *serviceOp) SetDefaultAddress(ctx context.Context, customerID, addressID int64) (*core.Address, error) {
 	r := &addressResource{}
@@ -26,6 +26,9 @@ func (m *mockRequester) CreatePath(resource string) string {
 	return "/admin/openapi/" + m.apiVersion + "/" + resource
 }
 func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	if fields := core.SelectedFields(ctx); len(fields) > 0 {
+		path += "?fields=" + strings.Join(fields, ",")
+	}
 	return m.do(ctx, http.MethodGet, path, nil, result)
 }
 func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
@@ -243,3 +246,244 @@ func TestCustomerCreateGroup(t *testing.T) {
 		t.Errorf("expected 'Wholesale', got %q", g.Name)
 	}
 }
+
+func TestCustomerAddTags(t *testing.T) {
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 5001, Tags: "vip, newsletter"}})
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&putBody)
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 5001, Tags: putBody["customer"]["tags"].(string)}})
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	defer close()
+
+	svc := NewService(mock)
+	c, err := svc.AddTags(context.Background(), 5001, []string{"wholesale", "vip"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := putBody["customer"]["tags"]; got != "vip, newsletter, wholesale" {
+		t.Errorf("expected merged tags, got %q", got)
+	}
+	if !c.TagList().Contains("wholesale") {
+		t.Errorf("expected returned customer to have 'wholesale', got %q", c.Tags)
+	}
+}
+
+func TestCustomerAddTags_IgnoresFieldSelection(t *testing.T) {
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			c := &core.Customer{ID: 5001, Tags: "vip, newsletter"}
+			if r.URL.Query().Get("fields") == "id" {
+				c.Tags = ""
+			}
+			json.NewEncoder(w).Encode(customerResource{Customer: c})
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&putBody)
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 5001}})
+		}
+	})
+	defer close()
+
+	ctx := core.WithFields(context.Background(), "id")
+	if _, err := NewService(mock).AddTags(ctx, 5001, []string{"wholesale"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := putBody["customer"]["tags"]; got != "vip, newsletter, wholesale" {
+		t.Errorf("expected existing tags kept, got %q", got)
+	}
+}
+
+func TestCustomerSetTags_Clear(t *testing.T) {
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("expected PUT, got %s", r.Method)
+		}
+		json.NewDecoder(r.Body).Decode(&putBody)
+		json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 5001}})
+	})
+	defer close()
+
+	svc := NewService(mock)
+	if _, err := svc.SetTags(context.Background(), 5001, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tags, ok := putBody["customer"]["tags"]
+	if !ok || tags != "" {
+		t.Errorf("expected explicit empty tags in body, got %v (present=%v)", tags, ok)
+	}
+}
+
+func TestCustomerFindDuplicates(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(customersResource{Customers: []core.Customer{
+			{ID: 3, Email: "Ann@Example.com ", Phone: "+1 (555) 010-0000"},
+			{ID: 1, Email: "ann@example.com", Phone: "+15550100000"},
+			{ID: 2, Email: "bob@example.com"},
+			{ID: 4},
+		}})
+	})
+	defer close()
+
+	svc := NewService(mock)
+	groups, err := svc.FindDuplicates(context.Background(), DuplicateByEmail)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Key != "ann@example.com" || len(groups[0].Customers) != 2 || groups[0].Customers[0].ID != 1 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	groups, err = svc.FindDuplicates(context.Background(), DuplicateByPhone)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Key != "+15550100000" {
+		t.Errorf("unexpected phone groups: %+v", groups)
+	}
+}
+
+func TestCustomerMerge(t *testing.T) {
+	var requests []string
+	var updates []core.Customer
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path[strings.Index(r.URL.Path, "customers/"):])
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/1.json"):
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 1, FirstName: "Ann", Tags: "vip",
+				Addresses: []core.Address{{ID: 10, Address1: "1 Main St", City: "Springfield", CountryCode: "US"}}}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/2.json"):
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 2, Email: "ann@example.com", LastName: "Lee", Tags: "newsletter",
+				Addresses: []core.Address{
+					{ID: 20, Address1: "1 main st", City: "Springfield", CountryCode: "US"},
+					{ID: 21, Address1: "9 Elm St", City: "Shelbyville", CountryCode: "US", Default: true},
+				}}})
+		case r.Method == http.MethodPut:
+			var body customerResource
+			json.NewDecoder(r.Body).Decode(&body)
+			updates = append(updates, *body.Customer)
+			json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"customer_address":{"id":30}}`))
+		}
+	})
+	defer close()
+
+	svc := NewService(mock)
+	c, err := svc.Merge(context.Background(), 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Email != "ann@example.com" {
+		t.Errorf("expected email copied, got %+v", c)
+	}
+	if len(updates) != 3 || updates[0].Tags != "vip, newsletter" || updates[0].LastName != "Lee" || updates[0].Email != "" {
+		t.Errorf("unexpected updates: %+v", updates)
+	}
+	if len(updates) == 3 && (updates[1].ID != 2 || updates[1].Email != "" || updates[2].Email != "ann@example.com") {
+		t.Errorf("expected duplicate's email released before the primary takes it: %+v", updates)
+	}
+	want := []string{
+		"GET customers/1.json", "GET customers/2.json", "PUT customers/1.json",
+		"POST customers/1/addresses.json", "PUT customers/2.json", "PUT customers/1.json", "DELETE customers/2.json",
+	}
+	if strings.Join(requests, ", ") != strings.Join(want, ", ") {
+		t.Errorf("unexpected requests:\n got %v\nwant %v", requests, want)
+	}
+}
+
+func TestCustomerMerge_ContactUpdateFails(t *testing.T) {
+	var requests []string
+	var restored core.Customer
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path[strings.Index(r.URL.Path, "customers/"):])
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/1.json"):
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 1}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/2.json"):
+			json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 2, Email: "ann@example.com"}})
+		case r.Method == http.MethodPut:
+			var body customerResource
+			json.NewDecoder(r.Body).Decode(&body)
+			if body.Customer.ID == 1 && body.Customer.Email != "" {
+				// The mock requester surfaces decode errors, not statuses.
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`email is invalid`))
+				return
+			}
+			if body.Customer.ID == 2 {
+				restored = *body.Customer
+			}
+			json.NewEncoder(w).Encode(body)
+		}
+	})
+	defer close()
+
+	if _, err := NewService(mock).Merge(context.Background(), 1, 2); err == nil {
+		t.Fatal("expected error")
+	}
+	if restored.Email != "ann@example.com" {
+		t.Errorf("expected duplicate's email restored, got %+v", restored)
+	}
+	for _, req := range requests {
+		if strings.HasPrefix(req, http.MethodDelete) {
+			t.Errorf("duplicate deleted after a failed merge: %v", requests)
+		}
+	}
+}
+
+func TestCustomerMerge_DuplicateWithOrders(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s", r.Method)
+		}
+		json.NewEncoder(w).Encode(customerResource{Customer: &core.Customer{ID: 2, OrdersCount: 3}})
+	})
+	defer close()
+
+	if _, err := NewService(mock).Merge(context.Background(), 1, 2); err == nil {
+		t.Fatal("expected error for duplicate with orders")
+	}
+}
+
+func TestSegmentCreateAndListMembers(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/customer_saved_searches.json"):
+			var body struct {
+				Segment *Segment `json:"customer_saved_search"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			body.Segment.ID = 9
+			json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/customer_saved_searches/9/customers.json"):
+			json.NewEncoder(w).Encode(customersResource{Customers: []core.Customer{{ID: 1}, {ID: 2}}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewSegmentService(mock)
+	seg, err := svc.Create(context.Background(), Segment{Name: "Repeat buyers", Query: "orders_count:>3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seg.ID != 9 || seg.Query != "orders_count:>3" {
+		t.Fatalf("unexpected segment: %+v", seg)
+	}
+	members, err := svc.ListSegmentMembers(context.Background(), seg.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("expected 2 members, got %d", len(members))
+	}
+}
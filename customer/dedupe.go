@@ -0,0 +1,233 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/imokyou/slshop/core"
+)
+
+const dedupePageSize = 250
+
+// DuplicateField selects the contact field FindDuplicates compares.
+type DuplicateField string
+
+const (
+	DuplicateByEmail DuplicateField = "email"
+	DuplicateByPhone DuplicateField = "phone"
+)
+
+// DuplicateGroup is a set of customers sharing the same normalized email or
+// phone.
+type DuplicateGroup struct {
+	Field     DuplicateField
+	Key       string          // normalized value shared by Customers
+	Customers []core.Customer // oldest (lowest ID) first
+}
+
+// =====================================================================
+// Duplicate Detection
+// =====================================================================
+
+// NormalizeEmail lower-cases and trims an email so that addresses differing
+// only in case or surrounding spaces compare equal.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone keeps the digits of a phone number and a leading "+", so
+// "+1 (555) 010-0000" and "+15550100000" compare equal. Numbers written
+// with and without a country code are not matched.
+func NormalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	var b strings.Builder
+	for i, r := range phone {
+		if r >= '0' && r <= '9' || r == '+' && i == 0 {
+			b.WriteRune(r)
+		}
+	}
+	if n := b.String(); n != "+" {
+		return n
+	}
+	return ""
+}
+
+// FindDuplicates pages through all customers and groups those whose
+// normalized email or phone (per by) is shared by more than one customer.
+// Customers without a value for the field are ignored. Groups are sorted by
+// key.
+func (s *serviceOp) FindDuplicates(ctx context.Context, by DuplicateField) ([]DuplicateGroup, error) {
+	var normalize func(c *core.Customer) string
+	switch by {
+	case DuplicateByEmail:
+		normalize = func(c *core.Customer) string { return NormalizeEmail(c.Email) }
+	case DuplicateByPhone:
+		normalize = func(c *core.Customer) string { return NormalizePhone(c.Phone) }
+	default:
+		return nil, fmt.Errorf("customer: unsupported duplicate field %q", by)
+	}
+
+	byKey := make(map[string][]core.Customer)
+	opts := &ListOptions{ListOptions: core.ListOptions{Limit: dedupePageSize}}
+	for {
+		page, err := s.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("customer: failed to list customers: %w", err)
+		}
+		for i := range page {
+			if key := normalize(&page[i]); key != "" {
+				byKey[key] = append(byKey[key], page[i])
+			}
+		}
+		if len(page) < dedupePageSize {
+			break
+		}
+		opts.SinceID = page[len(page)-1].ID
+	}
+
+	var groups []DuplicateGroup
+	for key, customers := range byKey {
+		if len(customers) < 2 {
+			continue
+		}
+		sort.Slice(customers, func(i, j int) bool { return customers[i].ID < customers[j].ID })
+		groups = append(groups, DuplicateGroup{Field: by, Key: key, Customers: customers})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Key < groups[j].Key })
+	return groups, nil
+}
+
+// =====================================================================
+// Merge
+// =====================================================================
+
+// Merge folds duplicateID into primaryID and deletes the duplicate. The API
+// has no merge endpoint and cannot move orders between customers, so a
+// duplicate with orders is rejected. Otherwise the primary gains the
+// duplicate's tags, note and addresses, and any name, email or phone it is
+// missing. Email and phone must be unique, so they are cleared on the
+// duplicate before the primary takes them, and restored on the duplicate if
+// that fails. The duplicate is deleted last, once the primary holds
+// everything, so a failed merge loses nothing.
+func (s *serviceOp) Merge(ctx context.Context, primaryID, duplicateID int64) (*core.Customer, error) {
+	ctx = core.WithoutFields(ctx) // the merge is built from whole customers
+	if primaryID == duplicateID {
+		return nil, fmt.Errorf("customer: cannot merge customer %d into itself", primaryID)
+	}
+	primary, err := s.mustGet(ctx, primaryID)
+	if err != nil {
+		return nil, err
+	}
+	dup, err := s.mustGet(ctx, duplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if dup.OrdersCount > 0 {
+		return nil, fmt.Errorf("customer: duplicate %d has %d orders, which cannot be moved to customer %d", duplicateID, dup.OrdersCount, primaryID)
+	}
+
+	update := core.Customer{
+		ID:        primaryID,
+		FirstName: firstNonEmpty(primary.FirstName, dup.FirstName),
+		LastName:  firstNonEmpty(primary.LastName, dup.LastName),
+		Tags:      primary.TagList().Add(dup.TagList()...).String(),
+		Note:      mergeNotes(primary.Note, dup.Note),
+	}
+	merged, err := s.Update(ctx, update)
+	if err != nil {
+		return nil, fmt.Errorf("customer: failed to update customer %d: %w", primaryID, err)
+	}
+	for _, addr := range dup.Addresses {
+		if hasAddress(primary.Addresses, addr) {
+			continue
+		}
+		addr.ID, addr.Default = 0, false
+		if _, err := s.CreateAddress(ctx, primaryID, addr); err != nil {
+			return merged, fmt.Errorf("customer: failed to copy address to customer %d: %w", primaryID, err)
+		}
+	}
+
+	contact := core.Customer{ID: primaryID}
+	if primary.Email == "" {
+		contact.Email = dup.Email
+	}
+	if primary.Phone == "" {
+		contact.Phone = dup.Phone
+	}
+	if contact.Email != "" || contact.Phone != "" {
+		if err := s.setContact(ctx, duplicateID, "", ""); err != nil {
+			return merged, fmt.Errorf("customer: failed to release contact details of duplicate %d: %w", duplicateID, err)
+		}
+		updated, err := s.Update(ctx, contact)
+		if err != nil {
+			if rErr := s.setContact(ctx, duplicateID, dup.Email, dup.Phone); rErr != nil {
+				err = fmt.Errorf("%w (restoring duplicate %d also failed: %v)", err, duplicateID, rErr)
+			}
+			return merged, fmt.Errorf("customer: failed to copy contact details to customer %d: %w", primaryID, err)
+		}
+		merged = updated
+	}
+
+	if err := s.Delete(ctx, duplicateID); err != nil {
+		return merged, fmt.Errorf("customer: failed to delete duplicate %d: %w", duplicateID, err)
+	}
+	return merged, nil
+}
+
+// setContact sets the email and phone that Merge moves; "" clears them,
+// which core.Customer cannot express since its fields are omitempty.
+func (s *serviceOp) setContact(ctx context.Context, id int64, email, phone string) error {
+	orNull := func(v string) interface{} {
+		if v == "" {
+			return nil
+		}
+		return v
+	}
+	body := map[string]map[string]interface{}{"customer": {"id": id, "email": orNull(email), "phone": orNull(phone)}}
+	return s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", basePath, id)), body, nil)
+}
+
+func (s *serviceOp) mustGet(ctx context.Context, id int64) (*core.Customer, error) {
+	c, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("customer: failed to get customer %d: %w", id, err)
+	}
+	return c, nil
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+func mergeNotes(primary, dup string) string {
+	switch {
+	case dup == "" || strings.Contains(primary, dup):
+		return primary
+	case primary == "":
+		return dup
+	}
+	return primary + "\n" + dup
+}
+
+// hasAddress reports whether addrs contains addr, comparing street, city,
+// zip and country case-insensitively.
+func hasAddress(addrs []core.Address, addr core.Address) bool {
+	key := func(a core.Address) string {
+		return strings.ToLower(strings.Join([]string{
+			strings.TrimSpace(a.Address1), strings.TrimSpace(a.Address2),
+			strings.TrimSpace(a.City), strings.TrimSpace(a.Zip), a.CountryCode,
+		}, "|"))
+	}
+	k := key(addr)
+	for _, a := range addrs {
+		if key(a) == k {
+			return true
+		}
+	}
+	return false
+}
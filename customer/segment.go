@@ -0,0 +1,57 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+const segmentsBasePath = "customer_saved_searches"
+
+// =====================================================================
+// Segment (Customer Saved Search)
+// =====================================================================
+
+type SegmentService interface {
+	List(ctx context.Context, opts *core.ListOptions) ([]Segment, error)
+	Get(ctx context.Context, id int64) (*Segment, error)
+	Create(ctx context.Context, s Segment) (*Segment, error)
+	Update(ctx context.Context, s Segment) (*Segment, error)
+	Delete(ctx context.Context, id int64) error
+	Count(ctx context.Context) (int, error)
+	ListSegmentMembers(ctx context.Context, segmentID int64, opts *core.ListOptions) ([]core.Customer, error)
+}
+
+func NewSegmentService(client core.Requester) SegmentService {
+	return &segmentOp{
+		ResourceService: core.NewResourceService(client, core.Resource[Segment]{
+			Path: segmentsBasePath, Key: "customer_saved_search", ListKey: "customer_saved_searches",
+			ID: func(s *Segment) int64 { return s.ID },
+		}),
+		client: client,
+	}
+}
+
+type segmentOp struct {
+	*core.ResourceService[Segment]
+	client core.Requester
+}
+
+// Segment is a saved customer search. Query uses the admin search syntax,
+// e.g. "accepts_marketing:1 orders_count:>3".
+type Segment struct {
+	ID        int64      `json:"id,omitempty"`
+	Name      string     `json:"name,omitempty"`
+	Query     string     `json:"query,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListSegmentMembers lists the customers currently matching a segment.
+func (s *segmentOp) ListSegmentMembers(ctx context.Context, segmentID int64, opts *core.ListOptions) ([]core.Customer, error) {
+	r := &customersResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d/customers.json", segmentsBasePath, segmentID)), r, opts)
+	return r.Customers, err
+}
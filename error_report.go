@@ -0,0 +1,113 @@
+package shopline
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxReportBody caps the response body excerpt included in an ErrorSnapshot.
+const maxReportBody = 2048
+
+// sensitiveBodyField matches JSON string fields whose values must not leave
+// the app in a support ticket.
+var sensitiveBodyField = regexp.MustCompile(`(?i)("(?:[a-z_]*token|[a-z_]*secret|password|authorization|email|phone)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// ErrorSnapshot is the information Shopline support asks for when a request
+// fails. Build one with ErrorReport.
+type ErrorSnapshot struct {
+	Time            time.Time `json:"time"`
+	SDKVersion      string    `json:"sdk_version"`
+	APIVersion      string    `json:"api_version,omitempty"`
+	Shop            string    `json:"shop,omitempty"`
+	Method          string    `json:"method,omitempty"`
+	Path            string    `json:"path,omitempty"`
+	Status          int       `json:"status,omitempty"`
+	TraceID         string    `json:"trace_id,omitempty"`
+	ErrorCode       string    `json:"error_code,omitempty"`
+	Message         string    `json:"message"`
+	BodyExcerpt     string    `json:"body_excerpt,omitempty"`
+	RetryAfter      string    `json:"retry_after,omitempty"`
+	RateLimitBucket string    `json:"rate_limit_bucket,omitempty"`
+}
+
+// ErrorReport returns an indented JSON snapshot of err for pasting into a
+// Shopline support ticket. API errors include the trace ID, shop, request
+// path and a body excerpt with tokens, secrets and contact details redacted;
+// other errors carry only the message.
+func ErrorReport(err error) string {
+	b, _ := json.MarshalIndent(NewErrorSnapshot(err), "", "  ")
+	return string(b)
+}
+
+// NewErrorSnapshot builds the snapshot rendered by ErrorReport.
+func NewErrorSnapshot(err error) ErrorSnapshot {
+	snap := ErrorSnapshot{
+		Time:       timeNow().UTC(),
+		SDKVersion: LibraryVersion,
+	}
+	if err == nil {
+		return snap
+	}
+	snap.Message = sanitizeMessage(err.Error())
+
+	var respErr *ResponseError
+	var rlErr *RateLimitError
+	switch {
+	case errors.As(err, &rlErr):
+		respErr = &rlErr.ResponseError
+		snap.RetryAfter = rlErr.RetryAfter.String()
+	case errors.As(err, &respErr):
+		if respErr.RetryAfter > 0 {
+			snap.RetryAfter = respErr.RetryAfter.String()
+		}
+	default:
+		return snap
+	}
+
+	snap.Shop = respErr.Shop
+	snap.Method = respErr.Method
+	snap.Path = respErr.Path
+	snap.APIVersion = apiVersionFromPath(respErr.Path)
+	snap.Status = respErr.Status
+	snap.TraceID = respErr.TraceID
+	snap.ErrorCode = respErr.Code
+	snap.RateLimitBucket = respErr.RateLimit.Bucket
+	snap.BodyExcerpt = sanitizeBody(respErr.RawBody)
+	return snap
+}
+
+// apiVersionFromPath extracts "v20251201" from "/admin/openapi/v20251201/...".
+func apiVersionFromPath(path string) string {
+	for _, seg := range strings.Split(path, "/") {
+		if len(seg) > 1 && seg[0] == 'v' && strings.Trim(seg[1:], "0123456789") == "" {
+			return seg
+		}
+	}
+	return ""
+}
+
+// sanitizeBody redacts sensitive fields and truncates the body.
+func sanitizeBody(body []byte) string {
+	s := sensitiveBodyField.ReplaceAllString(string(body), `$1"[REDACTED]"`)
+	if len(s) > maxReportBody {
+		n := maxReportBody
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n] + "...(truncated)"
+	}
+	return s
+}
+
+// sanitizeMessage drops the response body that decode errors append as
+// "(body: ...)" and redacts sensitive fields from the rest.
+func sanitizeMessage(msg string) string {
+	if i := strings.Index(msg, " (body: "); i >= 0 {
+		msg = msg[:i] + " (body omitted)"
+	}
+	return sensitiveBodyField.ReplaceAllString(msg, `$1"[REDACTED]"`)
+}
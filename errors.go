@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/imokyou/slshop/core"
 )
 
 // ResponseError represents an error response from the Shopline API.
@@ -17,6 +19,30 @@ type ResponseError struct {
 	// Errors can be a string, []string, or map[string][]string depending on the endpoint.
 	Errors  interface{} `json:"errors"`
 	RawBody []byte      `json:"-"`
+
+	// Code is the machine-readable error code (the body's "i18nCode", or a
+	// string "code"), e.g. "PRODUCT_NOT_FOUND". Empty if none was sent.
+	Code string `json:"-"`
+
+	// Fields holds per-field validation messages, parsed from an "errors"
+	// object ({"title": ["can't be blank"]}) or from an "errors" array of
+	// {"field": ..., "message": ...} items. Nil if there were none.
+	Fields map[string][]string `json:"-"`
+
+	// RateLimit and RetryAfter are captured from the response headers so
+	// callers doing their own retries need not keep the closed response.
+	// RetryAfter is zero if the header was absent, except on a
+	// *RateLimitError, where it defaults to 2s.
+	RateLimit  RateLimit     `json:"-"`
+	RetryAfter time.Duration `json:"-"`
+
+	// Method, Host and Path identify the failed request (query omitted).
+	Method string `json:"-"`
+	Host   string `json:"-"`
+	Path   string `json:"-"`
+
+	// Shop is the handle of the client's store, set by Client.Do.
+	Shop string `json:"-"`
 }
 
 // Error implements the error interface.
@@ -52,10 +78,34 @@ func (e *ResponseError) GetErrors() string {
 	}
 }
 
-// RateLimitError represents a rate limit error (HTTP 429).
+// Is makes a 404 match core.ErrNotFound and a 409 or 412 match
+// core.ErrConflict with errors.Is.
+func (e *ResponseError) Is(target error) bool {
+	switch target {
+	case core.ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case core.ErrConflict:
+		return e.Status == http.StatusConflict || e.Status == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// IsRetryable reports whether repeating the request unchanged may succeed:
+// rate limiting (429) and transient server errors (500, 502, 503, 504).
+// Validation and other 4xx errors are not retryable.
+func (e *ResponseError) IsRetryable() bool {
+	switch e.Status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// RateLimitError represents a rate limit error (HTTP 429). Its RetryAfter,
+// from the embedded ResponseError, is never zero.
 type RateLimitError struct {
 	ResponseError
-	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
@@ -63,6 +113,26 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("shopline: rate limited (429), retry after %s (traceId: %s)", e.RetryAfter, e.TraceID)
 }
 
+// RetryDeadlineError is returned by Do when the wait before the next retry
+// (Retry-After or backoff) would outlast the request context's deadline. Do
+// gives up immediately instead of sleeping until the deadline fires, so
+// bounded jobs can reschedule themselves for RetryAt.
+type RetryDeadlineError struct {
+	Wait    time.Duration // suggested wait before retrying
+	RetryAt time.Time     // earliest time a retry is expected to succeed
+	Err     error         // error of the last attempt, e.g. *RateLimitError
+}
+
+// Error implements the error interface.
+func (e *RetryDeadlineError) Error() string {
+	return fmt.Sprintf("shopline: retry in %s exceeds context deadline: %v", e.Wait, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryDeadlineError) Unwrap() error {
+	return e.Err
+}
+
 // parseResponseError creates a ResponseError from an HTTP response.
 // This is a convenience wrapper that reads the body first.
 func parseResponseError(resp *http.Response) error {
@@ -80,8 +150,10 @@ func parseResponseError(resp *http.Response) error {
 // and pre-read body bytes. This avoids double-reading the response body.
 func parseResponseErrorFromBytes(resp *http.Response, body []byte) error {
 	respErr := &ResponseError{
-		Status:  resp.StatusCode,
-		RawBody: body,
+		Status:     resp.StatusCode,
+		RawBody:    body,
+		RateLimit:  parseRateLimit(resp.Header),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 	}
 
 	// Try to parse JSON body
@@ -96,7 +168,9 @@ func parseResponseErrorFromBytes(resp *http.Response, body []byte) error {
 			}
 			if errors, ok := parsed["errors"]; ok {
 				respErr.Errors = errors
+				respErr.Fields = parseFieldErrors(errors)
 			}
+			respErr.Code = parseErrorCode(parsed)
 			// Some Shopline errors use "error" instead of "errors"
 			if errMsg, ok := parsed["error"].(string); ok && respErr.Message == "" {
 				respErr.Message = errMsg
@@ -106,22 +180,64 @@ func parseResponseErrorFromBytes(resp *http.Response, body []byte) error {
 			respErr.Message = string(body)
 		}
 	}
+	if respErr.TraceID == "" {
+		respErr.TraceID = traceIDFromHeader(resp.Header)
+	}
+	if req := resp.Request; req != nil && req.URL != nil {
+		respErr.Method, respErr.Host, respErr.Path = req.Method, req.URL.Host, req.URL.Path
+	}
 
 	// Handle rate limiting
 	if resp.StatusCode == http.StatusTooManyRequests {
-		rlErr := &RateLimitError{
-			ResponseError: *respErr,
+		if respErr.RetryAfter <= 0 {
+			respErr.RetryAfter = 2 * time.Second // default
 		}
-		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
-			if d := parseRetryAfter(retryAfter); d > 0 {
-				rlErr.RetryAfter = d
+		return &RateLimitError{ResponseError: *respErr}
+	}
+
+	return respErr
+}
+
+// parseErrorCode returns the body's i18nCode, or its code if that is a string
+// (numeric codes merely repeat the HTTP status).
+func parseErrorCode(parsed map[string]interface{}) string {
+	if code, ok := parsed["i18nCode"].(string); ok && code != "" {
+		return code
+	}
+	code, _ := parsed["code"].(string)
+	return code
+}
+
+// parseFieldErrors extracts per-field messages from an "errors" value.
+func parseFieldErrors(v interface{}) map[string][]string {
+	fields := map[string][]string{}
+	switch errs := v.(type) {
+	case map[string]interface{}:
+		for field, msgs := range errs {
+			switch m := msgs.(type) {
+			case string:
+				fields[field] = append(fields[field], m)
+			case []interface{}:
+				for _, msg := range m {
+					fields[field] = append(fields[field], fmt.Sprintf("%v", msg))
+				}
 			}
 		}
-		if rlErr.RetryAfter == 0 {
-			rlErr.RetryAfter = 2 * time.Second // default
+	case []interface{}:
+		for _, item := range errs {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			field, _ := obj["field"].(string)
+			msg, _ := obj["message"].(string)
+			if field != "" && msg != "" {
+				fields[field] = append(fields[field], msg)
+			}
 		}
-		return rlErr
 	}
-
-	return respErr
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
 }
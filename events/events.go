@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Events Service
+// =====================================================================
+
+// Service reads the store's event feed: who did what to which resource.
+// It covers more resource types than store.OperationLog, which only records
+// admin actions. Publish goes the other way and fires the app's own triggers.
+type Service interface {
+	List(ctx context.Context, opts *ListOptions) ([]Event, error)
+	Get(ctx context.Context, id int64) (*Event, error)
+	Count(ctx context.Context, opts *CountOptions) (int, error)
+
+	// Publish fires a custom trigger declared by the app, which merchants
+	// can use to start their automation workflows.
+	Publish(ctx context.Context, event AppEvent) error
+}
+
+func NewService(client core.Requester) Service {
+	return &serviceOp{client: client}
+}
+
+type serviceOp struct{ client core.Requester }
+
+// Common Event.Verb values.
+const (
+	VerbCreate    = "create"
+	VerbUpdate    = "update"
+	VerbDestroy   = "destroy"
+	VerbPublished = "published"
+	VerbConfirmed = "confirmed"
+)
+
+// =====================================================================
+// Query Options
+// =====================================================================
+
+type ListOptions struct {
+	core.ListOptions
+	// Filter limits events to resource types, comma-separated subject
+	// types such as "Product,Order".
+	Filter string `url:"filter,omitempty"`
+	Verb   string `url:"verb,omitempty"`
+}
+
+type CountOptions struct {
+	core.CountOptions
+	Filter string `url:"filter,omitempty"`
+	Verb   string `url:"verb,omitempty"`
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+type Event struct {
+	ID          int64      `json:"id,omitempty"`
+	SubjectID   int64      `json:"subject_id,omitempty"`
+	SubjectType string     `json:"subject_type,omitempty"` // e.g. "Product"
+	Verb        string     `json:"verb,omitempty"`
+	Arguments   []string   `json:"arguments,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	Message     string     `json:"message,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Path        string     `json:"path,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// AppEvent is a custom trigger fired by the app. Handle is the trigger's
+// handle as declared in the app's configuration; Payload holds the fields the
+// trigger declares and must encode as a JSON object.
+type AppEvent struct {
+	Handle     string      `json:"handle"`
+	Payload    interface{} `json:"payload,omitempty"`
+	OccurredAt *time.Time  `json:"occurred_at,omitempty"`
+}
+
+// JSON wrappers
+type eventResource struct {
+	Event *Event `json:"event"`
+}
+type eventsResource struct {
+	Events []Event `json:"events"`
+}
+type appEventResource struct {
+	Trigger *AppEvent `json:"trigger"`
+}
+type countResource struct {
+	Count int `json:"count"`
+}
+
+// =====================================================================
+// Implementation
+// =====================================================================
+
+// GET events.json
+func (s *serviceOp) List(ctx context.Context, opts *ListOptions) ([]Event, error) {
+	r := &eventsResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("events.json"), r, opts)
+	return r.Events, err
+}
+
+// GET events/{id}.json
+func (s *serviceOp) Get(ctx context.Context, id int64) (*Event, error) {
+	r := &eventResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("events/%d.json", id)), r, nil)
+	return core.Found(r.Event, err)
+}
+
+// GET events/count.json
+func (s *serviceOp) Count(ctx context.Context, opts *CountOptions) (int, error) {
+	r := &countResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("events/count.json"), r, opts)
+	return r.Count, err
+}
+
+// POST flow/triggers.json
+func (s *serviceOp) Publish(ctx context.Context, event AppEvent) error {
+	if event.Handle == "" {
+		return fmt.Errorf("events: trigger handle is required")
+	}
+	return s.client.Post(ctx, s.client.CreatePath("flow/triggers.json"), appEventResource{Trigger: &event}, nil)
+}
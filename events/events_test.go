@@ -0,0 +1,161 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for events tests. It records the
+// options of the last Get instead of encoding them.
+type mockRequester struct {
+	server   *httptest.Server
+	lastOpts interface{}
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	m.lastOpts = opts
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return core.ErrNotFound
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestEventList(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/admin/openapi/v20251201/events.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"events":[{"id":1,"subject_id":9,"subject_type":"Product","verb":"create","arguments":["Parka"],"created_at":"2026-01-02T03:04:05Z"}]}`))
+	})
+	defer close()
+
+	opts := &ListOptions{Filter: "Product,Order", Verb: VerbCreate}
+	evs, err := NewService(mock).List(context.Background(), opts)
+	if err != nil || len(evs) != 1 || evs[0].SubjectType != "Product" || evs[0].Arguments[0] != "Parka" {
+		t.Fatalf("List = %+v, %v", evs, err)
+	}
+	if evs[0].CreatedAt == nil || !evs[0].CreatedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("unexpected created_at %v", evs[0].CreatedAt)
+	}
+	if mock.lastOpts != opts {
+		t.Errorf("expected options passed through, got %+v", mock.lastOpts)
+	}
+}
+
+func TestEventGet(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/openapi/v20251201/events/1.json":
+			w.Write([]byte(`{"event":{"id":1,"verb":"destroy","author":"Ann"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer close()
+
+	svc := NewService(mock)
+	ev, err := svc.Get(context.Background(), 1)
+	if err != nil || ev.Verb != VerbDestroy || ev.Author != "Ann" {
+		t.Fatalf("Get = %+v, %v", ev, err)
+	}
+	if _, err := svc.Get(context.Background(), 2); !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestEventCount(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/admin/openapi/v20251201/events/count.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"count":12}`))
+	})
+	defer close()
+
+	n, err := NewService(mock).Count(context.Background(), &CountOptions{Verb: VerbUpdate})
+	if err != nil || n != 12 {
+		t.Fatalf("Count = %d, %v", n, err)
+	}
+}
+
+func TestEventPublish(t *testing.T) {
+	var body map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/openapi/v20251201/flow/triggers.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+	})
+	defer close()
+
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := NewService(mock).Publish(context.Background(), AppEvent{
+		Handle:     "loyalty-tier-changed",
+		Payload:    map[string]interface{}{"customer_id": 42, "tier": "gold"},
+		OccurredAt: &at,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	trigger := body["trigger"]
+	if trigger["handle"] != "loyalty-tier-changed" || trigger["occurred_at"] != "2026-05-01T12:00:00Z" {
+		t.Errorf("unexpected trigger: %v", trigger)
+	}
+	if payload, _ := trigger["payload"].(map[string]interface{}); payload["tier"] != "gold" || payload["customer_id"] != float64(42) {
+		t.Errorf("unexpected payload: %v", trigger["payload"])
+	}
+}
+
+func TestEventPublish_RequiresHandle(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	defer close()
+
+	if err := NewService(mock).Publish(context.Background(), AppEvent{Payload: map[string]int{"x": 1}}); err == nil {
+		t.Error("expected error without a handle")
+	}
+}
@@ -42,7 +42,10 @@ func main() {
 	// Step 1: Generate Auth URL
 	// ============================
 	nonce := fmt.Sprintf("state_%d", time.Now().UnixNano())
-	authURL := app.AuthorizeURL(handle, nonce)
+	authURL, err := app.AuthorizeURLChecked(handle, nonce)
+	if err != nil {
+		log.Fatal(err)
+	}
 	fmt.Println("================================")
 	fmt.Println("Please open the following URL in your browser to authorize the app:")
 	fmt.Println()
@@ -0,0 +1,229 @@
+package fulfillment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/order"
+)
+
+// Fulfillment order statuses that AutoFulfill acts on.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+)
+
+// =====================================================================
+// Automatic Fulfillment
+// =====================================================================
+
+// Item is an unfulfilled fulfillment order line item offered to a Router.
+type Item struct {
+	OrderID            int64
+	FulfillmentOrderID int64
+	LineItemID         int64
+	VariantID          int64
+	SKU                string
+	Quantity           int   // units still to fulfill
+	AssignedLocationID int64 // location the fulfillment order is at now
+}
+
+// Router picks the location that ships an item. Returning 0 leaves the item
+// unfulfilled, e.g. because no warehouse has it in stock.
+type Router interface {
+	Route(ctx context.Context, item Item) (locationID int64, err error)
+}
+
+// RouterFunc adapts a function to a Router.
+type RouterFunc func(ctx context.Context, item Item) (int64, error)
+
+// Route implements Router.
+func (f RouterFunc) Route(ctx context.Context, item Item) (int64, error) {
+	return f(ctx, item)
+}
+
+// SKURouting routes items by SKU. Items whose SKU is not listed stay at the
+// location they are assigned to.
+type SKURouting map[string]int64
+
+// Route implements Router.
+func (r SKURouting) Route(_ context.Context, item Item) (int64, error) {
+	if loc, ok := r[item.SKU]; ok {
+		return loc, nil
+	}
+	return item.AssignedLocationID, nil
+}
+
+// Move records line items moved from a fulfillment order to another
+// location.
+type Move struct {
+	FulfillmentOrderID      int64
+	LocationID              int64
+	MovedFulfillmentOrderID int64 // fulfillment order now holding the items
+}
+
+// AutoFulfillResult reports what AutoFulfill did.
+type AutoFulfillResult struct {
+	Fulfillments []order.Fulfillment // one per location
+	Moves        []Move
+	Skipped      []Item // items the router left unfulfilled
+}
+
+// AutoFulfill fulfills an order's open fulfillment orders with the locations
+// chosen by routing. Line items routed away from their fulfillment order's
+// location are first moved there, then everything at a location is shipped
+// in a single fulfillment, so the order gets as few fulfillments as there are
+// shipping locations.
+//
+//	res, err := fulfillment.AutoFulfill(ctx, client, orderID, fulfillment.SKURouting{
+//	    "SKU-EU-1": euWarehouse,
+//	    "SKU-US-1": usWarehouse,
+//	})
+//
+// Calls are not transactional: on error, moves and fulfillments made so far
+// remain and are reported in the result. Running AutoFulfill again picks up
+// where it stopped, since only unfulfilled quantities are routed.
+func AutoFulfill(ctx context.Context, client core.Requester, orderID int64, routing Router) (*AutoFulfillResult, error) {
+	ctx = core.WithoutFields(ctx) // routing needs whole fulfillment orders
+	fos, err := listFulfillmentOrders(ctx, client, orderID)
+	if err != nil {
+		return nil, err
+	}
+	skus, err := lineItemSKUs(ctx, client, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AutoFulfillResult{}
+	byLocation := make(map[int64][]foLineItems)
+	for _, fo := range fos {
+		if fo.Status != StatusOpen && fo.Status != StatusInProgress {
+			continue
+		}
+		groups := make(map[int64][]FulfillmentOrderLineItem)
+		for _, li := range fo.LineItems {
+			qty := li.FulfillableQuantity
+			if qty <= 0 {
+				continue
+			}
+			item := Item{
+				OrderID:            orderID,
+				FulfillmentOrderID: fo.ID,
+				LineItemID:         li.LineItemID,
+				VariantID:          li.VariantID,
+				SKU:                skus[li.LineItemID],
+				Quantity:           qty,
+				AssignedLocationID: fo.AssignedLocationID,
+			}
+			loc, err := routing.Route(ctx, item)
+			if err != nil {
+				return res, fmt.Errorf("fulfillment: failed to route line item %d: %w", li.LineItemID, err)
+			}
+			if loc == 0 {
+				res.Skipped = append(res.Skipped, item)
+				continue
+			}
+			groups[loc] = append(groups[loc], FulfillmentOrderLineItem{ID: li.ID, Quantity: qty})
+		}
+
+		for _, loc := range sortedLocations(groups) {
+			foID := fo.ID
+			if loc != fo.AssignedLocationID {
+				foID, err = moveLineItems(ctx, client, fo.ID, loc, groups[loc])
+				if err != nil {
+					return res, err
+				}
+				res.Moves = append(res.Moves, Move{FulfillmentOrderID: fo.ID, LocationID: loc, MovedFulfillmentOrderID: foID})
+			}
+			byLocation[loc] = append(byLocation[loc], foLineItems{FulfillmentOrderID: foID, LineItems: groups[loc]})
+		}
+	}
+
+	for _, loc := range sortedLocations(byLocation) {
+		f, err := createFulfillment(ctx, client, byLocation[loc])
+		if err != nil {
+			return res, fmt.Errorf("fulfillment: failed to fulfill at location %d: %w", loc, err)
+		}
+		res.Fulfillments = append(res.Fulfillments, *f)
+	}
+	return res, nil
+}
+
+// foLineItems selects line items of one fulfillment order for a fulfillment.
+type foLineItems struct {
+	FulfillmentOrderID int64                      `json:"fulfillment_order_id"`
+	LineItems          []FulfillmentOrderLineItem `json:"fulfillment_order_line_items"`
+}
+
+func sortedLocations[T any](m map[int64]T) []int64 {
+	locs := make([]int64, 0, len(m))
+	for loc := range m {
+		locs = append(locs, loc)
+	}
+	sort.Slice(locs, func(i, j int) bool { return locs[i] < locs[j] })
+	return locs
+}
+
+func listFulfillmentOrders(ctx context.Context, client core.Requester, orderID int64) ([]FulfillmentOrder, error) {
+	var r struct {
+		FulfillmentOrders []FulfillmentOrder `json:"fulfillment_orders"`
+	}
+	path := client.CreatePath(fmt.Sprintf("orders/%d/fulfillment_orders.json", orderID))
+	if err := client.Get(ctx, path, &r, nil); err != nil {
+		return nil, fmt.Errorf("fulfillment: failed to list fulfillment orders of order %d: %w", orderID, err)
+	}
+	return r.FulfillmentOrders, nil
+}
+
+// lineItemSKUs maps the order's line item IDs to SKUs.
+func lineItemSKUs(ctx context.Context, client core.Requester, orderID int64) (map[int64]string, error) {
+	o, err := order.NewService(client).Get(core.WithFields(ctx, "id", "line_items"), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("fulfillment: failed to get order %d: %w", orderID, err)
+	}
+	skus := make(map[int64]string, len(o.LineItems))
+	for _, li := range o.LineItems {
+		skus[li.ID] = li.SKU
+	}
+	return skus, nil
+}
+
+// moveLineItems moves items of a fulfillment order to location and returns
+// the ID of the fulfillment order now holding them.
+func moveLineItems(ctx context.Context, client core.Requester, foID, location int64, items []FulfillmentOrderLineItem) (int64, error) {
+	body := map[string]interface{}{
+		"fulfillment_order": map[string]interface{}{
+			"new_location_id":              location,
+			"fulfillment_order_line_items": items,
+		},
+	}
+	var r struct {
+		Moved *FulfillmentOrder `json:"moved_fulfillment_order"`
+	}
+	path := client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/move.json", foID))
+	if err := client.Post(ctx, path, body, &r); err != nil {
+		return 0, fmt.Errorf("fulfillment: failed to move fulfillment order %d to location %d: %w", foID, location, err)
+	}
+	if r.Moved == nil || r.Moved.ID == 0 {
+		return 0, fmt.Errorf("fulfillment: move of fulfillment order %d returned no fulfillment order", foID)
+	}
+	return r.Moved.ID, nil
+}
+
+func createFulfillment(ctx context.Context, client core.Requester, items []foLineItems) (*order.Fulfillment, error) {
+	body := map[string]interface{}{
+		"fulfillment": map[string]interface{}{"line_items_by_fulfillment_order": items},
+	}
+	var r struct {
+		Fulfillment *order.Fulfillment `json:"fulfillment"`
+	}
+	if err := client.Post(ctx, client.CreatePath("fulfillments.json"), body, &r); err != nil {
+		return nil, err
+	}
+	if r.Fulfillment == nil {
+		return &order.Fulfillment{}, nil
+	}
+	return r.Fulfillment, nil
+}
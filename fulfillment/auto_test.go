@@ -0,0 +1,90 @@
+package fulfillment
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// fakeRequester serves one order with two fulfillment orders and records
+// writes.
+type fakeRequester struct {
+	posts map[string][]string
+}
+
+func (f *fakeRequester) CreatePath(resource string) string { return resource }
+func (f *fakeRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	var body string
+	switch path {
+	case "orders/1/fulfillment_orders.json":
+		body = `{"fulfillment_orders":[
+			{"id":10,"order_id":1,"assigned_location_id":100,"status":"open","line_items":[
+				{"id":11,"line_item_id":501,"fulfillable_quantity":2},
+				{"id":12,"line_item_id":502,"fulfillable_quantity":1},
+				{"id":13,"line_item_id":503,"fulfillable_quantity":0}]},
+			{"id":20,"order_id":1,"assigned_location_id":200,"status":"open","line_items":[
+				{"id":21,"line_item_id":504,"fulfillable_quantity":3},
+				{"id":22,"line_item_id":505,"fulfillable_quantity":1}]},
+			{"id":30,"order_id":1,"assigned_location_id":100,"status":"closed","line_items":[
+				{"id":31,"line_item_id":506,"fulfillable_quantity":1}]}]}`
+	case "orders/1.json":
+		body = `{"order":{"id":1,"line_items":[
+			{"id":501,"sku":"A"},{"id":502,"sku":"EU-B"},{"id":503,"sku":"A"},
+			{"id":504,"sku":"C"},{"id":505,"sku":"GONE"},{"id":506,"sku":"A"}]}}`
+	}
+	return json.Unmarshal([]byte(body), result)
+}
+func (f *fakeRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	b, _ := json.Marshal(body)
+	f.posts[path] = append(f.posts[path], string(b))
+	resp := `{"fulfillment":{"id":900}}`
+	if strings.HasSuffix(path, "/move.json") {
+		resp = `{"moved_fulfillment_order":{"id":99}}`
+	}
+	return json.Unmarshal([]byte(resp), result)
+}
+func (f *fakeRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return nil
+}
+func (f *fakeRequester) Delete(ctx context.Context, path string) error { return nil }
+
+func TestAutoFulfill(t *testing.T) {
+	f := &fakeRequester{posts: make(map[string][]string)}
+	routing := RouterFunc(func(ctx context.Context, item Item) (int64, error) {
+		switch {
+		case item.SKU == "GONE":
+			return 0, nil
+		case strings.HasPrefix(item.SKU, "EU-"):
+			return 200, nil
+		}
+		return SKURouting{}.Route(ctx, item)
+	})
+
+	res, err := AutoFulfill(context.Background(), f, 1, routing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	moves := f.posts["fulfillment_orders/10/move.json"]
+	if len(moves) != 1 || !strings.Contains(moves[0], `"new_location_id":200`) || !strings.Contains(moves[0], `[{"id":12,"quantity":1}]`) {
+		t.Errorf("expected EU-B to be moved to location 200, got %v", moves)
+	}
+	created := f.posts["fulfillments.json"]
+	if len(created) != 2 {
+		t.Fatalf("expected one fulfillment per location, got %v", created)
+	}
+	if !strings.Contains(created[0], `{"fulfillment_order_id":10,"fulfillment_order_line_items":[{"id":11,"quantity":2}]}`) {
+		t.Errorf("unexpected fulfillment at location 100: %s", created[0])
+	}
+	if !strings.Contains(created[1], `"fulfillment_order_id":99`) || !strings.Contains(created[1], `"fulfillment_order_id":20`) ||
+		strings.Contains(created[1], `"id":22`) {
+		t.Errorf("expected moved and local items combined at location 200: %s", created[1])
+	}
+	if len(res.Fulfillments) != 2 || len(res.Moves) != 1 || res.Moves[0].MovedFulfillmentOrderID != 99 {
+		t.Errorf("unexpected result %+v", res)
+	}
+	if len(res.Skipped) != 1 || res.Skipped[0].SKU != "GONE" {
+		t.Errorf("expected GONE to be skipped, got %+v", res.Skipped)
+	}
+}
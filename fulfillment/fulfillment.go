@@ -0,0 +1,158 @@
+// Package fulfillment implements the callback side of fulfillment services:
+// the notifications Shopline sends to the CallbackURL of a fulfillment
+// service registered with order.FulfillmentServiceDefService when a merchant
+// requests or cancels fulfillment by a third-party warehouse.
+package fulfillment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+// NotificationPath is appended by Shopline to the fulfillment service's
+// CallbackURL when sending notifications.
+const NotificationPath = "/fulfillment_order_notification"
+
+// Notification kinds.
+const (
+	KindFulfillmentRequest  = "FULFILLMENT_REQUEST"
+	KindCancellationRequest = "CANCELLATION_REQUEST"
+)
+
+// maxNotificationBodySize limits notification reads to 1MB.
+const maxNotificationBodySize = 1 << 20
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// Notification is a fulfillment or cancellation request sent to a
+// fulfillment service. The assigned fulfillment orders are included when
+// Shopline sends them; otherwise list them with the fulfillment order API.
+type Notification struct {
+	Kind             string            `json:"kind"`
+	Message          string            `json:"message,omitempty"`
+	FulfillmentOrder *FulfillmentOrder `json:"fulfillment_order,omitempty"`
+	ShopDomain       string            `json:"-"` // from the X-Shopline-Shop-Domain header
+}
+
+// FulfillmentOrder is the part of an order assigned to the fulfillment
+// service's location.
+type FulfillmentOrder struct {
+	ID                 int64                      `json:"id,omitempty"`
+	OrderID            int64                      `json:"order_id,omitempty"`
+	AssignedLocationID int64                      `json:"assigned_location_id,omitempty"`
+	Status             string                     `json:"status,omitempty"`
+	RequestStatus      string                     `json:"request_status,omitempty"`
+	LineItems          []FulfillmentOrderLineItem `json:"line_items,omitempty"`
+}
+
+type FulfillmentOrderLineItem struct {
+	ID                  int64 `json:"id,omitempty"`
+	LineItemID          int64 `json:"line_item_id,omitempty"`
+	VariantID           int64 `json:"variant_id,omitempty"`
+	Quantity            int   `json:"quantity,omitempty"`
+	FulfillableQuantity int   `json:"fulfillable_quantity,omitempty"`
+}
+
+// =====================================================================
+// Callback Handler
+// =====================================================================
+
+// FulfillmentProvider handles the notifications of a fulfillment service.
+// Returning an error responds with HTTP 500 so Shopline retries the
+// notification; return nil once the request has been recorded.
+type FulfillmentProvider interface {
+	// FulfillmentRequested is called when the merchant asks the service to
+	// fulfill items.
+	FulfillmentRequested(ctx context.Context, n Notification) error
+
+	// CancellationRequested is called when the merchant asks the service to
+	// cancel a previously accepted request.
+	CancellationRequested(ctx context.Context, n Notification) error
+}
+
+// HandlerOption configures a CallbackHandler.
+type HandlerOption func(*CallbackHandler)
+
+// WithVerifier sets the signature check run before the provider. Pass
+// App.VerifyWebhookRequest from the root package, since notifications are
+// signed like webhooks. Requests failing verification are rejected with
+// HTTP 401.
+func WithVerifier(verify func(r *http.Request) bool) HandlerOption {
+	return func(h *CallbackHandler) { h.verify = verify }
+}
+
+// WithErrorHandler sets a callback for provider errors, which are otherwise
+// only reported to Shopline as HTTP 500.
+func WithErrorHandler(fn func(n Notification, err error)) HandlerOption {
+	return func(h *CallbackHandler) { h.onError = fn }
+}
+
+// CallbackHandler is an http.Handler for fulfillment service notifications.
+// Mount it at the CallbackURL plus NotificationPath. Notifications of other
+// kinds are acknowledged and dropped.
+//
+//	h := fulfillment.NewCallbackHandler(my3PL, fulfillment.WithVerifier(app.VerifyWebhookRequest))
+//	http.Handle("/3pl"+fulfillment.NotificationPath, h)
+//	client.FulfillmentSvcDef.Create(ctx, order.FulfillmentServiceDef{
+//	    Name: "My 3PL", CallbackURL: "https://app.example.com/3pl", FulfillmentOrdersOptIn: true,
+//	})
+type CallbackHandler struct {
+	provider FulfillmentProvider
+	verify   func(r *http.Request) bool
+	onError  func(n Notification, err error)
+}
+
+// NewCallbackHandler creates a CallbackHandler dispatching to provider.
+func NewCallbackHandler(provider FulfillmentProvider, opts ...HandlerOption) *CallbackHandler {
+	h := &CallbackHandler{provider: provider}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.verify != nil && !h.verify(r) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNotificationBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil || n.Kind == "" {
+		http.Error(w, "invalid notification", http.StatusBadRequest)
+		return
+	}
+	n.ShopDomain = r.Header.Get(webhook.HeaderShopDomain)
+
+	switch n.Kind {
+	case KindFulfillmentRequest:
+		err = h.provider.FulfillmentRequested(r.Context(), n)
+	case KindCancellationRequest:
+		err = h.provider.CancellationRequested(r.Context(), n)
+	}
+	if err != nil {
+		if h.onError != nil {
+			h.onError(n, err)
+		}
+		http.Error(w, fmt.Sprintf("%s failed", n.Kind), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,78 @@
+package fulfillment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+type recordingProvider struct {
+	requested, cancelled []Notification
+	err                  error
+}
+
+func (p *recordingProvider) FulfillmentRequested(ctx context.Context, n Notification) error {
+	p.requested = append(p.requested, n)
+	return p.err
+}
+
+func (p *recordingProvider) CancellationRequested(ctx context.Context, n Notification) error {
+	p.cancelled = append(p.cancelled, n)
+	return p.err
+}
+
+func post(h http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, NotificationPath, strings.NewReader(body))
+	req.Header.Set(webhook.HeaderShopDomain, "open001.myshopline.com")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCallbackHandler(t *testing.T) {
+	p := &recordingProvider{}
+	h := NewCallbackHandler(p)
+
+	rec := post(h, `{"kind":"FULFILLMENT_REQUEST","message":"ship fast","fulfillment_order":{"id":7,"order_id":1001,"line_items":[{"id":1,"quantity":2}]}}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if len(p.requested) != 1 {
+		t.Fatalf("expected one fulfillment request, got %d", len(p.requested))
+	}
+	n := p.requested[0]
+	if n.Message != "ship fast" || n.ShopDomain != "open001.myshopline.com" || n.FulfillmentOrder.ID != 7 || n.FulfillmentOrder.LineItems[0].Quantity != 2 {
+		t.Errorf("unexpected notification: %+v", n)
+	}
+
+	post(h, `{"kind":"CANCELLATION_REQUEST"}`)
+	post(h, `{"kind":"SOMETHING_NEW"}`)
+	if len(p.cancelled) != 1 || len(p.requested) != 1 {
+		t.Errorf("unexpected dispatch: %d requested, %d cancelled", len(p.requested), len(p.cancelled))
+	}
+}
+
+func TestCallbackHandler_Errors(t *testing.T) {
+	var reported error
+	p := &recordingProvider{err: errors.New("warehouse offline")}
+	h := NewCallbackHandler(p, WithErrorHandler(func(n Notification, err error) { reported = err }))
+
+	if rec := post(h, `{"kind":"FULFILLMENT_REQUEST"}`); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500 on provider error, got %d", rec.Code)
+	}
+	if reported == nil {
+		t.Error("expected provider error to be reported")
+	}
+	if rec := post(h, `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for malformed body, got %d", rec.Code)
+	}
+	rejecting := NewCallbackHandler(p, WithVerifier(func(*http.Request) bool { return false }))
+	if rec := post(rejecting, `{"kind":"FULFILLMENT_REQUEST"}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 for bad signature, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,130 @@
+package shopline
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// hedgeConfig duplicates slow GET requests; see WithHedgedRequests.
+type hedgeConfig struct {
+	delay     time.Duration
+	maxHedges int
+}
+
+type hedgeResult struct {
+	index   int
+	resp    *http.Response
+	err     error
+	release func()
+}
+
+// sendRequest performs a single HTTP round trip, hedged for GETs when
+// configured.
+func (c *Client) sendRequest(req *http.Request) (*http.Response, error) {
+	if c.hedge == nil || req.Method != http.MethodGet {
+		return c.httpClient.Do(req)
+	}
+	acquire := func() (func(), bool) { return func() {}, true }
+	if c.limiter != nil {
+		acquire = func() (func(), bool) { return c.limiter.tryAcquire(c.handle) }
+	}
+	return c.hedge.do(c.httpClient, req, acquire)
+}
+
+// do sends req, and another copy every h.delay while no response has
+// arrived, up to h.maxHedges copies. The first successful round trip wins;
+// the others are cancelled. If every copy fails the last error is returned.
+//
+// req itself runs in the slot Do acquired; each further copy needs a slot
+// of its own from acquire, and is skipped when none is free, so hedging
+// never exceeds the client's concurrency limit.
+func (h *hedgeConfig) do(hc *http.Client, req *http.Request, acquire func() (func(), bool)) (*http.Response, error) {
+	results := make(chan hedgeResult, h.maxHedges+1)
+	var cancels []context.CancelFunc
+	launch := func(release func()) {
+		ctx, cancel := context.WithCancel(req.Context())
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := hc.Do(req.Clone(ctx))
+			results <- hedgeResult{index: index, resp: resp, err: err, release: release}
+		}()
+	}
+
+	launch(func() {})
+	pending := 1
+	timer := time.NewTimer(h.delay)
+	defer timer.Stop()
+	hedgeC := timer.C
+	hedges := 0
+
+	var lastErr error
+	for {
+		select {
+		case <-hedgeC:
+			hedges++
+			if release, ok := acquire(); ok {
+				launch(release)
+				pending++
+			}
+			if hedges >= h.maxHedges {
+				hedgeC = nil
+			} else {
+				timer.Reset(h.delay)
+			}
+		case r := <-results:
+			pending--
+			if r.err != nil {
+				cancels[r.index]()
+				r.release()
+				lastErr = r.err
+				if pending == 0 {
+					return nil, lastErr
+				}
+				continue
+			}
+			for i, cancel := range cancels {
+				if i != r.index {
+					cancel()
+				}
+			}
+			go discardHedges(results, pending)
+			r.resp.Body = &cancelOnClose{ReadCloser: r.resp.Body, cancel: cancels[r.index], release: r.release}
+			return r.resp, nil
+		}
+	}
+}
+
+// discardHedges closes the responses of losing copies that were already in
+// flight when the winner arrived.
+func discardHedges(results <-chan hedgeResult, n int) {
+	for ; n > 0; n-- {
+		r := <-results
+		if r.resp != nil {
+			io.Copy(io.Discard, r.resp.Body)
+			r.resp.Body.Close()
+		}
+		r.release()
+	}
+}
+
+// cancelOnClose releases the winning copy's context and limiter slot once
+// its body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel  context.CancelFunc
+	release func()
+	once    sync.Once
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.cancel()
+		b.release()
+	})
+	return err
+}
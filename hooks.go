@@ -0,0 +1,102 @@
+package shopline
+
+import (
+	"net/http"
+	"time"
+)
+
+// Hooks are callbacks invoked by Do around each attempt of a request, e.g.
+// to record Prometheus histograms or traces. Any field may be nil. Hooks run
+// synchronously on the calling goroutine, so they should return quickly, and
+// must be safe for concurrent use.
+type Hooks struct {
+	// OnRequest is called before each attempt is sent.
+	OnRequest func(RequestInfo)
+
+	// OnResponse is called after each attempt, with either a response
+	// status or a transport error.
+	OnResponse func(ResponseInfo)
+
+	// OnRetry is called before waiting to retry a failed attempt.
+	OnRetry func(RetryInfo)
+
+	// OnCircuitOpen is called when the circuit breaker rejects an attempt.
+	OnCircuitOpen func(req *http.Request, err error)
+}
+
+// RequestInfo describes an attempt about to be sent.
+type RequestInfo struct {
+	Request *http.Request
+	Attempt int // 1 for the first attempt
+}
+
+// ResponseInfo describes the outcome of an attempt.
+type ResponseInfo struct {
+	Request    *http.Request
+	Attempt    int
+	Duration   time.Duration // time until response headers arrived
+	StatusCode int           // 0 on transport errors
+	TraceID    string
+	RateLimit  RateLimit
+	Err        error // transport error, if any
+}
+
+// RetryInfo describes a retry about to be waited for.
+type RetryInfo struct {
+	Request    *http.Request
+	Attempt    int           // the attempt that failed
+	Wait       time.Duration // backoff or Retry-After before the next attempt
+	StatusCode int           // 0 on transport errors
+	Err        error         // transport error, if any
+}
+
+// WithHooks sets callbacks observing each request attempt:
+//
+//	shopline.WithHooks(shopline.Hooks{
+//	    OnResponse: func(info shopline.ResponseInfo) {
+//	        latency.WithLabelValues(info.Request.Method, strconv.Itoa(info.StatusCode)).
+//	            Observe(info.Duration.Seconds())
+//	    },
+//	    OnRetry: func(info shopline.RetryInfo) { retries.Inc() },
+//	})
+func WithHooks(h Hooks) Option {
+	return func(c *Client) {
+		c.hooks = h
+	}
+}
+
+func (c *Client) hookRequest(req *http.Request, attempt int) {
+	if c.hooks.OnRequest != nil {
+		c.hooks.OnRequest(RequestInfo{Request: req, Attempt: attempt + 1})
+	}
+}
+
+func (c *Client) hookResponse(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) {
+	if c.hooks.OnResponse == nil {
+		return
+	}
+	info := ResponseInfo{Request: req, Attempt: attempt + 1, Duration: timeNow().Sub(start), Err: err}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+		info.TraceID = traceIDFromHeader(resp.Header)
+		info.RateLimit = parseRateLimit(resp.Header)
+	}
+	c.hooks.OnResponse(info)
+}
+
+func (c *Client) hookRetry(req *http.Request, attempt int, wait time.Duration, resp *http.Response, err error) {
+	if c.hooks.OnRetry == nil {
+		return
+	}
+	info := RetryInfo{Request: req, Attempt: attempt + 1, Wait: wait, Err: err}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	c.hooks.OnRetry(info)
+}
+
+func (c *Client) hookCircuitOpen(req *http.Request, err error) {
+	if c.hooks.OnCircuitOpen != nil {
+		c.hooks.OnCircuitOpen(req, err)
+	}
+}
@@ -0,0 +1,73 @@
+package shopline
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ============================================================
+// Hooks Tests
+// ============================================================
+
+func TestWithHooks(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Remaining", "39")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0.001")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"product":{"id":1}}`))
+	})
+	defer server.Close()
+
+	var requests []RequestInfo
+	var responses []ResponseInfo
+	var retries []RetryInfo
+	WithHooks(Hooks{
+		OnRequest:  func(info RequestInfo) { requests = append(requests, info) },
+		OnResponse: func(info ResponseInfo) { responses = append(responses, info) },
+		OnRetry:    func(info RetryInfo) { retries = append(retries, info) },
+	})(client)
+	WithRetryPolicy(RetryPolicy{MaxRetries: 1, BaseBackoff: time.Millisecond})(client)
+
+	if _, err := client.Product.Get(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 || requests[0].Attempt != 1 || requests[1].Attempt != 2 {
+		t.Errorf("unexpected requests: %+v", requests)
+	}
+	if len(responses) != 2 || responses[0].StatusCode != http.StatusTooManyRequests || responses[1].StatusCode != http.StatusOK {
+		t.Fatalf("unexpected responses: %+v", responses)
+	}
+	if responses[1].RateLimit.Remaining != 39 {
+		t.Errorf("expected rate limit remaining 39, got %d", responses[1].RateLimit.Remaining)
+	}
+	if len(retries) != 1 || retries[0].Attempt != 1 || retries[0].StatusCode != http.StatusTooManyRequests || retries[0].Wait != time.Millisecond {
+		t.Errorf("unexpected retries: %+v", retries)
+	}
+}
+
+func TestWithHooks_CircuitOpen(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	var opened error
+	WithHooks(Hooks{OnCircuitOpen: func(req *http.Request, err error) { opened = err }})(client)
+	WithCircuitBreaker(1, time.Minute)(client)
+	WithRetry(0)(client)
+
+	client.Product.Get(context.Background(), 1)
+	client.Product.Get(context.Background(), 1)
+	if opened == nil || !strings.Contains(opened.Error(), "circuit breaker is open") {
+		t.Errorf("expected circuit open hook, got %v", opened)
+	}
+}
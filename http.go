@@ -9,10 +9,11 @@ import (
 	"math/rand"
 	"net/http"
 	"net/url"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/imokyou/slshop/core"
 )
 
 const (
@@ -46,11 +47,13 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body in
 	reqURL := c.baseURL.ResolveReference(rel)
 
 	var buf io.Reader
+	var compressed bool
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err := c.marshalJSON(body)
 		if err != nil {
 			return nil, fmt.Errorf("shopline: failed to marshal request body: %w", err)
 		}
+		jsonBody, compressed = c.compressBody(jsonBody)
 		buf = bytes.NewBuffer(jsonBody)
 	}
 
@@ -63,6 +66,16 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body in
 	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", UserAgent)
+	if c.compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	setContextHeaders(ctx, req)
+	if key := core.IdempotencyKey(ctx); key != "" && req.Header.Get(IdempotencyKeyHeader) == "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
 
 	// Set authorization header
 	// If TokenManager is set, dynamically fetch a valid token (may trigger refresh).
@@ -83,13 +96,32 @@ func (c *Client) NewRequest(ctx context.Context, method, relPath string, body in
 }
 
 // Do sends an HTTP request and decodes the JSON response.
-// It handles retries for rate limiting (429) and server errors (503)
-// with exponential backoff and jitter. It respects context cancellation
-// during retry waits.
+// It retries transport errors and retryable status codes (by default 429
+// and 503) as decided by the client's RetryPolicy, with exponential backoff
+// and jitter. It respects context cancellation during retry waits. Retries
+// are skipped for requests whose context was built with core.WithNoRetry.
 func (c *Client) Do(req *http.Request, result interface{}) (*http.Response, error) {
 	var resp *http.Response
 	var err error
 
+	if c.limiter != nil {
+		release, err := c.limiter.acquire(req.Context(), c.handle)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	policy := c.retryPolicy
+	if policy == nil {
+		def := DefaultRetryPolicy()
+		policy = &def
+	}
+	maxRetries := policy.retriesFor(req, c.maxRetries)
+	if core.NoRetry(req.Context()) {
+		maxRetries = 0
+	}
+
 	// P0-1: Pre-save request body before the retry loop.
 	// The body is a one-time-use stream — if we don't save it before the first
 	// attempt, retries will send empty bodies silently.
@@ -103,56 +135,77 @@ func (c *Client) Do(req *http.Request, result interface{}) (*http.Response, erro
 		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 	}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	attempts := 0
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		attempts = attempt + 1
 		// Check circuit breaker before each attempt
 		if c.cb != nil {
 			if cbErr := c.cb.Allow(); cbErr != nil {
+				c.hookCircuitOpen(req, cbErr)
 				return nil, cbErr
 			}
 		}
 
 		if attempt > 0 {
-			c.logDebugf("Retry attempt %d/%d for %s %s", attempt, c.maxRetries, req.Method, req.URL)
+			c.logDebugf("Retry attempt %d/%d for %s %s", attempt, maxRetries, req.Method, req.URL)
 			// Restore body for retry
 			if bodyBytes != nil {
 				req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 			}
 		}
 
-		resp, err = c.httpClient.Do(req)
+		c.hookRequest(req, attempt)
+		start := timeNow()
+		resp, err = c.sendRequest(req)
+		c.hookResponse(req, attempt, start, resp, err)
+		if err == nil {
+			decompressResponse(resp)
+		}
 		if err != nil {
 			if c.cb != nil {
 				c.cb.RecordFailure()
 			}
-			if attempt < c.maxRetries {
+			if attempt < maxRetries && policy.retryError(err) {
 				// P1-4: Exponential backoff with jitter for network errors
-				backoff := backoffDuration(attempt, time.Second)
+				backoff := policy.backoff(attempt, time.Second)
 				c.logDebugf("Request error: %v, backing off %s", err, backoff)
+				c.setGauge(MetricRetryWait, backoff.Seconds())
+				if exceedsDeadline(req.Context(), backoff) {
+					return nil, c.retryDeadlineError(backoff, fmt.Errorf("shopline: request failed: %w", err))
+				}
+				c.hookRetry(req, attempt, backoff, nil, err)
 				// P0-2: Respect context cancellation during sleep
 				if sleepErr := sleepWithContext(req.Context(), backoff); sleepErr != nil {
 					return nil, fmt.Errorf("shopline: request cancelled during retry: %w", sleepErr)
 				}
 				continue
 			}
-			return nil, fmt.Errorf("shopline: request failed after %d retries: %w", c.maxRetries, err)
+			return nil, fmt.Errorf("shopline: request failed after %d retries: %w", attempt, err)
 		}
 
 		// Check for retryable status codes
-		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if policy.retryStatus(resp.StatusCode) {
 			if c.cb != nil {
 				c.cb.RecordFailure()
 			}
-			if attempt < c.maxRetries {
+			if attempt < maxRetries {
 				// P1-5: Correctly parse Retry-After header
 				retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
 				if retryAfter <= 0 {
 					// Fall back to exponential backoff
-					retryAfter = backoffDuration(attempt, 2*time.Second)
+					retryAfter = policy.backoff(attempt, 2*time.Second)
+				}
+				c.logDebugf("Retryable response (HTTP %d, traceId: %s), retrying after %s", resp.StatusCode, traceIDFromHeader(resp.Header), retryAfter)
+				c.setGauge(MetricRetryWait, retryAfter.Seconds())
+				if exceedsDeadline(req.Context(), retryAfter) {
+					body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+					resp.Body.Close()
+					return resp, c.retryDeadlineError(retryAfter, c.responseError(resp, body))
 				}
 				// Read and discard body before closing to allow connection reuse
 				io.Copy(io.Discard, resp.Body)
 				resp.Body.Close()
-				c.logDebugf("Rate limited or service unavailable (HTTP %d), retrying after %s", resp.StatusCode, retryAfter)
+				c.hookRetry(req, attempt, retryAfter, resp, nil)
 				// P0-2: Respect context cancellation during sleep
 				if sleepErr := sleepWithContext(req.Context(), retryAfter); sleepErr != nil {
 					return nil, fmt.Errorf("shopline: request cancelled during retry: %w", sleepErr)
@@ -167,11 +220,35 @@ func (c *Client) Do(req *http.Request, result interface{}) (*http.Response, erro
 	if resp == nil {
 		return nil, fmt.Errorf("shopline: no response received")
 	}
+	traceID := traceIDFromHeader(resp.Header)
+	c.logDebugf("%s %s: HTTP %d (traceId: %s)", req.Method, req.URL.Path, resp.StatusCode, traceID)
+	recordResponseInfo(req, resp, attempts)
+
+	if c.streams(resp, result) {
+		if err := c.decodeStream(resp.Body, result); err != nil {
+			return resp, fmt.Errorf("shopline: failed to decode response (traceId: %s): %w", traceID, err)
+		}
+		if c.cb != nil {
+			c.cb.RecordSuccess()
+		}
+		return resp, nil
+	}
 
 	// P1-6: Limit response body size to prevent OOM
 	// P0-3: Read body fully, then close — do NOT defer close and return resp
 	//       with an open body, which creates a data race for callers.
-	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	var body []byte
+	var readErr error
+	if c.pooledBuffers() {
+		// The buffer is reused once Do returns, so nothing below may keep
+		// a reference to body.
+		buf := getBuffer()
+		defer putBuffer(buf)
+		_, readErr = buf.ReadFrom(io.LimitReader(resp.Body, maxResponseBodySize))
+		body = buf.Bytes()
+	} else {
+		body, readErr = io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	}
 	resp.Body.Close()
 
 	if readErr != nil {
@@ -180,13 +257,13 @@ func (c *Client) Do(req *http.Request, result interface{}) (*http.Response, erro
 
 	// Check for errors
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return resp, parseResponseErrorFromBytes(resp, body)
+		return resp, c.responseError(resp, bytes.Clone(body))
 	}
 
 	// Decode response body
 	if result != nil && len(body) > 0 {
-		if err := json.Unmarshal(body, result); err != nil {
-			return resp, fmt.Errorf("shopline: failed to decode response: %w (body: %s)", err, string(body))
+		if err := c.decodeResponse(req, body, result); err != nil {
+			return resp, fmt.Errorf("shopline: failed to decode response (traceId: %s): %w (body: %s)", traceID, err, string(body))
 		}
 	}
 
@@ -198,16 +275,90 @@ func (c *Client) Do(req *http.Request, result interface{}) (*http.Response, erro
 	return resp, nil
 }
 
+// recordResponseInfo stores the metadata of resp in the PageInfo set on the
+// request's context with core.WithPageInfo and the RequestInfo set with
+// WithRequestInfo, and the cursors of a successful response's Link header in
+// the Pagination set with core.WithPagination, if any.
+func recordResponseInfo(req *http.Request, resp *http.Response, attempts int) {
+	ctx := req.Context()
+	if info := RequestInfoFromContext(ctx); info != nil {
+		*info = RequestTrace{
+			Request:    req,
+			Attempt:    attempts,
+			StatusCode: resp.StatusCode,
+			TraceID:    traceIDFromHeader(resp.Header),
+		}
+	}
+	var pagination core.Pagination
+	ok := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if ok {
+		pagination = core.ParseLinkHeader(resp.Header.Get("Link"))
+	}
+	if info := core.PageInfoFrom(ctx); info != nil {
+		*info = core.PageInfo{
+			Pagination: pagination,
+			StatusCode: resp.StatusCode,
+			TraceID:    traceIDFromHeader(resp.Header),
+			RateLimit:  parseRateLimit(resp.Header),
+		}
+	}
+	if p := core.PaginationFrom(ctx); p != nil && ok {
+		*p = pagination
+	}
+}
+
+// decodeResponse unmarshals body into result. When strict decoding or a drift
+// handler is configured, unknown fields are detected with DisallowUnknownFields.
+func (c *Client) decodeResponse(req *http.Request, body []byte, result interface{}) error {
+	if !c.strictDecoding && c.onDecodeDrift == nil {
+		return c.unmarshalJSON(body, result)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(result)
+	field, unknown := unknownFieldName(err)
+	if !unknown {
+		return err
+	}
+	if c.onDecodeDrift != nil {
+		c.onDecodeDrift(req, field)
+	}
+	if c.strictDecoding {
+		return err
+	}
+	// Strict decoding stops at the unknown field; decode again leniently.
+	return c.unmarshalJSON(body, result)
+}
+
+// unknownFieldName extracts the key from encoding/json's unknown field error.
+func unknownFieldName(err error) (string, bool) {
+	const prefix = "json: unknown field "
+	if err == nil || !strings.HasPrefix(err.Error(), prefix) {
+		return "", false
+	}
+	return strings.Trim(strings.TrimPrefix(err.Error(), prefix), `"`), true
+}
+
 // Get performs a GET request to the given path and decodes the response.
 func (c *Client) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	var queryString string
 	if opts != nil {
-		queryString := buildQueryString(opts)
-		if queryString != "" {
-			if strings.Contains(path, "?") {
-				path += "&" + queryString
-			} else {
-				path += "?" + queryString
-			}
+		qs, err := buildQueryString(opts)
+		if err != nil {
+			return err
+		}
+		queryString = qs
+	}
+	queryString, err := withSelectedFields(ctx, queryString, result)
+	if err != nil {
+		return err
+	}
+	if queryString != "" {
+		if strings.Contains(path, "?") {
+			path += "&" + queryString
+		} else {
+			path += "?" + queryString
 		}
 	}
 
@@ -253,6 +404,33 @@ func (c *Client) Delete(ctx context.Context, path string) error {
 	return err
 }
 
+// exceedsDeadline reports whether waiting d would outlast ctx's deadline.
+func exceedsDeadline(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && timeNow().Add(d).After(deadline)
+}
+
+// responseError parses an error response and records the client's store
+// on it.
+func (c *Client) responseError(resp *http.Response, body []byte) error {
+	err := parseResponseErrorFromBytes(resp, body)
+	switch e := err.(type) {
+	case *ResponseError:
+		e.Shop = c.handle
+	case *RateLimitError:
+		e.Shop = c.handle
+	}
+	return err
+}
+
+// retryDeadlineError logs and counts a retry abandoned because of the
+// context deadline.
+func (c *Client) retryDeadlineError(wait time.Duration, err error) error {
+	c.logDebugf("Retry wait %s exceeds context deadline, giving up: %v", wait, err)
+	c.incCounter(MetricRetryDeadline)
+	return &RetryDeadlineError{Wait: wait, RetryAt: timeNow().Add(wait), Err: err}
+}
+
 // sleepWithContext sleeps for the specified duration or until the context is
 // cancelled, whichever comes first. Returns ctx.Err() if cancelled.
 func sleepWithContext(ctx context.Context, d time.Duration) error {
@@ -269,11 +447,20 @@ func sleepWithContext(ctx context.Context, d time.Duration) error {
 // backoffDuration returns an exponential backoff duration with jitter.
 // The formula is: base * 2^attempt, capped at maxBackoff, with ±25% jitter.
 func backoffDuration(attempt int, base time.Duration) time.Duration {
+	return cappedBackoff(attempt, base, maxBackoff)
+}
+
+// cappedBackoff is backoffDuration with a caller-supplied cap.
+func cappedBackoff(attempt int, base, max time.Duration) time.Duration {
 	backoff := base * time.Duration(1<<uint(attempt))
-	if backoff > maxBackoff {
-		backoff = maxBackoff
+	if backoff > max || backoff <= 0 {
+		backoff = max
+	}
+	// Add jitter: 75%-125% of backoff to prevent thundering herd.
+	// Backoffs too short to split (a nanosecond in tests) are used as is.
+	if backoff/2 <= 0 {
+		return backoff
 	}
-	// Add jitter: 75%-125% of backoff to prevent thundering herd
 	jitter := time.Duration(rand.Int63n(int64(backoff/2))) - backoff/4
 	return backoff + jitter
 }
@@ -301,71 +488,3 @@ func parseRetryAfter(header string) time.Duration {
 	}
 	return 0
 }
-
-// buildQueryString converts a struct with `url` tags to a query string.
-// Supports basic scalar types and slice types ([]string, []int64, []int, etc.).
-// Slice fields are expanded into repeated query parameters: ids=1&ids=2&ids=3.
-func buildQueryString(opts interface{}) string {
-	if opts == nil {
-		return ""
-	}
-
-	v := reflect.ValueOf(opts)
-	if v.Kind() == reflect.Ptr {
-		if v.IsNil() {
-			return ""
-		}
-		v = v.Elem()
-	}
-
-	if v.Kind() != reflect.Struct {
-		return ""
-	}
-
-	params := url.Values{}
-	buildQueryStringFromStruct(v, params)
-	return params.Encode()
-}
-
-// buildQueryStringFromStruct recursively walks a struct (including embedded structs)
-// and populates params from fields tagged with `url`.
-func buildQueryStringFromStruct(v reflect.Value, params url.Values) {
-	t := v.Type()
-
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
-
-		// Recurse into embedded (anonymous) structs to pick up their url tags
-		if fieldType.Anonymous && field.Kind() == reflect.Struct {
-			buildQueryStringFromStruct(field, params)
-			continue
-		}
-
-		tag := fieldType.Tag.Get("url")
-		if tag == "" || tag == "-" {
-			continue
-		}
-
-		parts := strings.Split(tag, ",")
-		name := parts[0]
-		omitempty := len(parts) > 1 && parts[1] == "omitempty"
-
-		// Handle slice types: expand into repeated parameters
-		if field.Kind() == reflect.Slice {
-			if omitempty && field.Len() == 0 {
-				continue
-			}
-			for j := 0; j < field.Len(); j++ {
-				params.Add(name, fmt.Sprintf("%v", field.Index(j).Interface()))
-			}
-			continue
-		}
-
-		if omitempty && field.IsZero() {
-			continue
-		}
-
-		params.Set(name, fmt.Sprintf("%v", field.Interface()))
-	}
-}
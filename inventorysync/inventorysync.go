@@ -0,0 +1,117 @@
+// Package inventorysync reads a location's stock by SKU and computes the
+// adjustments that reconcile it with another source, such as a warehouse
+// export.
+//
+//	live, err := inventorysync.Snapshot(ctx, client, locationID)
+//	for _, adj := range inventorysync.Diff(live, warehouse) {
+//	    item := itemIDs[adj.SKU] // the caller's SKU → inventory item ID map
+//	    client.Inventory.AdjustLevel(ctx, item, locationID, adj.Delta)
+//	}
+package inventorysync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/product"
+)
+
+// pageSize is the Limit used for inventory reads.
+const pageSize = 250
+
+// Stock maps SKUs to available quantities at one location.
+type Stock map[string]int
+
+// Adjustment is the change to a SKU's available quantity that Diff found.
+type Adjustment struct {
+	SKU   string
+	From  int
+	To    int
+	Delta int // To - From, as passed to InventoryService.AdjustLevel
+}
+
+// Snapshot reads the available quantity of every inventory item stocked at
+// locationID, keyed by the item's SKU. Levels are read with cursor pagination
+// and items with since_id, so it works for catalogues of any size. Items
+// without a SKU are left out; a SKU shared by two items is an error, as it
+// cannot be reconciled.
+func Snapshot(ctx context.Context, client core.Requester, locationID int64) (Stock, error) {
+	inv := product.NewInventoryService(client)
+
+	available := make(map[int64]int)
+	ctx, page := core.WithPagination(ctx)
+	opts := &product.InventoryLevelListOptions{LocationIDs: fmt.Sprint(locationID)}
+	opts.Limit = pageSize
+	for opts != nil {
+		levels, err := inv.ListLevels(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("inventorysync: failed to list inventory levels: %w", err)
+		}
+		for _, l := range levels {
+			if l.LocationID == 0 || l.LocationID == locationID {
+				available[l.InventoryItemID] += l.Available
+			}
+		}
+		next := page.NextOptions(&opts.ListOptions)
+		if next == nil {
+			break
+		}
+		// The cursor carries the location filter.
+		opts = &product.InventoryLevelListOptions{ListOptions: *next}
+	}
+
+	stock := make(Stock, len(available))
+	owner := make(map[string]int64, len(available))
+	remaining := len(available)
+	var sinceID int64
+	for remaining > 0 {
+		items, err := inv.ListItems(ctx, &core.ListOptions{SinceID: sinceID, Limit: pageSize})
+		if err != nil {
+			return nil, fmt.Errorf("inventorysync: failed to list inventory items: %w", err)
+		}
+		for _, item := range items {
+			qty, ok := available[item.ID]
+			if !ok {
+				continue
+			}
+			remaining--
+			if item.SKU == "" {
+				continue
+			}
+			if other, dup := owner[item.SKU]; dup {
+				return nil, fmt.Errorf("inventorysync: SKU %q is used by inventory items %d and %d", item.SKU, other, item.ID)
+			}
+			owner[item.SKU] = item.ID
+			stock[item.SKU] = qty
+		}
+		if len(items) < pageSize {
+			break
+		}
+		sinceID = items[len(items)-1].ID
+	}
+	return stock, nil
+}
+
+// Diff returns the adjustments that turn the quantities in a into those in b,
+// ordered by SKU. A SKU missing from either side counts as zero, so b should
+// hold every SKU the location is meant to stock.
+func Diff(a, b Stock) []Adjustment {
+	var adjs []Adjustment
+	add := func(sku string) {
+		if delta := b[sku] - a[sku]; delta != 0 {
+			adjs = append(adjs, Adjustment{SKU: sku, From: a[sku], To: b[sku], Delta: delta})
+		}
+	}
+	for sku := range a {
+		add(sku)
+	}
+	for sku := range b {
+		if _, ok := a[sku]; !ok {
+			add(sku)
+		}
+	}
+	sort.Slice(adjs, func(i, j int) bool { return adjs[i].SKU < adjs[j].SKU })
+	return adjs
+}
@@ -0,0 +1,92 @@
+package inventorysync
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/product"
+)
+
+// fakeRequester serves inventory levels in two cursor pages and items by
+// since_id.
+type fakeRequester struct {
+	items      []product.InventoryItem
+	levelCalls []string
+}
+
+func (f *fakeRequester) CreatePath(resource string) string { return resource }
+func (f *fakeRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	var body interface{}
+	switch path {
+	case "inventory_levels.json":
+		o := opts.(*product.InventoryLevelListOptions)
+		f.levelCalls = append(f.levelCalls, o.LocationIDs+"|"+o.PageInfo)
+		levels := []product.InventoryLevel{{InventoryItemID: 1, LocationID: 7, Available: 5}, {InventoryItemID: 2, LocationID: 7, Available: 3}}
+		*core.PaginationFrom(ctx) = core.Pagination{NextPageInfo: "p2"}
+		if o.PageInfo == "p2" {
+			levels = []product.InventoryLevel{{InventoryItemID: 3, LocationID: 7, Available: 0}, {InventoryItemID: 4, LocationID: 7, Available: 9}}
+			*core.PaginationFrom(ctx) = core.Pagination{}
+		}
+		body = map[string]interface{}{"inventory_levels": levels}
+	case "inventory_items.json":
+		o := opts.(*core.ListOptions)
+		var items []product.InventoryItem
+		for _, item := range f.items {
+			if item.ID > o.SinceID {
+				items = append(items, item)
+			}
+		}
+		body = map[string]interface{}{"inventory_items": items}
+	}
+	data, _ := json.Marshal(body)
+	return json.Unmarshal(data, result)
+}
+func (f *fakeRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return nil
+}
+func (f *fakeRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return nil
+}
+func (f *fakeRequester) Delete(ctx context.Context, path string) error { return nil }
+
+func TestSnapshot(t *testing.T) {
+	f := &fakeRequester{items: []product.InventoryItem{
+		{ID: 1, SKU: "A"}, {ID: 2, SKU: "B"}, {ID: 3, SKU: "C"}, {ID: 4}, {ID: 5, SKU: "E"},
+	}}
+	stock, err := Snapshot(context.Background(), f, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Stock{"A": 5, "B": 3, "C": 0}
+	if !reflect.DeepEqual(stock, want) {
+		t.Errorf("got %v, want %v", stock, want)
+	}
+	if !reflect.DeepEqual(f.levelCalls, []string{"7|", "|p2"}) {
+		t.Errorf("unexpected level requests %v", f.levelCalls)
+	}
+
+	f.items[1].SKU = "A"
+	if _, err := Snapshot(context.Background(), f, 7); err == nil || !strings.Contains(err.Error(), `SKU "A"`) {
+		t.Errorf("expected duplicate SKU error, got %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	live := Stock{"A": 5, "B": 3, "C": 0}
+	warehouse := Stock{"A": 5, "B": 1, "D": 4}
+	got := Diff(live, warehouse)
+	want := []Adjustment{
+		{SKU: "B", From: 3, To: 1, Delta: -2},
+		{SKU: "D", From: 0, To: 4, Delta: 4},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if adjs := Diff(live, live); len(adjs) != 0 {
+		t.Errorf("expected no adjustments, got %+v", adjs)
+	}
+}
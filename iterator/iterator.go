@@ -0,0 +1,152 @@
+package iterator
+
+import (
+	"context"
+)
+
+// =====================================================================
+// Page Iterator
+// =====================================================================
+
+// PageFunc fetches a single page of results. page is 1-based.
+//
+// Any List method on a service can be adapted:
+//
+//	fetch := func(ctx context.Context, page int) ([]product.Product, error) {
+//	    return client.Product.List(ctx, &core.ListOptions{Page: page, Limit: 250})
+//	}
+type PageFunc[T any] func(ctx context.Context, page int) ([]T, error)
+
+// Option configures an Iterator.
+type Option func(*config)
+
+type config struct {
+	parallel  int
+	pageSize  int
+	startPage int
+}
+
+// Parallel prefetches up to n pages concurrently while the caller consumes
+// the current one. Items are always delivered in page order, so n only
+// changes wall-clock time, never the sequence seen by the caller.
+//
+// Every prefetch goes through the client's normal request path, so the
+// retry policy, Retry-After handling and circuit breaker still apply. Keep
+// n small (2-8): each in-flight page counts against the store's rate limit.
+// Values below 1 are treated as 1 (sequential).
+func Parallel(n int) Option {
+	return func(c *config) {
+		if n < 1 {
+			n = 1
+		}
+		c.parallel = n
+	}
+}
+
+// PageSize tells the iterator the Limit used by the PageFunc. A page with
+// fewer items than this is treated as the last page, which saves one empty
+// round-trip at the end of a scan. Without it, the scan ends on the first
+// empty page.
+func PageSize(n int) Option {
+	return func(c *config) {
+		c.pageSize = n
+	}
+}
+
+// StartPage sets the first page to fetch (default 1).
+func StartPage(page int) Option {
+	return func(c *config) {
+		if page < 1 {
+			page = 1
+		}
+		c.startPage = page
+	}
+}
+
+// Iterator walks every page returned by a PageFunc.
+// It is intended for read-only scans (backfills, exports, full catalog syncs);
+// page-number pagination is not stable against concurrent writes.
+type Iterator[T any] struct {
+	fetch PageFunc[T]
+	cfg   config
+}
+
+// New creates an Iterator over the pages produced by fetch.
+func New[T any](fetch PageFunc[T], opts ...Option) *Iterator[T] {
+	cfg := config{parallel: 1, startPage: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Iterator[T]{fetch: fetch, cfg: cfg}
+}
+
+// pageResult carries the outcome of a single page fetch.
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// Each calls fn for every item in page order. It stops at the last page,
+// at the first fetch error, or as soon as fn returns an error; in-flight
+// prefetches are cancelled before Each returns.
+func (it *Iterator[T]) Each(ctx context.Context, fn func(T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	next := it.cfg.startPage
+	// queue holds one channel per in-flight page, in page order.
+	var queue []chan pageResult[T]
+	launch := func() {
+		page := next
+		next++
+		ch := make(chan pageResult[T], 1) // buffered so abandoned fetches never block
+		queue = append(queue, ch)
+		go func() {
+			items, err := it.fetch(ctx, page)
+			ch <- pageResult[T]{items: items, err: err}
+		}()
+	}
+
+	for i := 0; i < it.cfg.parallel; i++ {
+		launch()
+	}
+
+	for len(queue) > 0 {
+		var r pageResult[T]
+		select {
+		case r = <-queue[0]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		queue = queue[1:]
+
+		if r.err != nil {
+			return r.err
+		}
+		for _, item := range r.items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if it.isLastPage(len(r.items)) {
+			return nil
+		}
+		launch()
+	}
+	return nil
+}
+
+// All collects every item into a single slice.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := it.Each(ctx, func(item T) error {
+		all = append(all, item)
+		return nil
+	})
+	return all, err
+}
+
+// isLastPage reports whether a page of n items ends the scan.
+func (it *Iterator[T]) isLastPage(n int) bool {
+	return n == 0 || (it.cfg.pageSize > 0 && n < it.cfg.pageSize)
+}
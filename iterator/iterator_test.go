@@ -0,0 +1,154 @@
+package iterator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedSource serves total items split into pages of size, recording the
+// peak number of concurrent fetches.
+type pagedSource struct {
+	total, size int
+	delay       func(page int) time.Duration
+
+	inFlight int32
+	peak     int32
+	mu       sync.Mutex
+	fetched  []int
+}
+
+func (s *pagedSource) fetch(ctx context.Context, page int) ([]int, error) {
+	n := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		p := atomic.LoadInt32(&s.peak)
+		if n <= p || atomic.CompareAndSwapInt32(&s.peak, p, n) {
+			break
+		}
+	}
+	s.mu.Lock()
+	s.fetched = append(s.fetched, page)
+	s.mu.Unlock()
+
+	if s.delay != nil {
+		select {
+		case <-time.After(s.delay(page)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	var items []int
+	for i := (page - 1) * s.size; i < page*s.size && i < s.total; i++ {
+		items = append(items, i)
+	}
+	return items, nil
+}
+
+func TestIterator_Sequential(t *testing.T) {
+	src := &pagedSource{total: 25, size: 10}
+	items, err := New(src.fetch, PageSize(10)).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 25 {
+		t.Fatalf("expected 25 items, got %d", len(items))
+	}
+	if len(src.fetched) != 3 {
+		t.Errorf("expected 3 page fetches (short page ends scan), got %d", len(src.fetched))
+	}
+}
+
+func TestIterator_EmptyPageEndsScanWithoutPageSize(t *testing.T) {
+	src := &pagedSource{total: 20, size: 10}
+	items, err := New(src.fetch).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 20 {
+		t.Fatalf("expected 20 items, got %d", len(items))
+	}
+	if len(src.fetched) != 3 {
+		t.Errorf("expected 3 page fetches, got %d", len(src.fetched))
+	}
+}
+
+func TestIterator_ParallelPreservesOrder(t *testing.T) {
+	// Later pages finish first to make sure ordering does not depend on timing.
+	src := &pagedSource{total: 95, size: 10, delay: func(page int) time.Duration {
+		return time.Duration(12-page) * time.Millisecond
+	}}
+	items, err := New(src.fetch, Parallel(4), PageSize(10)).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 95 {
+		t.Fatalf("expected 95 items, got %d", len(items))
+	}
+	for i, v := range items {
+		if v != i {
+			t.Fatalf("items out of order at %d: got %d", i, v)
+		}
+	}
+	if peak := atomic.LoadInt32(&src.peak); peak > 4 {
+		t.Errorf("expected at most 4 concurrent fetches, got %d", peak)
+	}
+	if peak := atomic.LoadInt32(&src.peak); peak < 2 {
+		t.Errorf("expected pages to be prefetched concurrently, peak was %d", peak)
+	}
+}
+
+func TestIterator_FetchErrorStopsScan(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, page int) ([]int, error) {
+		if page == 2 {
+			return nil, boom
+		}
+		return []int{page}, nil
+	}
+	var seen []int
+	err := New(fetch, Parallel(3)).Each(context.Background(), func(v int) error {
+		seen = append(seen, v)
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if len(seen) != 1 || seen[0] != 1 {
+		t.Errorf("expected only page 1 to be delivered, got %v", seen)
+	}
+}
+
+func TestIterator_CallbackErrorStopsScan(t *testing.T) {
+	src := &pagedSource{total: 100, size: 10}
+	stop := errors.New("stop")
+	count := 0
+	err := New(src.fetch, Parallel(2), PageSize(10)).Each(context.Background(), func(v int) error {
+		count++
+		if v == 14 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected stop, got %v", err)
+	}
+	if count != 15 {
+		t.Errorf("expected 15 callbacks, got %d", count)
+	}
+}
+
+func TestIterator_StartPage(t *testing.T) {
+	src := &pagedSource{total: 30, size: 10}
+	items, err := New(src.fetch, StartPage(2), PageSize(10)).All(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 20 || items[0] != 10 {
+		t.Errorf("expected 20 items starting at 10, got %d starting at %v", len(items), items)
+	}
+}
@@ -0,0 +1,67 @@
+package shopline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ConcurrencyLimiter caps the number of in-flight requests per store handle.
+// Share one limiter between every Client of an app so that clients created
+// for the same store draw from the same slots:
+//
+//	limiter := shopline.NewConcurrencyLimiter(4)
+//	client, _ := shopline.NewClient(app, handle, token,
+//	    shopline.WithConcurrencyLimiter(limiter),
+//	)
+//
+// It is safe for concurrent use.
+type ConcurrencyLimiter struct {
+	max int
+
+	mu   sync.Mutex
+	sems map[string]chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter allowing n concurrent requests per
+// store handle.
+func NewConcurrencyLimiter(n int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{max: n, sems: make(map[string]chan struct{})}
+}
+
+// acquire blocks until a slot for handle is free or ctx is done. The
+// returned func releases the slot.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context, handle string) (func(), error) {
+	l.mu.Lock()
+	sem, ok := l.sems[handle]
+	if !ok {
+		sem = make(chan struct{}, l.max)
+		l.sems[handle] = sem
+	}
+	l.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("shopline: waiting for a request slot: %w", ctx.Err())
+	}
+}
+
+// tryAcquire takes a slot for handle if one is free, without waiting.
+func (l *ConcurrencyLimiter) tryAcquire(handle string) (func(), bool) {
+	l.mu.Lock()
+	sem, ok := l.sems[handle]
+	if !ok {
+		sem = make(chan struct{}, l.max)
+		l.sems[handle] = sem
+	}
+	l.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, true
+	default:
+		return nil, false
+	}
+}
@@ -2,6 +2,7 @@ package localizations
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/imokyou/slshop/core"
 )
@@ -16,14 +17,21 @@ type Service interface {
 	AddLanguages(ctx context.Context, languages []string) (*LanguageData, error)
 	DeleteLanguages(ctx context.Context, languages []string) (*LanguageData, error)
 	GetAvailableLanguages(ctx context.Context) ([]AvailableLanguage, error)
+	ListLocales(ctx context.Context) ([]Locale, error)
 
 	// Translation management
 	GetTranslation(ctx context.Context, opts *TranslationQuery) (*TranslationData, error)
 	UpdateTranslation(ctx context.Context, data TranslationUpdateRequest) error
 	DeleteTranslation(ctx context.Context, data TranslationDeleteRequest) error
 	BatchQueryTranslation(ctx context.Context, opts *TranslationBatchQuery) ([]TranslationData, error)
+	BatchUpsertTranslations(ctx context.Context, resourceType, resourceID string, translations []TranslationEntry) error
+	ListTranslatableResources(ctx context.Context, resourceType string, opts *core.ListOptions) ([]TranslatableResource, error)
 }
 
+// MaxTranslationsPerRequest is the number of translation entries the API
+// accepts in a single write; BatchUpsertTranslations splits larger sets.
+const MaxTranslationsPerRequest = 100
+
 func NewService(client core.Requester) Service {
 	return &serviceOp{client: client}
 }
@@ -44,6 +52,14 @@ type AvailableLanguage struct {
 	Name   string `json:"name,omitempty"`
 }
 
+// Locale is a language enabled on the store.
+type Locale struct {
+	Locale    string `json:"locale,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Primary   bool   `json:"primary,omitempty"`
+	Published bool   `json:"published,omitempty"`
+}
+
 type TranslationQuery struct {
 	ResourceID   string `url:"resource_id,omitempty"`
 	ResourceType string `url:"resource_type,omitempty"`
@@ -84,6 +100,26 @@ type TranslationDeleteRequest struct {
 	Locales      []string `json:"locales,omitempty"`
 }
 
+// TranslatableResource is a resource together with the source-language
+// content that can be translated.
+type TranslatableResource struct {
+	ResourceID   string                `json:"resource_id,omitempty"`
+	ResourceType string                `json:"resource_type,omitempty"`
+	Content      []TranslatableContent `json:"translatable_content,omitempty"`
+}
+
+type TranslatableContent struct {
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+	Digest string `json:"digest,omitempty"`
+	Locale string `json:"locale,omitempty"`
+}
+
+type translatableResourcesQuery struct {
+	core.ListOptions
+	ResourceType string `url:"resource_type,omitempty"`
+}
+
 // JSON wrappers
 type languageDataResource struct {
 	Data *LanguageData `json:"data"`
@@ -91,6 +127,12 @@ type languageDataResource struct {
 type availableLanguagesResource struct {
 	Languages []AvailableLanguage `json:"languages"`
 }
+type localesResource struct {
+	Locales []Locale `json:"locales"`
+}
+type translatableResourcesResource struct {
+	Data []TranslatableResource `json:"data"`
+}
 type translationDataResource struct {
 	Data *TranslationData `json:"data"`
 }
@@ -106,7 +148,7 @@ type translationBatchResource struct {
 func (s *serviceOp) GetLanguages(ctx context.Context) (*LanguageData, error) {
 	r := &languageDataResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("store/languages.json"), r, nil)
-	return r.Data, err
+	return core.Found(r.Data, err)
 }
 
 // POST store/languages.json
@@ -133,11 +175,18 @@ func (s *serviceOp) GetAvailableLanguages(ctx context.Context) ([]AvailableLangu
 	return r.Languages, err
 }
 
+// GET store/locales.json
+func (s *serviceOp) ListLocales(ctx context.Context) ([]Locale, error) {
+	r := &localesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("store/locales.json"), r, nil)
+	return r.Locales, err
+}
+
 // GET ugc/resource.json
 func (s *serviceOp) GetTranslation(ctx context.Context, opts *TranslationQuery) (*TranslationData, error) {
 	r := &translationDataResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("ugc/resource.json"), r, opts)
-	return r.Data, err
+	return core.Found(r.Data, err)
 }
 
 // PUT ugc/resource.json
@@ -156,3 +205,31 @@ func (s *serviceOp) BatchQueryTranslation(ctx context.Context, opts *Translation
 	err := s.client.Get(ctx, s.client.CreatePath("ugc/resources.json"), r, opts)
 	return r.Data, err
 }
+
+// PUT ugc/resource.json, once per MaxTranslationsPerRequest entries.
+// Chunks are written in order and the first failure stops the batch.
+func (s *serviceOp) BatchUpsertTranslations(ctx context.Context, resourceType, resourceID string, translations []TranslationEntry) error {
+	for start := 0; start < len(translations); start += MaxTranslationsPerRequest {
+		end := min(start+MaxTranslationsPerRequest, len(translations))
+		err := s.UpdateTranslation(ctx, TranslationUpdateRequest{
+			ResourceID:   resourceID,
+			ResourceType: resourceType,
+			Translations: translations[start:end],
+		})
+		if err != nil {
+			return fmt.Errorf("localizations: upsert translations %d-%d: %w", start, end-1, err)
+		}
+	}
+	return nil
+}
+
+// GET ugc/translatable_resources.json
+func (s *serviceOp) ListTranslatableResources(ctx context.Context, resourceType string, opts *core.ListOptions) ([]TranslatableResource, error) {
+	q := &translatableResourcesQuery{ResourceType: resourceType}
+	if opts != nil {
+		q.ListOptions = *opts
+	}
+	r := &translatableResourcesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("ugc/translatable_resources.json"), r, q)
+	return r.Data, err
+}
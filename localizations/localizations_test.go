@@ -0,0 +1,144 @@
+package localizations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for localizations tests. It
+// records the options of the last Get instead of encoding them.
+type mockRequester struct {
+	server   *httptest.Server
+	lastOpts interface{}
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	m.lastOpts = opts
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errors.New(resp.Status)
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestListLocales(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/admin/openapi/v20251201/store/locales.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"locales":[{"locale":"en","name":"English","primary":true,"published":true},{"locale":"fr","name":"French"}]}`))
+	})
+	defer close()
+
+	locales, err := NewService(mock).ListLocales(context.Background())
+	if err != nil || len(locales) != 2 || !locales[0].Primary || locales[1].Locale != "fr" || locales[1].Published {
+		t.Fatalf("ListLocales = %+v, %v", locales, err)
+	}
+}
+
+func TestBatchUpsertTranslations(t *testing.T) {
+	var sizes []int
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/admin/openapi/v20251201/ugc/resource.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body TranslationUpdateRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.ResourceType != "PRODUCT" || body.ResourceID != "42" {
+			t.Errorf("unexpected resource %q %q", body.ResourceType, body.ResourceID)
+		}
+		sizes = append(sizes, len(body.Translations))
+	})
+	defer close()
+
+	entries := make([]TranslationEntry, 2*MaxTranslationsPerRequest+1)
+	for i := range entries {
+		entries[i] = TranslationEntry{Key: "title", Value: "Titre", Locale: "fr"}
+	}
+	if err := NewService(mock).BatchUpsertTranslations(context.Background(), "PRODUCT", "42", entries); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sizes) != 3 || sizes[0] != MaxTranslationsPerRequest || sizes[2] != 1 {
+		t.Errorf("unexpected chunk sizes %v", sizes)
+	}
+}
+
+func TestBatchUpsertTranslations_StopsOnFailure(t *testing.T) {
+	calls := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+	})
+	defer close()
+
+	entries := make([]TranslationEntry, 3*MaxTranslationsPerRequest)
+	err := NewService(mock).BatchUpsertTranslations(context.Background(), "PRODUCT", "42", entries)
+	if err == nil || !strings.Contains(err.Error(), "100-199") {
+		t.Fatalf("expected error naming the failed chunk, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the batch to stop after the failed chunk, got %d calls", calls)
+	}
+}
+
+func TestListTranslatableResources(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/admin/openapi/v20251201/ugc/translatable_resources.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"data":[{"resource_id":"42","resource_type":"PRODUCT","translatable_content":[{"key":"title","value":"Parka","digest":"abc","locale":"en"}]}]}`))
+	})
+	defer close()
+
+	res, err := NewService(mock).ListTranslatableResources(context.Background(), "PRODUCT", &core.ListOptions{Limit: 50})
+	if err != nil || len(res) != 1 || len(res[0].Content) != 1 || res[0].Content[0].Digest != "abc" {
+		t.Fatalf("ListTranslatableResources = %+v, %v", res, err)
+	}
+	q, ok := mock.lastOpts.(*translatableResourcesQuery)
+	if !ok || q.ResourceType != "PRODUCT" || q.Limit != 50 {
+		t.Errorf("unexpected query %+v", mock.lastOpts)
+	}
+}
@@ -46,7 +46,7 @@ func (s *marketOp) List(ctx context.Context, opts *core.ListOptions) ([]Market,
 func (s *marketOp) Get(ctx context.Context, id int64) (*Market, error) {
 	r := &marketResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("markets/%d.json", id)), r, nil)
-	return r.Market, err
+	return core.Found(r.Market, err)
 }
 
 // =====================================================================
@@ -56,6 +56,11 @@ func (s *marketOp) Get(ctx context.Context, id int64) (*Market, error) {
 type LocationService interface {
 	List(ctx context.Context) ([]Location, error)
 	Get(ctx context.Context, id int64) (*Location, error)
+	Create(ctx context.Context, l Location) (*Location, error)
+	Update(ctx context.Context, l Location) (*Location, error)
+	Activate(ctx context.Context, id int64) (*Location, error)
+	Deactivate(ctx context.Context, id int64) (*Location, error)
+	Delete(ctx context.Context, id int64) error
 }
 
 func NewLocationService(client core.Requester) LocationService {
@@ -96,9 +101,39 @@ func (s *locationOp) List(ctx context.Context) ([]Location, error) {
 func (s *locationOp) Get(ctx context.Context, id int64) (*Location, error) {
 	r := &locationResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("locations/%d.json", id)), r, nil)
+	return core.Found(r.Location, err)
+}
+func (s *locationOp) Create(ctx context.Context, l Location) (*Location, error) {
+	r := &locationResource{}
+	err := s.client.Post(ctx, s.client.CreatePath("locations.json"), locationResource{Location: &l}, r)
+	return r.Location, err
+}
+func (s *locationOp) Update(ctx context.Context, l Location) (*Location, error) {
+	r := &locationResource{}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("locations/%d.json", l.ID)), locationResource{Location: &l}, r)
+	return r.Location, err
+}
+
+// Activate and Deactivate use dedicated endpoints because Location.Active is
+// omitted from the payload when false and cannot be cleared through Update.
+func (s *locationOp) Activate(ctx context.Context, id int64) (*Location, error) {
+	r := &locationResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("locations/%d/activate.json", id)), nil, r)
+	return r.Location, err
+}
+func (s *locationOp) Deactivate(ctx context.Context, id int64) (*Location, error) {
+	r := &locationResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("locations/%d/deactivate.json", id)), nil, r)
 	return r.Location, err
 }
 
+// Delete removes a location. The platform rejects deleting an active
+// location or one that still stocks inventory, so deactivate it and move its
+// stock first.
+func (s *locationOp) Delete(ctx context.Context, id int64) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("locations/%d.json", id)))
+}
+
 // =====================================================================
 // Publication
 // =====================================================================
@@ -137,13 +172,22 @@ type GiftCardService interface {
 	List(ctx context.Context, opts *core.ListOptions) ([]GiftCard, error)
 	Get(ctx context.Context, id int64) (*GiftCard, error)
 	Create(ctx context.Context, c GiftCard) (*GiftCard, error)
+	ListTransactions(ctx context.Context, giftCardID int64) ([]GiftCardTransaction, error)
 }
 
 func NewGiftCardService(client core.Requester) GiftCardService {
-	return &giftCardOp{client: client}
+	return &giftCardOp{
+		ResourceService: core.NewResourceService(client, core.Resource[GiftCard]{
+			Path: "gift_cards", Key: "gift_card", ListKey: "gift_cards",
+		}),
+		client: client,
+	}
 }
 
-type giftCardOp struct{ client core.Requester }
+type giftCardOp struct {
+	*core.ResourceService[GiftCard]
+	client core.Requester
+}
 
 type GiftCard struct {
 	ID           int64      `json:"id,omitempty"`
@@ -158,25 +202,32 @@ type GiftCard struct {
 	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
 }
 
-type giftCardResource struct {
-	GiftCard *GiftCard `json:"gift_card"`
-}
-type giftCardsResource struct {
-	GiftCards []GiftCard `json:"gift_cards"`
-}
+// Gift card transaction kinds.
+const (
+	GiftCardTransactionDebit  = "debit"  // balance spent on an order
+	GiftCardTransactionCredit = "credit" // balance added, e.g. by a refund
+)
 
-func (s *giftCardOp) List(ctx context.Context, opts *core.ListOptions) ([]GiftCard, error) {
-	r := &giftCardsResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("gift_cards.json"), r, opts)
-	return r.GiftCards, err
+// GiftCardTransaction is a change to a gift card's balance.
+type GiftCardTransaction struct {
+	ID          int64      `json:"id,omitempty"`
+	GiftCardID  int64      `json:"gift_card_id,omitempty"`
+	OrderID     int64      `json:"order_id,omitempty"`
+	Kind        string     `json:"kind,omitempty"`
+	Amount      string     `json:"amount,omitempty"`
+	Currency    string     `json:"currency,omitempty"`
+	Note        string     `json:"note,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
 }
-func (s *giftCardOp) Get(ctx context.Context, id int64) (*GiftCard, error) {
-	r := &giftCardResource{}
-	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("gift_cards/%d.json", id)), r, nil)
-	return r.GiftCard, err
+
+type giftCardTransactionsResource struct {
+	Transactions []GiftCardTransaction `json:"transactions"`
 }
-func (s *giftCardOp) Create(ctx context.Context, c GiftCard) (*GiftCard, error) {
-	r := &giftCardResource{}
-	err := s.client.Post(ctx, s.client.CreatePath("gift_cards.json"), giftCardResource{GiftCard: &c}, r)
-	return r.GiftCard, err
+
+// ListTransactions lists the debits and credits of a gift card.
+func (s *giftCardOp) ListTransactions(ctx context.Context, giftCardID int64) ([]GiftCardTransaction, error) {
+	r := &giftCardTransactionsResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("gift_cards/%d/transactions.json", giftCardID)), r, nil)
+	return r.Transactions, err
 }
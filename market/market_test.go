@@ -0,0 +1,146 @@
+package market
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for market tests.
+type mockRequester struct {
+	server *httptest.Server
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestLocationCreate(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/openapi/v20251201/locations.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body locationResource
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Location == nil || body.Location.Name != "Warehouse" || body.Location.CountryCode != "US" {
+			t.Errorf("unexpected body: %+v", body.Location)
+		}
+		body.Location.ID = 7
+		json.NewEncoder(w).Encode(body)
+	})
+	defer close()
+
+	l, err := NewLocationService(mock).Create(context.Background(), Location{Name: "Warehouse", City: "Austin", CountryCode: "US"})
+	if err != nil || l.ID != 7 || l.City != "Austin" {
+		t.Fatalf("Create = %+v, %v", l, err)
+	}
+}
+
+func TestLocationUpdate(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/admin/openapi/v20251201/locations/7.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["location"]["phone"] != "555-0100" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		if _, ok := body["location"]["active"]; ok {
+			t.Errorf("expected active omitted, got %v", body)
+		}
+		w.Write([]byte(`{"location":{"id":7,"name":"Warehouse","phone":"555-0100","active":true}}`))
+	})
+	defer close()
+
+	l, err := NewLocationService(mock).Update(context.Background(), Location{ID: 7, Phone: "555-0100"})
+	if err != nil || l.Phone != "555-0100" || !l.Active {
+		t.Fatalf("Update = %+v, %v", l, err)
+	}
+}
+
+func TestLocationActivateDeactivate(t *testing.T) {
+	var paths []string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if b, _ := io.ReadAll(r.Body); len(b) != 0 {
+			t.Errorf("expected no body, got %s", b)
+		}
+		paths = append(paths, r.URL.Path)
+		active := strings.HasSuffix(r.URL.Path, "/activate.json")
+		json.NewEncoder(w).Encode(locationResource{Location: &Location{ID: 7, Active: active}})
+	})
+	defer close()
+
+	svc := NewLocationService(mock)
+	l, err := svc.Activate(context.Background(), 7)
+	if err != nil || !l.Active {
+		t.Fatalf("Activate = %+v, %v", l, err)
+	}
+	l, err = svc.Deactivate(context.Background(), 7)
+	if err != nil || l.Active {
+		t.Fatalf("Deactivate = %+v, %v", l, err)
+	}
+	want := "/admin/openapi/v20251201/locations/7/activate.json /admin/openapi/v20251201/locations/7/deactivate.json"
+	if got := strings.Join(paths, " "); got != want {
+		t.Errorf("unexpected paths %s", got)
+	}
+}
+
+func TestLocationDelete(t *testing.T) {
+	called := false
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Method != http.MethodDelete || r.URL.Path != "/admin/openapi/v20251201/locations/7.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	if err := NewLocationService(mock).Delete(context.Background(), 7); err != nil || !called {
+		t.Fatalf("Delete: called=%v, err=%v", called, err)
+	}
+}
@@ -24,6 +24,8 @@ type DiscountService interface {
 	CreateDiscountCode(ctx context.Context, priceRuleID int64, c DiscountCode) (*DiscountCode, error)
 	UpdateDiscountCode(ctx context.Context, priceRuleID int64, c DiscountCode) (*DiscountCode, error)
 	DeleteDiscountCode(ctx context.Context, priceRuleID, codeID int64) error
+
+	ListCodeUsages(ctx context.Context, priceRuleID, codeID int64, opts *core.ListOptions) ([]DiscountCodeUsage, error)
 }
 
 func NewDiscountService(client core.Requester) DiscountService {
@@ -57,6 +59,18 @@ type DiscountCode struct {
 	CreatedAt   *time.Time `json:"created_at,omitempty"`
 }
 
+// DiscountCodeUsage is one redemption of a discount code.
+type DiscountCodeUsage struct {
+	ID             int64      `json:"id,omitempty"`
+	DiscountCodeID int64      `json:"discount_code_id,omitempty"`
+	Code           string     `json:"code,omitempty"`
+	OrderID        int64      `json:"order_id,omitempty"`
+	CustomerID     int64      `json:"customer_id,omitempty"`
+	Amount         string     `json:"amount,omitempty"` // discount granted on the order
+	Currency       string     `json:"currency,omitempty"`
+	UsedAt         *time.Time `json:"used_at,omitempty"`
+}
+
 type priceRuleResource struct {
 	PriceRule *PriceRule `json:"price_rule"`
 }
@@ -69,6 +83,9 @@ type discountCodeResource struct {
 type discountCodesResource struct {
 	DiscountCodes []DiscountCode `json:"discount_codes"`
 }
+type discountCodeUsagesResource struct {
+	Usages []DiscountCodeUsage `json:"usages"`
+}
 
 func (s *discountOp) ListPriceRules(ctx context.Context, opts *core.ListOptions) ([]PriceRule, error) {
 	r := &priceRulesResource{}
@@ -78,7 +95,7 @@ func (s *discountOp) ListPriceRules(ctx context.Context, opts *core.ListOptions)
 func (s *discountOp) GetPriceRule(ctx context.Context, id int64) (*PriceRule, error) {
 	r := &priceRuleResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("price_rules/%d.json", id)), r, nil)
-	return r.PriceRule, err
+	return core.Found(r.PriceRule, err)
 }
 func (s *discountOp) CreatePriceRule(ctx context.Context, rule PriceRule) (*PriceRule, error) {
 	r := &priceRuleResource{}
@@ -101,7 +118,7 @@ func (s *discountOp) ListDiscountCodes(ctx context.Context, priceRuleID int64) (
 func (s *discountOp) GetDiscountCode(ctx context.Context, priceRuleID, codeID int64) (*DiscountCode, error) {
 	r := &discountCodeResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("price_rules/%d/discount_codes/%d.json", priceRuleID, codeID)), r, nil)
-	return r.DiscountCode, err
+	return core.Found(r.DiscountCode, err)
 }
 func (s *discountOp) CreateDiscountCode(ctx context.Context, priceRuleID int64, c DiscountCode) (*DiscountCode, error) {
 	r := &discountCodeResource{}
@@ -116,3 +133,10 @@ func (s *discountOp) UpdateDiscountCode(ctx context.Context, priceRuleID int64,
 func (s *discountOp) DeleteDiscountCode(ctx context.Context, priceRuleID, codeID int64) error {
 	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("price_rules/%d/discount_codes/%d.json", priceRuleID, codeID)))
 }
+
+// ListCodeUsages lists the orders a discount code was redeemed on.
+func (s *discountOp) ListCodeUsages(ctx context.Context, priceRuleID, codeID int64, opts *core.ListOptions) ([]DiscountCodeUsage, error) {
+	r := &discountCodeUsagesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("price_rules/%d/discount_codes/%d/usages.json", priceRuleID, codeID)), r, opts)
+	return r.Usages, err
+}
@@ -225,3 +225,24 @@ func TestCreateDiscountCode(t *testing.T) {
 		t.Errorf("expected 'WELCOME', got %q", code.Code)
 	}
 }
+
+func TestListCodeUsages(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "price_rules/1/discount_codes/200/usages.json") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(discountCodeUsagesResource{Usages: []DiscountCodeUsage{
+			{ID: 1, DiscountCodeID: 200, Code: "WELCOME", OrderID: 1001, Amount: "10.00"},
+		}})
+	})
+	defer close()
+
+	svc := NewDiscountService(mock)
+	usages, err := svc.ListCodeUsages(context.Background(), 1, 200, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(usages) != 1 || usages[0].OrderID != 1001 {
+		t.Errorf("unexpected usages: %+v", usages)
+	}
+}
@@ -0,0 +1,239 @@
+// Package media uploads files through staged uploads: the API hands out a
+// short-lived upload target, the file goes straight to storage, and the
+// resulting URL is attached to a product. Unlike embedding images in JSON
+// as base64, the file never passes through the API, so large images and
+// videos upload quickly and stay clear of request body limits.
+//
+//	f, _ := os.Open("parka.jpg")
+//	fi, _ := f.Stat()
+//	target, err := client.Media.StageUpload(ctx, "parka.jpg", fi.Size(), "image/jpeg")
+//	err = media.Upload(ctx, nil, target, f, fi.Size())
+//	img, err := client.Media.AttachToProduct(ctx, productID, target, product.Image{Alt: "Parka"})
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/product"
+)
+
+// Resource types of a staged upload, derived from its MIME type.
+const (
+	ResourceImage = "IMAGE"
+	ResourceVideo = "VIDEO"
+	ResourceFile  = "FILE"
+)
+
+// =====================================================================
+// Service
+// =====================================================================
+
+type Service interface {
+	// StageUpload reserves an upload target for a file of size bytes.
+	StageUpload(ctx context.Context, filename string, size int64, mimeType string) (*StagedTarget, error)
+	// AttachToProduct adds an uploaded image to a product. image sets the
+	// alt text, position and variants; its Src is the target's resource URL.
+	AttachToProduct(ctx context.Context, productID int64, target *StagedTarget, image product.Image) (*product.Image, error)
+}
+
+func NewService(client core.Requester) Service {
+	return &serviceOp{client: client}
+}
+
+type serviceOp struct{ client core.Requester }
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// StagedTarget is where to upload a file and how to refer to it afterwards.
+type StagedTarget struct {
+	// URL is where Upload sends the file, with HTTPMethod.
+	URL        string `json:"url"`
+	HTTPMethod string `json:"http_method,omitempty"`
+	// ResourceURL refers to the uploaded file in later API calls.
+	ResourceURL string `json:"resource_url"`
+	// Parameters go with the upload: as form fields of a POST, or as
+	// headers of a PUT.
+	Parameters []Parameter `json:"parameters,omitempty"`
+}
+
+type Parameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type stagedUpload struct {
+	Filename   string `json:"filename"`
+	FileSize   int64  `json:"file_size"`
+	MimeType   string `json:"mime_type"`
+	Resource   string `json:"resource"`
+	HTTPMethod string `json:"http_method"`
+}
+
+// JSON wrappers
+type stagedUploadResource struct {
+	StagedUpload stagedUpload `json:"staged_upload"`
+}
+type stagedTargetResource struct {
+	StagedTarget *StagedTarget `json:"staged_target"`
+}
+type imageResource struct {
+	Image *product.Image `json:"image"`
+}
+
+// =====================================================================
+// Implementation
+// =====================================================================
+
+// POST staged_uploads.json
+func (s *serviceOp) StageUpload(ctx context.Context, filename string, size int64, mimeType string) (*StagedTarget, error) {
+	if filename == "" || size <= 0 || mimeType == "" {
+		return nil, fmt.Errorf("media: staged upload needs a filename, a positive size and a MIME type, got %q, %d, %q", filename, size, mimeType)
+	}
+	body := stagedUploadResource{StagedUpload: stagedUpload{
+		Filename:   filename,
+		FileSize:   size,
+		MimeType:   mimeType,
+		Resource:   resourceType(mimeType),
+		HTTPMethod: http.MethodPut,
+	}}
+	r := &stagedTargetResource{}
+	if err := s.client.Post(ctx, s.client.CreatePath("staged_uploads.json"), body, r); err != nil {
+		return nil, err
+	}
+	if r.StagedTarget == nil || r.StagedTarget.URL == "" {
+		return nil, fmt.Errorf("media: staged upload of %q returned no target", filename)
+	}
+	return r.StagedTarget, nil
+}
+
+// POST products/{id}/images.json
+func (s *serviceOp) AttachToProduct(ctx context.Context, productID int64, target *StagedTarget, image product.Image) (*product.Image, error) {
+	if target == nil || target.ResourceURL == "" {
+		return nil, fmt.Errorf("media: staged target has no resource URL")
+	}
+	image.ID, image.ProductID = 0, 0
+	image.Src = target.ResourceURL
+	r := &imageResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("products/%d/images.json", productID)), imageResource{Image: &image}, r)
+	return r.Image, err
+}
+
+func resourceType(mimeType string) string {
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		return ResourceImage
+	case strings.HasPrefix(mimeType, "video/"):
+		return ResourceVideo
+	}
+	return ResourceFile
+}
+
+// =====================================================================
+// Upload
+// =====================================================================
+
+// Upload sends size bytes read from body to target. A PUT target receives
+// the file as the request body with the target's parameters as headers; a
+// POST target receives a multipart form of the parameters followed by the
+// file. The request goes to storage, not the API, so it carries no access
+// token and bypasses the client's rate limiter and retries.
+//
+// hc defaults to a client without a timeout, as large files take a while;
+// bound the upload with ctx instead.
+func Upload(ctx context.Context, hc *http.Client, target *StagedTarget, body io.Reader, size int64) error {
+	if hc == nil {
+		hc = uploadClient
+	}
+	var (
+		req *http.Request
+		err error
+	)
+	switch strings.ToUpper(target.HTTPMethod) {
+	case http.MethodPost:
+		req, err = multipartRequest(ctx, target, body, size)
+	case http.MethodPut, "":
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, target.URL, body)
+		if err == nil {
+			req.ContentLength = size
+			for _, p := range target.Parameters {
+				req.Header.Set(p.Name, p.Value)
+			}
+		}
+	default:
+		return fmt.Errorf("media: unsupported upload method %q", target.HTTPMethod)
+	}
+	if err != nil {
+		return fmt.Errorf("media: failed to build upload request: %w", err)
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("media: upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("media: upload failed: HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+var uploadClient = &http.Client{}
+
+// multipartRequest streams the form without buffering the file, and with
+// a Content-Length, which storage services require for form uploads.
+func multipartRequest(ctx context.Context, target *StagedTarget, body io.Reader, size int64) (*http.Request, error) {
+	var head, tail bytes.Buffer
+	mw := multipart.NewWriter(&head)
+	for _, p := range target.Parameters {
+		if err := mw.WriteField(p.Name, p.Value); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := mw.CreateFormFile("file", fileName(target)); err != nil {
+		return nil, err
+	}
+	contentType := mw.FormDataContentType()
+	n := head.Len()
+	// Close writes the final boundary after what it has written so far;
+	// split it off as the part that follows the file.
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	tail.Write(head.Bytes()[n:])
+	head.Truncate(n)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, io.MultiReader(&head, io.LimitReader(body, size), &tail))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(head.Len()) + size + int64(tail.Len())
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+// fileName is the last path element of the resource URL, which storage
+// services ignore but multipart requires.
+func fileName(target *StagedTarget) string {
+	name := target.ResourceURL
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		name = "file"
+	}
+	return name
+}
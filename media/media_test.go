@@ -0,0 +1,166 @@
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/product"
+)
+
+// mockRequester implements core.Requester for media tests.
+type mockRequester struct {
+	server *httptest.Server
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestStageUploadAndAttach(t *testing.T) {
+	var staged stagedUploadResource
+	var attached imageResource
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/staged_uploads.json"):
+			json.NewDecoder(r.Body).Decode(&staged)
+			w.Write([]byte(`{"staged_target":{"url":"https://storage.example.com/up","http_method":"PUT",` +
+				`"resource_url":"https://cdn.example.com/tmp/parka.jpg","parameters":[{"name":"Content-Type","value":"image/jpeg"}]}}`))
+		case strings.HasSuffix(r.URL.Path, "/products/9/images.json"):
+			json.NewDecoder(r.Body).Decode(&attached)
+			w.Write([]byte(`{"image":{"id":30,"product_id":9,"src":"https://cdn.example.com/parka.jpg"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+	svc := NewService(mock)
+
+	if _, err := svc.StageUpload(context.Background(), "parka.jpg", 0, "image/jpeg"); err == nil {
+		t.Error("expected error for an empty file")
+	}
+	target, err := svc.StageUpload(context.Background(), "parka.jpg", 2048, "image/jpeg")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if staged.StagedUpload.Resource != ResourceImage || staged.StagedUpload.FileSize != 2048 {
+		t.Errorf("unexpected request: %+v", staged)
+	}
+	if target.ResourceURL != "https://cdn.example.com/tmp/parka.jpg" || len(target.Parameters) != 1 {
+		t.Errorf("unexpected target: %+v", target)
+	}
+
+	img, err := svc.AttachToProduct(context.Background(), 9, target, product.Image{ID: 5, Alt: "Parka", Position: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.ID != 30 {
+		t.Errorf("unexpected image: %+v", img)
+	}
+	if a := attached.Image; a.ID != 0 || a.Src != target.ResourceURL || a.Alt != "Parka" {
+		t.Errorf("unexpected attach request: %+v", a)
+	}
+}
+
+func TestUpload_Put(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPut || string(b) != "jpegdata" || r.ContentLength != 8 {
+			t.Errorf("unexpected upload %s %q (length %d)", r.Method, b, r.ContentLength)
+		}
+		if r.Header.Get("X-Goog-Meta-Id") != "abc" {
+			t.Errorf("parameters should be headers: %v", r.Header)
+		}
+	}))
+	defer storage.Close()
+
+	target := &StagedTarget{URL: storage.URL, HTTPMethod: "PUT", Parameters: []Parameter{{Name: "X-Goog-Meta-Id", Value: "abc"}}}
+	if err := Upload(context.Background(), nil, target, strings.NewReader("jpegdata"), 8); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpload_Multipart(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("bad form: %v", err)
+			return
+		}
+		if r.FormValue("key") != "tmp/parka.jpg" || r.FormValue("policy") != "p" {
+			t.Errorf("unexpected fields: %v", r.MultipartForm.Value)
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			t.Errorf("missing file: %v", err)
+			return
+		}
+		b, _ := io.ReadAll(f)
+		if string(b) != "jpegdata" || fh.Filename != "parka.jpg" {
+			t.Errorf("unexpected file %q %q", fh.Filename, b)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer storage.Close()
+
+	target := &StagedTarget{
+		URL:         storage.URL,
+		HTTPMethod:  "POST",
+		ResourceURL: "https://cdn.example.com/tmp/parka.jpg?v=1",
+		Parameters:  []Parameter{{Name: "key", Value: "tmp/parka.jpg"}, {Name: "policy", Value: "p"}},
+	}
+	if err := Upload(context.Background(), nil, target, strings.NewReader("jpegdata-and-more"), 8); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUpload_Error(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer storage.Close()
+
+	err := Upload(context.Background(), nil, &StagedTarget{URL: storage.URL}, strings.NewReader("x"), 1)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
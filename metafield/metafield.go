@@ -134,7 +134,12 @@ type countResource struct {
 // MetafieldDefinition Implementation
 // =====================================================================
 
+// Create creates a metafield definition. Definitions in platform-owned
+// namespaces are rejected with ErrReservedNamespace before any request is sent.
 func (s *defOp) Create(ctx context.Context, def MetafieldDefinition) (*MetafieldDefinition, error) {
+	if err := validateDefinition(def); err != nil {
+		return nil, err
+	}
 	r := &defResource{}
 	err := s.client.Post(ctx, s.client.CreatePath("metafield_definitions.json"), defResource{MetafieldDefinition: &def}, r)
 	return r.MetafieldDefinition, err
@@ -152,7 +157,7 @@ func (s *defOp) List(ctx context.Context, opts *DefinitionListOptions) ([]Metafi
 func (s *defOp) Get(ctx context.Context, id int64) (*MetafieldDefinition, error) {
 	r := &defResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("metafield_definitions/%d.json", id)), r, nil)
-	return r.MetafieldDefinition, err
+	return core.Found(r.MetafieldDefinition, err)
 }
 func (s *defOp) Delete(ctx context.Context, id int64) error {
 	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("metafield_definitions/%d.json", id)))
@@ -189,7 +194,7 @@ func (s *resOp) Get(ctx context.Context, ownerResource string, ownerID, metafiel
 	r := &mfResource{}
 	path := fmt.Sprintf("%s/%d/metafields/%d.json", ownerResource, ownerID, metafieldID)
 	err := s.client.Get(ctx, s.client.CreatePath(path), r, nil)
-	return r.Metafield, err
+	return core.Found(r.Metafield, err)
 }
 func (s *resOp) Delete(ctx context.Context, ownerResource string, ownerID, metafieldID int64) error {
 	path := fmt.Sprintf("%s/%d/metafields/%d.json", ownerResource, ownerID, metafieldID)
@@ -224,7 +229,7 @@ func (s *storeOp) List(ctx context.Context, opts *core.ListOptions) ([]Metafield
 func (s *storeOp) Get(ctx context.Context, metafieldID int64) (*Metafield, error) {
 	r := &mfResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("metafields/%d.json", metafieldID)), r, nil)
-	return r.Metafield, err
+	return core.Found(r.Metafield, err)
 }
 func (s *storeOp) Delete(ctx context.Context, metafieldID int64) error {
 	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("metafields/%d.json", metafieldID)))
@@ -0,0 +1,86 @@
+package metafield
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// =====================================================================
+// Well-known Namespaces
+// =====================================================================
+
+// Platform-owned metafield namespaces. Apps may read and write values in
+// these namespaces but must not create definitions in them.
+const (
+	// NamespaceShopline is reserved for internal platform data.
+	NamespaceShopline = "shopline"
+	// NamespaceGlobal holds storefront SEO overrides.
+	NamespaceGlobal = "global"
+	// NamespaceReviews holds product rating aggregates written by review apps.
+	NamespaceReviews = "reviews"
+	// NamespaceSizeChart holds product size chart data.
+	NamespaceSizeChart = "size_chart"
+)
+
+// Well-known keys in the platform-owned namespaces.
+const (
+	KeyTitleTag       = "title_tag"       // global.title_tag: single_line_text_field
+	KeyDescriptionTag = "description_tag" // global.description_tag: multi_line_text_field
+	KeyRating         = "rating"          // reviews.rating: rating
+	KeyRatingCount    = "rating_count"    // reviews.rating_count: number_integer
+	KeySizeChart      = "size_chart"      // size_chart.size_chart: json
+)
+
+// WellKnownKey documents a platform-defined metafield and its value type.
+type WellKnownKey struct {
+	Namespace   string
+	Key         string
+	Type        string
+	Description string
+}
+
+// WellKnownKeys lists the platform-defined metafields.
+var WellKnownKeys = []WellKnownKey{
+	{NamespaceGlobal, KeyTitleTag, "single_line_text_field", "SEO page title"},
+	{NamespaceGlobal, KeyDescriptionTag, "multi_line_text_field", "SEO meta description"},
+	{NamespaceReviews, KeyRating, "rating", "Average product rating"},
+	{NamespaceReviews, KeyRatingCount, "number_integer", "Number of product ratings"},
+	{NamespaceSizeChart, KeySizeChart, "json", "Product size chart"},
+}
+
+var reservedNamespaces = map[string]bool{
+	NamespaceShopline:  true,
+	NamespaceGlobal:    true,
+	NamespaceReviews:   true,
+	NamespaceSizeChart: true,
+}
+
+// ErrReservedNamespace is returned by DefinitionService.Create when the
+// definition targets a platform-owned namespace.
+var ErrReservedNamespace = errors.New("metafield: namespace is reserved by Shopline")
+
+// IsReservedNamespace reports whether namespace is platform-owned.
+// The comparison is case-insensitive.
+func IsReservedNamespace(namespace string) bool {
+	return reservedNamespaces[strings.ToLower(namespace)]
+}
+
+// LookupWellKnown returns the documented definition for namespace.key.
+func LookupWellKnown(namespace, key string) (WellKnownKey, bool) {
+	for _, k := range WellKnownKeys {
+		if strings.EqualFold(k.Namespace, namespace) && strings.EqualFold(k.Key, key) {
+			return k, true
+		}
+	}
+	return WellKnownKey{}, false
+}
+
+// validateDefinition rejects definitions that would clash with
+// platform-owned namespaces.
+func validateDefinition(def MetafieldDefinition) error {
+	if IsReservedNamespace(def.Namespace) {
+		return fmt.Errorf("%w: %q", ErrReservedNamespace, def.Namespace)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package metafield
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// failingRequester fails the test if any request is sent.
+type failingRequester struct{ t *testing.T }
+
+func (f failingRequester) Get(context.Context, string, interface{}, interface{}) error {
+	f.t.Error("unexpected GET")
+	return nil
+}
+func (f failingRequester) Post(context.Context, string, interface{}, interface{}) error {
+	f.t.Error("unexpected POST")
+	return nil
+}
+func (f failingRequester) Put(context.Context, string, interface{}, interface{}) error {
+	f.t.Error("unexpected PUT")
+	return nil
+}
+func (f failingRequester) Delete(context.Context, string) error {
+	f.t.Error("unexpected DELETE")
+	return nil
+}
+func (f failingRequester) CreatePath(resource string) string { return resource }
+
+func TestDefinitionCreate_ReservedNamespace(t *testing.T) {
+	svc := NewDefinitionService(failingRequester{t})
+	_, err := svc.Create(context.Background(), MetafieldDefinition{Namespace: "Global", Key: "title_tag"})
+	if !errors.Is(err, ErrReservedNamespace) {
+		t.Fatalf("expected ErrReservedNamespace, got %v", err)
+	}
+}
+
+func TestLookupWellKnown(t *testing.T) {
+	k, ok := LookupWellKnown(NamespaceReviews, KeyRating)
+	if !ok || k.Type != "rating" {
+		t.Errorf("expected reviews.rating of type rating, got %+v (ok=%v)", k, ok)
+	}
+	if _, ok := LookupWellKnown("custom", "anything"); ok {
+		t.Error("expected custom.anything to be unknown")
+	}
+	if IsReservedNamespace("custom") {
+		t.Error("expected custom namespace to be allowed")
+	}
+}
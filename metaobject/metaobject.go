@@ -0,0 +1,202 @@
+// Package metaobject manages metaobject definitions and entries: structured
+// content types with their own fields, such as size guides, store locations
+// or FAQ entries, that storefront themes render directly.
+//
+//	def, err := client.MetaobjectDefinition.Create(ctx, metaobject.Definition{
+//	    Type: "faq",
+//	    Name: "FAQ",
+//	    FieldDefinitions: []metaobject.FieldDefinition{
+//	        {Key: "question", Name: "Question", Type: "single_line_text_field", Required: true},
+//	        {Key: "answer", Name: "Answer", Type: "multi_line_text_field"},
+//	    },
+//	})
+//
+// Metaobjects are only available on stores and API versions that expose
+// them; elsewhere every call fails with a 404.
+package metaobject
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Entry statuses. Only active entries are visible on the storefront.
+const (
+	StatusActive = "active"
+	StatusDraft  = "draft"
+)
+
+// =====================================================================
+// Definition Service
+// =====================================================================
+
+type DefinitionService interface {
+	Create(ctx context.Context, def Definition) (*Definition, error)
+	Update(ctx context.Context, def Definition) (*Definition, error)
+	List(ctx context.Context, opts *core.ListOptions) ([]Definition, error)
+	Get(ctx context.Context, id int64) (*Definition, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+func NewDefinitionService(client core.Requester) DefinitionService {
+	return &defOp{
+		ResourceService: core.NewResourceService(client, core.Resource[Definition]{
+			Path: "metaobject_definitions", Key: "metaobject_definition", ListKey: "metaobject_definitions",
+			ID: func(d *Definition) int64 { return d.ID },
+		}),
+	}
+}
+
+type defOp struct {
+	*core.ResourceService[Definition]
+}
+
+// =====================================================================
+// Entry Service
+// =====================================================================
+
+type Service interface {
+	Create(ctx context.Context, m Metaobject) (*Metaobject, error)
+	Update(ctx context.Context, m Metaobject) (*Metaobject, error)
+	List(ctx context.Context, opts *ListOptions) ([]Metaobject, error)
+	Get(ctx context.Context, id int64) (*Metaobject, error)
+	Delete(ctx context.Context, id int64) error
+
+	// Publish makes an entry visible on the storefront, Unpublish hides it
+	// again. Both need a definition with the publishable capability.
+	Publish(ctx context.Context, id int64) (*Metaobject, error)
+	Unpublish(ctx context.Context, id int64) (*Metaobject, error)
+}
+
+func NewService(client core.Requester) Service {
+	return &entryOp{
+		ResourceService: core.NewResourceService(client, core.Resource[Metaobject]{
+			Path: "metaobjects", Key: "metaobject", ListKey: "metaobjects",
+			ID: func(m *Metaobject) int64 { return m.ID },
+		}),
+		client: client,
+	}
+}
+
+type entryOp struct {
+	*core.ResourceService[Metaobject]
+	client core.Requester
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+type Definition struct {
+	ID               int64             `json:"id,omitempty"`
+	Type             string            `json:"type,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	DisplayNameKey   string            `json:"display_name_key,omitempty"`
+	FieldDefinitions []FieldDefinition `json:"field_definitions,omitempty"`
+	Capabilities     *Capabilities     `json:"capabilities,omitempty"`
+	EntriesCount     int               `json:"entries_count,omitempty"`
+	CreatedAt        *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time        `json:"updated_at,omitempty"`
+}
+
+type FieldDefinition struct {
+	Key         string       `json:"key,omitempty"`
+	Name        string       `json:"name,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Type        string       `json:"type,omitempty"`
+	Required    bool         `json:"required,omitempty"`
+	Validations []Validation `json:"validations,omitempty"`
+}
+
+type Validation struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Capabilities are optional behaviours of a definition's entries.
+type Capabilities struct {
+	// Publishable entries have a status; without it every entry is active.
+	Publishable bool `json:"publishable,omitempty"`
+	// Renderable entries get their own storefront page.
+	Renderable bool `json:"renderable,omitempty"`
+}
+
+type Metaobject struct {
+	ID          int64      `json:"id,omitempty"`
+	Type        string     `json:"type,omitempty"`
+	Handle      string     `json:"handle,omitempty"`
+	DisplayName string     `json:"display_name,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Fields      []Field    `json:"fields,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// Field is the value of one field of an entry, encoded like a metafield
+// value of the field's type.
+type Field struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// Value returns the value of the field named key, or "" if the entry has
+// none.
+func (m *Metaobject) Value(key string) string {
+	for _, f := range m.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// ListOptions selects entries of one definition type.
+type ListOptions struct {
+	core.ListOptions
+	Type string `url:"type,omitempty"`
+}
+
+// JSON wrappers
+type entriesResource struct {
+	Metaobjects []Metaobject `json:"metaobjects"`
+}
+
+// =====================================================================
+// Definition Implementation
+// =====================================================================
+
+func (s *defOp) Create(ctx context.Context, def Definition) (*Definition, error) {
+	if def.Type == "" {
+		return nil, fmt.Errorf("metaobject: definition needs a type")
+	}
+	return s.ResourceService.Create(ctx, def)
+}
+
+// =====================================================================
+// Entry Implementation
+// =====================================================================
+
+func (s *entryOp) Create(ctx context.Context, m Metaobject) (*Metaobject, error) {
+	if m.Type == "" {
+		return nil, fmt.Errorf("metaobject: entry needs the type of its definition")
+	}
+	return s.ResourceService.Create(ctx, m)
+}
+
+// List takes the entry ListOptions, which add the definition type filter.
+func (s *entryOp) List(ctx context.Context, opts *ListOptions) ([]Metaobject, error) {
+	r := &entriesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("metaobjects.json"), r, opts)
+	return r.Metaobjects, err
+}
+func (s *entryOp) Publish(ctx context.Context, id int64) (*Metaobject, error) {
+	return s.Update(ctx, Metaobject{ID: id, Status: StatusActive})
+}
+func (s *entryOp) Unpublish(ctx context.Context, id int64) (*Metaobject, error) {
+	return s.Update(ctx, Metaobject{ID: id, Status: StatusDraft})
+}
@@ -0,0 +1,112 @@
+package metaobject
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for metaobject tests.
+type mockRequester struct {
+	server *httptest.Server
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestDefinitionCreate(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/metaobject_definitions.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Definition *Definition `json:"metaobject_definition"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Definition == nil || len(body.Definition.FieldDefinitions) != 1 || !body.Definition.Capabilities.Publishable {
+			t.Errorf("unexpected body: %+v", body.Definition)
+		}
+		body.Definition.ID = 3
+		json.NewEncoder(w).Encode(body)
+	})
+	defer close()
+
+	svc := NewDefinitionService(mock)
+	def, err := svc.Create(context.Background(), Definition{
+		Type:             "faq",
+		FieldDefinitions: []FieldDefinition{{Key: "question", Type: "single_line_text_field", Required: true}},
+		Capabilities:     &Capabilities{Publishable: true},
+	})
+	if err != nil || def.ID != 3 {
+		t.Fatalf("Create = %+v, %v", def, err)
+	}
+	if _, err := svc.Create(context.Background(), Definition{Name: "FAQ"}); err == nil {
+		t.Error("expected error without a type")
+	}
+}
+
+func TestEntryPublish(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/metaobjects/8.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		status, _ := body["metaobject"]["status"].(string)
+		w.Write([]byte(`{"metaobject":{"id":8,"type":"faq","status":"` + status + `","fields":[{"key":"question","value":"Do you ship abroad?"}]}}`))
+	})
+	defer close()
+
+	svc := NewService(mock)
+	m, err := svc.Publish(context.Background(), 8)
+	if err != nil || m.Status != StatusActive {
+		t.Fatalf("Publish = %+v, %v", m, err)
+	}
+	if got := m.Value("question"); got != "Do you ship abroad?" {
+		t.Errorf("Value(question) = %q", got)
+	}
+	if m, err = svc.Unpublish(context.Background(), 8); err != nil || m.Status != StatusDraft {
+		t.Fatalf("Unpublish = %+v, %v", m, err)
+	}
+}
@@ -0,0 +1,41 @@
+package shopline
+
+// Metrics receives operational metrics from the SDK, e.g. to forward them to
+// Prometheus or StatsD. Implementations must be safe for concurrent use.
+//
+// Every metric carries a "shop" label with the store handle.
+type Metrics interface {
+	// IncCounter adds one to the named counter.
+	IncCounter(name string, labels map[string]string)
+
+	// SetGauge sets the named gauge to value.
+	SetGauge(name string, value float64, labels map[string]string)
+}
+
+// Metric names reported by the TokenManager.
+const (
+	MetricTokenRefreshes     = "shopline_token_refreshes_total"
+	MetricTokenRefreshErrors = "shopline_token_refresh_errors_total"
+	MetricTokenWaits         = "shopline_token_coalesced_waits_total"
+	MetricTokenExpiry        = "shopline_token_expiry_timestamp_seconds"
+)
+
+// Metric names reported by the Client; see WithMetrics.
+const (
+	MetricRetryWait     = "shopline_retry_wait_seconds"
+	MetricRetryDeadline = "shopline_retry_deadline_exceeded_total"
+)
+
+// incCounter reports a counter increment if metrics are set.
+func (c *Client) incCounter(name string) {
+	if c.metrics != nil {
+		c.metrics.IncCounter(name, map[string]string{"shop": c.handle})
+	}
+}
+
+// setGauge reports a gauge value if metrics are set.
+func (c *Client) setGauge(name string, value float64) {
+	if c.metrics != nil {
+		c.metrics.SetGauge(name, value, map[string]string{"shop": c.handle})
+	}
+}
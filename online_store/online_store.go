@@ -3,6 +3,8 @@ package onlinestore
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/imokyou/slshop/core"
@@ -15,6 +17,18 @@ import (
 type ThemeService interface {
 	List(ctx context.Context) ([]Theme, error)
 	Get(ctx context.Context, id int64) (*Theme, error)
+
+	// Publish makes the theme the store's live theme. The previous main
+	// theme becomes unpublished.
+	Publish(ctx context.Context, id int64) (*Theme, error)
+
+	// Duplicate copies the theme under a new name. The copy is unpublished
+	// and Processing until its files have been copied.
+	Duplicate(ctx context.Context, id int64, name string) (*Theme, error)
+
+	// PreviewURL returns the storefront URL that renders the theme without
+	// publishing it.
+	PreviewURL(id int64) string
 }
 
 func NewThemeService(client core.Requester) ThemeService {
@@ -23,6 +37,12 @@ func NewThemeService(client core.Requester) ThemeService {
 
 type themeOp struct{ client core.Requester }
 
+// Theme roles.
+const (
+	ThemeRoleMain        = "main"
+	ThemeRoleUnpublished = "unpublished"
+)
+
 type Theme struct {
 	ID           int64      `json:"id,omitempty"`
 	Name         string     `json:"name,omitempty"`
@@ -49,8 +69,35 @@ func (s *themeOp) List(ctx context.Context) ([]Theme, error) {
 func (s *themeOp) Get(ctx context.Context, id int64) (*Theme, error) {
 	r := &themeResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("themes/%d.json", id)), r, nil)
+	return core.Found(r.Theme, err)
+}
+func (s *themeOp) Publish(ctx context.Context, id int64) (*Theme, error) {
+	r := &themeResource{}
+	body := themeResource{Theme: &Theme{ID: id, Role: ThemeRoleMain}}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("themes/%d.json", id)), body, r)
 	return r.Theme, err
 }
+func (s *themeOp) Duplicate(ctx context.Context, id int64, name string) (*Theme, error) {
+	if name == "" {
+		return nil, fmt.Errorf("onlinestore: duplicate of theme %d needs a name", id)
+	}
+	r := &themeResource{}
+	body := themeResource{Theme: &Theme{Name: name}}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("themes/%d/duplicate.json", id)), body, r)
+	return r.Theme, err
+}
+
+// PreviewURL uses the store host of the client the service was created
+// with; for a client without one it returns a path relative to the
+// storefront.
+func (s *themeOp) PreviewURL(id int64) string {
+	u := url.URL{Path: "/", RawQuery: url.Values{"preview_theme_id": {strconv.FormatInt(id, 10)}}.Encode()}
+	if b, ok := s.client.(interface{ GetBaseURL() *url.URL }); ok && b.GetBaseURL() != nil {
+		u.Scheme = b.GetBaseURL().Scheme
+		u.Host = b.GetBaseURL().Host
+	}
+	return u.String()
+}
 
 // =====================================================================
 // Page
@@ -98,7 +145,7 @@ func (s *pageOp) List(ctx context.Context, opts *core.ListOptions) ([]Page, erro
 func (s *pageOp) Get(ctx context.Context, id int64) (*Page, error) {
 	r := &pageResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("pages/%d.json", id)), r, nil)
-	return r.Page, err
+	return core.Found(r.Page, err)
 }
 func (s *pageOp) Create(ctx context.Context, p Page) (*Page, error) {
 	r := &pageResource{}
@@ -126,11 +173,11 @@ type ScriptTagService interface {
 }
 
 func NewScriptTagService(client core.Requester) ScriptTagService {
-	return &scriptTagOp{client: client}
+	return core.NewResourceService(client, core.Resource[ScriptTag]{
+		Path: "script_tags", Key: "script_tag", ListKey: "script_tags",
+	})
 }
 
-type scriptTagOp struct{ client core.Requester }
-
 type ScriptTag struct {
 	ID           int64      `json:"id,omitempty"`
 	Event        string     `json:"event,omitempty"`
@@ -139,29 +186,3 @@ type ScriptTag struct {
 	CreatedAt    *time.Time `json:"created_at,omitempty"`
 	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
 }
-
-type scriptTagResource struct {
-	ScriptTag *ScriptTag `json:"script_tag"`
-}
-type scriptTagsResource struct {
-	ScriptTags []ScriptTag `json:"script_tags"`
-}
-
-func (s *scriptTagOp) List(ctx context.Context, opts *core.ListOptions) ([]ScriptTag, error) {
-	r := &scriptTagsResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("script_tags.json"), r, opts)
-	return r.ScriptTags, err
-}
-func (s *scriptTagOp) Get(ctx context.Context, id int64) (*ScriptTag, error) {
-	r := &scriptTagResource{}
-	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("script_tags/%d.json", id)), r, nil)
-	return r.ScriptTag, err
-}
-func (s *scriptTagOp) Create(ctx context.Context, t ScriptTag) (*ScriptTag, error) {
-	r := &scriptTagResource{}
-	err := s.client.Post(ctx, s.client.CreatePath("script_tags.json"), scriptTagResource{ScriptTag: &t}, r)
-	return r.ScriptTag, err
-}
-func (s *scriptTagOp) Delete(ctx context.Context, id int64) error {
-	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("script_tags/%d.json", id)))
-}
@@ -0,0 +1,217 @@
+package shopline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUserTokenRequired is returned by UserTokenManager when it has no valid
+// token for a staff user. Online tokens cannot be refreshed, so the user has
+// to go through OAuth again.
+var ErrUserTokenRequired = errors.New("shopline: user token missing or expired, re-authorize the user")
+
+// OnlineUser is the staff user an online access token acts for.
+type OnlineUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"firstName,omitempty"`
+	LastName     string `json:"lastName,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Locale       string `json:"locale,omitempty"`
+	AccountOwner bool   `json:"accountOwner,omitempty"`
+}
+
+// OnlineTokenResponse is the response of an online (user-scoped) token
+// exchange. The token expires with the user's session and cannot be
+// refreshed.
+type OnlineTokenResponse struct {
+	Code     int    `json:"code"`
+	I18nCode string `json:"i18nCode"`
+	Message  string `json:"message"`
+	Data     struct {
+		AccessToken         string     `json:"accessToken"`
+		ExpireTime          string     `json:"expireTime"`
+		Scope               string     `json:"scope"`
+		AssociatedUserScope string     `json:"associatedUserScope"`
+		AssociatedUser      OnlineUser `json:"associatedUser"`
+	} `json:"data"`
+	TraceID string `json:"traceId"`
+}
+
+func (r *OnlineTokenResponse) status() (int, string, string) { return r.Code, r.Message, r.TraceID }
+
+// ExpireAt returns the token's expiry. It fails if the response has no
+// valid expiry, as an online token cannot be refreshed and guessing one
+// could hand out an expired token as valid.
+func (r *OnlineTokenResponse) ExpireAt() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, r.Data.ExpireTime)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("shopline: invalid online token expiry %q: %w", r.Data.ExpireTime, err)
+	}
+	return t, nil
+}
+
+// GetOnlineAccessToken exchanges an authorization code for an online access
+// token, which acts for the staff user who authorized the app with that
+// user's permissions, instead of for the store.
+//
+// POST https://{handle}.{DomainSuffix}/admin/oauth/token/create
+func (app App) GetOnlineAccessToken(ctx context.Context, handle, code string) (*OnlineTokenResponse, error) {
+	bodyJSON, err := json.Marshal(map[string]string{"code": code, "accessMode": "online"})
+	if err != nil {
+		return nil, fmt.Errorf("shopline: failed to marshal body: %w", err)
+	}
+	var resp OnlineTokenResponse
+	if _, err := app.doAuthRequestInto(ctx, handle, "create", bytes.NewReader(bodyJSON), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// =====================================================================
+// UserTokenManager
+// =====================================================================
+
+// UserTokenManager keeps the online tokens of an app's staff users, keyed by
+// store handle and user ID, so embedded apps can act on behalf of the user
+// in front of them:
+//
+//	users := shopline.NewUserTokenManager(app, store)
+//
+//	// OAuth callback with an online grant:
+//	user, err := users.Exchange(ctx, handle, code)
+//
+//	// Later requests from that user:
+//	client, err := users.NewClient(ctx, handle, user.ID)
+//	if errors.Is(err, shopline.ErrUserTokenRequired) {
+//	    // redirect to app.AuthorizeURL(handle, state)
+//	}
+//
+// It is safe for concurrent use.
+type UserTokenManager struct {
+	app   App
+	store TokenStore
+
+	// exchange obtains a token for a code; GetOnlineAccessToken unless
+	// replaced in tests.
+	exchange func(ctx context.Context, handle, code string) (*OnlineTokenResponse, error)
+
+	mu     sync.Mutex
+	tokens map[userTokenKey]*ManagedToken
+}
+
+type userTokenKey struct {
+	handle string
+	userID int64
+}
+
+// NewUserTokenManager creates a UserTokenManager. store may be nil to keep
+// tokens in memory only.
+func NewUserTokenManager(app App, store TokenStore) *UserTokenManager {
+	return &UserTokenManager{
+		app:      app,
+		store:    store,
+		exchange: app.GetOnlineAccessToken,
+		tokens:   make(map[userTokenKey]*ManagedToken),
+	}
+}
+
+// storeKey returns the persistence key of a user's token.
+func (m *UserTokenManager) storeKey(handle string, userID int64) string {
+	return fmt.Sprintf("%s:%s:user:%d", handle, m.app.AppKey, userID)
+}
+
+// Exchange trades an authorization code for an online token, saves it and
+// returns the user it belongs to.
+func (m *UserTokenManager) Exchange(ctx context.Context, handle, code string) (*OnlineUser, error) {
+	resp, err := m.exchange(ctx, handle, code)
+	if err != nil {
+		return nil, err
+	}
+	user := resp.Data.AssociatedUser
+	if user.ID == 0 {
+		return nil, fmt.Errorf("shopline: token response for %s has no associated user; was an online token requested?", handle)
+	}
+	expireAt, err := resp.ExpireAt()
+	if err != nil {
+		return nil, err
+	}
+	token := &ManagedToken{
+		AccessToken: resp.Data.AccessToken,
+		ExpireAt:    expireAt,
+		Scope:       resp.Data.AssociatedUserScope,
+	}
+	if err := m.SetToken(ctx, handle, user.ID, token); err != nil {
+		return &user, err
+	}
+	return &user, nil
+}
+
+// SetToken saves a user's token, e.g. one obtained with
+// GetOnlineAccessToken.
+func (m *UserTokenManager) SetToken(ctx context.Context, handle string, userID int64, token *ManagedToken) error {
+	m.mu.Lock()
+	m.tokens[userTokenKey{handle, userID}] = token
+	m.mu.Unlock()
+
+	if m.store != nil {
+		if err := m.store.Set(ctx, m.storeKey(handle, userID), token); err != nil {
+			return fmt.Errorf("shopline: failed to persist user token: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetToken returns the user's access token, loading it from the store on
+// first use. It returns ErrUserTokenRequired if there is no token or it has
+// expired.
+func (m *UserTokenManager) GetToken(ctx context.Context, handle string, userID int64) (string, error) {
+	key := userTokenKey{handle, userID}
+	m.mu.Lock()
+	token := m.tokens[key]
+	m.mu.Unlock()
+
+	if token == nil && m.store != nil {
+		stored, err := m.store.Get(ctx, m.storeKey(handle, userID))
+		if err != nil {
+			return "", fmt.Errorf("shopline: failed to load user token: %w", err)
+		}
+		if stored != nil {
+			m.mu.Lock()
+			m.tokens[key] = stored
+			m.mu.Unlock()
+			token = stored
+		}
+	}
+	if token.IsExpired() {
+		return "", ErrUserTokenRequired
+	}
+	return token.AccessToken, nil
+}
+
+// InvalidateToken forgets a user's token, e.g. on logout.
+func (m *UserTokenManager) InvalidateToken(ctx context.Context, handle string, userID int64) error {
+	m.mu.Lock()
+	delete(m.tokens, userTokenKey{handle, userID})
+	m.mu.Unlock()
+
+	if m.store != nil {
+		return m.store.Delete(ctx, m.storeKey(handle, userID))
+	}
+	return nil
+}
+
+// NewClient creates a Client for the store that acts as the user. The
+// client uses the token current at the time of the call; create a new one
+// per user request.
+func (m *UserTokenManager) NewClient(ctx context.Context, handle string, userID int64, opts ...Option) (*Client, error) {
+	token, err := m.GetToken(ctx, handle, userID)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(m.app, handle, token, opts...)
+}
@@ -17,13 +17,33 @@ func WithVersion(version string) Option {
 }
 
 // WithRetry sets the maximum number of retries for failed requests.
-// Retries are performed on HTTP 429 (rate limited) and HTTP 503 responses.
+// Retries are performed on HTTP 429 (rate limited) and HTTP 503 responses
+// unless WithRetryPolicy says otherwise.
 func WithRetry(retries int) Option {
 	return func(c *Client) {
 		c.maxRetries = retries
 	}
 }
 
+// WithRetryPolicy replaces the default retry behaviour: which status codes
+// and transport errors are retried, per-method retry limits and backoff
+// timing. policy.MaxRetries overrides an earlier WithRetry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = &policy
+		c.maxRetries = policy.MaxRetries
+	}
+}
+
+// WithMetrics reports client metrics to m: the wait chosen before each retry
+// (MetricRetryWait), retries abandoned because of a context deadline
+// (MetricRetryDeadline) and, with WithTokenManager, the token metrics.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client for API requests.
 func WithHTTPClient(httpClient *http.Client) Option {
 	return func(c *Client) {
@@ -45,6 +65,27 @@ func WithBaseURL(baseURL string) Option {
 	}
 }
 
+// WithDomainSuffix sets the domain stores are served under, for regional
+// domains and partner sandboxes. The API host becomes "{handle}.{suffix}".
+// It also applies to OAuth token refreshes made by WithTokenManager.
+// Default: "myshopline.com".
+//
+//	shopline.WithDomainSuffix("myshopline.cn")
+func WithDomainSuffix(suffix string) Option {
+	return func(c *Client) {
+		c.app.DomainSuffix = suffix
+	}
+}
+
+// WithAdminDomain sends API requests to a custom admin domain of the store
+// (e.g. "admin.mybrand.com") instead of "{handle}.{suffix}".
+// WithBaseURL takes precedence over both.
+func WithAdminDomain(domain string) Option {
+	return func(c *Client) {
+		c.adminDomain = domain
+	}
+}
+
 // WithTokenManager enables automatic token management with persistence
 // and concurrency-safe refresh. The TokenStore is used to persist tokens
 // across process restarts.
@@ -84,6 +125,29 @@ func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
 	}
 }
 
+// WithMaxConcurrentRequests limits the client to n in-flight requests;
+// further calls block until a slot frees up or their context is done. A
+// slot is held for the whole call, including retries, so goroutine fan-outs
+// stay under the store's burst limit. n <= 0 removes the limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = NewConcurrencyLimiter(n)
+	}
+}
+
+// WithConcurrencyLimiter makes the client take its slots from l, keyed by
+// the store handle. Use it instead of WithMaxConcurrentRequests when several
+// clients may talk to the same store.
+func WithConcurrencyLimiter(l *ConcurrencyLimiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
 // WithTimeout overrides the HTTP client's request timeout.
 // The default timeout is 30 seconds.
 //
@@ -97,3 +161,49 @@ func WithTimeout(d time.Duration) Option {
 		c.httpClient.Timeout = d
 	}
 }
+
+// WithHedgedRequests reduces tail latency of reads: when a GET has not
+// answered within delay, an identical request is sent, up to maxHedges
+// extra copies spaced delay apart. The first response wins and the others
+// are cancelled. Only GETs are hedged, since they are safe to duplicate.
+//
+// Each copy counts against the store's rate limit; choose a delay near the
+// observed p95 latency so hedges stay rare:
+//
+//	shopline.WithHedgedRequests(300*time.Millisecond, 1)
+func WithHedgedRequests(delay time.Duration, maxHedges int) Option {
+	return func(c *Client) {
+		if maxHedges <= 0 {
+			c.hedge = nil
+			return
+		}
+		c.hedge = &hedgeConfig{delay: delay, maxHedges: maxHedges}
+	}
+}
+
+// DecodeDriftFunc is called when a response contains a field that the target
+// model does not declare. field is the unknown JSON key.
+type DecodeDriftFunc func(req *http.Request, field string)
+
+// WithStrictDecoding makes Do fail when a response contains fields the SDK
+// models do not declare. Useful in CI against a test store to catch API
+// model drift early; not recommended in production.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
+// WithDecodeDriftHandler reports response fields unknown to the SDK models
+// without failing the request, e.g. to log or count model drift:
+//
+//	shopline.WithDecodeDriftHandler(func(req *http.Request, field string) {
+//	    log.Printf("model drift: %s %s has unknown field %s", req.Method, req.URL.Path, field)
+//	})
+//
+// Only the first unknown field of each response is reported.
+func WithDecodeDriftHandler(fn DecodeDriftFunc) Option {
+	return func(c *Client) {
+		c.onDecodeDrift = fn
+	}
+}
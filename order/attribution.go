@@ -0,0 +1,84 @@
+package order
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UTM holds the campaign parameters an order's visit was tagged with.
+type UTM struct {
+	Source   string
+	Medium   string
+	Campaign string
+	Term     string
+	Content  string
+}
+
+// IsZero reports whether no UTM parameter is set.
+func (u UTM) IsZero() bool {
+	return u == UTM{}
+}
+
+// ParseUTM reads the utm_* parameters from a landing site such as
+// "/products/tee?utm_source=google&utm_medium=cpc". It accepts full URLs,
+// paths and bare query strings, and ignores anything it cannot parse.
+func ParseUTM(landingSite string) UTM {
+	q := landingSite
+	if i := strings.IndexByte(q, '?'); i >= 0 {
+		q = q[i+1:]
+	} else if !strings.Contains(q, "=") {
+		return UTM{}
+	}
+	if i := strings.IndexByte(q, '#'); i >= 0 {
+		q = q[:i]
+	}
+	values, _ := url.ParseQuery(q)
+	return UTM{
+		Source:   values.Get("utm_source"),
+		Medium:   values.Get("utm_medium"),
+		Campaign: values.Get("utm_campaign"),
+		Term:     values.Get("utm_term"),
+		Content:  values.Get("utm_content"),
+	}
+}
+
+// UTM returns the order's campaign parameters, parsed from LandingSite.
+// Parameters missing there are taken from note attributes of the same name,
+// where some storefront scripts record them instead.
+func (o *Order) UTM() UTM {
+	u := ParseUTM(o.LandingSite)
+	for _, a := range o.NoteAttributes {
+		var field *string
+		switch strings.ToLower(a.Name) {
+		case "utm_source":
+			field = &u.Source
+		case "utm_medium":
+			field = &u.Medium
+		case "utm_campaign":
+			field = &u.Campaign
+		case "utm_term":
+			field = &u.Term
+		case "utm_content":
+			field = &u.Content
+		default:
+			continue
+		}
+		if *field == "" {
+			*field = a.Value
+		}
+	}
+	return u
+}
+
+// ReferringDomain returns the host of ReferringSite, without a leading
+// "www.", or "" for direct visits and unparsable values.
+func (o *Order) ReferringDomain() string {
+	if o.ReferringSite == "" {
+		return ""
+	}
+	u, err := url.Parse(o.ReferringSite)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
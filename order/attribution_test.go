@@ -0,0 +1,52 @@
+package order
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+func TestParseUTM(t *testing.T) {
+	tests := []struct {
+		in   string
+		want UTM
+	}{
+		{"/products/tee?utm_source=google&utm_medium=cpc&utm_campaign=spring%20sale", UTM{Source: "google", Medium: "cpc", Campaign: "spring sale"}},
+		{"https://shop.example.com/?utm_term=tee&utm_content=banner#top", UTM{Term: "tee", Content: "banner"}},
+		{"utm_source=newsletter", UTM{Source: "newsletter"}},
+		{"/collections/all", UTM{}},
+		{"", UTM{}},
+	}
+	for _, tt := range tests {
+		if got := ParseUTM(tt.in); got != tt.want {
+			t.Errorf("ParseUTM(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOrderAttribution(t *testing.T) {
+	var o Order
+	err := json.Unmarshal([]byte(`{
+		"source_name": "web",
+		"referring_site": "https://www.Google.com/search?q=tee",
+		"landing_site": "/?utm_source=google&utm_medium=cpc",
+		"note_attributes": [
+			{"name": "utm_source", "value": "ignored"},
+			{"name": "UTM_Campaign", "value": "spring"}
+		]
+	}`), &o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := UTM{Source: "google", Medium: "cpc", Campaign: "spring"}
+	if got := o.UTM(); got != want {
+		t.Errorf("UTM() = %+v, want %+v", got, want)
+	}
+	if got := o.ReferringDomain(); got != "google.com" {
+		t.Errorf("ReferringDomain() = %q", got)
+	}
+	if !(&Order{NoteAttributes: []core.NoteAttribute{{Name: "gift", Value: "yes"}}}).UTM().IsZero() {
+		t.Error("expected zero UTM without parameters")
+	}
+}
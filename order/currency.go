@@ -0,0 +1,87 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// ErrUnsupportedCurrency is returned when an order or draft order is created
+// in a presentment currency the shop has not enabled.
+var ErrUnsupportedCurrency = errors.New("order: presentment currency not enabled for shop")
+
+// shopCurrency mirrors the fields of store.Currency needed for validation.
+type shopCurrency struct {
+	Code    string `json:"code"`
+	Primary bool   `json:"primary"`
+	Enabled bool   `json:"enabled"`
+}
+
+type shopCurrenciesResource struct {
+	Currencies []shopCurrency `json:"currencies"`
+}
+
+// currencyTTL is how long the shop's enabled currencies are cached.
+const currencyTTL = time.Hour
+
+// currencyCache holds the shop's enabled currency codes so that creating
+// orders in a presentment currency does not cost an extra request each. It
+// is safe for concurrent use.
+type currencyCache struct {
+	client core.Requester
+	now    func() time.Time
+
+	mu        sync.Mutex
+	codes     map[string]bool
+	fetchedAt time.Time
+}
+
+func newCurrencyCache(client core.Requester) *currencyCache {
+	return &currencyCache{client: client, now: time.Now}
+}
+
+// checkPresentmentCurrency verifies code against the shop's enabled
+// currencies (the same list as store.Service.GetSettlementCurrency) so that
+// multi-currency orders fail fast instead of being rejected by the API.
+// An empty code means the shop currency and is not checked. The list is
+// loaded on first use and again once it is older than currencyTTL.
+func (c *currencyCache) checkPresentmentCurrency(ctx context.Context, code string) error {
+	if code == "" {
+		return nil
+	}
+	codes, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+	if !codes[strings.ToUpper(code)] {
+		return fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+	return nil
+}
+
+// load returns the cached currency codes, fetching them if missing or
+// expired.
+func (c *currencyCache) load(ctx context.Context) (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.codes != nil && c.now().Sub(c.fetchedAt) < currencyTTL {
+		return c.codes, nil
+	}
+	r := &shopCurrenciesResource{}
+	if err := c.client.Get(core.WithoutFields(ctx), c.client.CreatePath("currency/currencies.json"), r, nil); err != nil {
+		return nil, fmt.Errorf("order: failed to load shop currencies: %w", err)
+	}
+	codes := make(map[string]bool, len(r.Currencies))
+	for _, cur := range r.Currencies {
+		if cur.Enabled || cur.Primary {
+			codes[strings.ToUpper(cur.Code)] = true
+		}
+	}
+	c.codes, c.fetchedAt = codes, c.now()
+	return codes, nil
+}
@@ -0,0 +1,56 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// DefaultBatchWorkers is used by BatchCreate when workers is not positive.
+const DefaultBatchWorkers = 4
+
+// DraftOrderResult is the outcome of creating drafts[Index] in BatchCreate.
+// Exactly one of DraftOrder and Err is set.
+type DraftOrderResult struct {
+	Index      int
+	DraftOrder *DraftOrder
+	Err        error
+}
+
+// BatchCreate creates drafts with up to workers concurrent requests and
+// returns one result per draft, in input order; a failed draft does not stop
+// the others. Each create carries its own idempotency key, so the client's
+// retries (see shopline.RetryPolicy) never duplicate a draft, and every
+// request goes through the client's rate limiter: more workers than the
+// store's limit allows only queue up.
+//
+// The error is non-nil only when ctx ends before every draft was attempted;
+// drafts not attempted then have ctx's error as their Err.
+func (s *draftOrderOp) BatchCreate(ctx context.Context, drafts []DraftOrder, workers int) ([]DraftOrderResult, error) {
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+	batchID, err := core.NewBatchID()
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to generate batch ID: %w", err)
+	}
+
+	results := make([]DraftOrderResult, len(drafts))
+	next := core.RunWorkers(ctx, len(drafts), workers, func(i int) {
+		ictx := core.WithIdempotencyKey(ctx, fmt.Sprintf("draft-%s-%d", batchID, i))
+		draft, err := s.Create(ictx, drafts[i])
+		if err != nil {
+			err = fmt.Errorf("order: draft order %d: %w", i, err)
+		}
+		results[i] = DraftOrderResult{Index: i, DraftOrder: draft, Err: err}
+	})
+
+	if next < len(drafts) {
+		for i := next; i < len(drafts); i++ {
+			results[i] = DraftOrderResult{Index: i, Err: ctx.Err()}
+		}
+		return results, fmt.Errorf("order: batch stopped after %d of %d draft orders: %w", next, len(drafts), ctx.Err())
+	}
+	return results, nil
+}
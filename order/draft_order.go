@@ -18,19 +18,36 @@ type DraftOrderService interface {
 	Complete(ctx context.Context, id int64) (*DraftOrder, error)
 	Count(ctx context.Context) (int, error)
 	SendInvoice(ctx context.Context, id int64, invoice DraftOrderInvoice) (*DraftOrderInvoice, error)
+	WaitForCompletion(ctx context.Context, draftID int64, pollInterval time.Duration) (*Order, error)
+	BatchCreate(ctx context.Context, drafts []DraftOrder, workers int) ([]DraftOrderResult, error)
 }
 
+// Draft order statuses.
+const (
+	DraftOrderStatusOpen        = "open"
+	DraftOrderStatusInvoiceSent = "invoice_sent"
+	DraftOrderStatusCompleted   = "completed"
+)
+
+// DefaultDraftPollInterval is used by WaitForCompletion when pollInterval is
+// not positive.
+const DefaultDraftPollInterval = 2 * time.Second
+
 func NewDraftOrderService(client core.Requester) DraftOrderService {
-	return &draftOrderOp{client: client}
+	return &draftOrderOp{client: client, currencies: newCurrencyCache(client)}
 }
 
-type draftOrderOp struct{ client core.Requester }
+type draftOrderOp struct {
+	client     core.Requester
+	currencies *currencyCache
+}
 
 type DraftOrder struct {
 	ID              int64                    `json:"id,omitempty"`
 	Name            string                   `json:"name,omitempty"`
 	Email           string                   `json:"email,omitempty"`
 	Currency        string                   `json:"currency,omitempty"`
+	PresentmentCurrency string               `json:"presentment_currency,omitempty"`
 	Status          string                   `json:"status,omitempty"`
 	Note            string                   `json:"note,omitempty"`
 	Tags            string                   `json:"tags,omitempty"`
@@ -71,6 +88,9 @@ type draftOrderInvoiceResource struct {
 }
 
 func (s *draftOrderOp) Create(ctx context.Context, order DraftOrder) (*DraftOrder, error) {
+	if err := s.currencies.checkPresentmentCurrency(ctx, order.PresentmentCurrency); err != nil {
+		return nil, err
+	}
 	path := s.client.CreatePath(draftOrdersBasePath + ".json")
 	body := draftOrderResource{DraftOrder: &order}
 	resource := &draftOrderResource{}
@@ -90,7 +110,7 @@ func (s *draftOrderOp) Get(ctx context.Context, id int64) (*DraftOrder, error) {
 	path := s.client.CreatePath(fmt.Sprintf("%s/%d.json", draftOrdersBasePath, id))
 	resource := &draftOrderResource{}
 	err := s.client.Get(ctx, path, resource, nil)
-	return resource.DraftOrder, err
+	return core.Found(resource.DraftOrder, err)
 }
 
 func (s *draftOrderOp) Delete(ctx context.Context, id int64) error {
@@ -118,3 +138,35 @@ func (s *draftOrderOp) SendInvoice(ctx context.Context, id int64, invoice DraftO
 	err := s.client.Post(ctx, path, body, resource)
 	return resource.DraftOrderInvoice, err
 }
+
+// WaitForCompletion polls the draft order every pollInterval until it has
+// been turned into an order, then returns that order. Completion may happen
+// asynchronously after Complete returns, or when the customer pays the
+// invoice, so the draft is re-read rather than trusting the Complete
+// response. It stops with ctx's error when ctx is done; bound it with a
+// deadline.
+func (s *draftOrderOp) WaitForCompletion(ctx context.Context, draftID int64, pollInterval time.Duration) (*Order, error) {
+	ctx = core.WithoutFields(ctx) // the order returned must be whole
+	if pollInterval <= 0 {
+		pollInterval = DefaultDraftPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		draft, err := s.Get(ctx, draftID)
+		if err != nil {
+			return nil, fmt.Errorf("order: failed to poll draft order %d: %w", draftID, err)
+		}
+		if draft.OrderID != 0 {
+			path := s.client.CreatePath(fmt.Sprintf("%s/%d.json", ordersBasePath, draft.OrderID))
+			resource := &orderResource{}
+			err := s.client.Get(ctx, path, resource, nil)
+			return core.Found(resource.Order, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("order: draft order %d not completed (status %q): %w", draftID, draft.Status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Risk recommendations reported by risk sources.
+const (
+	RiskRecommendationAccept      = "accept"
+	RiskRecommendationInvestigate = "investigate"
+	RiskRecommendationCancel      = "cancel"
+)
+
+// Transaction statuses that count as failed payment attempts.
+const (
+	TransactionStatusFailure = "failure"
+	TransactionStatusError   = "error"
+)
+
+// RiskAction is the recommended handling of an order.
+type RiskAction string
+
+const (
+	RiskActionAccept      RiskAction = "accept"
+	RiskActionInvestigate RiskAction = "investigate"
+	RiskActionCancel      RiskAction = "cancel"
+)
+
+// Defaults used by FraudAnalyzer.
+const (
+	DefaultInvestigateScore      = 0.5
+	DefaultCancelScore           = 0.85
+	DefaultMaxFailedTransactions = 3
+)
+
+// =====================================================================
+// Risk Assessment
+// =====================================================================
+
+// RiskAssessment consolidates the risks, payment attempts and customer
+// history of an order into a single recommendation.
+type RiskAssessment struct {
+	OrderID            int64
+	Score              float64 // highest risk score, 0.0–1.0
+	Action             RiskAction
+	Reasons            []string // why Action is not accept
+	Risks              []Risk
+	FailedTransactions int
+	CustomerOrders     int // orders_count of the customer, 0 for guests
+	FirstOrder         bool
+}
+
+// FraudAnalyzer builds RiskAssessments from the order API. The zero
+// thresholds are replaced by the Default* values.
+//
+//	fa := order.NewFraudAnalyzer(client.Order)
+//	a, err := fa.Analyze(ctx, orderID)
+//	if a.Action == order.RiskActionCancel { ... }
+type FraudAnalyzer struct {
+	orders Service
+
+	InvestigateScore      float64
+	CancelScore           float64
+	MaxFailedTransactions int
+}
+
+// NewFraudAnalyzer creates a FraudAnalyzer with the default thresholds.
+func NewFraudAnalyzer(orders Service) *FraudAnalyzer {
+	return &FraudAnalyzer{
+		orders:                orders,
+		InvestigateScore:      DefaultInvestigateScore,
+		CancelScore:           DefaultCancelScore,
+		MaxFailedTransactions: DefaultMaxFailedTransactions,
+	}
+}
+
+// Analyze fetches the order, its risks and its transactions and assesses
+// them.
+func (a *FraudAnalyzer) Analyze(ctx context.Context, orderID int64) (*RiskAssessment, error) {
+	ctx = core.WithoutFields(ctx) // Assess needs whole objects
+	o, err := a.orders.Get(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to get order %d: %w", orderID, err)
+	}
+	risks, err := a.orders.ListRisks(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to list risks of order %d: %w", orderID, err)
+	}
+	txns, err := a.orders.ListTransactions(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to list transactions of order %d: %w", orderID, err)
+	}
+	return a.Assess(o, risks, txns), nil
+}
+
+// Assess builds a RiskAssessment from data already fetched. The action is
+// cancel if any risk asks for it or the score reaches CancelScore, and
+// investigate if the score reaches InvestigateScore, a risk recommends
+// investigating, payment failed repeatedly, or a first-time customer has an
+// unverified email.
+func (a *FraudAnalyzer) Assess(o *Order, risks []Risk, txns []Transaction) *RiskAssessment {
+	as := &RiskAssessment{OrderID: o.ID, Score: maxRiskScore(risks), Action: RiskActionAccept, Risks: risks}
+	escalate := func(action RiskAction, reason string) {
+		as.Reasons = append(as.Reasons, reason)
+		if action == RiskActionCancel || as.Action == RiskActionAccept {
+			as.Action = action
+		}
+	}
+
+	for _, r := range risks {
+		switch {
+		case r.CauseCancel || r.Recommendation == RiskRecommendationCancel:
+			escalate(RiskActionCancel, fmt.Sprintf("%s recommends cancelling: %s", riskSource(r), r.Message))
+		case r.Recommendation == RiskRecommendationInvestigate:
+			escalate(RiskActionInvestigate, fmt.Sprintf("%s recommends investigating: %s", riskSource(r), r.Message))
+		}
+	}
+	switch {
+	case as.Score >= orDefault(a.CancelScore, DefaultCancelScore):
+		escalate(RiskActionCancel, fmt.Sprintf("risk score %.2f", as.Score))
+	case as.Score >= orDefault(a.InvestigateScore, DefaultInvestigateScore):
+		escalate(RiskActionInvestigate, fmt.Sprintf("risk score %.2f", as.Score))
+	}
+
+	for _, t := range txns {
+		if t.Status == TransactionStatusFailure || t.Status == TransactionStatusError {
+			as.FailedTransactions++
+		}
+	}
+	maxFailed := a.MaxFailedTransactions
+	if maxFailed <= 0 {
+		maxFailed = DefaultMaxFailedTransactions
+	}
+	if as.FailedTransactions >= maxFailed {
+		escalate(RiskActionInvestigate, fmt.Sprintf("%d failed payment attempts", as.FailedTransactions))
+	}
+
+	if c := o.Customer; c != nil {
+		as.CustomerOrders = c.OrdersCount
+		as.FirstOrder = c.OrdersCount <= 1
+		if as.FirstOrder && !c.VerifiedEmail {
+			escalate(RiskActionInvestigate, "first order from a customer with an unverified email")
+		}
+	}
+	return as
+}
+
+// ListHighRiskOrders lists orders matching opts (which may be nil) and
+// returns the assessments of those whose highest risk score reaches
+// threshold. Each listed order costs up to two extra requests, so narrow opts
+// (e.g. by financial status or created_at) on large stores.
+func (a *FraudAnalyzer) ListHighRiskOrders(ctx context.Context, threshold float64, opts *ListOptions) ([]RiskAssessment, error) {
+	ctx = core.WithoutFields(ctx)
+	orders, err := a.orders.List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to list orders: %w", err)
+	}
+	var high []RiskAssessment
+	for i := range orders {
+		o := &orders[i]
+		risks, err := a.orders.ListRisks(ctx, o.ID)
+		if err != nil {
+			return high, fmt.Errorf("order: failed to list risks of order %d: %w", o.ID, err)
+		}
+		if maxRiskScore(risks) < threshold {
+			continue
+		}
+		txns, err := a.orders.ListTransactions(ctx, o.ID)
+		if err != nil {
+			return high, fmt.Errorf("order: failed to list transactions of order %d: %w", o.ID, err)
+		}
+		high = append(high, *a.Assess(o, risks, txns))
+	}
+	return high, nil
+}
+
+func orDefault(v, def float64) float64 {
+	if v <= 0 {
+		return def
+	}
+	return v
+}
+
+// riskScore parses Risk.Score; unparsable scores count as 0.
+func riskScore(r Risk) float64 {
+	s, err := strconv.ParseFloat(r.Score, 64)
+	if err != nil {
+		return 0
+	}
+	return s
+}
+
+func maxRiskScore(risks []Risk) float64 {
+	var max float64
+	for _, r := range risks {
+		if s := riskScore(r); s > max {
+			max = s
+		}
+	}
+	return max
+}
+
+func riskSource(r Risk) string {
+	if r.Source == "" {
+		return "risk"
+	}
+	return r.Source
+}
@@ -0,0 +1,59 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+func TestFraudAnalyzer_Assess(t *testing.T) {
+	fa := NewFraudAnalyzer(nil)
+
+	a := fa.Assess(&Order{ID: 1, Customer: &core.Customer{OrdersCount: 12, VerifiedEmail: true}},
+		[]Risk{{Score: "0.1", Recommendation: RiskRecommendationAccept}}, nil)
+	if a.Action != RiskActionAccept || len(a.Reasons) != 0 {
+		t.Errorf("expected accept, got %s %v", a.Action, a.Reasons)
+	}
+
+	a = fa.Assess(&Order{ID: 2, Customer: &core.Customer{OrdersCount: 1}},
+		[]Risk{{Score: "0.6"}},
+		[]Transaction{{Status: TransactionStatusFailure}, {Status: TransactionStatusError}, {Status: TransactionStatusFailure}})
+	if a.Action != RiskActionInvestigate || a.FailedTransactions != 3 || !a.FirstOrder || len(a.Reasons) != 3 {
+		t.Errorf("expected investigate with 3 reasons, got %+v", a)
+	}
+
+	a = fa.Assess(&Order{ID: 3}, []Risk{{Score: "0.3", Recommendation: RiskRecommendationInvestigate}, {Score: "0.2", CauseCancel: true}}, nil)
+	if a.Action != RiskActionCancel {
+		t.Errorf("expected cancel to win over investigate, got %s", a.Action)
+	}
+}
+
+func TestFraudAnalyzer_ListHighRiskOrders(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "orders.json"):
+			json.NewEncoder(w).Encode(ordersResource{Orders: []Order{{ID: 1}, {ID: 2}}})
+		case strings.HasSuffix(r.URL.Path, "orders/1/risks.json"):
+			json.NewEncoder(w).Encode(risksResource{Risks: []Risk{{Score: "0.2"}}})
+		case strings.HasSuffix(r.URL.Path, "orders/2/risks.json"):
+			json.NewEncoder(w).Encode(risksResource{Risks: []Risk{{Score: "0.9", Source: "External"}}})
+		case strings.HasSuffix(r.URL.Path, "orders/2/transactions.json"):
+			json.NewEncoder(w).Encode(map[string]any{"transactions": []Transaction{}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	defer close()
+
+	high, err := NewFraudAnalyzer(NewService(mock)).ListHighRiskOrders(context.Background(), 0.7, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(high) != 1 || high[0].OrderID != 2 || high[0].Action != RiskActionCancel {
+		t.Errorf("expected order 2 to be cancelled, got %+v", high)
+	}
+}
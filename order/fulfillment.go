@@ -28,6 +28,9 @@ type FulfillmentService interface {
 
 	MoveFulfillmentOrder(ctx context.Context, foID, locationID int64) error
 	HoldFulfillmentOrder(ctx context.Context, foID int64, hold FulfillmentHold) error
+	ReleaseHoldFulfillmentOrder(ctx context.Context, foID int64) error
+	RescheduleFulfillmentOrder(ctx context.Context, foID int64, newFulfillAt time.Time) error
+	CloseFulfillmentOrder(ctx context.Context, foID int64, message string) error
 
 	ListInventoryLocations(ctx context.Context) ([]InventoryLocation, error)
 	ListShippingMethods(ctx context.Context) ([]ShippingMethod, error)
@@ -220,7 +223,7 @@ func (s *fulfillmentOp) GetByFulfillmentOrder(ctx context.Context, foID, fID int
 	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/fulfillments/%d.json", foID, fID))
 	r := &fulfillmentResource{}
 	err := s.client.Get(ctx, path, r, nil)
-	return r.Fulfillment, err
+	return core.Found(r.Fulfillment, err)
 }
 func (s *fulfillmentOp) CreateByFulfillmentOrder(ctx context.Context, foID int64, f Fulfillment) (*Fulfillment, error) {
 	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/fulfillments.json", foID))
@@ -254,6 +257,33 @@ func (s *fulfillmentOp) HoldFulfillmentOrder(ctx context.Context, foID int64, ho
 	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/hold.json", foID))
 	return s.client.Post(ctx, path, hold, nil)
 }
+func (s *fulfillmentOp) ReleaseHoldFulfillmentOrder(ctx context.Context, foID int64) error {
+	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/release_hold.json", foID))
+	return s.client.Post(ctx, path, nil, nil)
+}
+
+// RescheduleFulfillmentOrder moves a scheduled fulfillment order to a new
+// fulfill_at time.
+func (s *fulfillmentOp) RescheduleFulfillmentOrder(ctx context.Context, foID int64, newFulfillAt time.Time) error {
+	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/reschedule.json", foID))
+	body := map[string]interface{}{
+		"fulfillment_order": map[string]string{"new_fulfill_at": newFulfillAt.Format(time.RFC3339)},
+	}
+	return s.client.Post(ctx, path, body, nil)
+}
+
+// CloseFulfillmentOrder marks an in-progress fulfillment order as incomplete,
+// with an optional message explaining why.
+func (s *fulfillmentOp) CloseFulfillmentOrder(ctx context.Context, foID int64, message string) error {
+	path := s.client.CreatePath(fmt.Sprintf("fulfillment_orders/%d/close.json", foID))
+	var body interface{}
+	if message != "" {
+		body = map[string]interface{}{
+			"fulfillment_order": map[string]string{"message": message},
+		}
+	}
+	return s.client.Post(ctx, path, body, nil)
+}
 func (s *fulfillmentOp) ListInventoryLocations(ctx context.Context) ([]InventoryLocation, error) {
 	r := &inventoryLocationsResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("inventory_locations.json"), r, nil)
@@ -279,7 +309,7 @@ func (s *carrierOp) List(ctx context.Context) ([]CarrierService, error) {
 func (s *carrierOp) Get(ctx context.Context, id int64) (*CarrierService, error) {
 	r := &carrierServiceResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("carrier_services/%d.json", id)), r, nil)
-	return r.CarrierService, err
+	return core.Found(r.CarrierService, err)
 }
 func (s *carrierOp) Create(ctx context.Context, c CarrierService) (*CarrierService, error) {
 	r := &carrierServiceResource{}
@@ -304,7 +334,7 @@ func (s *fulfillmentSvcOp) List(ctx context.Context) ([]FulfillmentServiceDef, e
 func (s *fulfillmentSvcOp) Get(ctx context.Context, id int64) (*FulfillmentServiceDef, error) {
 	r := &fulfillmentSvcDefResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("fulfillment_services/%d.json", id)), r, nil)
-	return r.FulfillmentService, err
+	return core.Found(r.FulfillmentService, err)
 }
 func (s *fulfillmentSvcOp) Create(ctx context.Context, svc FulfillmentServiceDef) (*FulfillmentServiceDef, error) {
 	r := &fulfillmentSvcDefResource{}
@@ -0,0 +1,48 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Invoice / Receipt
+// =====================================================================
+
+// Invoice is the official invoice (receipt) document of an order.
+type Invoice struct {
+	URL       string     `json:"url,omitempty"` // PDF download link
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type invoiceResource struct {
+	Invoice *Invoice `json:"invoice"`
+}
+
+// GetInvoiceURL returns a download link for the order's invoice PDF.
+// The link is short-lived; fetch a new one rather than storing it.
+func (s *serviceOp) GetInvoiceURL(ctx context.Context, orderID int64) (string, error) {
+	r := &invoiceResource{}
+	path := s.client.CreatePath(fmt.Sprintf("%s/%d/invoice.json", ordersBasePath, orderID))
+	if err := s.client.Get(ctx, path, r, nil); err != nil {
+		return "", err
+	}
+	if r.Invoice == nil || r.Invoice.URL == "" {
+		return "", fmt.Errorf("order: no invoice available for order %d: %w", orderID, core.ErrNotFound)
+	}
+	return r.Invoice.URL, nil
+}
+
+// SendInvoice emails the order's invoice to the given address. An empty to
+// sends it to the order's email.
+func (s *serviceOp) SendInvoice(ctx context.Context, orderID int64, to string) error {
+	path := s.client.CreatePath(fmt.Sprintf("%s/%d/send_invoice.json", ordersBasePath, orderID))
+	body := map[string]map[string]string{"invoice": {}}
+	if to != "" {
+		body["invoice"]["to"] = to
+	}
+	return s.client.Post(ctx, path, body, nil)
+}
@@ -0,0 +1,74 @@
+package order
+
+import "github.com/imokyou/slshop/core"
+
+// =====================================================================
+// Money accessors
+// =====================================================================
+//
+// Monetary fields stay strings on the models for wire compatibility.
+// These accessors parse them into core.Money carrying the order currency,
+// so callers never parse "199.00" themselves.
+
+// OrderTotals holds an order's totals as core.Money.
+type OrderTotals struct {
+	TotalPrice          core.Money
+	SubtotalPrice       core.Money
+	TotalTax            core.Money
+	TotalDiscounts      core.Money
+	TotalShippingPrice  core.Money
+	TotalLineItemsPrice core.Money
+}
+
+// Totals parses the order's monetary totals in the order currency.
+func (o *Order) Totals() (*OrderTotals, error) {
+	var t OrderTotals
+	fields := []struct {
+		dst *core.Money
+		src string
+	}{
+		{&t.TotalPrice, o.TotalPrice},
+		{&t.SubtotalPrice, o.SubtotalPrice},
+		{&t.TotalTax, o.TotalTax},
+		{&t.TotalDiscounts, o.TotalDiscounts},
+		{&t.TotalShippingPrice, o.TotalShippingPrice},
+		{&t.TotalLineItemsPrice, o.TotalLineItemsPrice},
+	}
+	for _, f := range fields {
+		m, err := core.NewMoney(f.src, o.Currency)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = m
+	}
+	return &t, nil
+}
+
+// TotalPriceMoney parses TotalPrice in the order currency.
+func (o *Order) TotalPriceMoney() (core.Money, error) {
+	return core.NewMoney(o.TotalPrice, o.Currency)
+}
+
+// AmountMoney parses the transaction amount in the transaction currency.
+func (t *Transaction) AmountMoney() (core.Money, error) {
+	return core.NewMoney(t.Amount, t.Currency)
+}
+
+// TotalMoney sums the refund's successful refund transactions.
+func (r *Refund) TotalMoney() (core.Money, error) {
+	total := core.Money{Currency: r.Currency}
+	for i := range r.Transactions {
+		txn := &r.Transactions[i]
+		if txn.Kind != TransactionKindRefund || (txn.Status != "" && txn.Status != TransactionStatusSuccess) {
+			continue
+		}
+		amount, err := txn.AmountMoney()
+		if err != nil {
+			return core.Money{}, err
+		}
+		if total, err = total.Add(amount); err != nil {
+			return core.Money{}, err
+		}
+	}
+	return total, nil
+}
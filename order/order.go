@@ -26,10 +26,17 @@ type Service interface {
 	Close(ctx context.Context, id int64) (*Order, error)
 	Open(ctx context.Context, id int64) (*Order, error)
 
+	AddTags(ctx context.Context, orderID int64, tags ...string) (*Order, error)
+	RemoveTags(ctx context.Context, orderID int64, tags ...string) (*Order, error)
+
+	GetInvoiceURL(ctx context.Context, orderID int64) (string, error)
+	SendInvoice(ctx context.Context, orderID int64, to string) error
+
 	ListRefunds(ctx context.Context, orderID int64) ([]Refund, error)
 	GetRefund(ctx context.Context, orderID, refundID int64) (*Refund, error)
 	CreateRefund(ctx context.Context, orderID int64, refund Refund) (*Refund, error)
 	CalculateRefund(ctx context.Context, orderID int64, refund Refund) (*Refund, error)
+	RefundAll(ctx context.Context, orderID int64, opts *RefundAllOptions) (*Refund, error)
 
 	ListRisks(ctx context.Context, orderID int64) ([]Risk, error)
 	GetRisk(ctx context.Context, orderID, riskID int64) (*Risk, error)
@@ -44,10 +51,13 @@ type Service interface {
 
 // NewService creates a new order Service.
 func NewService(client core.Requester) Service {
-	return &serviceOp{client: client}
+	return &serviceOp{client: client, currencies: newCurrencyCache(client)}
 }
 
-type serviceOp struct{ client core.Requester }
+type serviceOp struct {
+	client     core.Requester
+	currencies *currencyCache
+}
 
 // =====================================================================
 // Query Options
@@ -93,6 +103,7 @@ type Order struct {
 	BuyerNote               string                   `json:"buyer_note,omitempty"`
 	Tags                    string                   `json:"tags,omitempty"`
 	Currency                string                   `json:"currency,omitempty"`
+	PresentmentCurrency     string                   `json:"presentment_currency,omitempty"`
 	ExchangeRate            string                   `json:"exchange_rate,omitempty"`
 	CustomerLocale          string                   `json:"customer_locale,omitempty"`
 	MarketRegionCountryCode string                   `json:"market_region_country_code,omitempty"`
@@ -116,6 +127,12 @@ type Order struct {
 	Confirmed               bool                     `json:"confirmed,omitempty"`
 	BuyerAcceptsMarketing   bool                     `json:"buyer_accepts_marketing,omitempty"`
 	TaxesIncluded           bool                     `json:"taxes_included,omitempty"`
+	SourceName              string                   `json:"source_name,omitempty"`
+	SourceIdentifier        string                   `json:"source_identifier,omitempty"`
+	SourceURL               string                   `json:"source_url,omitempty"`
+	ReferringSite           string                   `json:"referring_site,omitempty"`
+	LandingSite             string                   `json:"landing_site,omitempty"`
+	LandingSiteRef          string                   `json:"landing_site_ref,omitempty"`
 	Customer                *core.Customer       `json:"customer,omitempty"`
 	BillingAddress          *core.Address        `json:"billing_address,omitempty"`
 	ShippingAddress         *core.Address        `json:"shipping_address,omitempty"`
@@ -259,10 +276,13 @@ func (s *serviceOp) Get(ctx context.Context, id int64) (*Order, error) {
 	path := s.client.CreatePath(fmt.Sprintf("%s/%d.json", ordersBasePath, id))
 	resource := &orderResource{}
 	err := s.client.Get(ctx, path, resource, nil)
-	return resource.Order, err
+	return core.Found(resource.Order, err)
 }
 
 func (s *serviceOp) Create(ctx context.Context, order Order) (*Order, error) {
+	if err := s.currencies.checkPresentmentCurrency(ctx, order.PresentmentCurrency); err != nil {
+		return nil, err
+	}
 	path := s.client.CreatePath(ordersBasePath + ".json")
 	body := orderResource{Order: &order}
 	resource := &orderResource{}
@@ -314,7 +334,7 @@ func (s *serviceOp) GetRefund(ctx context.Context, orderID, refundID int64) (*Re
 	path := s.client.CreatePath(fmt.Sprintf("%s/%d/refunds/%d.json", ordersBasePath, orderID, refundID))
 	resource := &refundResource{}
 	err := s.client.Get(ctx, path, resource, nil)
-	return resource.Refund, err
+	return core.Found(resource.Refund, err)
 }
 
 func (s *serviceOp) CreateRefund(ctx context.Context, orderID int64, refund Refund) (*Refund, error) {
@@ -344,7 +364,7 @@ func (s *serviceOp) GetRisk(ctx context.Context, orderID, riskID int64) (*Risk,
 	path := s.client.CreatePath(fmt.Sprintf("%s/%d/risks/%d.json", ordersBasePath, orderID, riskID))
 	resource := &riskResource{}
 	err := s.client.Get(ctx, path, resource, nil)
-	return resource.Risk, err
+	return core.Found(resource.Risk, err)
 }
 
 func (s *serviceOp) CreateRisk(ctx context.Context, orderID int64, risk Risk) (*Risk, error) {
@@ -382,5 +402,5 @@ func (s *serviceOp) GetTransaction(ctx context.Context, orderID, transactionID i
 	path := s.client.CreatePath(fmt.Sprintf("%s/%d/transactions/%d.json", ordersBasePath, orderID, transactionID))
 	resource := &transactionResource{}
 	err := s.client.Get(ctx, path, resource, nil)
-	return resource.Transaction, err
+	return core.Found(resource.Transaction, err)
 }
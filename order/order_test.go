@@ -3,10 +3,14 @@ package order
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/imokyou/slshop/core"
 )
@@ -49,6 +53,9 @@ func (m *mockRequester) do(ctx context.Context, method, path string, body, resul
 
 	req, _ := http.NewRequestWithContext(ctx, method, m.server.URL+path, reqBody)
 	req.Header.Set("Content-Type", "application/json")
+	if key := core.IdempotencyKey(ctx); key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -56,6 +63,9 @@ func (m *mockRequester) do(ctx context.Context, method, path string, body, resul
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusConflict || resp.StatusCode == http.StatusPreconditionFailed {
+		return fmt.Errorf("HTTP %d: %w", resp.StatusCode, core.ErrConflict)
+	}
 	if result != nil {
 		return json.NewDecoder(resp.Body).Decode(result)
 	}
@@ -160,6 +170,93 @@ func TestOrderCreate(t *testing.T) {
 	}
 }
 
+func TestOrderCreate_PresentmentCurrency(t *testing.T) {
+	posts, lookups := 0, 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "currency/currencies.json") {
+			lookups++
+			w.Write([]byte(`{"currencies":[{"code":"USD","primary":true},{"code":"EUR","enabled":true},{"code":"JPY"}]}`))
+			return
+		}
+		posts++
+		w.Write([]byte(`{"draft_order":{"id":1,"presentment_currency":"EUR"}}`))
+	})
+	defer close()
+
+	svc := NewDraftOrderService(mock)
+	if _, err := svc.Create(context.Background(), DraftOrder{PresentmentCurrency: "EUR"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := svc.Create(context.Background(), DraftOrder{PresentmentCurrency: "JPY"})
+	if !errors.Is(err, ErrUnsupportedCurrency) {
+		t.Errorf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+	if posts != 1 {
+		t.Errorf("expected only the valid draft order to be created, got %d creates", posts)
+	}
+	if lookups != 1 {
+		t.Errorf("expected the shop currencies to be loaded once, got %d loads", lookups)
+	}
+}
+
+func TestCurrencyCache_Expires(t *testing.T) {
+	lookups := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"currencies":[{"code":"USD","primary":true}]}`))
+	})
+	defer close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newCurrencyCache(mock)
+	c.now = func() time.Time { return now }
+	for i := 0; i < 2; i++ {
+		if err := c.checkPresentmentCurrency(context.Background(), "usd"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	now = now.Add(currencyTTL)
+	if err := c.checkPresentmentCurrency(context.Background(), "USD"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lookups != 2 {
+		t.Errorf("expected a reload after the TTL, got %d loads", lookups)
+	}
+}
+
+func TestOrderInvoice(t *testing.T) {
+	var sentTo string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/orders/42/invoice.json"):
+			w.Write([]byte(`{"invoice":{"url":"https://cdn.example.com/inv-42.pdf"}}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/orders/42/send_invoice.json"):
+			var body map[string]map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			sentTo = body["invoice"]["to"]
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewService(mock)
+	url, err := svc.GetInvoiceURL(context.Background(), 42)
+	if err != nil || url != "https://cdn.example.com/inv-42.pdf" {
+		t.Errorf("unexpected invoice url %q (%v)", url, err)
+	}
+	if err := svc.SendInvoice(context.Background(), 42, "ap@example.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sentTo != "ap@example.com" {
+		t.Errorf("expected invoice sent to ap@example.com, got %q", sentTo)
+	}
+}
+
 func TestOrderUpdate(t *testing.T) {
 	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
@@ -307,6 +404,83 @@ func TestOrderListTransactions(t *testing.T) {
 	}
 }
 
+func TestOrderEditRemoveLineItemAndDiscard(t *testing.T) {
+	var paths []string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "remove_line_item.json") {
+			var body map[string]int64
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["line_item_id"] != 55 {
+				t.Errorf("line_item_id = %d, want 55", body["line_item_id"])
+			}
+			w.Write([]byte(`{"edit_session":{"id":9,"order_id":1001},"calculated_order":{"id":1001,"total_price":"40.00"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer close()
+
+	svc := NewEditService(mock)
+	sess, err := svc.RemoveLineItem(context.Background(), 1001, 55)
+	if err != nil {
+		t.Fatalf("RemoveLineItem: %v", err)
+	}
+	if sess.ID != 9 || sess.CalculatedOrder == nil || sess.CalculatedOrder.TotalPrice != "40.00" {
+		t.Errorf("session = %+v", sess)
+	}
+	if err := svc.Discard(context.Background(), 1001); err != nil {
+		t.Fatalf("Discard: %v", err)
+	}
+	if len(paths) != 2 || !strings.HasSuffix(paths[1], "orders/1001/edit/discard.json") {
+		t.Errorf("paths = %v", paths)
+	}
+}
+
+func TestFulfillmentOrderStateTransitions(t *testing.T) {
+	var paths []string
+	var bodies []map[string]map[string]string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+		var body map[string]map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("{}"))
+	})
+	defer close()
+
+	svc := NewFulfillmentService(mock)
+	ctx := context.Background()
+	at := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	if err := svc.ReleaseHoldFulfillmentOrder(ctx, 7); err != nil {
+		t.Fatalf("release hold: %v", err)
+	}
+	if err := svc.RescheduleFulfillmentOrder(ctx, 7, at); err != nil {
+		t.Fatalf("reschedule: %v", err)
+	}
+	if err := svc.CloseFulfillmentOrder(ctx, 7, "out of stock"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	wantPaths := []string{"release_hold.json", "reschedule.json", "close.json"}
+	for i, want := range wantPaths {
+		if !strings.HasSuffix(paths[i], "/fulfillment_orders/7/"+want) {
+			t.Errorf("call %d: expected path ending in %s, got %s", i, want, paths[i])
+		}
+	}
+	if got := bodies[1]["fulfillment_order"]["new_fulfill_at"]; got != "2025-06-01T09:00:00Z" {
+		t.Errorf("expected new_fulfill_at 2025-06-01T09:00:00Z, got %q", got)
+	}
+	if got := bodies[2]["fulfillment_order"]["message"]; got != "out of stock" {
+		t.Errorf("expected close message, got %q", got)
+	}
+}
+
 // TestOrderListOptions_URLTags verifies that ListOptions fields have correct url tags.
 func TestOrderListOptions_URLTags(t *testing.T) {
 	opts := &ListOptions{
@@ -318,3 +492,218 @@ func TestOrderListOptions_URLTags(t *testing.T) {
 		t.Errorf("unexpected status: %s", opts.Status)
 	}
 }
+
+func TestDraftOrderWaitForCompletion(t *testing.T) {
+	polls := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "draft_orders/5.json"):
+			polls++
+			draft := &DraftOrder{ID: 5, Status: DraftOrderStatusOpen}
+			if polls == 3 {
+				draft.Status, draft.OrderID = DraftOrderStatusCompleted, 900
+			}
+			json.NewEncoder(w).Encode(draftOrderResource{DraftOrder: draft})
+		case strings.HasSuffix(r.URL.Path, "orders/900.json"):
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 900, Name: "#1001"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewDraftOrderService(mock)
+	o, err := svc.WaitForCompletion(context.Background(), 5, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.ID != 900 || polls != 3 {
+		t.Errorf("expected order 900 after 3 polls, got %d after %d", o.ID, polls)
+	}
+
+	polls = -100
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := svc.WaitForCompletion(ctx, 5, 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestSubscriptionLineItems(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "subscription_contracts/3/line_items/8.json"):
+			var body subscriptionLineItemResource
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(subscriptionResource{SubscriptionContract: &SubscriptionContract{
+				ID: 3, LineItems: []SubscriptionLineItem{*body.LineItem},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "subscription_contracts/3/pause.json"):
+			json.NewEncoder(w).Encode(subscriptionResource{SubscriptionContract: &SubscriptionContract{ID: 3, Status: SubscriptionStatusPaused}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewSubscriptionService(mock)
+	c, err := svc.UpdateLineItem(context.Background(), 3, SubscriptionLineItem{ID: 8, Quantity: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(c.LineItems) != 1 || c.LineItems[0].Quantity != 2 {
+		t.Errorf("unexpected line items: %+v", c.LineItems)
+	}
+	if c, err = svc.Pause(context.Background(), 3); err != nil || c.Status != SubscriptionStatusPaused {
+		t.Errorf("expected paused contract, got %+v (%v)", c, err)
+	}
+	if _, err := svc.Create(context.Background(), SubscriptionContract{CustomerID: 1}); err == nil {
+		t.Error("expected error for contract without line items")
+	}
+}
+
+func TestOrderRefundAll(t *testing.T) {
+	var calcBody, createBody refundResource
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, LineItems: []core.LineItem{
+				{ID: 1, Quantity: 2}, {ID: 2, Quantity: 1},
+			}, Refunds: []Refund{{RefundLineItems: []RefundLineItem{{LineItemID: 1, Quantity: 1}, {LineItemID: 2, Quantity: 1}}}}}})
+		case strings.HasSuffix(r.URL.Path, "refunds/calculate.json"):
+			json.NewDecoder(r.Body).Decode(&calcBody)
+			json.NewEncoder(w).Encode(refundResource{Refund: &Refund{
+				Currency:        "USD",
+				Shipping:        &RefundShipping{Amount: "5.00"},
+				RefundLineItems: []RefundLineItem{{LineItemID: 1, Quantity: 1, RestockType: RestockTypeReturn, LocationID: 7, Subtotal: "20.00"}},
+				Transactions:    []Transaction{{ParentID: 900, Amount: "25.00", Kind: TransactionKindSuggestedRefund, Gateway: "shopline_payments"}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "refunds.json"):
+			json.NewDecoder(r.Body).Decode(&createBody)
+			json.NewEncoder(w).Encode(refundResource{Refund: &Refund{ID: 55, OrderID: 1001}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewService(mock)
+	refund, err := svc.RefundAll(context.Background(), 1001, &RefundAllOptions{Restock: true, LocationID: 7, RefundShipping: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refund.ID != 55 {
+		t.Errorf("expected refund 55, got %d", refund.ID)
+	}
+	if len(calcBody.Refund.RefundLineItems) != 1 || calcBody.Refund.RefundLineItems[0].Quantity != 1 || !calcBody.Refund.Shipping.FullRefund {
+		t.Errorf("unexpected calculate request: %+v", calcBody.Refund)
+	}
+	c := createBody.Refund
+	if len(c.RefundLineItems) != 1 || c.RefundLineItems[0].LocationID != 7 || c.RefundLineItems[0].Subtotal != "" {
+		t.Errorf("unexpected refund line items: %+v", c.RefundLineItems)
+	}
+	if c.Shipping == nil || c.Shipping.Amount != "5.00" || c.Shipping.FullRefund {
+		t.Errorf("unexpected shipping: %+v", c.Shipping)
+	}
+	if len(c.Transactions) != 1 || c.Transactions[0].Kind != TransactionKindRefund || c.Transactions[0].ParentID != 900 || c.Transactions[0].Amount != "25.00" {
+		t.Errorf("unexpected transactions: %+v", c.Transactions)
+	}
+}
+
+func TestOrderRefundAll_RestockTypePerLine(t *testing.T) {
+	var calcBody refundResource
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, LineItems: []core.LineItem{
+				{ID: 1, Quantity: 2, FulfillableQuantity: 0}, // fulfilled
+				{ID: 2, Quantity: 3, FulfillableQuantity: 3}, // unfulfilled
+				{ID: 3, Quantity: 3, FulfillableQuantity: 1}, // partly fulfilled
+			}}})
+		case strings.HasSuffix(r.URL.Path, "refunds/calculate.json"):
+			json.NewDecoder(r.Body).Decode(&calcBody)
+			json.NewEncoder(w).Encode(calcBody)
+		case strings.HasSuffix(r.URL.Path, "refunds.json"):
+			json.NewEncoder(w).Encode(refundResource{Refund: &Refund{ID: 55}})
+		}
+	})
+	defer close()
+
+	if _, err := NewService(mock).RefundAll(context.Background(), 1001, &RefundAllOptions{Restock: true, LocationID: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, rli := range calcBody.Refund.RefundLineItems {
+		got = append(got, fmt.Sprintf("%d:%d:%s", rli.LineItemID, rli.Quantity, rli.RestockType))
+	}
+	want := "1:2:return 2:3:cancel 3:1:cancel 3:2:return"
+	if strings.Join(got, " ") != want {
+		t.Errorf("expected line items %s, got %s", want, strings.Join(got, " "))
+	}
+}
+
+func TestOrderRefundAll_RestockNeedsLocation(t *testing.T) {
+	svc := NewService(nil)
+	if _, err := svc.RefundAll(context.Background(), 1001, &RefundAllOptions{Restock: true}); err == nil {
+		t.Fatal("expected error for restock without location")
+	}
+}
+
+func TestDraftOrderBatchCreate(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]bool)
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		var body draftOrderResource
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		keys[r.Header.Get("Idempotency-Key")] = true
+		mu.Unlock()
+		if body.DraftOrder.Note == "bad" {
+			w.Write([]byte(`not json`))
+			return
+		}
+		body.DraftOrder.ID = 100
+		json.NewEncoder(w).Encode(body)
+	})
+	defer close()
+
+	drafts := []DraftOrder{{Note: "a"}, {Note: "bad"}, {Note: "c"}, {Note: "d"}, {Note: "e"}}
+	results, err := NewDraftOrderService(mock).BatchCreate(context.Background(), drafts, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != len(drafts) {
+		t.Fatalf("expected %d results, got %d", len(drafts), len(results))
+	}
+	for i, res := range results {
+		if res.Index != i {
+			t.Errorf("result %d has index %d", i, res.Index)
+		}
+		if failed := res.Err != nil; failed != (i == 1) {
+			t.Errorf("result %d: unexpected error state %v", i, res.Err)
+		}
+		if res.Err == nil && res.DraftOrder.Note != drafts[i].Note {
+			t.Errorf("result %d: got draft %+v", i, res.DraftOrder)
+		}
+	}
+	if len(keys) != len(drafts) || keys[""] {
+		t.Errorf("expected a distinct idempotency key per draft, got %v", keys)
+	}
+}
+
+func TestDraftOrderBatchCreate_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.Write([]byte(`{"draft_order":{"id":1}}`))
+	})
+	defer close()
+
+	results, err := NewDraftOrderService(mock).BatchCreate(ctx, make([]DraftOrder, 5), 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if !errors.Is(results[4].Err, context.Canceled) {
+		t.Errorf("expected unattempted draft to report cancellation, got %+v", results[4])
+	}
+}
@@ -92,7 +92,7 @@ func (s *paymentOp) UpdatePaymentSlip(ctx context.Context, slip PaymentSlip) (*P
 func (s *paymentOp) GetSettings(ctx context.Context) (*PaymentSettings, error) {
 	r := &paymentSettingsResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("payment/settings.json"), r, nil)
-	return r.Settings, err
+	return core.Found(r.Settings, err)
 }
 func (s *paymentOp) ListChannels(ctx context.Context) ([]PaymentChannel, error) {
 	r := &paymentChannelsResource{}
@@ -109,8 +109,10 @@ func (s *paymentOp) ListPayments(ctx context.Context, orderID int64) ([]OrderPay
 
 type AbandonedCheckoutService interface {
 	List(ctx context.Context, opts *core.ListOptions) ([]AbandonedCheckout, error)
+	Get(ctx context.Context, checkoutID int64) (*AbandonedCheckout, error)
 	Count(ctx context.Context) (int, error)
 	Archive(ctx context.Context, ids []int64) error
+	SendRecoveryEmail(ctx context.Context, checkoutID int64, template RecoveryEmail) error
 }
 
 func NewAbandonedCheckoutService(client core.Requester) AbandonedCheckoutService {
@@ -137,6 +139,23 @@ type AbandonedCheckout struct {
 	UpdatedAt            *time.Time          `json:"updated_at,omitempty"`
 }
 
+// RecoveryEmail customises the cart recovery email. Template selects a
+// notification template by handle; empty fields use the store defaults.
+type RecoveryEmail struct {
+	Template      string   `json:"template,omitempty"`
+	To            string   `json:"to,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+}
+
+type checkoutResource struct {
+	Checkout *AbandonedCheckout `json:"checkout"`
+}
+type recoveryEmailResource struct {
+	RecoveryEmail *RecoveryEmail `json:"recovery_email"`
+}
 type checkoutsResource struct {
 	Checkouts []AbandonedCheckout `json:"checkouts"`
 }
@@ -146,6 +165,11 @@ func (s *checkoutOp) List(ctx context.Context, opts *core.ListOptions) ([]Abando
 	err := s.client.Get(ctx, s.client.CreatePath("checkouts.json"), r, opts)
 	return r.Checkouts, err
 }
+func (s *checkoutOp) Get(ctx context.Context, checkoutID int64) (*AbandonedCheckout, error) {
+	r := &checkoutResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("checkouts/%d.json", checkoutID)), r, nil)
+	return core.Found(r.Checkout, err)
+}
 func (s *checkoutOp) Count(ctx context.Context) (int, error) {
 	r := &countResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("checkouts/count.json"), r, nil)
@@ -154,14 +178,24 @@ func (s *checkoutOp) Count(ctx context.Context) (int, error) {
 func (s *checkoutOp) Archive(ctx context.Context, ids []int64) error {
 	return s.client.Post(ctx, s.client.CreatePath("checkouts/checkouts_archive.json"), map[string][]int64{"ids": ids}, nil)
 }
+func (s *checkoutOp) SendRecoveryEmail(ctx context.Context, checkoutID int64, template RecoveryEmail) error {
+	path := s.client.CreatePath(fmt.Sprintf("checkouts/%d/send_recovery_email.json", checkoutID))
+	return s.client.Post(ctx, path, recoveryEmailResource{RecoveryEmail: &template}, nil)
+}
 
 // === Subscription ===
 
 type SubscriptionService interface {
 	Get(ctx context.Context, id int64) (*SubscriptionContract, error)
 	List(ctx context.Context, opts *core.ListOptions) ([]SubscriptionContract, error)
+	Create(ctx context.Context, c SubscriptionContract) (*SubscriptionContract, error)
 	Update(ctx context.Context, c SubscriptionContract) (*SubscriptionContract, error)
 	Cancel(ctx context.Context, id int64) (*SubscriptionContract, error)
+	Pause(ctx context.Context, id int64) (*SubscriptionContract, error)
+	Resume(ctx context.Context, id int64) (*SubscriptionContract, error)
+	AddLineItem(ctx context.Context, id int64, item SubscriptionLineItem) (*SubscriptionContract, error)
+	UpdateLineItem(ctx context.Context, id int64, item SubscriptionLineItem) (*SubscriptionContract, error)
+	RemoveLineItem(ctx context.Context, id, lineItemID int64) error
 	ReviseNextBillTime(ctx context.Context, id int64, t time.Time) (*SubscriptionContract, error)
 	SkipNextBill(ctx context.Context, id int64) (*SubscriptionContract, error)
 	CreateOrder(ctx context.Context, id int64) (*Order, error)
@@ -173,6 +207,14 @@ func NewSubscriptionService(client core.Requester) SubscriptionService {
 
 type subscriptionOp struct{ client core.Requester }
 
+// Subscription contract statuses.
+const (
+	SubscriptionStatusActive    = "active"
+	SubscriptionStatusPaused    = "paused"
+	SubscriptionStatusCancelled = "cancelled"
+	SubscriptionStatusExpired   = "expired"
+)
+
 type SubscriptionContract struct {
 	ID              int64                  `json:"id,omitempty"`
 	Status          string                 `json:"status,omitempty"`
@@ -213,17 +255,28 @@ type subscriptionResource struct {
 type subscriptionsResource struct {
 	SubscriptionContracts []SubscriptionContract `json:"subscription_contracts"`
 }
+type subscriptionLineItemResource struct {
+	LineItem *SubscriptionLineItem `json:"line_item"`
+}
 
 func (s *subscriptionOp) Get(ctx context.Context, id int64) (*SubscriptionContract, error) {
 	r := &subscriptionResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d.json", id)), r, nil)
-	return r.SubscriptionContract, err
+	return core.Found(r.SubscriptionContract, err)
 }
 func (s *subscriptionOp) List(ctx context.Context, opts *core.ListOptions) ([]SubscriptionContract, error) {
 	r := &subscriptionsResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("subscription_contracts.json"), r, opts)
 	return r.SubscriptionContracts, err
 }
+func (s *subscriptionOp) Create(ctx context.Context, c SubscriptionContract) (*SubscriptionContract, error) {
+	if len(c.LineItems) == 0 {
+		return nil, fmt.Errorf("order: subscription contract needs at least one line item")
+	}
+	r := &subscriptionResource{}
+	err := s.client.Post(ctx, s.client.CreatePath("subscription_contracts.json"), subscriptionResource{SubscriptionContract: &c}, r)
+	return r.SubscriptionContract, err
+}
 func (s *subscriptionOp) Update(ctx context.Context, c SubscriptionContract) (*SubscriptionContract, error) {
 	r := &subscriptionResource{}
 	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d.json", c.ID)), subscriptionResource{SubscriptionContract: &c}, r)
@@ -234,6 +287,32 @@ func (s *subscriptionOp) Cancel(ctx context.Context, id int64) (*SubscriptionCon
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/cancel.json", id)), nil, r)
 	return r.SubscriptionContract, err
 }
+func (s *subscriptionOp) Pause(ctx context.Context, id int64) (*SubscriptionContract, error) {
+	r := &subscriptionResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/pause.json", id)), nil, r)
+	return r.SubscriptionContract, err
+}
+func (s *subscriptionOp) Resume(ctx context.Context, id int64) (*SubscriptionContract, error) {
+	r := &subscriptionResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/resume.json", id)), nil, r)
+	return r.SubscriptionContract, err
+}
+
+// Line item edits return the updated contract so totals and the next
+// billing amount can be re-read in one call.
+func (s *subscriptionOp) AddLineItem(ctx context.Context, id int64, item SubscriptionLineItem) (*SubscriptionContract, error) {
+	r := &subscriptionResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/line_items.json", id)), subscriptionLineItemResource{LineItem: &item}, r)
+	return r.SubscriptionContract, err
+}
+func (s *subscriptionOp) UpdateLineItem(ctx context.Context, id int64, item SubscriptionLineItem) (*SubscriptionContract, error) {
+	r := &subscriptionResource{}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/line_items/%d.json", id, item.ID)), subscriptionLineItemResource{LineItem: &item}, r)
+	return r.SubscriptionContract, err
+}
+func (s *subscriptionOp) RemoveLineItem(ctx context.Context, id, lineItemID int64) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/line_items/%d.json", id, lineItemID)))
+}
 func (s *subscriptionOp) ReviseNextBillTime(ctx context.Context, id int64, t time.Time) (*SubscriptionContract, error) {
 	r := &subscriptionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("subscription_contracts/%d/revise_next_bill_time.json", id)), map[string]string{"next_billing_date": t.Format(time.RFC3339)}, r)
@@ -322,7 +401,7 @@ func (s *taxOp) ListCountries(ctx context.Context) ([]TaxCountry, error) {
 func (s *taxOp) GetCountry(ctx context.Context, id int64) (*TaxCountry, error) {
 	r := &taxCountryResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("countries/%d.json", id)), r, nil)
-	return r.Country, err
+	return core.Found(r.Country, err)
 }
 func (s *taxOp) CountCountries(ctx context.Context) (int, error) {
 	r := &countResource{}
@@ -337,7 +416,7 @@ func (s *taxOp) ListProvinces(ctx context.Context, countryID int64) ([]TaxProvin
 func (s *taxOp) GetProvince(ctx context.Context, id int64) (*TaxProvince, error) {
 	r := &taxProvinceResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("provinces/%d.json", id)), r, nil)
-	return r.Province, err
+	return core.Found(r.Province, err)
 }
 func (s *taxOp) CountProvinces(ctx context.Context, countryID int64) (int, error) {
 	r := &countResource{}
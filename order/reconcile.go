@@ -0,0 +1,124 @@
+package order
+
+import (
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Order financial statuses.
+const (
+	FinancialStatusPending           = "pending"
+	FinancialStatusAuthorized        = "authorized"
+	FinancialStatusPartiallyPaid     = "partially_paid"
+	FinancialStatusPaid              = "paid"
+	FinancialStatusPartiallyRefunded = "partially_refunded"
+	FinancialStatusRefunded          = "refunded"
+	FinancialStatusVoided            = "voided"
+)
+
+// Transaction kinds and statuses used when reconciling.
+const (
+	TransactionKindAuthorization = "authorization"
+	TransactionKindCapture       = "capture"
+	TransactionKindSale          = "sale"
+	TransactionKindRefund        = "refund"
+	TransactionKindVoid          = "void"
+
+	TransactionStatusSuccess = "success"
+)
+
+// =====================================================================
+// Financial Status Reconciliation
+// =====================================================================
+
+// FinancialStatusReport is the result of VerifyFinancialStatus.
+// Amounts are decimal strings in the order currency, with as many
+// fractional digits as the currency has (none for JPY).
+type FinancialStatusReport struct {
+	OrderID    int64
+	Actual     string // financial_status reported by the API
+	Expected   string // status derived from the transaction list
+	Authorized string
+	Captured   string
+	Refunded   string
+	Mismatch   bool
+}
+
+// VerifyFinancialStatus recomputes the financial status an order should have
+// from its transactions and compares it with o.FinancialStatus.
+//
+// Only successful transactions are counted. Captures and sales count as paid;
+// refunds are netted against them; an authorization followed by a successful
+// void yields "voided". Pass the result of Service.ListTransactions as txns.
+//
+// It returns an error only if an amount cannot be parsed.
+func VerifyFinancialStatus(o *Order, txns []Transaction) (*FinancialStatusReport, error) {
+	if o == nil {
+		return nil, fmt.Errorf("order: order must not be nil")
+	}
+
+	total, err := core.ParseDecimal(o.TotalPrice)
+	if err != nil {
+		return nil, fmt.Errorf("order: invalid total_price %q: %w", o.TotalPrice, err)
+	}
+
+	var authorized, captured, refunded core.Decimal
+	voided := false
+	for _, txn := range txns {
+		if txn.Status != TransactionStatusSuccess {
+			continue
+		}
+		amount, err := core.ParseDecimal(txn.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("order: invalid amount %q on transaction %d: %w", txn.Amount, txn.ID, err)
+		}
+		switch txn.Kind {
+		case TransactionKindAuthorization:
+			authorized, err = authorized.Add(amount)
+		case TransactionKindCapture, TransactionKindSale:
+			captured, err = captured.Add(amount)
+		case TransactionKindRefund:
+			refunded, err = refunded.Add(amount)
+		case TransactionKindVoid:
+			voided = true
+		}
+		if err != nil {
+			return nil, fmt.Errorf("order: summing transaction %d: %w", txn.ID, err)
+		}
+	}
+
+	places := core.CurrencyDecimals(o.Currency)
+	report := &FinancialStatusReport{
+		OrderID:    o.ID,
+		Actual:     o.FinancialStatus,
+		Expected:   expectedFinancialStatus(total, authorized, captured, refunded, voided),
+		Authorized: authorized.StringFixed(places),
+		Captured:   captured.StringFixed(places),
+		Refunded:   refunded.StringFixed(places),
+	}
+	report.Mismatch = report.Actual != report.Expected
+	return report, nil
+}
+
+// expectedFinancialStatus maps transaction totals to a financial status.
+func expectedFinancialStatus(total, authorized, captured, refunded core.Decimal, voided bool) string {
+	switch {
+	case refunded.Sign() > 0:
+		if refunded.Cmp(captured) >= 0 {
+			return FinancialStatusRefunded
+		}
+		return FinancialStatusPartiallyRefunded
+	case captured.Sign() > 0:
+		if captured.Cmp(total) >= 0 {
+			return FinancialStatusPaid
+		}
+		return FinancialStatusPartiallyPaid
+	case authorized.Sign() > 0:
+		if voided {
+			return FinancialStatusVoided
+		}
+		return FinancialStatusAuthorized
+	}
+	return FinancialStatusPending
+}
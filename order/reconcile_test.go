@@ -0,0 +1,99 @@
+package order
+
+import "testing"
+
+func TestVerifyFinancialStatus(t *testing.T) {
+	ok := TransactionStatusSuccess
+	tests := []struct {
+		name     string
+		total    string
+		actual   string
+		txns     []Transaction
+		expected string
+		mismatch bool
+	}{
+		{"no transactions", "100.00", "pending", nil, FinancialStatusPending, false},
+		{"sale paid", "100.00", "paid", []Transaction{{Kind: "sale", Status: ok, Amount: "100.00"}}, FinancialStatusPaid, false},
+		{"failed sale ignored", "100.00", "paid", []Transaction{{Kind: "sale", Status: "failure", Amount: "100.00"}}, FinancialStatusPending, true},
+		{"authorized only", "100.00", "authorized", []Transaction{{Kind: "authorization", Status: ok, Amount: "100.00"}}, FinancialStatusAuthorized, false},
+		{"voided", "100.00", "voided", []Transaction{
+			{Kind: "authorization", Status: ok, Amount: "100.00"},
+			{Kind: "void", Status: ok, Amount: "100.00"},
+		}, FinancialStatusVoided, false},
+		{"partial capture", "100.00", "paid", []Transaction{
+			{Kind: "authorization", Status: ok, Amount: "100.00"},
+			{Kind: "capture", Status: ok, Amount: "40.00"},
+		}, FinancialStatusPartiallyPaid, true},
+		{"partial refund", "100.00", "partially_refunded", []Transaction{
+			{Kind: "sale", Status: ok, Amount: "100.00"},
+			{Kind: "refund", Status: ok, Amount: "30.10"},
+		}, FinancialStatusPartiallyRefunded, false},
+		{"full refund in two parts", "100.00", "partially_refunded", []Transaction{
+			{Kind: "sale", Status: ok, Amount: "100.00"},
+			{Kind: "refund", Status: ok, Amount: "70.10"},
+			{Kind: "refund", Status: ok, Amount: "29.90"},
+		}, FinancialStatusRefunded, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Order{ID: 1, TotalPrice: tt.total, FinancialStatus: tt.actual}
+			report, err := VerifyFinancialStatus(o, tt.txns)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Expected != tt.expected {
+				t.Errorf("expected status %q, got %q", tt.expected, report.Expected)
+			}
+			if report.Mismatch != tt.mismatch {
+				t.Errorf("expected mismatch=%v, got %v", tt.mismatch, report.Mismatch)
+			}
+		})
+	}
+}
+
+func TestVerifyFinancialStatus_CurrencyDecimals(t *testing.T) {
+	o := &Order{ID: 1, Currency: "JPY", TotalPrice: "1500", FinancialStatus: "partially_refunded"}
+	report, err := VerifyFinancialStatus(o, []Transaction{
+		{Kind: "sale", Status: TransactionStatusSuccess, Amount: "1500"},
+		{Kind: "refund", Status: TransactionStatusSuccess, Amount: "500"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Captured != "1500" || report.Refunded != "500" {
+		t.Errorf("expected JPY amounts without decimals, got captured=%s refunded=%s", report.Captured, report.Refunded)
+	}
+}
+
+func TestVerifyFinancialStatus_InvalidAmount(t *testing.T) {
+	o := &Order{TotalPrice: "100.00"}
+	_, err := VerifyFinancialStatus(o, []Transaction{{ID: 9, Kind: "sale", Status: "success", Amount: "abc"}})
+	if err == nil {
+		t.Fatal("expected error for unparseable amount")
+	}
+}
+
+func TestOrderTotalsAndRefundTotal(t *testing.T) {
+	o := &Order{Currency: "USD", TotalPrice: "120.50", TotalTax: "10.50"}
+	totals, err := o.Totals()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if totals.TotalPrice.String() != "120.50 USD" || totals.TotalTax.Amount.String() != "10.50" {
+		t.Errorf("unexpected totals: %+v", totals)
+	}
+
+	r := &Refund{Currency: "USD", Transactions: []Transaction{
+		{Kind: "refund", Status: "success", Amount: "20.00", Currency: "USD"},
+		{Kind: "refund", Status: "failure", Amount: "99.00", Currency: "USD"},
+		{Kind: "refund", Status: "success", Amount: "0.25", Currency: "USD"},
+	}}
+	total, err := r.TotalMoney()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total.String() != "20.25 USD" {
+		t.Errorf("expected 20.25 USD, got %s", total)
+	}
+}
@@ -0,0 +1,52 @@
+package order
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// =====================================================================
+// Cart Recovery URLs
+// =====================================================================
+
+// UTMParams are the campaign parameters appended to recovery links. Empty
+// fields are left out.
+type UTMParams struct {
+	Source   string // utm_source, e.g. "klaviyo"
+	Medium   string // utm_medium, e.g. "email"
+	Campaign string // utm_campaign, e.g. "cart_recovery_1h"
+	Term     string // utm_term
+	Content  string // utm_content, e.g. "cta_button"
+}
+
+// AddUTMParams returns rawURL with the non-empty UTM parameters set,
+// replacing any existing values for the same keys and keeping every other
+// query parameter (such as the checkout key) intact.
+func AddUTMParams(rawURL string, utm UTMParams) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("order: invalid recovery url %q: %w", rawURL, err)
+	}
+	q := u.Query()
+	for key, value := range map[string]string{
+		"utm_source":   utm.Source,
+		"utm_medium":   utm.Medium,
+		"utm_campaign": utm.Campaign,
+		"utm_term":     utm.Term,
+		"utm_content":  utm.Content,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// RecoveryURL returns the checkout's recovery link tagged with utm.
+func (c *AbandonedCheckout) RecoveryURL(utm UTMParams) (string, error) {
+	if c.AbandonedCheckoutURL == "" {
+		return "", fmt.Errorf("order: checkout %d has no abandoned_checkout_url", c.ID)
+	}
+	return AddUTMParams(c.AbandonedCheckoutURL, utm)
+}
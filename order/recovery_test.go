@@ -0,0 +1,44 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAbandonedCheckoutRecoveryURL(t *testing.T) {
+	c := &AbandonedCheckout{ID: 1, AbandonedCheckoutURL: "https://shop.example.com/checkouts/abc/recover?key=k1&utm_source=old"}
+	got, err := c.RecoveryURL(UTMParams{Source: "email", Campaign: "cart recovery"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "https://shop.example.com/checkouts/abc/recover?key=k1&utm_campaign=cart+recovery&utm_source=email"
+	if got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if _, err := (&AbandonedCheckout{ID: 2}).RecoveryURL(UTMParams{}); err == nil {
+		t.Error("expected error for checkout without recovery url")
+	}
+}
+
+func TestAbandonedCheckoutSendRecoveryEmail(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "checkouts/7/send_recovery_email.json") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body recoveryEmailResource
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.RecoveryEmail == nil || body.RecoveryEmail.Template != "cart_reminder" {
+			t.Errorf("unexpected body: %+v", body.RecoveryEmail)
+		}
+		w.Write([]byte(`{}`))
+	})
+	defer close()
+
+	svc := NewAbandonedCheckoutService(mock)
+	if err := svc.SendRecoveryEmail(context.Background(), 7, RecoveryEmail{Template: "cart_reminder"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
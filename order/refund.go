@@ -0,0 +1,142 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Restock types of refund line items.
+const (
+	RestockTypeNoRestock = "no_restock"
+	RestockTypeCancel    = "cancel" // unfulfilled items go back to stock
+	RestockTypeReturn    = "return" // fulfilled items are returned to stock
+)
+
+// TransactionKindSuggestedRefund is the kind of the transactions returned by
+// CalculateRefund.
+const TransactionKindSuggestedRefund = "suggested_refund"
+
+// =====================================================================
+// Full Refund
+// =====================================================================
+
+// RefundAllOptions controls RefundAll. The zero value refunds every item
+// without restocking and leaves shipping unrefunded.
+type RefundAllOptions struct {
+	// Restock puts the refunded items back in stock: unfulfilled items with
+	// RestockTypeCancel and fulfilled ones with RestockTypeReturn.
+	Restock        bool
+	LocationID     int64 // location restocked items go to; required when restocking
+	RefundShipping bool  // also refund the full shipping amount
+	Note           string
+}
+
+// RefundAll refunds everything not yet refunded on an order. It lists the
+// remaining quantity of every line item, lets CalculateRefund work out the
+// amounts and the transactions to refund against, and creates the refund
+// from the result. It fails without creating anything if nothing is left to
+// refund. A partly fulfilled line is refunded as two line items when
+// restocking, one per restock type.
+func (s *serviceOp) RefundAll(ctx context.Context, orderID int64, opts *RefundAllOptions) (*Refund, error) {
+	ctx = core.WithoutFields(ctx) // the refund is built from the whole order
+	if opts == nil {
+		opts = &RefundAllOptions{}
+	}
+	if opts.Restock && opts.LocationID == 0 {
+		return nil, fmt.Errorf("order: restocking requires a location")
+	}
+
+	o, err := s.Get(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to get order %d: %w", orderID, err)
+	}
+
+	req := Refund{Note: opts.Note, Restock: opts.Restock}
+	refunded := refundedQuantities(o.Refunds)
+	for _, li := range o.LineItems {
+		qty := li.Quantity - refunded[li.ID]
+		if qty <= 0 {
+			continue
+		}
+		if !opts.Restock {
+			req.RefundLineItems = append(req.RefundLineItems, RefundLineItem{LineItemID: li.ID, Quantity: qty, RestockType: RestockTypeNoRestock})
+			continue
+		}
+		unfulfilled := min(max(li.FulfillableQuantity, 0), qty)
+		if unfulfilled > 0 {
+			req.RefundLineItems = append(req.RefundLineItems, RefundLineItem{
+				LineItemID: li.ID, Quantity: unfulfilled, RestockType: RestockTypeCancel, LocationID: opts.LocationID,
+			})
+		}
+		if fulfilled := qty - unfulfilled; fulfilled > 0 {
+			req.RefundLineItems = append(req.RefundLineItems, RefundLineItem{
+				LineItemID: li.ID, Quantity: fulfilled, RestockType: RestockTypeReturn, LocationID: opts.LocationID,
+			})
+		}
+	}
+	if opts.RefundShipping {
+		req.Shipping = &RefundShipping{FullRefund: true}
+	}
+	if len(req.RefundLineItems) == 0 && req.Shipping == nil {
+		return nil, fmt.Errorf("order: order %d has nothing left to refund", orderID)
+	}
+
+	calc, err := s.CalculateRefund(ctx, orderID, req)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to calculate refund of order %d: %w", orderID, err)
+	}
+	if calc == nil {
+		return nil, fmt.Errorf("order: refund calculation of order %d returned no refund", orderID)
+	}
+
+	create := Refund{
+		Note:            req.Note,
+		Restock:         req.Restock,
+		Currency:        calc.Currency,
+		RefundLineItems: make([]RefundLineItem, 0, len(calc.RefundLineItems)),
+	}
+	for _, rli := range calc.RefundLineItems {
+		create.RefundLineItems = append(create.RefundLineItems, RefundLineItem{
+			LineItemID:  rli.LineItemID,
+			Quantity:    rli.Quantity,
+			RestockType: rli.RestockType,
+			LocationID:  rli.LocationID,
+		})
+	}
+	if opts.RefundShipping && calc.Shipping != nil {
+		// Send the calculated amount rather than full_refund so the
+		// created refund matches the calculation exactly.
+		create.Shipping = &RefundShipping{Amount: calc.Shipping.Amount}
+	}
+	for _, t := range calc.Transactions {
+		if t.Kind != TransactionKindSuggestedRefund && t.Kind != TransactionKindRefund {
+			continue
+		}
+		create.Transactions = append(create.Transactions, Transaction{
+			ParentID: t.ParentID,
+			Amount:   t.Amount,
+			Currency: t.Currency,
+			Gateway:  t.Gateway,
+			Kind:     TransactionKindRefund,
+		})
+	}
+
+	refund, err := s.CreateRefund(ctx, orderID, create)
+	if err != nil {
+		return nil, fmt.Errorf("order: failed to create refund of order %d: %w", orderID, err)
+	}
+	return refund, nil
+}
+
+// refundedQuantities sums the refunded quantity per line item ID.
+func refundedQuantities(refunds []Refund) map[int64]int {
+	q := make(map[int64]int)
+	for _, r := range refunds {
+		for _, rli := range r.RefundLineItems {
+			q[rli.LineItemID] += rli.Quantity
+		}
+	}
+	return q
+}
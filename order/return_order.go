@@ -145,7 +145,9 @@ type EditService interface {
 	AddCustomItem(ctx context.Context, orderID int64, e EditAddCustomItem) (*EditSession, error)
 	AddDiscount(ctx context.Context, orderID int64, e EditAddDiscount) (*EditSession, error)
 	RemoveDiscount(ctx context.Context, orderID int64, e EditRemoveDiscount) (*EditSession, error)
+	RemoveLineItem(ctx context.Context, orderID, lineItemID int64) (*EditSession, error)
 	Commit(ctx context.Context, orderID int64) (*Order, error)
+	Discard(ctx context.Context, orderID int64) error
 }
 
 func NewEditService(client core.Requester) EditService {
@@ -158,6 +160,9 @@ type EditSession struct {
 	ID      int64  `json:"id,omitempty"`
 	OrderID int64  `json:"order_id,omitempty"`
 	Status  string `json:"status,omitempty"`
+	// CalculatedOrder previews the order with the session's edits applied.
+	// It is set by every edit step, so totals can be shown before Commit.
+	CalculatedOrder *Order `json:"calculated_order,omitempty"`
 }
 
 type EditSetQuantity struct {
@@ -187,41 +192,63 @@ type EditRemoveDiscount struct {
 }
 
 type editSessionResource struct {
-	EditSession *EditSession `json:"edit_session"`
+	EditSession     *EditSession `json:"edit_session"`
+	CalculatedOrder *Order       `json:"calculated_order"`
+}
+
+// session returns the edit session with the calculated order attached, which
+// the API may send next to the session instead of inside it.
+func (r *editSessionResource) session() *EditSession {
+	if r.EditSession != nil && r.EditSession.CalculatedOrder == nil {
+		r.EditSession.CalculatedOrder = r.CalculatedOrder
+	}
+	return r.EditSession
 }
 
 func (s *editOp) Start(ctx context.Context, orderID int64) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/start.json", orderID)), nil, r)
-	return r.EditSession, err
+	return r.session(), err
 }
 func (s *editOp) SetQuantity(ctx context.Context, orderID int64, e EditSetQuantity) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/set_quantity.json", orderID)), e, r)
-	return r.EditSession, err
+	return r.session(), err
 }
 func (s *editOp) AddLineItem(ctx context.Context, orderID int64, e EditAddLineItem) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/add_line_item.json", orderID)), e, r)
-	return r.EditSession, err
+	return r.session(), err
 }
 func (s *editOp) AddCustomItem(ctx context.Context, orderID int64, e EditAddCustomItem) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/add_custom_item.json", orderID)), e, r)
-	return r.EditSession, err
+	return r.session(), err
 }
 func (s *editOp) AddDiscount(ctx context.Context, orderID int64, e EditAddDiscount) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/add_discount.json", orderID)), e, r)
-	return r.EditSession, err
+	return r.session(), err
 }
 func (s *editOp) RemoveDiscount(ctx context.Context, orderID int64, e EditRemoveDiscount) (*EditSession, error) {
 	r := &editSessionResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/remove_discount.json", orderID)), e, r)
-	return r.EditSession, err
+	return r.session(), err
+}
+func (s *editOp) RemoveLineItem(ctx context.Context, orderID, lineItemID int64) (*EditSession, error) {
+	r := &editSessionResource{}
+	body := map[string]int64{"line_item_id": lineItemID}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/remove_line_item.json", orderID)), body, r)
+	return r.session(), err
 }
 func (s *editOp) Commit(ctx context.Context, orderID int64) (*Order, error) {
 	r := &orderResource{}
 	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/commit.json", orderID)), nil, r)
 	return r.Order, err
 }
+
+// Discard abandons the order's edit session without applying its changes, so
+// a new one can be started after a failed or partial edit.
+func (s *editOp) Discard(ctx context.Context, orderID int64) error {
+	return s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("orders/%d/edit/discard.json", orderID)), nil, nil)
+}
@@ -0,0 +1,71 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// maxTagAttempts bounds the read-modify-write loop in AddTags/RemoveTags.
+const maxTagAttempts = 3
+
+// ErrTagConflict is returned by AddTags/RemoveTags when the order kept
+// changing between read and write on every attempt.
+var ErrTagConflict = errors.New("order: tags update conflicted with concurrent order edits")
+
+// TagList returns the order's tags parsed from the comma-separated Tags field.
+func (o *Order) TagList() core.Tags {
+	return core.ParseTags(o.Tags)
+}
+
+// AddTags adds tags to an order without clobbering tags written by others.
+func (s *serviceOp) AddTags(ctx context.Context, orderID int64, tags ...string) (*Order, error) {
+	return s.updateTags(ctx, orderID, func(t core.Tags) core.Tags { return t.Add(tags...) })
+}
+
+// RemoveTags removes tags from an order, leaving all other tags intact.
+func (s *serviceOp) RemoveTags(ctx context.Context, orderID int64, tags ...string) (*Order, error) {
+	return s.updateTags(ctx, orderID, func(t core.Tags) core.Tags { return t.Remove(tags...) })
+}
+
+// updateTags performs an optimistic read-modify-write of the order's tags.
+//
+// The write carries the UpdatedAt of the copy the change was made against,
+// so the API rejects it with core.ErrConflict if another writer saved the
+// order since; the change is then redone against a fresh copy. After
+// maxTagAttempts it gives up with ErrTagConflict. An order read without
+// UpdatedAt is not written at all, since the write could not be guarded.
+func (s *serviceOp) updateTags(ctx context.Context, orderID int64, apply func(core.Tags) core.Tags) (*Order, error) {
+	ctx = core.WithoutFields(ctx) // the tags are merged into the whole order
+	path := s.client.CreatePath(fmt.Sprintf("%s/%d.json", ordersBasePath, orderID))
+	for attempt := 0; attempt < maxTagAttempts; attempt++ {
+		current, err := s.Get(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		next := apply(current.TagList())
+		if next.String() == current.TagList().String() {
+			return current, nil // nothing to change
+		}
+
+		// Sent as a map because Order.Tags is omitempty and would drop the
+		// empty string needed when the last tag is removed.
+		if current.UpdatedAt == nil {
+			return nil, fmt.Errorf("order: order %d has no updated_at to guard the tags update with", orderID)
+		}
+		order := map[string]interface{}{
+			"id":         orderID,
+			"tags":       next.String(),
+			"updated_at": current.UpdatedAt,
+		}
+		resource := &orderResource{}
+		err = s.client.Put(ctx, path, map[string]interface{}{"order": order}, resource)
+		if errors.Is(err, core.ErrConflict) {
+			continue
+		}
+		return resource.Order, err
+	}
+	return nil, ErrTagConflict
+}
@@ -0,0 +1,161 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestOrderAddTags(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: "rush", UpdatedAt: &updated}})
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&putBody)
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: putBody["order"]["tags"].(string)}})
+		}
+	})
+	defer close()
+
+	o, err := NewService(mock).AddTags(context.Background(), 1001, "gift", "rush")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.Tags != "rush, gift" {
+		t.Errorf("expected 'rush, gift', got %q", o.Tags)
+	}
+}
+
+func TestOrderRemoveTags_LastTagSendsEmpty(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: "rush", UpdatedAt: &updated}})
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&putBody)
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001}})
+		}
+	})
+	defer close()
+
+	if _, err := NewService(mock).RemoveTags(context.Background(), 1001, "rush"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags, ok := putBody["order"]["tags"]; !ok || tags != "" {
+		t.Errorf("expected explicit empty tags, got %v (present=%v)", tags, ok)
+	}
+}
+
+func TestOrderAddTags_NoopSkipsWrite(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected no write, got %s", r.Method)
+		}
+		json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: "rush"}})
+	})
+	defer close()
+
+	if _, err := NewService(mock).AddTags(context.Background(), 1001, "rush"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrderAddTags_SendsUpdatedAt(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var putBody map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, UpdatedAt: &updated}})
+		case http.MethodPut:
+			json.NewDecoder(r.Body).Decode(&putBody)
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001}})
+		}
+	})
+	defer close()
+
+	if _, err := NewService(mock).AddTags(context.Background(), 1001, "gift"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := putBody["order"]["updated_at"]; got != updated.Format(time.RFC3339) {
+		t.Errorf("expected updated_at precondition %s, got %v", updated.Format(time.RFC3339), got)
+	}
+}
+
+func TestOrderAddTags_RetriesOnConflict(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	reads, writes := 0, 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			reads++
+			tags := "rush"
+			if reads > 1 {
+				tags = "rush, vip" // written by someone else meanwhile
+			}
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: tags, UpdatedAt: &updated}})
+		case http.MethodPut:
+			writes++
+			if writes == 1 {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			var body map[string]map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: body["order"]["tags"].(string)}})
+		}
+	})
+	defer close()
+
+	o, err := NewService(mock).AddTags(context.Background(), 1001, "gift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if o.Tags != "rush, vip, gift" || reads != 2 {
+		t.Errorf("expected the change redone on a fresh copy, got %q after %d reads", o.Tags, reads)
+	}
+}
+
+func TestOrderAddTags_Conflict(t *testing.T) {
+	updated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	writes := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			writes++
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, UpdatedAt: &updated}})
+	})
+	defer close()
+
+	_, err := NewService(mock).AddTags(context.Background(), 1001, "gift")
+	if !errors.Is(err, ErrTagConflict) {
+		t.Fatalf("expected ErrTagConflict, got %v", err)
+	}
+	if writes != maxTagAttempts {
+		t.Errorf("expected %d writes, got %d", maxTagAttempts, writes)
+	}
+}
+
+func TestOrderAddTags_NoUpdatedAt(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected no unguarded write, got %s", r.Method)
+		}
+		json.NewEncoder(w).Encode(orderResource{Order: &Order{ID: 1001, Tags: "rush"}})
+	})
+	defer close()
+
+	if _, err := NewService(mock).AddTags(context.Background(), 1001, "gift"); err == nil {
+		t.Fatal("expected error for an order without updated_at")
+	}
+}
@@ -0,0 +1,22 @@
+package order
+
+import "github.com/imokyou/slshop/carriers"
+
+// ResolveTrackingURL returns the fulfillment's tracking link: the URL the
+// API returned if there is one, otherwise one built from the tracking
+// number and company with the carriers table. It returns "" when the
+// company is unknown or there is no number.
+func (f *Fulfillment) ResolveTrackingURL() string {
+	if f.TrackingURL != "" {
+		return f.TrackingURL
+	}
+	if len(f.TrackingURLs) > 0 && f.TrackingURLs[0] != "" {
+		return f.TrackingURLs[0]
+	}
+	number := f.TrackingNumber
+	if number == "" && len(f.TrackingNumbers) > 0 {
+		number = f.TrackingNumbers[0]
+	}
+	u, _ := carriers.TrackingURL(f.TrackingCompany, number)
+	return u
+}
@@ -0,0 +1,21 @@
+package order
+
+import "testing"
+
+func TestResolveTrackingURL(t *testing.T) {
+	tests := []struct {
+		f    Fulfillment
+		want string
+	}{
+		{Fulfillment{TrackingURL: "https://t.example.com/1", TrackingCompany: "UPS", TrackingNumber: "1Z"}, "https://t.example.com/1"},
+		{Fulfillment{TrackingURLs: []string{"https://t.example.com/2"}}, "https://t.example.com/2"},
+		{Fulfillment{TrackingCompany: "FedEx", TrackingNumber: "7489"}, "https://www.fedex.com/fedextrack/?trknbr=7489"},
+		{Fulfillment{TrackingCompany: "usps", TrackingNumbers: []string{"9400", "9401"}}, "https://tools.usps.com/go/TrackConfirmAction?tLabels=9400"},
+		{Fulfillment{TrackingCompany: "Unknown Post", TrackingNumber: "1"}, ""},
+	}
+	for i, tt := range tests {
+		if got := tt.f.ResolveTrackingURL(); got != tt.want {
+			t.Errorf("%d: got %q, want %q", i, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Create Validation
+// =====================================================================
+
+// FieldError is a problem with one field of an order or draft order. Field
+// is the JSON path of the field, e.g. "line_items[1].quantity".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError lists every problem Validate found.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		parts[i] = fe.Field + " " + fe.Message
+	}
+	return "order: invalid input: " + strings.Join(parts, "; ")
+}
+
+// Fields groups the messages by field, in the shape of the API's
+// ResponseError.Fields, so local and remote errors render alike.
+func (e *ValidationError) Fields() map[string][]string {
+	m := make(map[string][]string, len(e.Errors))
+	for _, fe := range e.Errors {
+		m[fe.Field] = append(m[fe.Field], fe.Message)
+	}
+	return m
+}
+
+// Validate checks an order about to be created for the mistakes the API
+// answers with 422: no line items, non-positive quantities, line items that
+// are neither a variant nor a titled and priced custom item, malformed
+// prices, currencies and emails, and addresses missing address1, city or
+// country. It returns a *ValidationError, or nil. Create does not call it;
+// run it before the request to report errors without a round trip.
+func (o *Order) Validate() error {
+	v := &validator{}
+	v.lineItems(o.LineItems)
+	v.currency("currency", o.Currency)
+	v.currency("presentment_currency", o.PresentmentCurrency)
+	v.email("email", o.Email)
+	v.address("billing_address", o.BillingAddress)
+	v.address("shipping_address", o.ShippingAddress)
+	return v.err()
+}
+
+// Validate checks a draft order about to be created with the same rules as
+// Order.Validate.
+func (d *DraftOrder) Validate() error {
+	v := &validator{}
+	v.lineItems(d.LineItems)
+	v.currency("currency", d.Currency)
+	v.currency("presentment_currency", d.PresentmentCurrency)
+	v.email("email", d.Email)
+	v.address("billing_address", d.BillingAddress)
+	v.address("shipping_address", d.ShippingAddress)
+	return v.err()
+}
+
+type validator struct {
+	errs []FieldError
+}
+
+func (v *validator) add(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+func (v *validator) lineItems(items []core.LineItem) {
+	if len(items) == 0 {
+		v.add("line_items", "must contain at least one item")
+		return
+	}
+	for i, li := range items {
+		field := fmt.Sprintf("line_items[%d]", i)
+		if li.Quantity <= 0 {
+			v.add(field+".quantity", "must be positive, got %d", li.Quantity)
+		}
+		if !hasID(li.VariantID) {
+			if li.Title == "" {
+				v.add(field+".title", "is required for an item without variant_id")
+			}
+			if li.Price == "" {
+				v.add(field+".price", "is required for an item without variant_id")
+			}
+		}
+		v.price(field+".price", li.Price)
+	}
+}
+
+// hasID reports whether id, a variant or product ID the API sends as a
+// number or a string, is set.
+func hasID(id interface{}) bool {
+	switch id := id.(type) {
+	case nil:
+		return false
+	case string:
+		return id != "" && id != "0"
+	case int64:
+		return id != 0
+	case int:
+		return id != 0
+	case float64:
+		return id != 0
+	}
+	return true
+}
+
+func (v *validator) price(field, s string) {
+	if s == "" {
+		return
+	}
+	d, err := core.ParseDecimal(s)
+	if err != nil {
+		v.add(field, "is not a decimal amount: %q", s)
+		return
+	}
+	if d.Sign() < 0 {
+		v.add(field, "must not be negative, got %s", s)
+	}
+}
+
+// currency accepts empty codes and three uppercase letters (ISO 4217).
+func (v *validator) currency(field, code string) {
+	if code == "" {
+		return
+	}
+	if len(code) != 3 || strings.IndexFunc(code, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		v.add(field, "must be a three-letter ISO 4217 code such as USD, got %q", code)
+	}
+}
+
+func (v *validator) email(field, email string) {
+	if email == "" {
+		return
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 || strings.ContainsAny(email, " \t") {
+		v.add(field, "is not a valid email address: %q", email)
+	}
+}
+
+func (v *validator) address(field string, a *core.Address) {
+	if a == nil {
+		return
+	}
+	if strings.TrimSpace(a.Address1) == "" {
+		v.add(field+".address1", "is required")
+	}
+	if strings.TrimSpace(a.City) == "" {
+		v.add(field+".city", "is required")
+	}
+	if a.Country == "" && a.CountryCode == "" {
+		v.add(field+".country_code", "is required")
+	} else if a.CountryCode != "" && len(a.CountryCode) != 2 {
+		v.add(field+".country_code", "must be a two-letter ISO 3166 code, got %q", a.CountryCode)
+	}
+}
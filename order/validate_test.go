@@ -0,0 +1,70 @@
+package order
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+func TestOrderValidate(t *testing.T) {
+	valid := Order{
+		Currency:  "USD",
+		Email:     "buyer@example.com",
+		LineItems: []core.LineItem{{VariantID: int64(11), Quantity: 1}, {Title: "Gift wrap", Price: "2.50", Quantity: 1}},
+		ShippingAddress: &core.Address{
+			Address1: "1 Main St", City: "Springfield", CountryCode: "US",
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid order: %v", err)
+	}
+
+	bad := Order{
+		Currency: "usd",
+		Email:    "buyer@",
+		LineItems: []core.LineItem{
+			{VariantID: "0", Quantity: 0},
+			{VariantID: int64(12), Quantity: 2, Price: "-1"},
+		},
+		BillingAddress: &core.Address{City: "Springfield", CountryCode: "USA"},
+	}
+	err := bad.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	var fields []string
+	for _, fe := range verr.Errors {
+		fields = append(fields, fe.Field)
+	}
+	want := []string{
+		"line_items[0].quantity",
+		"line_items[0].title",
+		"line_items[0].price",
+		"line_items[1].price",
+		"currency",
+		"email",
+		"billing_address.address1",
+		"billing_address.country_code",
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %v\nwant %v", fields, want)
+	}
+	if got := verr.Fields()["line_items[0].quantity"]; len(got) != 1 {
+		t.Errorf("Fields() = %v", verr.Fields())
+	}
+}
+
+func TestDraftOrderValidate(t *testing.T) {
+	err := (&DraftOrder{}).Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 1 || verr.Errors[0].Field != "line_items" {
+		t.Fatalf("err = %v, want missing line_items", err)
+	}
+	d := DraftOrder{PresentmentCurrency: "EUR", LineItems: []core.LineItem{{VariantID: float64(5), Quantity: 3}}}
+	if err := d.Validate(); err != nil {
+		t.Errorf("valid draft: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/order"
+)
+
+// OrderBundle is an order together with the records support tooling usually
+// shows next to it.
+type OrderBundle struct {
+	Order        *order.Order
+	Refunds      []order.Refund
+	Transactions []order.Transaction
+	Fulfillments []order.Fulfillment
+	Customer     *core.Customer // nil for guest orders and deleted customers
+}
+
+// FetchOrderBundle fetches an order with its refunds, transactions,
+// fulfillments and customer. The calls run concurrently, the customer as soon
+// as the order names it, so the bundle takes about as long as two requests
+// instead of five. The first failure cancels the calls still running and is
+// returned; a missing order is core.ErrNotFound. A field selection made with
+// core.WithFields is ignored, as it would not fit the other endpoints.
+//
+//	b, err := client.FetchOrderBundle(ctx, orderID)
+func (c *Client) FetchOrderBundle(ctx context.Context, orderID int64) (*OrderBundle, error) {
+	g, ctx := newGroup(core.WithoutFields(ctx))
+	b := &OrderBundle{}
+
+	g.Go(func() error {
+		o, err := c.Order.Get(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("shopline: failed to get order %d: %w", orderID, err)
+		}
+		b.Order = o
+		if o.Customer == nil || o.Customer.ID == 0 {
+			return nil
+		}
+		cust, err := c.Customer.Get(ctx, o.Customer.ID)
+		if errors.Is(err, core.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("shopline: failed to get customer %d of order %d: %w", o.Customer.ID, orderID, err)
+		}
+		b.Customer = cust
+		return nil
+	})
+	g.Go(func() (err error) {
+		b.Refunds, err = c.Order.ListRefunds(ctx, orderID)
+		if err != nil {
+			err = fmt.Errorf("shopline: failed to list refunds of order %d: %w", orderID, err)
+		}
+		return err
+	})
+	g.Go(func() (err error) {
+		b.Transactions, err = c.Order.ListTransactions(ctx, orderID)
+		if err != nil {
+			err = fmt.Errorf("shopline: failed to list transactions of order %d: %w", orderID, err)
+		}
+		return err
+	})
+	g.Go(func() (err error) {
+		b.Fulfillments, err = c.Fulfillment.List(ctx, orderID, nil)
+		if err != nil {
+			err = fmt.Errorf("shopline: failed to list fulfillments of order %d: %w", orderID, err)
+		}
+		return err
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// group runs functions concurrently and cancels its context on the first
+// error, like golang.org/x/sync/errgroup.
+type group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+	once   sync.Once
+	err    error
+}
+
+func newGroup(ctx context.Context) (*group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &group{cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine.
+func (g *group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel(err)
+			})
+		}
+	}()
+}
+
+// Wait waits for every function and returns the first error.
+func (g *group) Wait() error {
+	g.wg.Wait()
+	g.cancel(nil)
+	return g.err
+}
@@ -0,0 +1,100 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================
+// Order Bundle Tests
+// ============================================================
+
+func TestFetchOrderBundle(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orders/7.json"):
+			w.Write([]byte(`{"order":{"id":7,"customer":{"id":42}}}`))
+		case strings.HasSuffix(r.URL.Path, "/orders/7/refunds.json"):
+			w.Write([]byte(`{"refunds":[{"id":1}]}`))
+		case strings.HasSuffix(r.URL.Path, "/orders/7/transactions.json"):
+			w.Write([]byte(`{"transactions":[{"id":2},{"id":3}]}`))
+		case strings.HasSuffix(r.URL.Path, "/orders/7/fulfillments.json"):
+			w.Write([]byte(`{"fulfillments":[{"id":4}]}`))
+		case strings.HasSuffix(r.URL.Path, "/customers/42.json"):
+			w.Write([]byte(`{"customer":{"id":42,"email":"a@example.com"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer server.Close()
+
+	b, err := client.FetchOrderBundle(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("FetchOrderBundle: %v", err)
+	}
+	if b.Order == nil || b.Order.ID != 7 {
+		t.Errorf("Order = %+v", b.Order)
+	}
+	if len(b.Refunds) != 1 || len(b.Transactions) != 2 || len(b.Fulfillments) != 1 {
+		t.Errorf("refunds=%d transactions=%d fulfillments=%d", len(b.Refunds), len(b.Transactions), len(b.Fulfillments))
+	}
+	if b.Customer == nil || b.Customer.Email != "a@example.com" {
+		t.Errorf("Customer = %+v", b.Customer)
+	}
+}
+
+func TestFetchOrderBundle_DeletedCustomer(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/orders/7.json"):
+			w.Write([]byte(`{"order":{"id":7,"customer":{"id":42}}}`))
+		case strings.HasSuffix(r.URL.Path, "/customers/42.json"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":"Not Found"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+	defer server.Close()
+
+	b, err := client.FetchOrderBundle(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("FetchOrderBundle: %v", err)
+	}
+	if b.Customer != nil {
+		t.Errorf("Customer = %+v, want nil", b.Customer)
+	}
+}
+
+func TestFetchOrderBundle_ErrorCancelsOthers(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/refunds.json") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors":"bad"}`))
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	defer server.Close()
+
+	start := time.Now()
+	_, err := client.FetchOrderBundle(context.Background(), 7)
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.Status != http.StatusBadRequest {
+		t.Fatalf("err = %v, want the refunds 400", err)
+	}
+	if !strings.Contains(err.Error(), "refunds") {
+		t.Errorf("err = %v, want it to name refunds", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("took %v; other calls were not cancelled", d)
+	}
+}
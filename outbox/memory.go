@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-memory Store for tests and local development.
+// It provides no durability; use a database-backed Store in production.
+type MemoryStore struct {
+	mu        sync.Mutex
+	processed map[string]bool
+	entries   map[string]Entry
+	dead      map[string]Entry
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		processed: make(map[string]bool),
+		entries:   make(map[string]Entry),
+		dead:      make(map[string]Entry),
+	}
+}
+
+// memoryTx buffers writes until the transaction commits.
+type memoryTx struct {
+	s         *MemoryStore
+	processed []string
+	entries   []Entry
+}
+
+func (tx *memoryTx) MarkProcessed(_ context.Context, webhookID string) (bool, error) {
+	if tx.s.processed[webhookID] {
+		return false, nil
+	}
+	for _, id := range tx.processed {
+		if id == webhookID {
+			return false, nil
+		}
+	}
+	tx.processed = append(tx.processed, webhookID)
+	return true, nil
+}
+
+func (tx *memoryTx) Enqueue(_ context.Context, e Entry) error {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	tx.entries = append(tx.entries, e)
+	return nil
+}
+
+// InTx implements Store. The store is locked for the duration of fn.
+func (s *MemoryStore) InTx(_ context.Context, fn func(tx Tx) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &memoryTx{s: s}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, id := range tx.processed {
+		s.processed[id] = true
+	}
+	for _, e := range tx.entries {
+		s.entries[e.ID] = e
+	}
+	return nil
+}
+
+// Pending implements Store.
+func (s *MemoryStore) Pending(_ context.Context, now time.Time, limit int) ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []Entry
+	for _, e := range s.entries {
+		if !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// Complete implements Store.
+func (s *MemoryStore) Complete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+	return nil
+}
+
+// Reschedule implements Store.
+func (s *MemoryStore) Reschedule(_ context.Context, id string, attempts int, next time.Time, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[id]; ok {
+		e.Attempts, e.NextAttemptAt, e.LastError = attempts, next, lastErr
+		s.entries[id] = e
+	}
+	return nil
+}
+
+// Dead implements Store.
+func (s *MemoryStore) Dead(_ context.Context, id string, lastErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[id]; ok {
+		e.LastError = lastErr
+		s.dead[id] = e
+		delete(s.entries, id)
+	}
+	return nil
+}
+
+// DeadEntries returns entries parked after exhausting their attempts.
+func (s *MemoryStore) DeadEntries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Entry, 0, len(s.dead))
+	for _, e := range s.dead {
+		out = append(out, e)
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+const (
+	defaultBatchSize   = 50
+	defaultMaxAttempts = 10
+	defaultInterval    = 5 * time.Second
+	maxRetryDelay      = time.Hour
+)
+
+// =====================================================================
+// Storage Interfaces
+// =====================================================================
+
+// Entry is an intended side effect, e.g. "create fulfillment for order X".
+type Entry struct {
+	ID            string
+	Kind          string // selects the executor registered with Dispatcher.Register
+	Payload       []byte // executor-specific data, typically JSON
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// Tx is the transactional view of the store passed to webhook processing.
+// Everything done through a Tx commits or rolls back together.
+type Tx interface {
+	// MarkProcessed records that a webhook delivery was handled. It returns
+	// false if the delivery was already recorded (a redelivery).
+	MarkProcessed(ctx context.Context, webhookID string) (bool, error)
+
+	// Enqueue adds an entry to the outbox.
+	Enqueue(ctx context.Context, e Entry) error
+}
+
+// Store persists webhook processing state and outbox entries in the
+// application's database. Implement it on top of a SQL transaction so that
+// business writes, the processed marker and outbox entries commit atomically.
+type Store interface {
+	// InTx runs fn in a single transaction, committing if fn returns nil.
+	InTx(ctx context.Context, fn func(tx Tx) error) error
+
+	// Pending returns up to limit entries with NextAttemptAt <= now, oldest first.
+	Pending(ctx context.Context, now time.Time, limit int) ([]Entry, error)
+
+	// Complete removes (or marks done) an executed entry.
+	Complete(ctx context.Context, id string) error
+
+	// Reschedule records a failed attempt.
+	Reschedule(ctx context.Context, id string, attempts int, next time.Time, lastErr string) error
+
+	// Dead parks an entry that exhausted its attempts for manual inspection.
+	Dead(ctx context.Context, id string, lastErr string) error
+}
+
+// =====================================================================
+// Webhook Integration
+// =====================================================================
+
+// WebhookFunc processes a delivery inside a transaction. Business writes
+// should use the same transaction as tx; side effects that call the Shopline
+// API should be enqueued via tx.Enqueue instead of being called directly.
+type WebhookFunc func(ctx context.Context, d webhook.Delivery, tx Tx) error
+
+// Handler adapts fn into a webhook.HandlerFunc with exactly-once processing:
+// redeliveries of the same webhook ID are acknowledged without running fn.
+//
+//	d.Handle("orders/paid", outbox.Handler(store, func(ctx context.Context, del webhook.Delivery, tx outbox.Tx) error {
+//	    return tx.Enqueue(ctx, outbox.Entry{ID: del.WebhookID, Kind: "fulfill", Payload: del.Body})
+//	}))
+func Handler(store Store, fn WebhookFunc) webhook.HandlerFunc {
+	return func(ctx context.Context, d webhook.Delivery) error {
+		return store.InTx(ctx, func(tx Tx) error {
+			if d.WebhookID != "" {
+				first, err := tx.MarkProcessed(ctx, d.WebhookID)
+				if err != nil {
+					return err
+				}
+				if !first {
+					return nil
+				}
+			}
+			return fn(ctx, d, tx)
+		})
+	}
+}
+
+// =====================================================================
+// Dispatcher
+// =====================================================================
+
+// ExecFunc executes an outbox entry. Executors must be idempotent: an entry
+// may run again if the process dies between execution and Complete.
+type ExecFunc func(ctx context.Context, e Entry) error
+
+// Option configures a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithBatchSize sets how many entries are fetched per poll (default 50).
+func WithBatchSize(n int) Option {
+	return func(d *Dispatcher) { d.batchSize = n }
+}
+
+// WithMaxAttempts sets how many times an entry is tried before it is parked
+// with Store.Dead (default 10).
+func WithMaxAttempts(n int) Option {
+	return func(d *Dispatcher) { d.maxAttempts = n }
+}
+
+// WithInterval sets the polling interval used by Run (default 5s). A
+// non-positive interval keeps the default.
+func WithInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		if interval > 0 {
+			d.interval = interval
+		}
+	}
+}
+
+// WithErrorHandler sets a callback for store errors encountered by Run.
+func WithErrorHandler(fn func(error)) Option {
+	return func(d *Dispatcher) { d.onError = fn }
+}
+
+// Dispatcher executes pending outbox entries with retries.
+type Dispatcher struct {
+	store       Store
+	batchSize   int
+	maxAttempts int
+	interval    time.Duration
+	onError     func(error)
+	now         func() time.Time
+
+	mu        sync.RWMutex
+	executors map[string]ExecFunc
+}
+
+// NewDispatcher creates a Dispatcher reading from store.
+func NewDispatcher(store Store, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		store:       store,
+		batchSize:   defaultBatchSize,
+		maxAttempts: defaultMaxAttempts,
+		interval:    defaultInterval,
+		now:         time.Now,
+		executors:   make(map[string]ExecFunc),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Register sets the executor for entries of the given kind.
+func (d *Dispatcher) Register(kind string, fn ExecFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.executors[kind] = fn
+}
+
+// RunOnce executes one batch of due entries and returns how many succeeded.
+// Execution failures are rescheduled with exponential backoff, not returned;
+// the error is non-nil only if the store itself fails.
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	entries, err := d.store.Pending(ctx, d.now(), d.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox: failed to load pending entries: %w", err)
+	}
+
+	done := 0
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return done, err
+		}
+		execErr := d.execute(ctx, e)
+		if execErr == nil {
+			if err := d.store.Complete(ctx, e.ID); err != nil {
+				return done, fmt.Errorf("outbox: failed to complete entry %s: %w", e.ID, err)
+			}
+			done++
+			continue
+		}
+
+		attempts := e.Attempts + 1
+		if attempts >= d.maxAttempts {
+			err = d.store.Dead(ctx, e.ID, execErr.Error())
+		} else {
+			err = d.store.Reschedule(ctx, e.ID, attempts, d.now().Add(retryDelay(attempts)), execErr.Error())
+		}
+		if err != nil {
+			return done, fmt.Errorf("outbox: failed to record failure of entry %s: %w", e.ID, err)
+		}
+	}
+	return done, nil
+}
+
+// Run polls the store until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		// Store errors are treated as transient: report and retry next tick.
+		if _, err := d.RunOnce(ctx); err != nil && ctx.Err() == nil && d.onError != nil {
+			d.onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// execute runs the executor for e, converting panics into errors.
+func (d *Dispatcher) execute(ctx context.Context, e Entry) (err error) {
+	d.mu.RLock()
+	fn, ok := d.executors[e.Kind]
+	d.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("outbox: no executor registered for kind %q", e.Kind)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("outbox: executor for %q panicked: %v", e.Kind, r)
+		}
+	}()
+	return fn(ctx, e)
+}
+
+// retryDelay returns 2^attempts seconds, capped at one hour.
+func retryDelay(attempts int) time.Duration {
+	if attempts > 12 {
+		return maxRetryDelay
+	}
+	d := time.Duration(1<<uint(attempts)) * time.Second
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
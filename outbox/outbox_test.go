@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/webhook"
+)
+
+func TestHandler_ExactlyOnce(t *testing.T) {
+	store := NewMemoryStore()
+	calls := 0
+	h := Handler(store, func(ctx context.Context, d webhook.Delivery, tx Tx) error {
+		calls++
+		return tx.Enqueue(ctx, Entry{ID: "fulfill-" + d.WebhookID, Kind: "fulfill"})
+	})
+
+	d := webhook.Delivery{Topic: "orders/paid", WebhookID: "wh-1"}
+	for i := 0; i < 3; i++ {
+		if err := h(context.Background(), d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected handler to run once, ran %d times", calls)
+	}
+	pending, _ := store.Pending(context.Background(), time.Now(), 0)
+	if len(pending) != 1 {
+		t.Errorf("expected 1 pending entry, got %d", len(pending))
+	}
+}
+
+func TestHandler_RollbackOnError(t *testing.T) {
+	store := NewMemoryStore()
+	h := Handler(store, func(ctx context.Context, d webhook.Delivery, tx Tx) error {
+		tx.Enqueue(ctx, Entry{ID: "x", Kind: "fulfill"})
+		return errors.New("db write failed")
+	})
+	d := webhook.Delivery{WebhookID: "wh-2"}
+	if err := h(context.Background(), d); err == nil {
+		t.Fatal("expected error")
+	}
+	pending, _ := store.Pending(context.Background(), time.Now(), 0)
+	if len(pending) != 0 {
+		t.Errorf("expected rollback to drop the entry, got %d pending", len(pending))
+	}
+	// The delivery was not marked processed, so a redelivery runs again.
+	ran := false
+	Handler(store, func(context.Context, webhook.Delivery, Tx) error { ran = true; return nil })(context.Background(), d)
+	if !ran {
+		t.Error("expected redelivery to be processed after rollback")
+	}
+}
+
+func TestDispatcher_RetryThenDead(t *testing.T) {
+	store := NewMemoryStore()
+	store.InTx(context.Background(), func(tx Tx) error {
+		tx.Enqueue(context.Background(), Entry{ID: "ok", Kind: "good"})
+		return tx.Enqueue(context.Background(), Entry{ID: "bad", Kind: "bad"})
+	})
+
+	clock := time.Now()
+	d := NewDispatcher(store, WithMaxAttempts(2))
+	d.now = func() time.Time { return clock }
+	d.Register("good", func(context.Context, Entry) error { return nil })
+	d.Register("bad", func(context.Context, Entry) error { return errors.New("api down") })
+
+	done, err := d.RunOnce(context.Background())
+	if err != nil || done != 1 {
+		t.Fatalf("expected 1 done, got %d (%v)", done, err)
+	}
+	pending, _ := store.Pending(context.Background(), clock, 0)
+	if len(pending) != 0 {
+		t.Errorf("expected failed entry to be backed off, got %d due", len(pending))
+	}
+
+	clock = clock.Add(time.Hour)
+	d.RunOnce(context.Background())
+	dead := store.DeadEntries()
+	if len(dead) != 1 || dead[0].ID != "bad" || dead[0].LastError != "api down" {
+		t.Errorf("expected bad entry to be dead, got %+v", dead)
+	}
+}
+
+func TestDispatcher_NonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		d := NewDispatcher(NewMemoryStore(), WithInterval(interval))
+		if d.interval != defaultInterval {
+			t.Errorf("WithInterval(%s): expected default interval, got %s", interval, d.interval)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		d.Run(ctx)
+	}
+}
+
+// fakeRequester records writes and their idempotency keys and fails them
+// with err.
+type fakeRequester struct {
+	err    error
+	writes []string
+	keys   []string
+}
+
+func (f *fakeRequester) Get(context.Context, string, interface{}, interface{}) error { return nil }
+func (f *fakeRequester) Post(ctx context.Context, path string, body, _ interface{}) error {
+	b, _ := json.Marshal(body)
+	f.writes = append(f.writes, "POST "+path+" "+string(b))
+	f.keys = append(f.keys, core.IdempotencyKey(ctx))
+	return f.err
+}
+func (f *fakeRequester) Put(ctx context.Context, path string, body, _ interface{}) error {
+	b, _ := json.Marshal(body)
+	f.writes = append(f.writes, "PUT "+path+" "+string(b))
+	f.keys = append(f.keys, core.IdempotencyKey(ctx))
+	return f.err
+}
+func (f *fakeRequester) Delete(context.Context, string) error { return nil }
+func (f *fakeRequester) CreatePath(resource string) string    { return "/admin/openapi/v1/" + resource }
+
+// statusError mimics shopline.ResponseError's retryability.
+type statusError struct{ retryable bool }
+
+func (e statusError) Error() string     { return "api error" }
+func (e statusError) IsRetryable() bool { return e.retryable }
+
+func TestRequester_QueuesRetryableWrites(t *testing.T) {
+	store := NewMemoryStore()
+	down := &fakeRequester{err: statusError{retryable: true}}
+	r := NewRequester(down, store)
+
+	err := r.Put(context.Background(), "/fulfillments/1.json", map[string]string{"tracking_number": "1Z"}, nil)
+	var queued *QueuedError
+	if !errors.As(err, &queued) || !errors.Is(err, down.err) {
+		t.Fatalf("expected QueuedError wrapping the request error, got %v", err)
+	}
+	pending, _ := store.Pending(context.Background(), time.Now(), 0)
+	if len(pending) != 1 || pending[0].ID != queued.EntryID || pending[0].Kind != KindWrite {
+		t.Fatalf("expected queued write entry, got %+v", pending)
+	}
+
+	up := &fakeRequester{}
+	if done, err := NewWorker(up, store).RunOnce(context.Background()); err != nil || done != 1 {
+		t.Fatalf("expected 1 replayed write, got %d (%v)", done, err)
+	}
+	if len(up.writes) != 1 || up.writes[0] != `PUT /fulfillments/1.json {"tracking_number":"1Z"}` {
+		t.Errorf("unexpected replay: %v", up.writes)
+	}
+	if down.keys[0] == "" || up.keys[0] != down.keys[0] {
+		t.Errorf("replay should reuse the original idempotency key, got %q then %q", down.keys[0], up.keys[0])
+	}
+}
+
+func TestRequester_KeepsCallerKey(t *testing.T) {
+	down := &fakeRequester{err: statusError{retryable: true}}
+	r := NewRequester(down, NewMemoryStore())
+	r.Post(core.WithIdempotencyKey(context.Background(), "refund-7"), "/refunds.json", nil, nil)
+	if down.keys[0] != "refund-7" {
+		t.Errorf("expected caller's key, got %q", down.keys[0])
+	}
+}
+
+func TestRequester_CancelledNotQueued(t *testing.T) {
+	store := NewMemoryStore()
+	r := NewRequester(&fakeRequester{err: fmt.Errorf("shopline: request cancelled: %w", context.Canceled)}, store)
+
+	if err := r.Post(context.Background(), "/orders.json", nil, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation, got %v", err)
+	}
+	if pending, _ := store.Pending(context.Background(), time.Now(), 0); len(pending) != 0 {
+		t.Errorf("expected nothing queued, got %d", len(pending))
+	}
+}
+
+func TestRequester_ValidationErrorNotQueued(t *testing.T) {
+	store := NewMemoryStore()
+	invalid := statusError{retryable: false}
+	r := NewRequester(&fakeRequester{err: invalid}, store)
+
+	if err := r.Post(context.Background(), "/orders.json", nil, nil); err != invalid {
+		t.Errorf("expected validation error unchanged, got %v", err)
+	}
+	if pending, _ := store.Pending(context.Background(), time.Now(), 0); len(pending) != 0 {
+		t.Errorf("expected nothing queued, got %d", len(pending))
+	}
+}
@@ -0,0 +1,205 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// KindWrite is the entry kind of API writes queued by Requester.
+const KindWrite = "shopline_write"
+
+// =====================================================================
+// Queued Writes
+// =====================================================================
+
+// Write is the payload of a KindWrite entry: an API request to send again.
+// IdempotencyKey is the key the original request carried; replays send it
+// again, so a write whose response was lost is not applied twice.
+type Write struct {
+	Method         string          `json:"method"`
+	Path           string          `json:"path"`
+	Body           json.RawMessage `json:"body,omitempty"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+}
+
+// QueuedError is returned by Requester when a failed write was persisted for
+// replay by a Worker. Err is the error of the original request.
+type QueuedError struct {
+	EntryID string
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *QueuedError) Error() string {
+	return fmt.Sprintf("outbox: write queued as %s: %v", e.EntryID, e.Err)
+}
+
+// Unwrap returns the error of the original request.
+func (e *QueuedError) Unwrap() error {
+	return e.Err
+}
+
+// Requester is a core.Requester that persists POST and PUT requests failing
+// with a retryable error (transport errors, 429 and 5xx, after the client's
+// own retries) to a Store, so a Worker can replay them once Shopline is
+// reachable again. Validation and other 4xx errors are returned unchanged.
+// Pass it to any service constructor:
+//
+//	fulfillments := order.NewFulfillmentService(outbox.NewRequester(client, store))
+//	_, err := fulfillments.UpdateTracking(ctx, orderID, fulfillmentID, tracking)
+//	var queued *outbox.QueuedError
+//	if errors.As(err, &queued) {
+//	    // delivered later by the Worker
+//	}
+//
+// Every write is sent with an Idempotency-Key header: the key already on ctx
+// (see core.WithIdempotencyKey), or a fresh one. The key is queued with the
+// write and sent again on replay, so a write whose response was lost in
+// transit is not applied twice. Queued writes cannot fill the caller's
+// result.
+type Requester struct {
+	client core.Requester
+	store  Store
+}
+
+// NewRequester creates a Requester sending through client and queueing to
+// store.
+func NewRequester(client core.Requester, store Store) *Requester {
+	return &Requester{client: client, store: store}
+}
+
+// Get implements core.Requester.
+func (r *Requester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	return r.client.Get(ctx, path, result, opts)
+}
+
+// Post implements core.Requester.
+func (r *Requester) Post(ctx context.Context, path string, body, result interface{}) error {
+	ctx, err := withKey(ctx)
+	if err != nil {
+		return err
+	}
+	return r.queueOnFailure(ctx, http.MethodPost, path, body, r.client.Post(ctx, path, body, result))
+}
+
+// Put implements core.Requester.
+func (r *Requester) Put(ctx context.Context, path string, body, result interface{}) error {
+	ctx, err := withKey(ctx)
+	if err != nil {
+		return err
+	}
+	return r.queueOnFailure(ctx, http.MethodPut, path, body, r.client.Put(ctx, path, body, result))
+}
+
+// Delete implements core.Requester.
+func (r *Requester) Delete(ctx context.Context, path string) error {
+	return r.client.Delete(ctx, path)
+}
+
+// CreatePath implements core.Requester.
+func (r *Requester) CreatePath(resource string) string {
+	return r.client.CreatePath(resource)
+}
+
+func (r *Requester) queueOnFailure(ctx context.Context, method, path string, body interface{}, err error) error {
+	if err == nil || !core.IsRetryable(err) {
+		return err
+	}
+	w := Write{Method: method, Path: path, IdempotencyKey: core.IdempotencyKey(ctx)}
+	if body != nil {
+		raw, mErr := json.Marshal(body)
+		if mErr != nil {
+			return err
+		}
+		w.Body = raw
+	}
+	payload, mErr := json.Marshal(w)
+	if mErr != nil {
+		return err
+	}
+	id, idErr := newEntryID()
+	if idErr != nil {
+		return fmt.Errorf("outbox: failed to queue write: %w (request error: %v)", idErr, err)
+	}
+	// ctx may have ended since the request failed; queue the write anyway.
+	qctx := context.WithoutCancel(ctx)
+	qErr := r.store.InTx(qctx, func(tx Tx) error {
+		return tx.Enqueue(qctx, Entry{ID: id, Kind: KindWrite, Payload: payload})
+	})
+	if qErr != nil {
+		return fmt.Errorf("outbox: failed to queue write: %w (request error: %v)", qErr, err)
+	}
+	return &QueuedError{EntryID: id, Err: err}
+}
+
+// withKey returns ctx with an idempotency key, keeping one already set.
+func withKey(ctx context.Context) (context.Context, error) {
+	if core.IdempotencyKey(ctx) != "" {
+		return ctx, nil
+	}
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ctx, fmt.Errorf("outbox: failed to generate idempotency key: %w", err)
+	}
+	return core.WithIdempotencyKey(ctx, "outbox-"+hex.EncodeToString(b[:])), nil
+}
+
+func newEntryID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return "write-" + hex.EncodeToString(b[:]), nil
+}
+
+// =====================================================================
+// Worker
+// =====================================================================
+
+// Worker is a Dispatcher that replays the writes queued by Requester, with
+// the Dispatcher's backoff and dead-lettering. Other kinds can be registered
+// on it as usual.
+//
+//	w := outbox.NewWorker(client, store, outbox.WithInterval(time.Minute))
+//	go w.Run(ctx)
+type Worker struct {
+	*Dispatcher
+}
+
+// NewWorker creates a Worker sending queued writes through client.
+func NewWorker(client core.Requester, store Store, opts ...Option) *Worker {
+	d := NewDispatcher(store, opts...)
+	d.Register(KindWrite, ReplayWrite(client))
+	return &Worker{Dispatcher: d}
+}
+
+// ReplayWrite returns the executor for KindWrite entries, for registering
+// on an existing Dispatcher.
+func ReplayWrite(client core.Requester) ExecFunc {
+	return func(ctx context.Context, e Entry) error {
+		var w Write
+		if err := json.Unmarshal(e.Payload, &w); err != nil {
+			return fmt.Errorf("outbox: invalid write payload: %w", err)
+		}
+		var body interface{}
+		if len(w.Body) > 0 {
+			body = w.Body
+		}
+		if w.IdempotencyKey != "" {
+			ctx = core.WithIdempotencyKey(ctx, w.IdempotencyKey)
+		}
+		switch w.Method {
+		case http.MethodPost:
+			return client.Post(ctx, w.Path, body, nil)
+		case http.MethodPut:
+			return client.Put(ctx, w.Path, body, nil)
+		}
+		return fmt.Errorf("outbox: unsupported write method %q", w.Method)
+	}
+}
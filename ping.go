@@ -0,0 +1,102 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// PingReason classifies why Ping failed.
+type PingReason int
+
+const (
+	// PingUnavailable means the API could not be reached or answered with
+	// a server error or rate limit; the token may still be valid.
+	PingUnavailable PingReason = iota
+	// PingUnauthorized means the API rejected the token (401): it is
+	// invalid, expired or revoked, and the merchant must re-authorize.
+	PingUnauthorized
+	// PingForbidden means the token is valid but may not read the shop
+	// (403), e.g. the app lost a scope or the store is frozen.
+	PingForbidden
+	// PingTimeout means the context deadline or the HTTP client timeout
+	// expired before the API answered.
+	PingTimeout
+)
+
+// String returns the reason's name.
+func (r PingReason) String() string {
+	switch r {
+	case PingUnauthorized:
+		return "unauthorized"
+	case PingForbidden:
+		return "forbidden"
+	case PingTimeout:
+		return "timeout"
+	}
+	return "unavailable"
+}
+
+// PingError is returned by Ping when the check fails.
+type PingError struct {
+	Reason PingReason
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *PingError) Error() string {
+	return fmt.Sprintf("shopline: ping failed (%s): %v", e.Reason, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PingError) Unwrap() error {
+	return e.Err
+}
+
+// Ping checks that the API is reachable and the client's token is accepted,
+// using the cheapest authenticated read (the shop's ID) without retries. It
+// has no side effects, except that a TokenManager may refresh an expired
+// token. Failures are *PingError, whose Reason separates a rejected token
+// from a forbidden one, a timeout and an outage:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+//	defer cancel()
+//	var pingErr *shopline.PingError
+//	if err := client.Ping(ctx); errors.As(err, &pingErr) && pingErr.Reason == shopline.PingUnauthorized {
+//	    // ask the merchant to reinstall
+//	}
+func (c *Client) Ping(ctx context.Context) error {
+	ctx = core.WithFields(core.WithNoRetry(ctx), "id")
+	var r struct {
+		Shop *struct {
+			ID int64 `json:"id"`
+		} `json:"shop"`
+	}
+	err := c.Get(ctx, c.CreatePath("shop.json"), &r, nil)
+	if err == nil {
+		return nil
+	}
+	return &PingError{Reason: pingReason(err), Err: err}
+}
+
+// pingReason classifies a Ping error.
+func pingReason(err error) PingReason {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.Status {
+		case http.StatusUnauthorized:
+			return PingUnauthorized
+		case http.StatusForbidden:
+			return PingForbidden
+		}
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return PingTimeout
+	}
+	return PingUnavailable
+}
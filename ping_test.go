@@ -0,0 +1,68 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ============================================================
+// Ping Tests
+// ============================================================
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		delay  time.Duration
+		want   PingReason
+	}{
+		{"unauthorized", http.StatusUnauthorized, 0, PingUnauthorized},
+		{"forbidden", http.StatusForbidden, 0, PingForbidden},
+		{"server error", http.StatusServiceUnavailable, 0, PingUnavailable},
+		{"timeout", http.StatusOK, 200 * time.Millisecond, PingTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(tt.delay)
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"shop":{"id":1}}`))
+			})
+			defer server.Close()
+			WithRetry(3)(client)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := client.Ping(ctx)
+			var pingErr *PingError
+			if !errors.As(err, &pingErr) || pingErr.Reason != tt.want {
+				t.Fatalf("expected %s, got %v", tt.want, err)
+			}
+			if n := atomic.LoadInt32(&calls); n != 1 {
+				t.Errorf("expected no retries, got %d calls", n)
+			}
+		})
+	}
+}
+
+func TestPing_OK(t *testing.T) {
+	var fields string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		w.Write([]byte(`{"shop":{"id":1}}`))
+	})
+	defer server.Close()
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields != "id" {
+		t.Errorf("expected fields=id, got %q", fields)
+	}
+}
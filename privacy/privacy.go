@@ -0,0 +1,114 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+// Mandatory privacy (GDPR) webhook topics. Every public app must respond to
+// all three.
+const (
+	TopicCustomersDataRequest = "customers/data_request"
+	TopicCustomersRedact      = "customers/redact"
+	TopicShopRedact           = "shop/redact"
+)
+
+// =====================================================================
+// Handler
+// =====================================================================
+
+// Handler processes the mandatory privacy webhooks.
+//
+// Returning an error responds with HTTP 500 so Shopline redelivers the
+// webhook; return nil once the request has been recorded or fulfilled.
+type Handler interface {
+	// CustomersDataRequest is called when a customer asks the merchant for
+	// the data the app stores about them.
+	CustomersDataRequest(ctx context.Context, p CustomersDataRequestPayload) error
+
+	// CustomersRedact is called when the merchant asks the app to delete a
+	// customer's data.
+	CustomersRedact(ctx context.Context, p CustomersRedactPayload) error
+
+	// ShopRedact is called some time after the app is uninstalled; the app
+	// should delete all data it holds for the store.
+	ShopRedact(ctx context.Context, p ShopRedactPayload) error
+}
+
+// Register wires h into d for all three privacy topics.
+//
+//	d := webhook.NewDispatcher(webhook.WithVerifier(app.VerifyWebhookRequest))
+//	privacy.Register(d, myComplianceHandler)
+//	http.Handle("/webhooks", d)
+func Register(d *webhook.Dispatcher, h Handler) {
+	d.Handle(TopicCustomersDataRequest, func(ctx context.Context, del webhook.Delivery) error {
+		var p CustomersDataRequestPayload
+		if err := decode(del, &p); err != nil {
+			return err
+		}
+		return h.CustomersDataRequest(ctx, p)
+	})
+	d.Handle(TopicCustomersRedact, func(ctx context.Context, del webhook.Delivery) error {
+		var p CustomersRedactPayload
+		if err := decode(del, &p); err != nil {
+			return err
+		}
+		return h.CustomersRedact(ctx, p)
+	})
+	d.Handle(TopicShopRedact, func(ctx context.Context, del webhook.Delivery) error {
+		var p ShopRedactPayload
+		if err := decode(del, &p); err != nil {
+			return err
+		}
+		return h.ShopRedact(ctx, p)
+	})
+}
+
+func decode(del webhook.Delivery, v interface{}) error {
+	if err := json.Unmarshal(del.Body, v); err != nil {
+		return fmt.Errorf("privacy: failed to decode %s payload: %w", del.Topic, err)
+	}
+	return nil
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+// Customer identifies the customer a privacy request refers to.
+type Customer struct {
+	ID    int64  `json:"id,omitempty"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// DataRequest identifies a customer data request.
+type DataRequest struct {
+	ID int64 `json:"id,omitempty"`
+}
+
+// CustomersDataRequestPayload is the body of a customers/data_request webhook.
+type CustomersDataRequestPayload struct {
+	ShopID          int64       `json:"shop_id,omitempty"`
+	ShopDomain      string      `json:"shop_domain,omitempty"`
+	OrdersRequested []int64     `json:"orders_requested,omitempty"`
+	Customer        Customer    `json:"customer"`
+	DataRequest     DataRequest `json:"data_request"`
+}
+
+// CustomersRedactPayload is the body of a customers/redact webhook.
+type CustomersRedactPayload struct {
+	ShopID         int64    `json:"shop_id,omitempty"`
+	ShopDomain     string   `json:"shop_domain,omitempty"`
+	Customer       Customer `json:"customer"`
+	OrdersToRedact []int64  `json:"orders_to_redact,omitempty"`
+}
+
+// ShopRedactPayload is the body of a shop/redact webhook.
+type ShopRedactPayload struct {
+	ShopID     int64  `json:"shop_id,omitempty"`
+	ShopDomain string `json:"shop_domain,omitempty"`
+}
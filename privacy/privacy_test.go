@@ -0,0 +1,75 @@
+package privacy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/webhook"
+)
+
+type recordingHandler struct {
+	dataRequest *CustomersDataRequestPayload
+	redact      *CustomersRedactPayload
+	shopRedact  *ShopRedactPayload
+}
+
+func (h *recordingHandler) CustomersDataRequest(_ context.Context, p CustomersDataRequestPayload) error {
+	h.dataRequest = &p
+	return nil
+}
+func (h *recordingHandler) CustomersRedact(_ context.Context, p CustomersRedactPayload) error {
+	h.redact = &p
+	return nil
+}
+func (h *recordingHandler) ShopRedact(_ context.Context, p ShopRedactPayload) error {
+	h.shopRedact = &p
+	return nil
+}
+
+func deliver(t *testing.T, d *webhook.Dispatcher, topic, body string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(webhook.HeaderTopic, topic)
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestRegister_AllTopics(t *testing.T) {
+	h := &recordingHandler{}
+	d := webhook.NewDispatcher()
+	Register(d, h)
+
+	if code := deliver(t, d, TopicCustomersDataRequest,
+		`{"shop_id":1,"shop_domain":"a.myshopline.com","orders_requested":[10,11],"customer":{"id":7,"email":"c@test.com"},"data_request":{"id":99}}`); code != http.StatusOK {
+		t.Fatalf("data_request: expected 200, got %d", code)
+	}
+	if h.dataRequest == nil || h.dataRequest.Customer.ID != 7 || h.dataRequest.DataRequest.ID != 99 || len(h.dataRequest.OrdersRequested) != 2 {
+		t.Errorf("unexpected data request payload: %+v", h.dataRequest)
+	}
+
+	if code := deliver(t, d, TopicCustomersRedact, `{"shop_id":1,"customer":{"id":7},"orders_to_redact":[10]}`); code != http.StatusOK {
+		t.Fatalf("customers/redact: expected 200, got %d", code)
+	}
+	if h.redact == nil || h.redact.Customer.ID != 7 || len(h.redact.OrdersToRedact) != 1 {
+		t.Errorf("unexpected redact payload: %+v", h.redact)
+	}
+
+	if code := deliver(t, d, TopicShopRedact, `{"shop_id":1,"shop_domain":"a.myshopline.com"}`); code != http.StatusOK {
+		t.Fatalf("shop/redact: expected 200, got %d", code)
+	}
+	if h.shopRedact == nil || h.shopRedact.ShopDomain != "a.myshopline.com" {
+		t.Errorf("unexpected shop redact payload: %+v", h.shopRedact)
+	}
+}
+
+func TestRegister_MalformedPayload(t *testing.T) {
+	d := webhook.NewDispatcher()
+	Register(d, &recordingHandler{})
+	if code := deliver(t, d, TopicShopRedact, `not json`); code != http.StatusInternalServerError {
+		t.Errorf("expected 500 for malformed payload, got %d", code)
+	}
+}
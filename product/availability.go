@@ -0,0 +1,150 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Variant inventory policies.
+const (
+	InventoryPolicyDeny     = "deny"     // stop selling when out of stock
+	InventoryPolicyContinue = "continue" // allow backorders
+)
+
+// =====================================================================
+// Cart Availability
+// =====================================================================
+
+// VariantQuantity is a requested quantity of a variant (one cart line).
+type VariantQuantity struct {
+	VariantID int64
+	Quantity  int
+}
+
+// VariantAvailability is the availability of one requested variant.
+type VariantAvailability struct {
+	VariantID       int64
+	InventoryItemID int64
+	Requested       int
+	Available       int    // units on hand at the location (or all locations)
+	InventoryPolicy string // "deny" or "continue"
+	Tracked         bool   // false if the variant does not track inventory
+
+	// Sufficient is true if the requested quantity can be sold: stock covers
+	// it, inventory is not tracked, or the policy allows backorders.
+	Sufficient bool
+	// Backorder is true if Sufficient relies on the "continue" policy.
+	Backorder bool
+}
+
+// maxVariantIDs is the most variant IDs a single variants.json request
+// accepts.
+const maxVariantIDs = 250
+
+type variantsResource struct {
+	Variants []Variant `json:"variants"`
+}
+
+type variantListOptions struct {
+	IDs   string `url:"ids,omitempty"`
+	Limit int    `url:"limit,omitempty"`
+}
+
+// CheckAvailability reports, for each requested variant, whether the quantity
+// can be sold. Use it in headless checkouts before accepting an order.
+//
+// Variants are listed by ID, up to 250 per request (duplicates in items are
+// summed), and stock for all of them is read with a single batched
+// inventory_levels request, so a typical cart costs two requests. A
+// locationID of 0 sums stock across all locations. A variant that does not
+// exist fails the check with core.ErrNotFound.
+func (s *inventoryOp) CheckAvailability(ctx context.Context, items []VariantQuantity, locationID int64) ([]VariantAvailability, error) {
+	ctx = core.WithoutFields(ctx) // availability needs the inventory fields
+	var order []int64
+	requested := make(map[int64]int)
+	for _, it := range items {
+		if _, seen := requested[it.VariantID]; !seen {
+			order = append(order, it.VariantID)
+		}
+		requested[it.VariantID] += it.Quantity
+	}
+
+	variants, err := s.listVariants(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	var itemIDs []string
+	for _, id := range order {
+		v, ok := variants[id]
+		if !ok {
+			return nil, fmt.Errorf("product: variant %d: %w", id, core.ErrNotFound)
+		}
+		if v.InventoryItemID != 0 {
+			itemIDs = append(itemIDs, strconv.FormatInt(v.InventoryItemID, 10))
+		}
+	}
+
+	stock := make(map[int64]int)
+	if len(itemIDs) > 0 {
+		opts := &InventoryLevelListOptions{InventoryItemIDs: strings.Join(itemIDs, ",")}
+		if locationID != 0 {
+			opts.LocationIDs = strconv.FormatInt(locationID, 10)
+		}
+		levels, err := s.ListLevels(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range levels {
+			if locationID == 0 || l.LocationID == locationID {
+				stock[l.InventoryItemID] += l.Available
+			}
+		}
+	}
+
+	result := make([]VariantAvailability, 0, len(order))
+	for _, id := range order {
+		v := variants[id]
+		a := VariantAvailability{
+			VariantID:       id,
+			InventoryItemID: v.InventoryItemID,
+			Requested:       requested[id],
+			Available:       stock[v.InventoryItemID],
+			InventoryPolicy: v.InventoryPolicy,
+			Tracked:         v.InventoryManagement != "",
+		}
+		switch {
+		case !a.Tracked, a.Available >= a.Requested:
+			a.Sufficient = true
+		case a.InventoryPolicy == InventoryPolicyContinue:
+			a.Sufficient = true
+			a.Backorder = true
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// listVariants fetches the variants with the given IDs, keyed by ID.
+func (s *inventoryOp) listVariants(ctx context.Context, ids []int64) (map[int64]*Variant, error) {
+	variants := make(map[int64]*Variant, len(ids))
+	for start := 0; start < len(ids); start += maxVariantIDs {
+		chunk := ids[start:min(start+maxVariantIDs, len(ids))]
+		strIDs := make([]string, len(chunk))
+		for i, id := range chunk {
+			strIDs[i] = strconv.FormatInt(id, 10)
+		}
+		r := &variantsResource{}
+		opts := &variantListOptions{IDs: strings.Join(strIDs, ","), Limit: len(chunk)}
+		if err := s.client.Get(ctx, s.client.CreatePath("variants.json"), r, opts); err != nil {
+			return nil, fmt.Errorf("product: failed to list variants: %w", err)
+		}
+		for i := range r.Variants {
+			variants[r.Variants[i].ID] = &r.Variants[i]
+		}
+	}
+	return variants, nil
+}
@@ -0,0 +1,50 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+func TestCheckAvailability(t *testing.T) {
+	requests := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/variants.json"):
+			json.NewEncoder(w).Encode(variantsResource{Variants: []Variant{
+				{ID: 1, InventoryItemID: 11, InventoryManagement: "shopline", InventoryPolicy: InventoryPolicyDeny},
+				{ID: 2, InventoryItemID: 12, InventoryManagement: "shopline", InventoryPolicy: InventoryPolicyContinue},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/inventory_levels.json"):
+			w.Write([]byte(`{"inventory_levels":[{"inventory_item_id":11,"location_id":5,"available":3},{"inventory_item_id":12,"location_id":5,"available":0}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	svc := NewInventoryService(mock)
+	got, err := svc.CheckAvailability(context.Background(), []VariantQuantity{
+		{VariantID: 1, Quantity: 2}, {VariantID: 2, Quantity: 1}, {VariantID: 1, Quantity: 1},
+	}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected one variants and one inventory request, got %d", requests)
+	}
+	if len(got) != 2 || got[0].Requested != 3 || !got[0].Sufficient || !got[1].Backorder {
+		t.Errorf("unexpected availability: %+v", got)
+	}
+
+	_, err = svc.CheckAvailability(context.Background(), []VariantQuantity{{VariantID: 9, Quantity: 1}}, 0)
+	if !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown variant, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Batch Upsert
+// =====================================================================
+
+// Defaults used by BatchUpsert for zero BatchOpts fields.
+const (
+	DefaultBatchChunkSize  = 50
+	DefaultBatchWorkers    = 4
+	DefaultBatchRetries    = 2
+	DefaultBatchRetryDelay = time.Second
+)
+
+// BatchOpts configures BatchUpsert. The zero value uses the defaults above.
+type BatchOpts struct {
+	// ChunkSize is the number of products sent before the next chunk
+	// starts. Failed products of a chunk are retried before moving on.
+	ChunkSize int
+	// Workers is the number of concurrent requests within a chunk.
+	Workers int
+	// Retries is how many more times the products of a chunk that failed
+	// with a retryable error are sent; negative disables chunk retries.
+	Retries int
+	// RetryDelay is the wait before the first chunk retry, doubled for
+	// each further one.
+	RetryDelay time.Duration
+	// OnChunk, if set, is called after each chunk with the number of
+	// products done so far, e.g. to report progress.
+	OnChunk func(done, total int)
+}
+
+// UpsertAction is what BatchUpsert did with a product.
+type UpsertAction string
+
+const (
+	UpsertCreated UpsertAction = "created"
+	UpsertUpdated UpsertAction = "updated"
+	UpsertFailed  UpsertAction = "failed"
+)
+
+// UpsertResult is the outcome of upserting products[Index] in BatchUpsert.
+// Product is set unless Action is UpsertFailed, in which case Err is.
+type UpsertResult struct {
+	Index    int
+	Action   UpsertAction
+	Product  *Product
+	Err      error
+	Attempts int
+}
+
+// BatchUpsert creates the products without an ID and updates those with
+// one, and returns one result per product, in input order; a failed product
+// does not stop the others.
+//
+// Products are sent in chunks of opts.ChunkSize with up to opts.Workers
+// concurrent requests. Every request goes through the client's rate limiter
+// and retry policy; products whose error outlasts them and is retryable
+// (rate limiting, 5xx and transport errors, not validation errors) are sent
+// again up to opts.Retries times before the next chunk starts. Each create
+// carries an idempotency key kept across those retries, so no product is
+// created twice.
+//
+// The error is non-nil only when ctx ends before every product was
+// attempted; products not attempted then have ctx's error as their Err.
+func (s *serviceOp) BatchUpsert(ctx context.Context, products []Product, opts BatchOpts) ([]UpsertResult, error) {
+	opts = opts.withDefaults()
+	batchID, err := core.NewBatchID()
+	if err != nil {
+		return nil, fmt.Errorf("product: failed to generate batch ID: %w", err)
+	}
+
+	results := make([]UpsertResult, len(products))
+	for start := 0; start < len(products); start += opts.ChunkSize {
+		end := min(start+opts.ChunkSize, len(products))
+		pending := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			pending = append(pending, i)
+		}
+
+		delay := opts.RetryDelay
+		for attempt := 0; ; attempt++ {
+			s.upsertAll(ctx, batchID, products, pending, results, opts.Workers)
+			if ctx.Err() != nil {
+				break
+			}
+			pending = pending[:0]
+			for i := start; i < end; i++ {
+				if results[i].Err != nil && core.IsRetryable(results[i].Err) {
+					pending = append(pending, i)
+				}
+			}
+			if len(pending) == 0 || attempt >= opts.Retries {
+				break
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+			}
+			delay *= 2
+		}
+
+		if err := ctx.Err(); err != nil {
+			attempted := start
+			for i := start; i < len(products); i++ {
+				if results[i].Attempts == 0 {
+					results[i] = UpsertResult{Index: i, Action: UpsertFailed, Err: err}
+				} else {
+					attempted++
+				}
+			}
+			return results, fmt.Errorf("product: batch upsert stopped after %d of %d products: %w", attempted, len(products), err)
+		}
+		if opts.OnChunk != nil {
+			opts.OnChunk(end, len(products))
+		}
+	}
+	return results, nil
+}
+
+func (o BatchOpts) withDefaults() BatchOpts {
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = DefaultBatchChunkSize
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultBatchWorkers
+	}
+	if o.Retries == 0 {
+		o.Retries = DefaultBatchRetries
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = DefaultBatchRetryDelay
+	}
+	return o
+}
+
+// upsertAll upserts products[i] for each i in indexes with up to workers
+// concurrent requests, recording the outcome in results[i]. It returns once
+// every index was attempted or ctx ended.
+func (s *serviceOp) upsertAll(ctx context.Context, batchID string, products []Product, indexes []int, results []UpsertResult, workers int) {
+	core.RunWorkers(ctx, len(indexes), workers, func(k int) {
+		i := indexes[k]
+		results[i] = s.upsert(ctx, batchID, i, products[i], results[i].Attempts+1)
+	})
+}
+
+func (s *serviceOp) upsert(ctx context.Context, batchID string, i int, p Product, attempts int) UpsertResult {
+	r := UpsertResult{Index: i, Attempts: attempts}
+	var err error
+	if p.ID == 0 {
+		ictx := core.WithIdempotencyKey(ctx, fmt.Sprintf("product-%s-%d", batchID, i))
+		r.Product, err = s.Create(ictx, p)
+		r.Action = UpsertCreated
+	} else {
+		r.Product, err = s.Update(ctx, p)
+		r.Action = UpsertUpdated
+	}
+	if err != nil {
+		r.Action, r.Product = UpsertFailed, nil
+		r.Err = fmt.Errorf("product: product %d: %w", i, err)
+	}
+	return r
+}
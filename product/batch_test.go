@@ -0,0 +1,133 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// statusError stands in for the client's ResponseError.
+type statusError int
+
+func (e statusError) Error() string     { return fmt.Sprintf("HTTP %d", int(e)) }
+func (e statusError) IsRetryable() bool { return e >= 500 }
+
+// writeRequester answers product creates and updates with fn.
+type writeRequester struct {
+	fn func(ctx context.Context, method string, p *Product) (*Product, error)
+}
+
+func (m *writeRequester) CreatePath(resource string) string { return resource }
+func (m *writeRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	return errors.New("unexpected GET " + path)
+}
+func (m *writeRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.write(ctx, "POST", body, result)
+}
+func (m *writeRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.write(ctx, "PUT", body, result)
+}
+func (m *writeRequester) Delete(ctx context.Context, path string) error {
+	return errors.New("unexpected DELETE " + path)
+}
+func (m *writeRequester) write(ctx context.Context, method string, body, result interface{}) error {
+	p, err := m.fn(ctx, method, body.(productResource).Product)
+	if err != nil {
+		return err
+	}
+	result.(*productResource).Product = p
+	return nil
+}
+
+var _ core.Requester = (*writeRequester)(nil)
+
+func TestBatchUpsert(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]int{}
+	flaky := 0
+	mock := &writeRequester{fn: func(ctx context.Context, method string, p *Product) (*Product, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch p.Title {
+		case "invalid":
+			return nil, statusError(422)
+		case "flaky":
+			keys[core.IdempotencyKey(ctx)]++
+			if flaky++; flaky < 3 {
+				return nil, statusError(503)
+			}
+		}
+		out := *p
+		if method == "POST" {
+			out.ID = 100 + int64(len(p.Title))
+		}
+		return &out, nil
+	}}
+
+	products := []Product{
+		{Title: "new"},
+		{ID: 7, Title: "existing"},
+		{Title: "invalid"},
+		{Title: "flaky"},
+		{ID: 8, Title: "other"},
+	}
+	var progress []int
+	results, err := NewService(mock).BatchUpsert(context.Background(), products, BatchOpts{
+		ChunkSize:  2,
+		Workers:    2,
+		RetryDelay: time.Millisecond,
+		OnChunk:    func(done, total int) { progress = append(progress, done) },
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []UpsertAction{UpsertCreated, UpsertUpdated, UpsertFailed, UpsertCreated, UpsertUpdated}
+	for i, r := range results {
+		if r.Index != i || r.Action != want[i] {
+			t.Errorf("result %d: index %d action %q, want %q (err %v)", i, r.Index, r.Action, want[i], r.Err)
+		}
+	}
+	if results[0].Product == nil || results[0].Product.ID != 103 {
+		t.Errorf("unexpected created product: %+v", results[0].Product)
+	}
+	var status statusError
+	if !errors.As(results[2].Err, &status) || status != 422 || results[2].Attempts != 1 {
+		t.Errorf("validation error should not be retried: %+v", results[2])
+	}
+	if results[3].Attempts != 3 {
+		t.Errorf("flaky product attempts = %d, want 3", results[3].Attempts)
+	}
+	if len(keys) != 1 {
+		t.Errorf("retries should reuse one idempotency key, got %v", keys)
+	}
+	if fmt.Sprint(progress) != "[2 4 5]" {
+		t.Errorf("progress = %v", progress)
+	}
+}
+
+func TestBatchUpsert_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mock := &writeRequester{fn: func(_ context.Context, _ string, p *Product) (*Product, error) {
+		cancel()
+		return p, nil
+	}}
+
+	results, err := NewService(mock).BatchUpsert(ctx, make([]Product, 5), BatchOpts{ChunkSize: 2, Workers: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if results[0].Action != UpsertCreated {
+		t.Errorf("first product: %+v", results[0])
+	}
+	for _, r := range results[2:] {
+		if r.Action != UpsertFailed || !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("product %d: %+v, want canceled", r.Index, r)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Bundle (Combined Products)
+// =====================================================================
+
+// BundleService manages bundle products: a sellable product whose stock and
+// fulfillment are made up of component variants.
+type BundleService interface {
+	List(ctx context.Context, opts *core.ListOptions) ([]Bundle, error)
+	Get(ctx context.Context, id int64) (*Bundle, error)
+	Create(ctx context.Context, b Bundle) (*Bundle, error)
+	Update(ctx context.Context, b Bundle) (*Bundle, error)
+	Delete(ctx context.Context, id int64) error
+
+	// SetComponents replaces the bundle's components.
+	SetComponents(ctx context.Context, bundleID int64, components []BundleComponent) (*Bundle, error)
+	// ListByProduct returns the bundles that include any variant of productID
+	// as a component.
+	ListByProduct(ctx context.Context, productID int64) ([]Bundle, error)
+}
+
+func NewBundleService(client core.Requester) BundleService {
+	return &bundleOp{client: client}
+}
+
+type bundleOp struct{ client core.Requester }
+
+type Bundle struct {
+	ID         int64             `json:"id,omitempty"`
+	ProductID  int64             `json:"product_id,omitempty"` // the bundle's own product
+	Title      string            `json:"title,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Components []BundleComponent `json:"components,omitempty"`
+	CreatedAt  *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt  *time.Time        `json:"updated_at,omitempty"`
+}
+
+type BundleComponent struct {
+	ProductID int64 `json:"product_id,omitempty"`
+	VariantID int64 `json:"variant_id,omitempty"`
+	Quantity  int   `json:"quantity,omitempty"`
+}
+
+type bundleResource struct {
+	Bundle *Bundle `json:"bundle"`
+}
+type bundlesResource struct {
+	Bundles []Bundle `json:"bundles"`
+}
+
+func (s *bundleOp) List(ctx context.Context, opts *core.ListOptions) ([]Bundle, error) {
+	r := &bundlesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("bundles.json"), r, opts)
+	return r.Bundles, err
+}
+func (s *bundleOp) Get(ctx context.Context, id int64) (*Bundle, error) {
+	r := &bundleResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("bundles/%d.json", id)), r, nil)
+	return core.Found(r.Bundle, err)
+}
+func (s *bundleOp) Create(ctx context.Context, b Bundle) (*Bundle, error) {
+	if err := validateComponents(b.Components); err != nil {
+		return nil, err
+	}
+	r := &bundleResource{}
+	err := s.client.Post(ctx, s.client.CreatePath("bundles.json"), bundleResource{Bundle: &b}, r)
+	return r.Bundle, err
+}
+func (s *bundleOp) Update(ctx context.Context, b Bundle) (*Bundle, error) {
+	r := &bundleResource{}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("bundles/%d.json", b.ID)), bundleResource{Bundle: &b}, r)
+	return r.Bundle, err
+}
+func (s *bundleOp) Delete(ctx context.Context, id int64) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("bundles/%d.json", id)))
+}
+func (s *bundleOp) SetComponents(ctx context.Context, bundleID int64, components []BundleComponent) (*Bundle, error) {
+	if len(components) == 0 {
+		return nil, fmt.Errorf("product: bundle %d needs at least one component", bundleID)
+	}
+	if err := validateComponents(components); err != nil {
+		return nil, err
+	}
+	r := &bundleResource{}
+	path := s.client.CreatePath(fmt.Sprintf("bundles/%d/components.json", bundleID))
+	err := s.client.Put(ctx, path, map[string][]BundleComponent{"components": components}, r)
+	return r.Bundle, err
+}
+func (s *bundleOp) ListByProduct(ctx context.Context, productID int64) ([]Bundle, error) {
+	r := &bundlesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("products/%d/bundles.json", productID)), r, nil)
+	return r.Bundles, err
+}
+
+// validateComponents rejects components the API would refuse, before a request is sent.
+func validateComponents(components []BundleComponent) error {
+	seen := make(map[int64]bool, len(components))
+	for _, c := range components {
+		if c.VariantID == 0 {
+			return fmt.Errorf("product: bundle component is missing variant_id")
+		}
+		if c.Quantity < 1 {
+			return fmt.Errorf("product: bundle component %d has quantity %d", c.VariantID, c.Quantity)
+		}
+		if seen[c.VariantID] {
+			return fmt.Errorf("product: bundle component %d is listed twice", c.VariantID)
+		}
+		seen[c.VariantID] = true
+	}
+	return nil
+}
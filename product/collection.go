@@ -2,7 +2,6 @@ package product
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/imokyou/slshop/core"
@@ -22,24 +21,39 @@ type CollectionService interface {
 }
 
 func NewCollectionService(client core.Requester) CollectionService {
-	return &collectionOp{client: client}
+	return core.NewResourceService(client, core.Resource[Collection]{
+		Path: "collections", Key: "collection", ListKey: "collections",
+		ID: func(c *Collection) int64 { return c.ID },
+	})
 }
 
-type collectionOp struct{ client core.Requester }
-
 type SmartCollectionService interface {
 	List(ctx context.Context, opts *core.ListOptions) ([]SmartCollection, error)
 	Get(ctx context.Context, id int64) (*SmartCollection, error)
 	Create(ctx context.Context, c SmartCollection) (*SmartCollection, error)
 	Update(ctx context.Context, c SmartCollection) (*SmartCollection, error)
 	Delete(ctx context.Context, id int64) error
+	SetPublishedAt(ctx context.Context, id int64, t time.Time) (*SmartCollection, error)
 }
 
 func NewSmartCollectionService(client core.Requester) SmartCollectionService {
-	return &smartCollectionOp{client: client}
+	return &smartCollectionOp{
+		ResourceService: core.NewResourceService(client, core.Resource[SmartCollection]{
+			Path: "smart_collections", Key: "smart_collection", ListKey: "smart_collections",
+			ID: func(c *SmartCollection) int64 { return c.ID },
+		}),
+		client: client,
+	}
 }
 
-type smartCollectionOp struct{ client core.Requester }
+type smartCollectionOp struct {
+	*core.ResourceService[SmartCollection]
+	client core.Requester
+}
+
+func (s *smartCollectionOp) SetPublishedAt(ctx context.Context, id int64, t time.Time) (*SmartCollection, error) {
+	return setPublishedAt[SmartCollection](ctx, s.client, "smart_collections", "smart_collection", id, t)
+}
 
 type ManualCollectionService interface {
 	List(ctx context.Context, opts *core.ListOptions) ([]ManualCollection, error)
@@ -47,13 +61,27 @@ type ManualCollectionService interface {
 	Create(ctx context.Context, c ManualCollection) (*ManualCollection, error)
 	Update(ctx context.Context, c ManualCollection) (*ManualCollection, error)
 	Delete(ctx context.Context, id int64) error
+	SetPublishedAt(ctx context.Context, id int64, t time.Time) (*ManualCollection, error)
 }
 
 func NewManualCollectionService(client core.Requester) ManualCollectionService {
-	return &manualCollectionOp{client: client}
+	return &manualCollectionOp{
+		ResourceService: core.NewResourceService(client, core.Resource[ManualCollection]{
+			Path: "custom_collections", Key: "custom_collection", ListKey: "custom_collections",
+			ID: func(c *ManualCollection) int64 { return c.ID },
+		}),
+		client: client,
+	}
 }
 
-type manualCollectionOp struct{ client core.Requester }
+type manualCollectionOp struct {
+	*core.ResourceService[ManualCollection]
+	client core.Requester
+}
+
+func (s *manualCollectionOp) SetPublishedAt(ctx context.Context, id int64, t time.Time) (*ManualCollection, error) {
+	return setPublishedAt[ManualCollection](ctx, s.client, "custom_collections", "custom_collection", id, t)
+}
 
 type Collection struct {
 	ID             int64      `json:"id,omitempty"`
@@ -62,6 +90,7 @@ type Collection struct {
 	BodyHTML       string     `json:"body_html,omitempty"`
 	SortOrder      string     `json:"sort_order,omitempty"`
 	TemplateSuffix string     `json:"template_suffix,omitempty"`
+	SEO            *SEO       `json:"seo,omitempty"`
 	Published      bool       `json:"published,omitempty"`
 	PublishedAt    *time.Time `json:"published_at,omitempty"`
 	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
@@ -74,6 +103,7 @@ type SmartCollection struct {
 	BodyHTML       string           `json:"body_html,omitempty"`
 	SortOrder      string           `json:"sort_order,omitempty"`
 	TemplateSuffix string           `json:"template_suffix,omitempty"`
+	SEO            *SEO             `json:"seo,omitempty"`
 	Published      bool             `json:"published,omitempty"`
 	Disjunctive    bool             `json:"disjunctive,omitempty"`
 	Rules          []CollectionRule `json:"rules,omitempty"`
@@ -88,6 +118,7 @@ type ManualCollection struct {
 	BodyHTML       string     `json:"body_html,omitempty"`
 	SortOrder      string     `json:"sort_order,omitempty"`
 	TemplateSuffix string     `json:"template_suffix,omitempty"`
+	SEO            *SEO       `json:"seo,omitempty"`
 	Published      bool       `json:"published,omitempty"`
 	PublishedAt    *time.Time `json:"published_at,omitempty"`
 	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
@@ -98,103 +129,3 @@ type CollectionRule struct {
 	Relation  string `json:"relation,omitempty"`
 	Condition string `json:"condition,omitempty"`
 }
-
-// JSON wrappers
-type collectionResource struct {
-	Collection *Collection `json:"collection"`
-}
-type collectionsResource struct {
-	Collections []Collection `json:"collections"`
-}
-type smartCollectionResource struct {
-	SmartCollection *SmartCollection `json:"smart_collection"`
-}
-type smartCollectionsResource struct {
-	SmartCollections []SmartCollection `json:"smart_collections"`
-}
-type manualCollectionResource struct {
-	CustomCollection *ManualCollection `json:"custom_collection"`
-}
-type manualCollectionsResource struct {
-	CustomCollections []ManualCollection `json:"custom_collections"`
-}
-
-// === Collection ===
-func (s *collectionOp) List(ctx context.Context, opts *core.ListOptions) ([]Collection, error) {
-	r := &collectionsResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("collections.json"), r, opts)
-	return r.Collections, err
-}
-func (s *collectionOp) Get(ctx context.Context, id int64) (*Collection, error) {
-	r := &collectionResource{}
-	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("collections/%d.json", id)), r, nil)
-	return r.Collection, err
-}
-func (s *collectionOp) Create(ctx context.Context, c Collection) (*Collection, error) {
-	r := &collectionResource{}
-	err := s.client.Post(ctx, s.client.CreatePath("collections.json"), collectionResource{Collection: &c}, r)
-	return r.Collection, err
-}
-func (s *collectionOp) Update(ctx context.Context, c Collection) (*Collection, error) {
-	r := &collectionResource{}
-	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("collections/%d.json", c.ID)), collectionResource{Collection: &c}, r)
-	return r.Collection, err
-}
-func (s *collectionOp) Delete(ctx context.Context, id int64) error {
-	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("collections/%d.json", id)))
-}
-func (s *collectionOp) Count(ctx context.Context) (int, error) {
-	r := &countResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("collections/count.json"), r, nil)
-	return r.Count, err
-}
-
-// === Smart Collection ===
-func (s *smartCollectionOp) List(ctx context.Context, opts *core.ListOptions) ([]SmartCollection, error) {
-	r := &smartCollectionsResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("smart_collections.json"), r, opts)
-	return r.SmartCollections, err
-}
-func (s *smartCollectionOp) Get(ctx context.Context, id int64) (*SmartCollection, error) {
-	r := &smartCollectionResource{}
-	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("smart_collections/%d.json", id)), r, nil)
-	return r.SmartCollection, err
-}
-func (s *smartCollectionOp) Create(ctx context.Context, c SmartCollection) (*SmartCollection, error) {
-	r := &smartCollectionResource{}
-	err := s.client.Post(ctx, s.client.CreatePath("smart_collections.json"), smartCollectionResource{SmartCollection: &c}, r)
-	return r.SmartCollection, err
-}
-func (s *smartCollectionOp) Update(ctx context.Context, c SmartCollection) (*SmartCollection, error) {
-	r := &smartCollectionResource{}
-	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("smart_collections/%d.json", c.ID)), smartCollectionResource{SmartCollection: &c}, r)
-	return r.SmartCollection, err
-}
-func (s *smartCollectionOp) Delete(ctx context.Context, id int64) error {
-	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("smart_collections/%d.json", id)))
-}
-
-// === Manual Collection ===
-func (s *manualCollectionOp) List(ctx context.Context, opts *core.ListOptions) ([]ManualCollection, error) {
-	r := &manualCollectionsResource{}
-	err := s.client.Get(ctx, s.client.CreatePath("custom_collections.json"), r, opts)
-	return r.CustomCollections, err
-}
-func (s *manualCollectionOp) Get(ctx context.Context, id int64) (*ManualCollection, error) {
-	r := &manualCollectionResource{}
-	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("custom_collections/%d.json", id)), r, nil)
-	return r.CustomCollection, err
-}
-func (s *manualCollectionOp) Create(ctx context.Context, c ManualCollection) (*ManualCollection, error) {
-	r := &manualCollectionResource{}
-	err := s.client.Post(ctx, s.client.CreatePath("custom_collections.json"), manualCollectionResource{CustomCollection: &c}, r)
-	return r.CustomCollection, err
-}
-func (s *manualCollectionOp) Update(ctx context.Context, c ManualCollection) (*ManualCollection, error) {
-	r := &manualCollectionResource{}
-	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("custom_collections/%d.json", c.ID)), manualCollectionResource{CustomCollection: &c}, r)
-	return r.CustomCollection, err
-}
-func (s *manualCollectionOp) Delete(ctx context.Context, id int64) error {
-	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("custom_collections/%d.json", id)))
-}
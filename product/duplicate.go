@@ -0,0 +1,169 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// DefaultHandleSuffix is appended to the handle of a duplicated product when
+// DuplicateOptions.HandleSuffix is empty.
+const DefaultHandleSuffix = "-copy"
+
+// maxHandleProbes bounds how many numbered handles Duplicate tries before
+// giving up.
+const maxHandleProbes = 50
+
+// =====================================================================
+// Product Duplication
+// =====================================================================
+
+// DuplicateOptions controls how Duplicate builds the copy. The zero value
+// produces a draft with the same title, the handle suffixed with
+// DefaultHandleSuffix, and all images.
+type DuplicateOptions struct {
+	Title        string // title of the copy; empty keeps the source title
+	HandleSuffix string // appended to the source handle; empty uses DefaultHandleSuffix
+	Status       string // status of the copy; empty uses StatusDraft
+	SKUSuffix    string // appended to every non-empty variant SKU
+	SkipImages   bool   // do not copy images
+}
+
+// Duplicate creates a copy of a product with its options, variants and
+// images. The API has no duplicate endpoint, so the product is fetched and
+// re-created: IDs, timestamps, publication and inventory levels are not
+// copied, and images are re-uploaded from their src. If variants were linked
+// to images, a follow-up update links the new variants to the new images.
+//
+// If the suffixed handle is taken, for example by an earlier copy, a counter
+// is appended ("parka-copy-2", "parka-copy-3", ...) until a free handle is
+// found.
+//
+//	p, err := client.Product.Duplicate(ctx, id, &product.DuplicateOptions{
+//	    Title: "Winter Parka 2026", HandleSuffix: "-2026",
+//	})
+func (s *serviceOp) Duplicate(ctx context.Context, productID int64, opts *DuplicateOptions) (*Product, error) {
+	ctx = core.WithoutFields(ctx) // the copy is built from the whole source
+	if opts == nil {
+		opts = &DuplicateOptions{}
+	}
+	src, err := s.Get(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("product: failed to get product %d: %w", productID, err)
+	}
+
+	dup, imageLinks := duplicateProduct(src, opts)
+	if dup.Handle != "" {
+		if dup.Handle, err = s.freeHandle(ctx, dup.Handle); err != nil {
+			return nil, fmt.Errorf("product: failed to pick a handle for copy of product %d: %w", productID, err)
+		}
+	}
+	created, err := s.Create(ctx, dup)
+	if err != nil {
+		return nil, fmt.Errorf("product: failed to create copy of product %d: %w", productID, err)
+	}
+	if len(imageLinks) == 0 || created == nil {
+		return created, nil
+	}
+
+	// The copy's images and variants come back in the order they were sent.
+	if len(created.Images) != len(dup.Images) || len(created.Variants) != len(dup.Variants) {
+		return created, fmt.Errorf("product: copy %d of product %d was created without its variant images", created.ID, productID)
+	}
+	link := Product{ID: created.ID}
+	for v := range created.Variants {
+		if img, ok := imageLinks[v]; ok {
+			link.Variants = append(link.Variants, Variant{
+				ID:      created.Variants[v].ID,
+				ImageID: created.Images[img].ID,
+			})
+		}
+	}
+	updated, err := s.Update(ctx, link)
+	if err != nil {
+		return created, fmt.Errorf("product: failed to link variant images of copy %d: %w", created.ID, err)
+	}
+	return updated, nil
+}
+
+// freeHandle returns handle if no product uses it yet, or else the first
+// free handle-2, handle-3, ...
+func (s *serviceOp) freeHandle(ctx context.Context, handle string) (string, error) {
+	candidate := handle
+	for n := 2; n <= maxHandleProbes+1; n++ {
+		ok, err := s.IsHandleAvailable(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", handle, n)
+	}
+	return "", fmt.Errorf("no free handle among %q and %d numbered variants", handle, maxHandleProbes)
+}
+
+// duplicateProduct builds the create payload for a copy of src. imageLinks
+// maps the index of each variant that had an image to the index of that
+// image in the payload.
+func duplicateProduct(src *Product, opts *DuplicateOptions) (p Product, imageLinks map[int]int) {
+	p = Product{
+		Title:       src.Title,
+		BodyHTML:    src.BodyHTML,
+		Vendor:      src.Vendor,
+		ProductType: src.ProductType,
+		Tags:        src.Tags,
+		Status:      opts.Status,
+	}
+	if src.SEO != nil {
+		seo := *src.SEO
+		p.SEO = &seo
+	}
+	if opts.Title != "" {
+		p.Title = opts.Title
+	}
+	if p.Status == "" {
+		p.Status = StatusDraft
+	}
+	if src.Handle != "" {
+		suffix := opts.HandleSuffix
+		if suffix == "" {
+			suffix = DefaultHandleSuffix
+		}
+		p.Handle = src.Handle + suffix
+	}
+
+	for _, o := range src.Options {
+		p.Options = append(p.Options, Option{
+			Name:     o.Name,
+			Position: o.Position,
+			Values:   append([]string(nil), o.Values...),
+		})
+	}
+
+	imageIndex := make(map[int64]int)
+	if !opts.SkipImages {
+		for i, img := range src.Images {
+			imageIndex[img.ID] = i
+			p.Images = append(p.Images, Image{Position: img.Position, Src: img.Src, Alt: img.Alt})
+		}
+	}
+
+	for i, v := range src.Variants {
+		if v.SKU != "" {
+			v.SKU += opts.SKUSuffix
+		}
+		if idx, ok := imageIndex[v.ImageID]; ok && v.ImageID != 0 {
+			if imageLinks == nil {
+				imageLinks = make(map[int]int)
+			}
+			imageLinks[i] = idx
+		}
+		v.ID, v.ProductID, v.ImageID = 0, 0, 0
+		v.InventoryItemID, v.InventoryQuantity = 0, 0
+		v.CreatedAt, v.UpdatedAt = nil, nil
+		p.Variants = append(p.Variants, v)
+	}
+	return p, imageLinks
+}
@@ -20,6 +20,8 @@ type InventoryService interface {
 	ListLevels(ctx context.Context, opts *InventoryLevelListOptions) ([]InventoryLevel, error)
 	SetLevel(ctx context.Context, level InventoryLevel) (*InventoryLevel, error)
 	AdjustLevel(ctx context.Context, inventoryItemID, locationID int64, adjustment int) (*InventoryLevel, error)
+
+	CheckAvailability(ctx context.Context, items []VariantQuantity, locationID int64) ([]VariantAvailability, error)
 }
 
 func NewInventoryService(client core.Requester) InventoryService {
@@ -75,7 +77,7 @@ func (s *inventoryOp) ListItems(ctx context.Context, opts *core.ListOptions) ([]
 func (s *inventoryOp) GetItem(ctx context.Context, id int64) (*InventoryItem, error) {
 	r := &inventoryItemResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("inventory_items/%d.json", id)), r, nil)
-	return r.InventoryItem, err
+	return core.Found(r.InventoryItem, err)
 }
 func (s *inventoryOp) UpdateItem(ctx context.Context, item InventoryItem) (*InventoryItem, error) {
 	r := &inventoryItemResource{}
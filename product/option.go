@@ -0,0 +1,256 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Product Option Service
+// =====================================================================
+
+// OptionService edits a product's options (e.g. "Size", "Color") together
+// with the option1..option3 values of its variants, which a plain Update
+// easily leaves inconsistent. Every method loads the product, applies the
+// change to options and variants alike, checks the result with
+// CheckVariantConsistency and only then sends it.
+type OptionService interface {
+	List(ctx context.Context, productID int64) ([]Option, error)
+
+	// Add appends an option; existing variants get value for it.
+	Add(ctx context.Context, productID int64, name, value string) (*Product, error)
+
+	// Rename changes an option's name.
+	Rename(ctx context.Context, productID int64, name, newName string) (*Product, error)
+
+	// Remove drops an option and the variants' values for it. It fails if
+	// variants would become indistinguishable; delete them first.
+	Remove(ctx context.Context, productID int64, name string) (*Product, error)
+
+	// RenameValue changes an option value on the option and its variants.
+	RenameValue(ctx context.Context, productID int64, name, value, newValue string) (*Product, error)
+
+	// ReorderValues sets the order of an option's values; values must list
+	// each existing value exactly once.
+	ReorderValues(ctx context.Context, productID int64, name string, values []string) (*Product, error)
+}
+
+func NewOptionService(client core.Requester) OptionService {
+	return &optionOp{client: client}
+}
+
+type optionOp struct{ client core.Requester }
+
+// CheckVariantConsistency reports the first inconsistency between a
+// product's options and its variants: more than MaxVariantOptions options,
+// duplicate option names or values, a variant value missing from its
+// option or set for a missing option, or two variants with the same values.
+func CheckVariantConsistency(p Product) error {
+	if len(p.Options) > MaxVariantOptions {
+		return fmt.Errorf("product: %d options exceeds the limit of %d", len(p.Options), MaxVariantOptions)
+	}
+	names := make(map[string]bool, len(p.Options))
+	for _, o := range p.Options {
+		if o.Name == "" {
+			return fmt.Errorf("product: option at position %d has no name", o.Position)
+		}
+		if names[o.Name] {
+			return fmt.Errorf("product: duplicate option %q", o.Name)
+		}
+		names[o.Name] = true
+		seen := make(map[string]bool, len(o.Values))
+		for _, v := range o.Values {
+			if seen[v] {
+				return fmt.Errorf("product: option %q has duplicate value %q", o.Name, v)
+			}
+			seen[v] = true
+		}
+	}
+
+	combos := make(map[[MaxVariantOptions]string]int64, len(p.Variants))
+	for _, v := range p.Variants {
+		values := variantOptions(v)
+		for i, value := range values {
+			switch {
+			case i >= len(p.Options) && value != "":
+				return fmt.Errorf("product: variant %d has option%d %q but the product has %d options", v.ID, i+1, value, len(p.Options))
+			case i < len(p.Options) && value == "":
+				return fmt.Errorf("product: variant %d has no value for option %q", v.ID, p.Options[i].Name)
+			case i < len(p.Options) && len(p.Options[i].Values) > 0 && !slices.Contains(p.Options[i].Values, value):
+				return fmt.Errorf("product: variant %d has value %q not listed for option %q", v.ID, value, p.Options[i].Name)
+			}
+		}
+		if other, ok := combos[values]; ok {
+			return fmt.Errorf("product: variants %d and %d have the same option values", other, v.ID)
+		}
+		combos[values] = v.ID
+	}
+	return nil
+}
+
+func variantOptions(v Variant) [MaxVariantOptions]string {
+	return [MaxVariantOptions]string{v.Option1, v.Option2, v.Option3}
+}
+
+func setVariantOptions(v *Variant, values [MaxVariantOptions]string) {
+	v.Option1, v.Option2, v.Option3 = values[0], values[1], values[2]
+}
+
+// optionIndex returns the index of the option called name.
+func optionIndex(p *Product, name string) (int, error) {
+	for i, o := range p.Options {
+		if o.Name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("product: product %d has no option %q", p.ID, name)
+}
+
+func (s *optionOp) List(ctx context.Context, productID int64) ([]Option, error) {
+	p, err := s.load(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	return p.Options, nil
+}
+
+func (s *optionOp) Add(ctx context.Context, productID int64, name, value string) (*Product, error) {
+	return s.edit(ctx, productID, func(p *Product) error {
+		if value == "" {
+			return fmt.Errorf("product: option %q needs a value for existing variants", name)
+		}
+		if len(p.Options) >= MaxVariantOptions {
+			return fmt.Errorf("product: product %d already has %d options", p.ID, MaxVariantOptions)
+		}
+		i := len(p.Options)
+		p.Options = append(p.Options, Option{Name: name, Values: []string{value}})
+		for j := range p.Variants {
+			values := variantOptions(p.Variants[j])
+			values[i] = value
+			setVariantOptions(&p.Variants[j], values)
+		}
+		return nil
+	})
+}
+
+func (s *optionOp) Rename(ctx context.Context, productID int64, name, newName string) (*Product, error) {
+	return s.edit(ctx, productID, func(p *Product) error {
+		i, err := optionIndex(p, name)
+		if err != nil {
+			return err
+		}
+		p.Options[i].Name = newName
+		return nil
+	})
+}
+
+func (s *optionOp) Remove(ctx context.Context, productID int64, name string) (*Product, error) {
+	return s.edit(ctx, productID, func(p *Product) error {
+		i, err := optionIndex(p, name)
+		if err != nil {
+			return err
+		}
+		p.Options = slices.Delete(p.Options, i, i+1)
+		for j := range p.Variants {
+			values := variantOptions(p.Variants[j])
+			copy(values[i:], values[i+1:])
+			values[MaxVariantOptions-1] = ""
+			setVariantOptions(&p.Variants[j], values)
+		}
+		return nil
+	})
+}
+
+func (s *optionOp) RenameValue(ctx context.Context, productID int64, name, value, newValue string) (*Product, error) {
+	return s.edit(ctx, productID, func(p *Product) error {
+		i, err := optionIndex(p, name)
+		if err != nil {
+			return err
+		}
+		k := slices.Index(p.Options[i].Values, value)
+		if k < 0 {
+			return fmt.Errorf("product: option %q has no value %q", name, value)
+		}
+		p.Options[i].Values[k] = newValue
+		for j := range p.Variants {
+			values := variantOptions(p.Variants[j])
+			if values[i] == value {
+				values[i] = newValue
+				setVariantOptions(&p.Variants[j], values)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *optionOp) ReorderValues(ctx context.Context, productID int64, name string, values []string) (*Product, error) {
+	return s.edit(ctx, productID, func(p *Product) error {
+		i, err := optionIndex(p, name)
+		if err != nil {
+			return err
+		}
+		current := slices.Clone(p.Options[i].Values)
+		reordered := slices.Clone(values)
+		slices.Sort(current)
+		slices.Sort(reordered)
+		if !slices.Equal(current, reordered) {
+			return fmt.Errorf("product: new order of option %q must list each of %v once", name, p.Options[i].Values)
+		}
+		p.Options[i].Values = slices.Clone(values)
+		return nil
+	})
+}
+
+// load gets a product, failing if it does not exist.
+func (s *optionOp) load(ctx context.Context, productID int64) (*Product, error) {
+	r := &productResource{}
+	ctx = core.WithoutFields(ctx) // edits need all options and variants
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", productsBasePath, productID)), r, nil)
+	return core.Found(r.Product, err)
+}
+
+// edit applies change to the product's options and variants and sends both
+// if the result is consistent.
+func (s *optionOp) edit(ctx context.Context, productID int64, change func(p *Product) error) (*Product, error) {
+	p, err := s.load(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(p); err != nil {
+		return nil, err
+	}
+	if err := CheckVariantConsistency(*p); err != nil {
+		return nil, err
+	}
+
+	// Options and variant values are sent explicitly: unset option slots
+	// must be null, which omitempty on Variant would drop.
+	options := make([]map[string]interface{}, len(p.Options))
+	for i, o := range p.Options {
+		options[i] = map[string]interface{}{"name": o.Name, "position": i + 1, "values": o.Values}
+		if o.ID != 0 {
+			options[i]["id"] = o.ID
+		}
+	}
+	variants := make([]map[string]interface{}, len(p.Variants))
+	for i, v := range p.Variants {
+		variant := map[string]interface{}{"id": v.ID}
+		for k, value := range variantOptions(v) {
+			if value == "" {
+				variant[fmt.Sprintf("option%d", k+1)] = nil
+			} else {
+				variant[fmt.Sprintf("option%d", k+1)] = value
+			}
+		}
+		variants[i] = variant
+	}
+	body := map[string]interface{}{
+		"product": map[string]interface{}{"id": p.ID, "options": options, "variants": variants},
+	}
+	r := &productResource{}
+	err = s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", productsBasePath, p.ID)), body, r)
+	return r.Product, err
+}
@@ -0,0 +1,126 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// optionTestProduct has options Color (Red, Blue) and Size (S) with two
+// variants.
+func optionTestProduct() Product {
+	return Product{
+		ID: 1,
+		Options: []Option{
+			{ID: 10, Name: "Color", Values: []string{"Red", "Blue"}},
+			{ID: 11, Name: "Size", Values: []string{"S"}},
+		},
+		Variants: []Variant{
+			{ID: 100, Option1: "Red", Option2: "S"},
+			{ID: 101, Option1: "Blue", Option2: "S"},
+		},
+	}
+}
+
+// newOptionServer serves optionTestProduct and records the update body.
+func newOptionServer(t *testing.T, sent *map[string]map[string]json.RawMessage) (OptionService, func()) {
+	client, closeFn := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			json.NewDecoder(r.Body).Decode(sent)
+			w.Write([]byte(`{"product":{"id":1}}`))
+			return
+		}
+		p := optionTestProduct()
+		json.NewEncoder(w).Encode(productResource{Product: &p})
+	})
+	return NewOptionService(client), closeFn
+}
+
+func TestOptionService_RemoveClearsVariantSlot(t *testing.T) {
+	var sent map[string]map[string]json.RawMessage
+	svc, closeFn := newOptionServer(t, &sent)
+	defer closeFn()
+
+	if _, err := svc.Remove(context.Background(), 1, "Size"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	variants := string(sent["product"]["variants"])
+	if !strings.Contains(variants, `"option2":null`) || !strings.Contains(variants, `"option1":"Blue"`) {
+		t.Errorf("expected option2 cleared on variants, got %s", variants)
+	}
+	if options := string(sent["product"]["options"]); strings.Contains(options, "Size") {
+		t.Errorf("expected Size to be removed, got %s", options)
+	}
+}
+
+func TestOptionService_RemoveRejectsDuplicateVariants(t *testing.T) {
+	var sent map[string]map[string]json.RawMessage
+	svc, closeFn := newOptionServer(t, &sent)
+	defer closeFn()
+
+	_, err := svc.Remove(context.Background(), 1, "Color")
+	if err == nil || !strings.Contains(err.Error(), "same option values") {
+		t.Errorf("expected duplicate variant error, got %v", err)
+	}
+	if sent != nil {
+		t.Error("expected nothing to be sent")
+	}
+}
+
+func TestOptionService_AddAndRenameValue(t *testing.T) {
+	var sent map[string]map[string]json.RawMessage
+	svc, closeFn := newOptionServer(t, &sent)
+	defer closeFn()
+
+	if _, err := svc.Add(context.Background(), 1, "Material", "Cotton"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variants := string(sent["product"]["variants"]); strings.Count(variants, `"option3":"Cotton"`) != 2 {
+		t.Errorf("expected every variant to get Cotton, got %s", variants)
+	}
+
+	sent = nil
+	if _, err := svc.RenameValue(context.Background(), 1, "Color", "Red", "Crimson"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if variants := string(sent["product"]["variants"]); !strings.Contains(variants, `"option1":"Crimson"`) {
+		t.Errorf("expected variant value renamed, got %s", variants)
+	}
+	if _, err := svc.RenameValue(context.Background(), 1, "Color", "Red", "Blue"); err == nil {
+		t.Error("expected renaming onto an existing value to fail")
+	}
+}
+
+func TestOptionService_ReorderValues(t *testing.T) {
+	var sent map[string]map[string]json.RawMessage
+	svc, closeFn := newOptionServer(t, &sent)
+	defer closeFn()
+
+	if _, err := svc.ReorderValues(context.Background(), 1, "Color", []string{"Red"}); err == nil {
+		t.Error("expected incomplete value list to fail")
+	}
+	if _, err := svc.ReorderValues(context.Background(), 1, "Color", []string{"Blue", "Red"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if options := string(sent["product"]["options"]); !strings.Contains(options, `["Blue","Red"]`) {
+		t.Errorf("expected reordered values, got %s", options)
+	}
+}
+
+func TestCheckVariantConsistency(t *testing.T) {
+	p := optionTestProduct()
+	if err := CheckVariantConsistency(p); err != nil {
+		t.Fatalf("expected consistent product, got %v", err)
+	}
+	p.Variants[1].Option1 = "Green"
+	if err := CheckVariantConsistency(p); err == nil || !strings.Contains(err.Error(), "not listed") {
+		t.Errorf("expected unlisted value error, got %v", err)
+	}
+	p = optionTestProduct()
+	p.Variants[0].Option3 = "Extra"
+	if err := CheckVariantConsistency(p); err == nil {
+		t.Error("expected error for value of a missing option")
+	}
+}
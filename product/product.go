@@ -21,6 +21,12 @@ type Service interface {
 	Create(ctx context.Context, p Product) (*Product, error)
 	Update(ctx context.Context, p Product) (*Product, error)
 	Delete(ctx context.Context, id int64) error
+	Duplicate(ctx context.Context, productID int64, opts *DuplicateOptions) (*Product, error)
+	SetPublishedAt(ctx context.Context, id int64, t time.Time) (*Product, error)
+	IsHandleAvailable(ctx context.Context, handle string) (bool, error)
+	SetStatus(ctx context.Context, id int64, status string) (*Product, error)
+	SetStatuses(ctx context.Context, ids []int64, status string) ([]StatusResult, error)
+	BatchUpsert(ctx context.Context, products []Product, opts BatchOpts) ([]UpsertResult, error)
 }
 
 func NewService(client core.Requester) Service {
@@ -38,6 +44,7 @@ type Product struct {
 	Handle      string     `json:"handle,omitempty"`
 	Status      string     `json:"status,omitempty"`
 	Tags        string     `json:"tags,omitempty"`
+	SEO         *SEO       `json:"seo,omitempty"`
 	Variants    []Variant  `json:"variants,omitempty"`
 	Options     []Option   `json:"options,omitempty"`
 	Images      []Image    `json:"images,omitempty"`
@@ -47,6 +54,13 @@ type Product struct {
 	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 
+// SEO is the search engine title and description of a product or
+// collection. Empty fields fall back to the title and description.
+type SEO struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
 type Variant struct {
 	ID                  int64      `json:"id,omitempty"`
 	ProductID           int64      `json:"product_id,omitempty"`
@@ -101,6 +115,10 @@ type productResource struct {
 type productsResource struct {
 	Products []Product `json:"products"`
 }
+type handleOptions struct {
+	Handle string `url:"handle"`
+	Fields string `url:"fields,omitempty"`
+}
 type countResource struct {
 	Count int `json:"count"`
 }
@@ -118,7 +136,7 @@ func (s *serviceOp) Count(ctx context.Context, opts *core.CountOptions) (int, er
 func (s *serviceOp) Get(ctx context.Context, id int64) (*Product, error) {
 	r := &productResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", productsBasePath, id)), r, nil)
-	return r.Product, err
+	return core.Found(r.Product, err)
 }
 func (s *serviceOp) Create(ctx context.Context, p Product) (*Product, error) {
 	r := &productResource{}
@@ -133,3 +151,30 @@ func (s *serviceOp) Update(ctx context.Context, p Product) (*Product, error) {
 func (s *serviceOp) Delete(ctx context.Context, id int64) error {
 	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", productsBasePath, id)))
 }
+
+// SetPublishedAt sets when the product becomes visible. A future t schedules
+// publication; the zero time unpublishes the product.
+func (s *serviceOp) SetPublishedAt(ctx context.Context, id int64, t time.Time) (*Product, error) {
+	return setPublishedAt[Product](ctx, s.client, productsBasePath, "product", id, t)
+}
+
+// IsHandleAvailable reports whether no product uses handle yet, so a Create
+// or Update with it will not fail with a duplicate-handle error. The check is
+// not atomic: another client may still take the handle first.
+func (s *serviceOp) IsHandleAvailable(ctx context.Context, handle string) (bool, error) {
+	if handle == "" {
+		return false, fmt.Errorf("product: handle is required")
+	}
+	r := &productsResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(productsBasePath+".json"), r, &handleOptions{Handle: handle, Fields: "id,handle"})
+	if err != nil {
+		return false, err
+	}
+	// Filters may match loosely; only an exact handle is a conflict.
+	for _, p := range r.Products {
+		if p.Handle == handle {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,214 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for product tests.
+type mockRequester struct {
+	server     *httptest.Server
+	apiVersion string
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv, apiVersion: "v20251201"}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/" + m.apiVersion + "/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	if fields := core.SelectedFields(ctx); len(fields) > 0 {
+		path += "?fields=" + strings.Join(fields, ",")
+	}
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestDuplicate(t *testing.T) {
+	var created, linked Product
+	probes := 0
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/products/1.json"):
+			json.NewEncoder(w).Encode(productResource{Product: &Product{
+				ID: 1, Title: "Parka", Handle: "parka", Status: StatusActive,
+				SEO:     &SEO{Title: "Warm parka", Description: "Our warmest parka."},
+				Options: []Option{{ID: 5, ProductID: 1, Name: "Size", Values: []string{"S", "M"}}},
+				Variants: []Variant{
+					{ID: 11, ProductID: 1, Option1: "S", SKU: "PARKA-S", ImageID: 21, InventoryQuantity: 4},
+					{ID: 12, ProductID: 1, Option1: "M", SKU: "PARKA-M"},
+				},
+				Images: []Image{{ID: 20, Src: "https://cdn.example.com/a.jpg"}, {ID: 21, Src: "https://cdn.example.com/b.jpg"}},
+			}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/products.json"):
+			// An earlier copy already took the default handle.
+			probes++
+			json.NewEncoder(w).Encode(productsResource{Products: []Product{{ID: 3, Handle: "parka-copy"}}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/products.json"):
+			req := productResource{}
+			json.NewDecoder(r.Body).Decode(&req)
+			created = *req.Product
+			p := *req.Product
+			p.ID = 2
+			p.Variants = []Variant{{ID: 31, Option1: "S"}, {ID: 32, Option1: "M"}}
+			p.Images = []Image{{ID: 40, Src: "a"}, {ID: 41, Src: "b"}}
+			json.NewEncoder(w).Encode(productResource{Product: &p})
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/products/2.json"):
+			req := productResource{}
+			json.NewDecoder(r.Body).Decode(&req)
+			linked = *req.Product
+			json.NewEncoder(w).Encode(productResource{Product: &Product{ID: 2, Title: "Parka 2026"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	p, err := NewService(mock).Duplicate(context.Background(), 1, &DuplicateOptions{Title: "Parka 2026", SKUSuffix: "-26"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.ID != 2 {
+		t.Errorf("expected copy 2, got %d", p.ID)
+	}
+	if created.Title != "Parka 2026" || created.Handle != "parka-copy-2" || created.Status != StatusDraft {
+		t.Errorf("unexpected copy: %+v", created)
+	}
+	if created.SEO == nil || created.SEO.Title != "Warm parka" || created.SEO.Description != "Our warmest parka." {
+		t.Errorf("expected SEO copied, got %+v", created.SEO)
+	}
+	if probes != 2 {
+		t.Errorf("expected 2 handle probes, got %d", probes)
+	}
+	if len(created.Images) != 2 || created.Images[1].ID != 0 || created.Images[1].Src != "https://cdn.example.com/b.jpg" {
+		t.Errorf("unexpected images: %+v", created.Images)
+	}
+	if v := created.Variants[0]; v.ID != 0 || v.ImageID != 0 || v.InventoryQuantity != 0 || v.SKU != "PARKA-S-26" {
+		t.Errorf("unexpected variant: %+v", v)
+	}
+	if created.Options[0].ID != 0 || created.Options[0].ProductID != 0 {
+		t.Errorf("option IDs should be cleared: %+v", created.Options[0])
+	}
+	if len(linked.Variants) != 1 || linked.Variants[0].ID != 31 || linked.Variants[0].ImageID != 41 {
+		t.Errorf("unexpected image links: %+v", linked.Variants)
+	}
+}
+
+func TestIsHandleAvailable(t *testing.T) {
+	taken := "blue-shirt"
+	client, closeFn := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		products := []Product{{ID: 1, Handle: "blue-shirt-2"}}
+		if taken != "" {
+			products = append(products, Product{ID: 2, Handle: taken})
+		}
+		json.NewEncoder(w).Encode(productsResource{Products: products})
+	})
+	defer closeFn()
+	svc := NewService(client)
+
+	if ok, err := svc.IsHandleAvailable(context.Background(), "blue-shirt"); err != nil || ok {
+		t.Errorf("expected taken handle, got %v, %v", ok, err)
+	}
+	taken = ""
+	if ok, err := svc.IsHandleAvailable(context.Background(), "blue-shirt"); err != nil || !ok {
+		t.Errorf("expected available handle despite similar handles, got %v, %v", ok, err)
+	}
+	if _, err := svc.IsHandleAvailable(context.Background(), ""); err == nil {
+		t.Error("expected error for empty handle")
+	}
+}
+
+func TestProductSEO(t *testing.T) {
+	data, _ := json.Marshal(Product{Title: "Shirt", SEO: &SEO{Title: "Blue Shirt | Shop"}})
+	if !strings.Contains(string(data), `"seo":{"title":"Blue Shirt | Shop"}`) {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	statuses := map[string]string{"1": StatusDraft, "2": StatusArchived, "3": StatusActive}
+	var puts []string
+	client, closeFn := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], ".json")
+		if r.Method == http.MethodPut {
+			var body productResource
+			json.NewDecoder(r.Body).Decode(&body)
+			puts = append(puts, id+":"+body.Product.Status)
+			json.NewEncoder(w).Encode(body)
+			return
+		}
+		if statuses[id] == "" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		if r.URL.Query().Get("fields") != "" {
+			w.Write([]byte(`{"product":{"id":` + id + `,"status":"` + statuses[id] + `"}}`))
+			return
+		}
+		w.Write([]byte(`{"product":{"id":` + id + `,"title":"Parka","status":"` + statuses[id] + `"}}`))
+	})
+	defer closeFn()
+	svc := NewService(client)
+	ctx := context.Background()
+
+	if p, err := svc.SetStatus(ctx, 1, StatusActive); err != nil || p.Status != StatusActive {
+		t.Errorf("expected draft product to be activated, got %+v, %v", p, err)
+	}
+	if _, err := svc.SetStatus(ctx, 2, StatusActive); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("expected archived to active to be rejected, got %v", err)
+	}
+	if _, err := svc.SetStatus(ctx, 1, "deleted"); err == nil {
+		t.Error("expected unknown status to be rejected")
+	}
+
+	results, err := svc.SetStatuses(ctx, []int64{1, 2, 3, 4}, StatusArchived)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if results[1].Err != nil || results[1].Product.Status != StatusArchived || results[1].Product.Title != "Parka" {
+		t.Errorf("expected already archived product to be returned whole, got %+v", results[1])
+	}
+	if results[3].Err == nil {
+		t.Error("expected missing product to fail")
+	}
+	want := "1:active,1:archived,3:archived"
+	if got := strings.Join(puts, ","); got != want {
+		t.Errorf("expected writes %s, got %s", want, got)
+	}
+}
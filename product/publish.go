@@ -0,0 +1,256 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// DefaultSchedulerInterval is used by Scheduler.Run when interval is not
+// positive.
+const DefaultSchedulerInterval = time.Minute
+
+// setPublishedAt sends published_at for a single resource, as null when t is
+// zero so the resource is unpublished.
+func setPublishedAt[T any](ctx context.Context, client core.Requester, path, key string, id int64, t time.Time) (*T, error) {
+	var at *time.Time
+	if !t.IsZero() {
+		at = &t
+	}
+	body := map[string]interface{}{
+		key: map[string]interface{}{"id": id, "published_at": at},
+	}
+	r := map[string]*T{}
+	err := client.Put(ctx, client.CreatePath(fmt.Sprintf("%s/%d.json", path, id)), body, &r)
+	return r[key], err
+}
+
+// =====================================================================
+// Publication Scheduler
+// =====================================================================
+
+// PublishableKind identifies the resource a Publication applies to.
+type PublishableKind string
+
+const (
+	KindProduct          PublishableKind = "product"
+	KindSmartCollection  PublishableKind = "smart_collection"
+	KindManualCollection PublishableKind = "custom_collection"
+)
+
+// Publication is a scheduled publish and/or unpublish of one resource.
+type Publication struct {
+	Kind        PublishableKind
+	ID          int64
+	PublishAt   time.Time // zero leaves the current publication untouched
+	UnpublishAt time.Time // zero never unpublishes
+}
+
+// next returns the earliest time at which the Scheduler still has work for p.
+func (p Publication) next(published bool) time.Time {
+	if !p.PublishAt.IsZero() && !published {
+		return p.PublishAt
+	}
+	return p.UnpublishAt
+}
+
+type publicationKey struct {
+	kind PublishableKind
+	id   int64
+}
+
+type scheduledPublication struct {
+	Publication
+	published bool // PublishAt has been sent to the API
+}
+
+// Scheduler keeps the publish times of products and collections in line with
+// a merchandising calendar. Publish times are sent to the API as soon as the
+// Scheduler runs, since the platform publishes at a future published_at by
+// itself; unpublishing has no such support, so it is done by RunOnce once
+// UnpublishAt has passed. Schedules live in memory only: re-schedule them
+// after a restart (Schedule is idempotent).
+//
+//	s := product.NewScheduler(client.Product, client.SmartCollection, client.ManualCollection)
+//	s.Schedule(product.Publication{Kind: product.KindProduct, ID: id,
+//	    PublishAt: blackFriday, UnpublishAt: cyberMonday.Add(24 * time.Hour)})
+//	go s.Run(ctx, time.Minute, func(err error) { log.Printf("publish scheduler: %v", err) })
+type Scheduler struct {
+	products Service
+	smart    SmartCollectionService
+	manual   ManualCollectionService
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[publicationKey]*scheduledPublication
+}
+
+// NewScheduler creates a Scheduler. Services for kinds that are never
+// scheduled may be nil.
+func NewScheduler(products Service, smart SmartCollectionService, manual ManualCollectionService) *Scheduler {
+	return &Scheduler{
+		products: products,
+		smart:    smart,
+		manual:   manual,
+		now:      time.Now,
+		entries:  make(map[publicationKey]*scheduledPublication),
+	}
+}
+
+// Schedule adds p, replacing any schedule for the same resource. The new
+// PublishAt is sent on the next run even if an earlier one was already sent.
+func (s *Scheduler) Schedule(p Publication) error {
+	if p.PublishAt.IsZero() && p.UnpublishAt.IsZero() {
+		return fmt.Errorf("product: publication of %s %d has neither a publish nor an unpublish time", p.Kind, p.ID)
+	}
+	if !p.PublishAt.IsZero() && !p.UnpublishAt.IsZero() && !p.UnpublishAt.After(p.PublishAt) {
+		return fmt.Errorf("product: %s %d would be unpublished before it is published", p.Kind, p.ID)
+	}
+	if !s.supports(p.Kind) {
+		return fmt.Errorf("product: scheduler has no service for %q", p.Kind)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[publicationKey{p.Kind, p.ID}] = &scheduledPublication{Publication: p}
+	return nil
+}
+
+// Cancel removes the schedule of a resource. A publish time already sent to
+// the API is not reverted.
+func (s *Scheduler) Cancel(kind PublishableKind, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, publicationKey{kind, id})
+}
+
+// Pending returns the schedules with work left, soonest first.
+func (s *Scheduler) Pending() []Publication {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]*scheduledPublication, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		ni, nj := entries[i].next(entries[i].published), entries[j].next(entries[j].published)
+		if !ni.Equal(nj) {
+			return ni.Before(nj)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	pending := make([]Publication, len(entries))
+	for i, e := range entries {
+		pending[i] = e.Publication
+	}
+	return pending
+}
+
+// RunOnce sends publish times not yet sent and unpublishes resources whose
+// UnpublishAt has passed, returning how many API updates were made. Schedules
+// with nothing left to do are dropped. A failed update is retried on the next
+// run; the first error is returned after all schedules were tried.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	due := make([]scheduledPublication, 0, len(s.entries))
+	for _, e := range s.entries {
+		due = append(due, *e)
+	}
+	s.mu.Unlock()
+
+	now := s.now()
+	updated := 0
+	var firstErr error
+	for _, e := range due {
+		if err := ctx.Err(); err != nil {
+			return updated, err
+		}
+		var err error
+		switch {
+		case !e.UnpublishAt.IsZero() && !now.Before(e.UnpublishAt):
+			if err = s.setPublishedAt(ctx, e.Kind, e.ID, time.Time{}); err == nil {
+				s.finish(e, false)
+			}
+		case !e.PublishAt.IsZero() && !e.published:
+			if err = s.setPublishedAt(ctx, e.Kind, e.ID, e.PublishAt); err == nil {
+				s.finish(e, !e.UnpublishAt.IsZero())
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("product: failed to update publication of %s %d: %w", e.Kind, e.ID, err)
+			}
+			continue
+		}
+		updated++
+	}
+	return updated, firstErr
+}
+
+// Run calls RunOnce every interval until ctx is cancelled. Errors are
+// reported to onError, which may be nil, and retried on the next tick.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		interval = DefaultSchedulerInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunOnce(ctx); err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish records a completed step of e. The schedule is kept, marked as
+// published, if keep is set; otherwise it is dropped. Schedules replaced
+// since RunOnce started are left alone.
+func (s *Scheduler) finish(e scheduledPublication, keep bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := publicationKey{e.Kind, e.ID}
+	cur, ok := s.entries[key]
+	if !ok || cur.Publication != e.Publication {
+		return
+	}
+	if keep {
+		cur.published = true
+		return
+	}
+	delete(s.entries, key)
+}
+
+func (s *Scheduler) supports(kind PublishableKind) bool {
+	switch kind {
+	case KindProduct:
+		return s.products != nil
+	case KindSmartCollection:
+		return s.smart != nil
+	case KindManualCollection:
+		return s.manual != nil
+	}
+	return false
+}
+
+func (s *Scheduler) setPublishedAt(ctx context.Context, kind PublishableKind, id int64, t time.Time) error {
+	var err error
+	switch kind {
+	case KindProduct:
+		_, err = s.products.SetPublishedAt(ctx, id, t)
+	case KindSmartCollection:
+		_, err = s.smart.SetPublishedAt(ctx, id, t)
+	case KindManualCollection:
+		_, err = s.manual.SetPublishedAt(ctx, id, t)
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetPublishedAt_Unpublish(t *testing.T) {
+	var body map[string]map[string]interface{}
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/custom_collections/7.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"custom_collection":{"id":7,"title":"Summer"}}`))
+	})
+	defer close()
+
+	c, err := NewManualCollectionService(mock).SetPublishedAt(context.Background(), 7, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c == nil || c.ID != 7 {
+		t.Fatalf("unexpected collection: %+v", c)
+	}
+	at, ok := body["custom_collection"]["published_at"]
+	if !ok || at != nil {
+		t.Errorf("expected published_at null, got %v", body)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	var sent []string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]+"="+asString(body["product"]["published_at"]))
+		w.Write([]byte(`{"product":{"id":1}}`))
+	})
+	defer close()
+
+	start := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	now := start
+	s := NewScheduler(NewService(mock), nil, nil)
+	s.now = func() time.Time { return now }
+
+	if err := s.Schedule(Publication{Kind: KindSmartCollection, ID: 2, PublishAt: start}); err == nil {
+		t.Error("expected error for a kind without service")
+	}
+	if err := s.Schedule(Publication{Kind: KindProduct, ID: 1, PublishAt: start, UnpublishAt: start}); err == nil {
+		t.Error("expected error for unpublish before publish")
+	}
+	err := s.Schedule(Publication{Kind: KindProduct, ID: 1, PublishAt: start.Add(time.Hour), UnpublishAt: start.Add(72 * time.Hour)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, step := range []struct {
+		advance time.Duration
+		updated int
+	}{{0, 1}, {time.Hour, 0}, {72 * time.Hour, 1}} {
+		now = start.Add(step.advance)
+		n, err := s.RunOnce(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n != step.updated {
+			t.Errorf("at +%v: expected %d updates, got %d", step.advance, step.updated, n)
+		}
+	}
+	want := []string{"1.json=2026-11-27T01:00:00Z", "1.json=null"}
+	if strings.Join(sent, " ") != strings.Join(want, " ") {
+		t.Errorf("expected requests %v, got %v", want, sent)
+	}
+	if p := s.Pending(); len(p) != 0 {
+		t.Errorf("expected no pending publications, got %+v", p)
+	}
+}
+
+func asString(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	s, _ := v.(string)
+	return s
+}
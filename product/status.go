@@ -0,0 +1,95 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Product statuses.
+const (
+	StatusActive   = "active"
+	StatusDraft    = "draft"
+	StatusArchived = "archived"
+)
+
+// ErrInvalidTransition is returned by SetStatus for a status change the
+// product lifecycle does not allow.
+var ErrInvalidTransition = errors.New("product: invalid status transition")
+
+// transitions lists the statuses each status may change to. An archived
+// product goes back to draft before it can be sold again, so it is reviewed
+// first.
+var transitions = map[string][]string{
+	StatusDraft:    {StatusActive, StatusArchived},
+	StatusActive:   {StatusDraft, StatusArchived},
+	StatusArchived: {StatusDraft},
+}
+
+// CanTransition reports whether a product may change from status from to
+// status to. Staying in the same status is always allowed.
+func CanTransition(from, to string) bool {
+	if from == to {
+		_, known := transitions[to]
+		return known
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusResult is the outcome of changing one product in SetStatuses.
+type StatusResult struct {
+	ID      int64
+	Product *Product // nil on error
+	Err     error
+}
+
+// SetStatus moves a product to status, one of StatusActive, StatusDraft and
+// StatusArchived. The current status is read first, and a change that
+// CanTransition rejects fails with ErrInvalidTransition without writing
+// anything. A product already in status is returned as it is, read in full
+// like the product an actual change returns.
+func (s *serviceOp) SetStatus(ctx context.Context, id int64, status string) (*Product, error) {
+	if _, ok := transitions[status]; !ok {
+		return nil, fmt.Errorf("product: unknown status %q", status)
+	}
+	current, err := s.Get(core.WithFields(ctx, "id", "status"), id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == status {
+		return s.Get(core.WithoutFields(ctx), id)
+	}
+	if !CanTransition(current.Status, status) {
+		return nil, fmt.Errorf("%w: product %d from %q to %q", ErrInvalidTransition, id, current.Status, status)
+	}
+	body := productResource{Product: &Product{ID: id, Status: status}}
+	r := &productResource{}
+	err = s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("%s/%d.json", productsBasePath, id)), body, r)
+	return r.Product, err
+}
+
+// SetStatuses calls SetStatus for each product in turn and returns one
+// result per ID, in order; a failed product does not stop the others. The
+// error is non-nil only when ctx ends first, in which case the remaining
+// products have ctx's error as their Err.
+func (s *serviceOp) SetStatuses(ctx context.Context, ids []int64, status string) ([]StatusResult, error) {
+	results := make([]StatusResult, len(ids))
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(ids); j++ {
+				results[j] = StatusResult{ID: ids[j], Err: err}
+			}
+			return results, fmt.Errorf("product: status change stopped after %d of %d products: %w", i, len(ids), err)
+		}
+		p, err := s.SetStatus(ctx, id, status)
+		results[i] = StatusResult{ID: id, Product: p, Err: err}
+	}
+	return results, nil
+}
@@ -0,0 +1,160 @@
+package product
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Platform limits on product variants.
+const (
+	MaxVariantOptions = 3   // option1..option3
+	MaxVariants       = 100 // variants per product
+)
+
+// =====================================================================
+// Variant Matrix
+// =====================================================================
+
+// VariantTemplate holds the fields copied onto every generated variant.
+type VariantTemplate struct {
+	Price               string
+	CompareAtPrice      string
+	Grams               int
+	Weight              float64
+	WeightUnit          string
+	InventoryManagement string
+	InventoryPolicy     string
+	RequiresShipping    bool
+	Taxable             bool
+
+	// SKUPattern builds each variant's SKU. Placeholders {option1}, {option2}
+	// and {option3} are replaced by the variant's option values (upper-cased,
+	// spaces as "-") and {index} by its 1-based position, e.g.
+	// "TEE-{option1}-{option2}" → "TEE-RED-XL". Empty leaves SKU unset.
+	SKUPattern string
+
+	// OptionOrder fixes the order of option names (option1, option2, ...).
+	// Names not listed follow in alphabetical order.
+	OptionOrder []string
+}
+
+// BuildVariants generates the cartesian product of options, returning the
+// product's Options and one Variant per combination. Option values keep their
+// given order, so the first value of every option forms the first variant.
+//
+//	opts, variants, err := product.BuildVariants(map[string][]string{
+//	    "Color": {"Red", "Blue"},
+//	    "Size":  {"S", "M", "L"},
+//	}, product.VariantTemplate{Price: "19.99", SKUPattern: "TEE-{option1}-{option2}"})
+//
+// It fails before anything is sent if the platform's option or variant limits
+// would be exceeded, or if an option has no values or duplicate values.
+func BuildVariants(options map[string][]string, base VariantTemplate) ([]Option, []Variant, error) {
+	if len(options) == 0 {
+		return nil, nil, fmt.Errorf("product: no options given")
+	}
+	if len(options) > MaxVariantOptions {
+		return nil, nil, fmt.Errorf("product: %d options exceeds the limit of %d", len(options), MaxVariantOptions)
+	}
+
+	names := optionNames(options, base.OptionOrder)
+	total := 1
+	for _, name := range names {
+		values := options[name]
+		if len(values) == 0 {
+			return nil, nil, fmt.Errorf("product: option %q has no values", name)
+		}
+		seen := make(map[string]bool, len(values))
+		for _, v := range values {
+			if v == "" {
+				return nil, nil, fmt.Errorf("product: option %q has an empty value", name)
+			}
+			if seen[v] {
+				return nil, nil, fmt.Errorf("product: option %q has duplicate value %q", name, v)
+			}
+			seen[v] = true
+		}
+		total *= len(values)
+		if total > MaxVariants {
+			return nil, nil, fmt.Errorf("product: options produce more than %d variants", MaxVariants)
+		}
+	}
+
+	opts := make([]Option, len(names))
+	for i, name := range names {
+		opts[i] = Option{Name: name, Position: i + 1, Values: options[name]}
+	}
+
+	variants := make([]Variant, 0, total)
+	combo := make([]string, len(names))
+	var walk func(depth int)
+	walk = func(depth int) {
+		if depth == len(names) {
+			variants = append(variants, newVariant(base, combo, len(variants)+1))
+			return
+		}
+		for _, v := range options[names[depth]] {
+			combo[depth] = v
+			walk(depth + 1)
+		}
+	}
+	walk(0)
+	return opts, variants, nil
+}
+
+// optionNames orders option names: those in order first, then the rest sorted.
+func optionNames(options map[string][]string, order []string) []string {
+	names := make([]string, 0, len(options))
+	used := make(map[string]bool, len(options))
+	for _, name := range order {
+		if _, ok := options[name]; ok && !used[name] {
+			names = append(names, name)
+			used[name] = true
+		}
+	}
+	var rest []string
+	for name := range options {
+		if !used[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
+func newVariant(base VariantTemplate, combo []string, index int) Variant {
+	v := Variant{
+		Title:               strings.Join(combo, " / "),
+		Price:               base.Price,
+		CompareAtPrice:      base.CompareAtPrice,
+		Position:            index,
+		Grams:               base.Grams,
+		Weight:              base.Weight,
+		WeightUnit:          base.WeightUnit,
+		InventoryManagement: base.InventoryManagement,
+		InventoryPolicy:     base.InventoryPolicy,
+		RequiresShipping:    base.RequiresShipping,
+		Taxable:             base.Taxable,
+	}
+	slots := []*string{&v.Option1, &v.Option2, &v.Option3}
+	for i, val := range combo {
+		*slots[i] = val
+	}
+	if base.SKUPattern != "" {
+		r := strings.NewReplacer(
+			"{option1}", skuPart(v.Option1),
+			"{option2}", skuPart(v.Option2),
+			"{option3}", skuPart(v.Option3),
+			"{index}", strconv.Itoa(index),
+		)
+		v.SKU = r.Replace(base.SKUPattern)
+	}
+	return v
+}
+
+// skuPart normalizes an option value for use in a SKU.
+func skuPart(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), "-"))
+}
@@ -0,0 +1,54 @@
+package product
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildVariants(t *testing.T) {
+	opts, variants, err := BuildVariants(map[string][]string{
+		"Size":  {"S", "M", "L"},
+		"Color": {"Navy Blue", "Red"},
+	}, VariantTemplate{
+		Price:       "19.99",
+		SKUPattern:  "TEE-{option1}-{option2}",
+		OptionOrder: []string{"Color"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(opts) != 2 || opts[0].Name != "Color" || opts[1].Name != "Size" || opts[1].Position != 2 {
+		t.Fatalf("unexpected options: %+v", opts)
+	}
+	if len(variants) != 6 {
+		t.Fatalf("expected 6 variants, got %d", len(variants))
+	}
+	first := variants[0]
+	if first.Option1 != "Navy Blue" || first.Option2 != "S" || first.Title != "Navy Blue / S" {
+		t.Errorf("unexpected first variant: %+v", first)
+	}
+	if first.SKU != "TEE-NAVY-BLUE-S" {
+		t.Errorf("expected SKU TEE-NAVY-BLUE-S, got %q", first.SKU)
+	}
+	if last := variants[5]; last.SKU != "TEE-RED-L" || last.Position != 6 || last.Price != "19.99" {
+		t.Errorf("unexpected last variant: %+v", last)
+	}
+}
+
+func TestBuildVariants_Limits(t *testing.T) {
+	many := make([]string, 11)
+	for i := range many {
+		many[i] = strings.Repeat("x", i+1)
+	}
+	cases := map[string]map[string][]string{
+		"too many options":  {"a": {"1"}, "b": {"1"}, "c": {"1"}, "d": {"1"}},
+		"too many variants": {"a": many, "b": many},
+		"empty option":      {"a": {}},
+		"duplicate value":   {"a": {"1", "1"}},
+	}
+	for name, options := range cases {
+		if _, _, err := BuildVariants(options, VariantTemplate{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package shopline
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	queryEncoderType = reflect.TypeOf((*core.QueryEncoder)(nil)).Elem()
+)
+
+// buildQueryString converts a struct with `url` tags to a query string,
+// following github.com/google/go-querystring semantics:
+//
+//   - `url:"name,omitempty"` skips zero values, empty slices and nil pointers
+//   - pointers and interfaces are dereferenced; a nil one encodes as ""
+//     unless omitempty is set
+//   - time.Time encodes as RFC 3339; add "unix" or "unixmilli" for epochs
+//   - bools encode as true/false; add "int" for 1/0
+//   - slices and arrays repeat the key (ids=1&ids=2); add "comma" for
+//     ids=1,2 or "brackets" for ids[]=1&ids[]=2
+//   - named nested structs encode as parent[child]=value
+//   - embedded structs (and pointers to them) are promoted; a field of the
+//     outer struct shadows an embedded one with the same name
+//   - fields whose type implements core.QueryEncoder encode themselves
+func buildQueryString(opts interface{}) (string, error) {
+	if opts == nil {
+		return "", nil
+	}
+
+	v := reflect.ValueOf(opts)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return "", nil
+	}
+
+	params, err := encodeStruct(v, "")
+	if err != nil {
+		return "", err
+	}
+	return params.Encode(), nil
+}
+
+// encodeStruct encodes the tagged fields of v. scope is the key of the
+// enclosing named struct, or "" at the top level.
+func encodeStruct(v reflect.Value, scope string) (url.Values, error) {
+	params := url.Values{}
+	var promoted []url.Values
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldType := t.Field(i)
+		tag := fieldType.Tag.Get("url")
+		if tag == "-" || !fieldType.IsExported() {
+			continue
+		}
+
+		// Promote embedded (anonymous) structs without a tag of their own.
+		if fieldType.Anonymous && tag == "" {
+			for field.Kind() == reflect.Ptr {
+				if field.IsNil() {
+					break
+				}
+				field = field.Elem()
+			}
+			if field.Kind() == reflect.Struct {
+				sub, err := encodeStruct(field, scope)
+				if err != nil {
+					return nil, err
+				}
+				promoted = append(promoted, sub)
+			}
+			continue
+		}
+		if tag == "" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+		if scope != "" {
+			name = scope + "[" + name + "]"
+		}
+		if opts.has("omitempty") && isEmptyValue(field) {
+			continue
+		}
+		if err := encodeField(params, name, field, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sub := range promoted {
+		for key, values := range sub {
+			if _, shadowed := params[key]; !shadowed {
+				params[key] = values
+			}
+		}
+	}
+	return params, nil
+}
+
+func encodeField(params url.Values, name string, field reflect.Value, opts tagOptions) error {
+	if field.Type().Implements(queryEncoderType) {
+		if (field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface) && field.IsNil() {
+			params.Add(name, "")
+			return nil
+		}
+		return field.Interface().(core.QueryEncoder).EncodeValues(name, &params)
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(queryEncoderType) {
+		return field.Addr().Interface().(core.QueryEncoder).EncodeValues(name, &params)
+	}
+
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			params.Add(name, "")
+			return nil
+		}
+		field = field.Elem()
+	}
+
+	switch {
+	case field.Type() == timeType:
+		params.Add(name, formatTime(field.Interface().(time.Time), opts))
+		return nil
+	case field.Kind() == reflect.Slice || field.Kind() == reflect.Array:
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+			params.Add(name, string(field.Bytes()))
+			return nil
+		}
+		return encodeList(params, name, field, opts)
+	case field.Kind() == reflect.Struct:
+		sub, err := encodeStruct(field, name)
+		if err != nil {
+			return err
+		}
+		for key, values := range sub {
+			params[key] = append(params[key], values...)
+		}
+		return nil
+	}
+	params.Add(name, formatScalar(field, opts))
+	return nil
+}
+
+func encodeList(params url.Values, name string, field reflect.Value, opts tagOptions) error {
+	values := make([]string, field.Len())
+	for i := range values {
+		elem := field.Index(i)
+		for elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		switch {
+		case elem.Kind() == reflect.Ptr || elem.Kind() == reflect.Interface:
+			values[i] = ""
+		case elem.Type() == timeType:
+			values[i] = formatTime(elem.Interface().(time.Time), opts)
+		case elem.Kind() == reflect.Struct || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map:
+			return fmt.Errorf("shopline: cannot encode %s in query parameter %q", elem.Type(), name)
+		default:
+			values[i] = formatScalar(elem, opts)
+		}
+	}
+
+	switch {
+	case opts.has("comma"):
+		params.Add(name, strings.Join(values, ","))
+	case opts.has("brackets"):
+		for _, s := range values {
+			params.Add(name+"[]", s)
+		}
+	default:
+		for _, s := range values {
+			params.Add(name, s)
+		}
+	}
+	return nil
+}
+
+func formatScalar(v reflect.Value, opts tagOptions) string {
+	if v.Kind() == reflect.Bool && opts.has("int") {
+		if v.Bool() {
+			return "1"
+		}
+		return "0"
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func formatTime(t time.Time, opts tagOptions) string {
+	switch {
+	case opts.has("unix"):
+		return strconv.FormatInt(t.Unix(), 10)
+	case opts.has("unixmilli"):
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// isEmptyValue reports whether omitempty drops v.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).IsZero()
+	}
+	return v.IsZero()
+}
+
+type tagOptions []string
+
+func parseTag(tag string) (string, tagOptions) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+func (o tagOptions) has(opt string) bool {
+	for _, s := range o {
+		if s == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// withSelectedFields appends the fields chosen with core.WithFields to
+// queryString, unless the options already select fields. The fields must
+// name fields of the resource result decodes into.
+func withSelectedFields(ctx context.Context, queryString string, result interface{}) (string, error) {
+	fields := core.SelectedFields(ctx)
+	if len(fields) == 0 {
+		return queryString, nil
+	}
+	if values, err := url.ParseQuery(queryString); err == nil && values.Has("fields") {
+		return queryString, nil
+	}
+	if err := core.ValidateResultFields(result, fields...); err != nil {
+		return "", err
+	}
+	param := url.Values{"fields": {strings.Join(fields, ",")}}.Encode()
+	if queryString == "" {
+		return param, nil
+	}
+	return queryString + "&" + param, nil
+}
@@ -0,0 +1,239 @@
+package shopline
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// cassetteMode selects between recording and replaying API traffic.
+type cassetteMode int
+
+const (
+	modeRecord cassetteMode = iota + 1
+	modeReplay
+)
+
+// redacted replaces secrets in recordings.
+const redacted = "REDACTED"
+
+// scrubbedHeaders are never written to recordings.
+var scrubbedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Appkey", "Sign"}
+
+// secretFieldPattern matches JSON string fields holding credentials.
+var secretFieldPattern = regexp.MustCompile(`("(?i:access_?token|refresh_?token|secret|password|api_?key)"\s*:\s*)"[^"]*"`)
+
+// WithRecorder records every API response to dir, one JSON file per distinct
+// request, for replay with WithReplay. Authorization and cookie headers are
+// dropped and token, secret and password fields in bodies are replaced with
+// "REDACTED", but review recordings before committing them: other personal
+// data in responses is kept as is.
+//
+//	client, _ := shopline.NewClient(app, handle, token, shopline.WithRecorder("testdata/recordings"))
+func WithRecorder(dir string) Option {
+	return func(c *Client) {
+		c.cassette = &cassette{mode: modeRecord, dir: dir, seen: make(map[string]int)}
+	}
+}
+
+// WithReplay serves responses recorded with WithRecorder instead of calling
+// the API, for hermetic integration tests. Requests match on method, path,
+// query and body; repeated identical requests get the recorded responses in
+// order, then the last one again. A request without a recording fails with a
+// *ReplayMissError, which is never retried.
+func WithReplay(dir string) Option {
+	return func(c *Client) {
+		c.cassette = &cassette{mode: modeReplay, dir: dir, seen: make(map[string]int)}
+	}
+}
+
+// ReplayMissError is returned in replay mode for a request that was not
+// recorded.
+type ReplayMissError struct {
+	Method string
+	URL    string
+	File   string // recording file the request would be read from
+}
+
+// Error implements the error interface.
+func (e *ReplayMissError) Error() string {
+	return fmt.Sprintf("shopline: no recording for %s %s (expected in %s)", e.Method, e.URL, e.File)
+}
+
+// interaction is one recorded request and its response.
+type interaction struct {
+	Request struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body"`
+	} `json:"response"`
+}
+
+// cassette is the shared state of a recording or replaying client.
+type cassette struct {
+	mode cassetteMode
+	dir  string
+
+	mu   sync.Mutex
+	seen map[string]int // interactions served or recorded per file
+}
+
+// transport wraps next with recording or replay.
+func (cs *cassette) transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &cassetteTransport{cs: cs, next: next}
+}
+
+type cassetteTransport struct {
+	cs   *cassette
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	file := filepath.Join(t.cs.dir, recordingName(req, body))
+
+	if t.cs.mode == modeReplay {
+		return t.cs.replay(req, file)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.cs.record(req, body, resp, file)
+}
+
+// recordingName derives a stable file name from the request.
+func recordingName(req *http.Request, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(req.Method + " " + req.URL.Path + "?" + req.URL.Query().Encode() + "\n"))
+	sum.Write(body)
+	path := strings.Trim(req.URL.Path, "/")
+	path = strings.NewReplacer("/", "_", ".", "_").Replace(path)
+	if len(path) > 80 {
+		path = path[len(path)-80:]
+	}
+	return fmt.Sprintf("%s_%s_%s.json", strings.ToLower(req.Method), path, hex.EncodeToString(sum.Sum(nil))[:12])
+}
+
+func (cs *cassette) record(req *http.Request, reqBody []byte, resp *http.Response, file string) (*http.Response, error) {
+	decompressResponse(resp)
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	var in interaction
+	in.Request.Method = req.Method
+	in.Request.URL = req.URL.RequestURI()
+	in.Request.Header = scrubHeader(req.Header)
+	in.Request.Body = scrubBody(reqBody)
+	in.Response.Status = resp.StatusCode
+	in.Response.Header = scrubHeader(resp.Header)
+	in.Response.Body = scrubBody(respBody)
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	var recorded []interaction
+	// The first request of a session overwrites earlier recordings.
+	if cs.seen[file] > 0 {
+		if data, err := os.ReadFile(file); err == nil {
+			json.Unmarshal(data, &recorded)
+		}
+	}
+	recorded = append(recorded, in)
+	cs.seen[file]++
+
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("shopline: failed to encode recording: %w", err)
+	}
+	if err := os.MkdirAll(cs.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("shopline: failed to create recording directory: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0o644); err != nil {
+		return nil, fmt.Errorf("shopline: failed to write recording: %w", err)
+	}
+	return resp, nil
+}
+
+func (cs *cassette) replay(req *http.Request, file string) (*http.Response, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, &ReplayMissError{Method: req.Method, URL: req.URL.RequestURI(), File: file}
+	}
+	var recorded []interaction
+	if err := json.Unmarshal(data, &recorded); err != nil || len(recorded) == 0 {
+		return nil, fmt.Errorf("shopline: invalid recording %s: %v", file, err)
+	}
+
+	cs.mu.Lock()
+	i := cs.seen[file]
+	cs.seen[file]++
+	cs.mu.Unlock()
+	if i >= len(recorded) {
+		i = len(recorded) - 1
+	}
+	in := recorded[i]
+
+	header := in.Response.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+		StatusCode:    in.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+		ContentLength: int64(len(in.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// scrubHeader returns h without credentials.
+func scrubHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range scrubbedHeaders {
+		h.Del(k)
+	}
+	// Recorded bodies are stored decoded.
+	h.Del("Content-Encoding")
+	h.Del("Content-Length")
+	return h
+}
+
+// scrubBody replaces credential fields in a JSON body.
+func scrubBody(body []byte) string {
+	return secretFieldPattern.ReplaceAllString(string(body), `$1"`+redacted+`"`)
+}
@@ -0,0 +1,70 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// ============================================================
+// Record and Replay Tests
+// ============================================================
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			w.Write([]byte(`{"product":{"id":1,"title":"First","access_token":"shhh"}}`))
+			return
+		}
+		w.Write([]byte(`{"product":{"id":1,"title":"Second"}}`))
+	}))
+	app := App{AppKey: "k", AppSecret: "s"}
+
+	rec, _ := NewClient(app, "shop", "secret-token", WithBaseURL(server.URL), WithRecorder(dir))
+	for i := 0; i < 2; i++ {
+		if _, err := rec.Product.Get(context.Background(), 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 recording, got %v", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "secret-token") || strings.Contains(string(data), "shhh") {
+		t.Errorf("recording contains secrets:\n%s", data)
+	}
+
+	replay, _ := NewClient(app, "shop", "other-token", WithBaseURL(server.URL), WithReplay(dir))
+	var titles []string
+	for i := 0; i < 3; i++ {
+		p, err := replay.Product.Get(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected replay error: %v", err)
+		}
+		titles = append(titles, p.Title)
+	}
+	if strings.Join(titles, ",") != "First,Second,Second" {
+		t.Errorf("expected recorded responses in order, got %v", titles)
+	}
+
+	_, err := replay.Product.Get(context.Background(), 2)
+	var miss *ReplayMissError
+	if !errors.As(err, &miss) || !strings.Contains(miss.URL, "/products/2.json") {
+		t.Errorf("expected ReplayMissError, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected replay not to reach the server, got %d calls", n)
+	}
+}
@@ -0,0 +1,84 @@
+package shopline
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// Rate limit response headers.
+const (
+	headerRateLimitLimit     = "X-Ratelimit-Limit"
+	headerRateLimitRemaining = "X-Ratelimit-Remaining"
+	headerRateLimitReset     = "X-Ratelimit-Reset"
+	headerRateLimitBucket    = "X-Ratelimit-Bucket"
+	headerTraceID            = "Traceid"
+	headerTraceIDAlt         = "X-Trace-Id"
+)
+
+// RateLimit holds the rate limit state reported by the API on a response.
+// Zero values mean the header was absent.
+type RateLimit = core.RateLimit
+
+// parseRateLimit reads the rate limit headers from h.
+func parseRateLimit(h http.Header) RateLimit {
+	rl := RateLimit{Bucket: h.Get(headerRateLimitBucket)}
+	rl.Limit, _ = strconv.Atoi(h.Get(headerRateLimitLimit))
+	rl.Remaining, _ = strconv.Atoi(h.Get(headerRateLimitRemaining))
+	rl.Reset = parseRetryAfter(h.Get(headerRateLimitReset))
+	return rl
+}
+
+// traceIDFromHeader returns the Shopline trace ID header, if any.
+func traceIDFromHeader(h http.Header) string {
+	if id := h.Get(headerTraceID); id != "" {
+		return id
+	}
+	return h.Get(headerTraceIDAlt)
+}
+
+// Response wraps the HTTP response of a Raw call. The body has already been
+// read and decoded into the result passed to Raw.
+type Response struct {
+	*http.Response
+	TraceID   string
+	RateLimit RateLimit
+}
+
+// newResponse builds a Response from an HTTP response; nil stays nil.
+func newResponse(resp *http.Response) *Response {
+	if resp == nil {
+		return nil
+	}
+	return &Response{
+		Response:  resp,
+		TraceID:   traceIDFromHeader(resp.Header),
+		RateLimit: parseRateLimit(resp.Header),
+	}
+}
+
+// Raw sends a request to an arbitrary endpoint through the client's normal
+// auth, retry and circuit breaker stack. Use it for endpoints the SDK does
+// not model yet.
+//
+// A path without a leading "/" is treated as a resource and expanded with
+// CreatePath ("products.json" → "/admin/openapi/{version}/products.json").
+// body is JSON-encoded (nil for none) and the response is decoded into result
+// (nil to discard).
+//
+// The returned Response is non-nil whenever the server answered, including
+// when err is a *ResponseError, so headers are available on failures too.
+func (c *Client) Raw(ctx context.Context, method, path string, body, result interface{}) (*Response, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = c.CreatePath(path)
+	}
+	req, err := c.NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req, result)
+	return newResponse(resp), err
+}
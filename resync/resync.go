@@ -0,0 +1,212 @@
+package resync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/outbox"
+	"github.com/imokyou/slshop/webhook"
+)
+
+// Kind is the outbox entry kind used for scheduled resyncs. Register
+// Executor under it on the outbox.Dispatcher.
+const Kind = "resync"
+
+const (
+	defaultMaxGap  = 15 * time.Minute
+	defaultOverlap = time.Minute
+)
+
+// =====================================================================
+// Window
+// =====================================================================
+
+// Window is a span of updated_at times for one resource of one shop that
+// must be re-read from the API because webhooks for it may have been lost.
+type Window struct {
+	ShopDomain string    `json:"shop_domain"`
+	Resource   string    `json:"resource"` // e.g. "orders", as passed to Handler
+	Min        time.Time `json:"min"`
+	Max        time.Time `json:"max"`
+}
+
+// Range returns the window as a core.DateRange.
+func (w Window) Range() core.DateRange {
+	return core.DateRange{Min: w.Min, Max: w.Max}
+}
+
+// ListOptions returns list options selecting the window by updated_at,
+// oldest first:
+//
+//	opts := &order.ListOptions{ListOptions: w.ListOptions()}
+//	opts.Limit = 250
+func (w Window) ListOptions() core.ListOptions {
+	o := core.ListOptions{SortBy: "updated_at", Order: core.SortAsc}
+	w.Range().SetUpdated(&o)
+	return o
+}
+
+// entryID is stable per window so a redelivered webhook that detects the
+// same gap enqueues the same entry.
+func (w Window) entryID() string {
+	return fmt.Sprintf("resync:%s:%s:%d-%d", w.ShopDomain, w.Resource, w.Min.Unix(), w.Max.Unix())
+}
+
+// =====================================================================
+// Gap Detection
+// =====================================================================
+
+// Option configures a Detector.
+type Option func(*Detector)
+
+// WithMaxGap sets how far apart the updated_at of consecutive webhooks for a
+// resource may be before the span between them is resynced (default 15m).
+// Use a value well above the quietest normal interval of the store.
+func WithMaxGap(d time.Duration) Option {
+	return func(det *Detector) { det.maxGap = d }
+}
+
+// WithOverlap widens each resync window on both sides (default 1m) to cover
+// clock skew and in-flight writes.
+func WithOverlap(d time.Duration) Option {
+	return func(det *Detector) { det.overlap = d }
+}
+
+// Detector tracks the last webhook seen per shop and resource and reports
+// holes: a sequence number that skips ahead, or an updated_at jump larger
+// than the max gap. State is kept in memory; after a restart the first
+// webhook per resource only sets the baseline. It is safe for concurrent use.
+type Detector struct {
+	maxGap  time.Duration
+	overlap time.Duration
+
+	mu   sync.Mutex
+	last map[string]mark
+}
+
+type mark struct {
+	updatedAt time.Time
+	seq       int64
+}
+
+// NewDetector creates a Detector.
+func NewDetector(opts ...Option) *Detector {
+	d := &Detector{
+		maxGap:  defaultMaxGap,
+		overlap: defaultOverlap,
+		last:    make(map[string]mark),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Observe records a webhook for resource of shop and returns the window to
+// resync if it reveals a hole since the previous one. seq is the delivery's
+// sequence number, or 0 if the topic has none.
+func (d *Detector) Observe(shopDomain, resource string, updatedAt time.Time, seq int64) (Window, bool) {
+	w, gap := d.check(shopDomain, resource, updatedAt, seq)
+	d.record(shopDomain, resource, updatedAt, seq)
+	return w, gap
+}
+
+func (d *Detector) check(shopDomain, resource string, updatedAt time.Time, seq int64) (Window, bool) {
+	d.mu.Lock()
+	prev, ok := d.last[shopDomain+"\x00"+resource]
+	d.mu.Unlock()
+	if !ok || !updatedAt.After(prev.updatedAt) {
+		return Window{}, false
+	}
+	seqGap := seq != 0 && prev.seq != 0 && seq > prev.seq+1
+	if !seqGap && updatedAt.Sub(prev.updatedAt) <= d.maxGap {
+		return Window{}, false
+	}
+	return Window{
+		ShopDomain: shopDomain,
+		Resource:   resource,
+		Min:        prev.updatedAt.Add(-d.overlap),
+		Max:        updatedAt.Add(d.overlap),
+	}, true
+}
+
+// record advances the mark; out-of-order deliveries never move it back.
+func (d *Detector) record(shopDomain, resource string, updatedAt time.Time, seq int64) {
+	key := shopDomain + "\x00" + resource
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := d.last[key]
+	if updatedAt.After(m.updatedAt) {
+		m.updatedAt = updatedAt
+	}
+	if seq > m.seq {
+		m.seq = seq
+	}
+	d.last[key] = m
+}
+
+// =====================================================================
+// Outbox Integration
+// =====================================================================
+
+// payload is the part of a resource webhook body the detector reads.
+type payload struct {
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// Handler wraps next (which may be nil) so that every delivery for resource
+// is checked for holes; a detected hole is enqueued as a Kind entry in the
+// same transaction as next's writes, so it survives crashes and retries.
+// Deliveries without updated_at are passed through unchecked.
+//
+//	det := resync.NewDetector()
+//	d.Handle("orders/updated", outbox.Handler(store, resync.Handler(det, "orders", processOrder)))
+//	dispatcher.Register(resync.Kind, resync.Executor(resyncOrders))
+func Handler(det *Detector, resource string, next outbox.WebhookFunc) outbox.WebhookFunc {
+	return func(ctx context.Context, del webhook.Delivery, tx outbox.Tx) error {
+		var p payload
+		if err := json.Unmarshal(del.Body, &p); err != nil || p.UpdatedAt == nil {
+			return run(ctx, next, del, tx)
+		}
+		if w, gap := det.check(del.ShopDomain, resource, *p.UpdatedAt, 0); gap {
+			body, err := json.Marshal(w)
+			if err != nil {
+				return fmt.Errorf("resync: failed to encode window: %w", err)
+			}
+			if err := tx.Enqueue(ctx, outbox.Entry{ID: w.entryID(), Kind: Kind, Payload: body}); err != nil {
+				return fmt.Errorf("resync: failed to enqueue %s: %w", w.entryID(), err)
+			}
+		}
+		if err := run(ctx, next, del, tx); err != nil {
+			return err
+		}
+		// Only advance once the delivery succeeded, so a retried delivery
+		// detects the same hole again.
+		det.record(del.ShopDomain, resource, *p.UpdatedAt, 0)
+		return nil
+	}
+}
+
+func run(ctx context.Context, next outbox.WebhookFunc, del webhook.Delivery, tx outbox.Tx) error {
+	if next == nil {
+		return nil
+	}
+	return next(ctx, del, tx)
+}
+
+// Executor adapts fn into an outbox.ExecFunc for Kind entries. fn should
+// list the window with Window.ListOptions (e.g. through an iterator) and
+// upsert what it finds; like every executor it must be idempotent.
+func Executor(fn func(ctx context.Context, w Window) error) outbox.ExecFunc {
+	return func(ctx context.Context, e outbox.Entry) error {
+		var w Window
+		if err := json.Unmarshal(e.Payload, &w); err != nil {
+			return fmt.Errorf("resync: failed to decode entry %s: %w", e.ID, err)
+		}
+		return fn(ctx, w)
+	}
+}
@@ -0,0 +1,77 @@
+package resync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imokyou/slshop/outbox"
+	"github.com/imokyou/slshop/webhook"
+)
+
+type recordingTx struct{ entries []outbox.Entry }
+
+func (tx *recordingTx) MarkProcessed(context.Context, string) (bool, error) { return true, nil }
+func (tx *recordingTx) Enqueue(_ context.Context, e outbox.Entry) error {
+	tx.entries = append(tx.entries, e)
+	return nil
+}
+
+func TestDetector_Observe(t *testing.T) {
+	det := NewDetector(WithMaxGap(10*time.Minute), WithOverlap(time.Minute))
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, gap := det.Observe("a", "orders", base, 1); gap {
+		t.Error("expected first observation to set the baseline")
+	}
+	if _, gap := det.Observe("a", "orders", base.Add(5*time.Minute), 2); gap {
+		t.Error("expected no gap within max gap")
+	}
+	w, gap := det.Observe("a", "orders", base.Add(7*time.Minute), 5)
+	if !gap || !w.Min.Equal(base.Add(4*time.Minute)) || !w.Max.Equal(base.Add(8*time.Minute)) {
+		t.Errorf("expected sequence gap window, got %+v (%v)", w, gap)
+	}
+	if _, gap := det.Observe("a", "orders", base.Add(time.Minute), 0); gap {
+		t.Error("expected out-of-order delivery not to report a gap")
+	}
+	if _, gap := det.Observe("a", "orders", base.Add(30*time.Minute), 0); !gap {
+		t.Error("expected updated_at gap")
+	}
+}
+
+func TestHandler_EnqueuesAndExecutes(t *testing.T) {
+	det := NewDetector(WithMaxGap(10 * time.Minute))
+	calls := 0
+	h := Handler(det, "orders", func(context.Context, webhook.Delivery, outbox.Tx) error {
+		calls++
+		return nil
+	})
+	tx := &recordingTx{}
+	for _, body := range []string{
+		`{"id":1,"updated_at":"2026-03-01T12:00:00Z"}`,
+		`{"id":2,"updated_at":"2026-03-01T13:00:00Z"}`,
+	} {
+		if err := h(context.Background(), webhook.Delivery{ShopDomain: "a", Body: []byte(body)}, tx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected next to run twice, got %d", calls)
+	}
+	if len(tx.entries) != 1 || tx.entries[0].Kind != Kind {
+		t.Fatalf("expected one resync entry, got %+v", tx.entries)
+	}
+
+	var got Window
+	exec := Executor(func(_ context.Context, w Window) error {
+		got = w
+		return nil
+	})
+	if err := exec(context.Background(), tx.entries[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	opts := got.ListOptions()
+	if got.Resource != "orders" || opts.UpdatedAtMin != "2026-03-01T11:59:00Z" || opts.UpdatedAtMax != "2026-03-01T13:01:00Z" {
+		t.Errorf("unexpected window %+v / %+v", got, opts)
+	}
+}
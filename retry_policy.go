@@ -0,0 +1,100 @@
+package shopline
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// IdempotencyKeyHeader marks a request as safe to replay. See
+// RetryPolicy.RequireIdempotencyKey.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy decides which failed requests Do retries and how long it waits
+// between attempts. Zero-valued fields fall back to the defaults noted below.
+//
+// Example — retry gateway errors too, but never replay a POST that carries
+// no idempotency key:
+//
+//	policy := shopline.DefaultRetryPolicy()
+//	policy.MaxRetries = 3
+//	policy.RetryableStatus = []int{429, 500, 502, 503, 504}
+//	policy.RequireIdempotencyKey = true
+//	client, _ := shopline.NewClient(app, handle, token, shopline.WithRetryPolicy(policy))
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+
+	// MethodMaxRetries overrides MaxRetries for specific HTTP methods,
+	// e.g. {"POST": 0}.
+	MethodMaxRetries map[string]int
+
+	// RetryableStatus lists the response status codes that are retried.
+	// Default: 429 and 503.
+	RetryableStatus []int
+
+	// RetryableError reports whether a transport error (connection refused,
+	// DNS failure, timeout, ...) is retried. Default: every transport error.
+	RetryableError func(err error) bool
+
+	// RequireIdempotencyKey disables retries of POST and PATCH requests
+	// that do not set the IdempotencyKeyHeader.
+	RequireIdempotencyKey bool
+
+	// BaseBackoff is the delay before the first retry, doubled on each
+	// further attempt and capped at MaxBackoff. A Retry-After header takes
+	// precedence for retried status codes. Defaults: 1s after a transport
+	// error, 2s after a retryable status, and a 30s cap.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when WithRetryPolicy is not set.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		RetryableStatus: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+		MaxBackoff:      maxBackoff,
+	}
+}
+
+// retriesFor returns how many retries req may use, given the client-wide
+// maximum.
+func (p *RetryPolicy) retriesFor(req *http.Request, max int) int {
+	if n, ok := p.MethodMaxRetries[req.Method]; ok {
+		max = n
+	}
+	if p.RequireIdempotencyKey && (req.Method == http.MethodPost || req.Method == http.MethodPatch) &&
+		req.Header.Get(IdempotencyKeyHeader) == "" {
+		return 0
+	}
+	return max
+}
+
+func (p *RetryPolicy) retryStatus(code int) bool {
+	if p.RetryableStatus == nil {
+		return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+	}
+	return slices.Contains(p.RetryableStatus, code)
+}
+
+func (p *RetryPolicy) retryError(err error) bool {
+	var miss *ReplayMissError
+	if errors.As(err, &miss) {
+		return false
+	}
+	return p.RetryableError == nil || p.RetryableError(err)
+}
+
+// backoff returns the wait before retry attempt+1, using def when
+// BaseBackoff is unset.
+func (p *RetryPolicy) backoff(attempt int, def time.Duration) time.Duration {
+	base, max := p.BaseBackoff, p.MaxBackoff
+	if base <= 0 {
+		base = def
+	}
+	if max <= 0 {
+		max = maxBackoff
+	}
+	return cappedBackoff(attempt, base, max)
+}
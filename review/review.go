@@ -0,0 +1,120 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Review Service
+// =====================================================================
+
+// Service manages product reviews for stores using Shopline's native
+// reviews.
+type Service interface {
+	List(ctx context.Context, opts *ListOptions) ([]Review, error)
+	Get(ctx context.Context, id int64) (*Review, error)
+	Reply(ctx context.Context, id int64, body string) (*Review, error)
+	Approve(ctx context.Context, id int64) (*Review, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+func NewService(client core.Requester) Service {
+	return &serviceOp{client: client}
+}
+
+type serviceOp struct{ client core.Requester }
+
+// Review.Status values.
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusHidden   = "hidden"
+)
+
+// =====================================================================
+// Query Options
+// =====================================================================
+
+type ListOptions struct {
+	core.ListOptions
+	ProductID int64  `url:"product_id,omitempty"`
+	Status    string `url:"status,omitempty"`
+	Rating    int    `url:"rating,omitempty"` // 1-5
+}
+
+// =====================================================================
+// Models
+// =====================================================================
+
+type Review struct {
+	ID            int64      `json:"id,omitempty"`
+	ProductID     int64      `json:"product_id,omitempty"`
+	CustomerID    int64      `json:"customer_id,omitempty"`
+	AuthorName    string     `json:"author_name,omitempty"`
+	AuthorEmail   string     `json:"author_email,omitempty"`
+	Rating        int        `json:"rating,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	Body          string     `json:"body,omitempty"`
+	Images        []string   `json:"images,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	VerifiedBuyer bool       `json:"verified_buyer,omitempty"`
+	Reply         *Reply     `json:"reply,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// Reply is the merchant's public answer to a review.
+type Reply struct {
+	Body      string     `json:"body,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// JSON wrappers
+type reviewResource struct {
+	Review *Review `json:"review"`
+}
+type reviewsResource struct {
+	Reviews []Review `json:"reviews"`
+}
+
+// =====================================================================
+// Implementation
+// =====================================================================
+
+// GET product_reviews.json
+func (s *serviceOp) List(ctx context.Context, opts *ListOptions) ([]Review, error) {
+	r := &reviewsResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("product_reviews.json"), r, opts)
+	return r.Reviews, err
+}
+
+// GET product_reviews/{id}.json
+func (s *serviceOp) Get(ctx context.Context, id int64) (*Review, error) {
+	r := &reviewResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("product_reviews/%d.json", id)), r, nil)
+	return core.Found(r.Review, err)
+}
+
+// POST product_reviews/{id}/reply.json
+func (s *serviceOp) Reply(ctx context.Context, id int64, body string) (*Review, error) {
+	r := &reviewResource{}
+	payload := map[string]Reply{"reply": {Body: body}}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("product_reviews/%d/reply.json", id)), payload, r)
+	return r.Review, err
+}
+
+// POST product_reviews/{id}/approve.json
+func (s *serviceOp) Approve(ctx context.Context, id int64) (*Review, error) {
+	r := &reviewResource{}
+	err := s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("product_reviews/%d/approve.json", id)), nil, r)
+	return r.Review, err
+}
+
+// DELETE product_reviews/{id}.json
+func (s *serviceOp) Delete(ctx context.Context, id int64) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("product_reviews/%d.json", id)))
+}
@@ -0,0 +1,159 @@
+package review
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// mockRequester implements core.Requester for review tests. It records the
+// options of the last Get instead of encoding them.
+type mockRequester struct {
+	server   *httptest.Server
+	lastOpts interface{}
+}
+
+func newMockRequester(handler http.HandlerFunc) (*mockRequester, func()) {
+	srv := httptest.NewServer(handler)
+	return &mockRequester{server: srv}, srv.Close
+}
+
+func (m *mockRequester) CreatePath(resource string) string {
+	return "/admin/openapi/v20251201/" + resource
+}
+func (m *mockRequester) Get(ctx context.Context, path string, result interface{}, opts interface{}) error {
+	m.lastOpts = opts
+	return m.do(ctx, http.MethodGet, path, nil, result)
+}
+func (m *mockRequester) Post(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPost, path, body, result)
+}
+func (m *mockRequester) Put(ctx context.Context, path string, body, result interface{}) error {
+	return m.do(ctx, http.MethodPut, path, body, result)
+}
+func (m *mockRequester) Delete(ctx context.Context, path string) error {
+	return m.do(ctx, http.MethodDelete, path, nil, nil)
+}
+func (m *mockRequester) do(_ context.Context, method, path string, body, result interface{}) error {
+	var b []byte
+	if body != nil {
+		b, _ = json.Marshal(body)
+	}
+	req, _ := http.NewRequest(method, m.server.URL+path, strings.NewReader(string(b)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return core.ErrNotFound
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+var _ core.Requester = (*mockRequester)(nil)
+
+func TestReviewList(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/admin/openapi/v20251201/product_reviews.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"reviews":[{"id":1,"product_id":9,"rating":5,"status":"pending","verified_buyer":true,"images":["https://img/1.jpg"]}]}`))
+	})
+	defer close()
+
+	opts := &ListOptions{ProductID: 9, Status: StatusPending}
+	reviews, err := NewService(mock).List(context.Background(), opts)
+	if err != nil || len(reviews) != 1 || reviews[0].Rating != 5 || !reviews[0].VerifiedBuyer || len(reviews[0].Images) != 1 {
+		t.Fatalf("List = %+v, %v", reviews, err)
+	}
+	if mock.lastOpts != opts {
+		t.Errorf("expected options passed through, got %+v", mock.lastOpts)
+	}
+}
+
+func TestReviewGet(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/openapi/v20251201/product_reviews/404.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Path != "/admin/openapi/v20251201/product_reviews/1.json" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"review":{"id":1,"title":"Warm","reply":{"body":"Thanks!"}}}`))
+	})
+	defer close()
+
+	svc := NewService(mock)
+	rv, err := svc.Get(context.Background(), 1)
+	if err != nil || rv.Title != "Warm" || rv.Reply == nil || rv.Reply.Body != "Thanks!" {
+		t.Fatalf("Get = %+v, %v", rv, err)
+	}
+	if _, err := svc.Get(context.Background(), 404); !errors.Is(err, core.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestReviewReply(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/openapi/v20251201/product_reviews/1/reply.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if len(body["reply"]) != 1 || body["reply"]["body"] != "Thanks for the review!" {
+			t.Errorf("unexpected body: %v", body)
+		}
+		w.Write([]byte(`{"review":{"id":1,"reply":{"body":"Thanks for the review!"}}}`))
+	})
+	defer close()
+
+	rv, err := NewService(mock).Reply(context.Background(), 1, "Thanks for the review!")
+	if err != nil || rv.Reply == nil || rv.Reply.Body != "Thanks for the review!" {
+		t.Fatalf("Reply = %+v, %v", rv, err)
+	}
+}
+
+func TestReviewApprove(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/admin/openapi/v20251201/product_reviews/1/approve.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if b, _ := io.ReadAll(r.Body); len(b) != 0 {
+			t.Errorf("expected no body, got %s", b)
+		}
+		w.Write([]byte(`{"review":{"id":1,"status":"approved"}}`))
+	})
+	defer close()
+
+	rv, err := NewService(mock).Approve(context.Background(), 1)
+	if err != nil || rv.Status != StatusApproved {
+		t.Fatalf("Approve = %+v, %v", rv, err)
+	}
+}
+
+func TestReviewDelete(t *testing.T) {
+	called := false
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Method != http.MethodDelete || r.URL.Path != "/admin/openapi/v20251201/product_reviews/1.json" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer close()
+
+	if err := NewService(mock).Delete(context.Background(), 1); err != nil || !called {
+		t.Fatalf("Delete: called=%v, err=%v", called, err)
+	}
+}
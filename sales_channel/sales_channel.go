@@ -93,7 +93,7 @@ func (s *serviceOp) ListProducts(ctx context.Context, opts *core.ListOptions) ([
 func (s *serviceOp) GetProduct(ctx context.Context, productID int64) (*ProductListing, error) {
 	r := &productListingResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("product_listings/%d.json", productID)), r, nil)
-	return r.ProductListing, err
+	return core.Found(r.ProductListing, err)
 }
 
 // PUT product_listings/{product_id}.json
@@ -137,7 +137,7 @@ func (s *serviceOp) ListCollections(ctx context.Context, opts *core.ListOptions)
 func (s *serviceOp) GetCollection(ctx context.Context, collectionID int64) (*CollectionListing, error) {
 	r := &collectionListingResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("collection_listings/%d.json", collectionID)), r, nil)
-	return r.CollectionListing, err
+	return core.Found(r.CollectionListing, err)
 }
 
 // PUT collection_listings/{collection_id}.json
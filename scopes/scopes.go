@@ -0,0 +1,197 @@
+// Package scopes lists the OAuth access scopes an app can request, so scope
+// strings are built from constants instead of typed by hand:
+//
+//	app.Scope = scopes.Join(scopes.Read(scopes.Products), scopes.All(scopes.Orders)...)
+//
+// A misspelled scope is not rejected at install time; the app just fails
+// later with permission errors. Parse and App.ValidateScope catch that early.
+package scopes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource is a group of API resources covered by a read and a write scope.
+type Resource string
+
+const (
+	Products                  Resource = "products"
+	ProductListings           Resource = "product_listings"
+	Inventory                 Resource = "inventory"
+	Orders                    Resource = "orders"
+	DraftOrders               Resource = "draft_orders"
+	Returns                   Resource = "returns"
+	Fulfillments              Resource = "fulfillments"
+	AssignedFulfillmentOrders Resource = "assigned_fulfillment_orders"
+	Shipping                  Resource = "shipping"
+	Customers                 Resource = "customers"
+	PriceRules                Resource = "price_rules"
+	Discounts                 Resource = "discounts"
+	MarketingEvents           Resource = "marketing_events"
+	GiftCards                 Resource = "gift_cards"
+	Content                   Resource = "content"
+	Themes                    Resource = "themes"
+	ScriptTags                Resource = "script_tags"
+	Translations              Resource = "translations"
+	Locales                   Resource = "locales"
+	Markets                   Resource = "markets"
+	Publications              Resource = "publications"
+	Metafields                Resource = "metafields"
+	Payments                  Resource = "shopline_payments"
+	StoreInformation          Resource = "store_information"
+	Locations                 Resource = "locations"
+	Reports                   Resource = "reports"
+	CustomerEvents            Resource = "customer_events"
+	OnlineStorePages          Resource = "online_store_pages"
+	SizeCharts                Resource = "size_charts"
+	ThirdPartyPayments        Resource = "third_party_payments"
+)
+
+// readOnly resources have no write scope.
+var readOnly = map[Resource]bool{
+	StoreInformation: true,
+	Locations:        true,
+	Reports:          true,
+	CustomerEvents:   true,
+}
+
+// resources is every known Resource.
+var resources = []Resource{
+	Products, ProductListings, Inventory, Orders, DraftOrders, Returns,
+	Fulfillments, AssignedFulfillmentOrders, Shipping, Customers,
+	PriceRules, Discounts, MarketingEvents, GiftCards, Content, Themes,
+	ScriptTags, Translations, Locales, Markets, Publications, Metafields,
+	Payments, StoreInformation, Locations, Reports, CustomerEvents,
+	OnlineStorePages, SizeCharts, ThirdPartyPayments,
+}
+
+// Scope is a single access scope such as "read_products".
+type Scope string
+
+// Read returns the read scope of r.
+func Read(r Resource) Scope {
+	return Scope("read_" + string(r))
+}
+
+// Write returns the write scope of r. Read-only resources have none; Parse
+// rejects the result for them.
+func Write(r Resource) Scope {
+	return Scope("write_" + string(r))
+}
+
+// All returns the read scope of every resource and the write scope of every
+// resource that has one.
+func All(rs ...Resource) []Scope {
+	out := make([]Scope, 0, 2*len(rs))
+	for _, r := range rs {
+		out = append(out, Read(r))
+		if !readOnly[r] {
+			out = append(out, Write(r))
+		}
+	}
+	return out
+}
+
+// Known returns every valid scope.
+func Known() []Scope {
+	return All(resources...)
+}
+
+// Valid reports whether s is a known scope.
+func Valid(s Scope) bool {
+	return known[s]
+}
+
+var known = func() map[Scope]bool {
+	m := make(map[Scope]bool, 2*len(resources))
+	for _, s := range Known() {
+		m[s] = true
+	}
+	return m
+}()
+
+// Join formats scopes as the comma-separated string used by App.Scope,
+// dropping duplicates.
+func Join(scopes ...Scope) string {
+	seen := make(map[Scope]bool, len(scopes))
+	parts := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		parts = append(parts, string(s))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Normalize lowercases a comma- or space-separated scope string, trims its
+// entries and drops empty and duplicate ones, keeping unknown scopes.
+func Normalize(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	scopes := make([]Scope, len(fields))
+	for i, f := range fields {
+		scopes[i] = Scope(f)
+	}
+	return Join(scopes...)
+}
+
+// Parse normalizes s and returns its scopes, failing on unknown ones with a
+// suggestion when the mistake looks like a typo.
+func Parse(s string) ([]Scope, error) {
+	var scopes []Scope
+	var unknown []string
+	for _, f := range strings.Split(Normalize(s), ",") {
+		if f == "" {
+			continue
+		}
+		sc := Scope(f)
+		if !Valid(sc) {
+			unknown = append(unknown, describeUnknown(sc))
+			continue
+		}
+		scopes = append(scopes, sc)
+	}
+	if len(unknown) > 0 {
+		return scopes, fmt.Errorf("scopes: unknown scopes: %s", strings.Join(unknown, ", "))
+	}
+	return scopes, nil
+}
+
+// describeUnknown names s and the closest known scope, if any is close.
+func describeUnknown(s Scope) string {
+	best, bestDist := Scope(""), 3
+	for _, k := range Known() {
+		if d := distance(string(s), string(k)); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	if best == "" {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%q (did you mean %q?)", s, best)
+}
+
+// distance is the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
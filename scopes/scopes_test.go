@@ -0,0 +1,44 @@
+package scopes
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJoinAndAll(t *testing.T) {
+	got := Join(Read(Products), Read(Products), Read(Locations))
+	if got != "read_products,read_locations" {
+		t.Errorf("unexpected join %q", got)
+	}
+	got = Join(All(Orders, Reports)...)
+	if got != "read_orders,write_orders,read_reports" {
+		t.Errorf("expected no write scope for read-only resource, got %q", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if got := Normalize(" Read_Products, read_orders,,read_products write_orders\n"); got != "read_products,read_orders,write_orders" {
+		t.Errorf("unexpected normalized scope %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("read_products,write_orders")
+	if err != nil || len(got) != 2 {
+		t.Fatalf("unexpected result %v, %v", got, err)
+	}
+	_, err = Parse("read_prodcts,write_reports,read_everything")
+	if err == nil {
+		t.Fatal("expected unknown scopes to fail")
+	}
+	for _, want := range []string{`"read_prodcts" (did you mean "read_products"?)`, `"write_reports"`, `"read_everything"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s in %q", want, err)
+		}
+	}
+	for _, s := range Known() {
+		if !Valid(s) {
+			t.Errorf("known scope %s is not valid", s)
+		}
+	}
+}
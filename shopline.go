@@ -4,20 +4,25 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/imokyou/slshop/access"
 	appopenapi "github.com/imokyou/slshop/app_openapi"
 	"github.com/imokyou/slshop/bulk"
 	"github.com/imokyou/slshop/customer"
+	"github.com/imokyou/slshop/events"
 	"github.com/imokyou/slshop/localizations"
 	"github.com/imokyou/slshop/market"
 	"github.com/imokyou/slshop/marketing"
+	"github.com/imokyou/slshop/media"
 	"github.com/imokyou/slshop/metafield"
+	"github.com/imokyou/slshop/metaobject"
 	onlinestore "github.com/imokyou/slshop/online_store"
 	"github.com/imokyou/slshop/order"
 	paymentsapp "github.com/imokyou/slshop/payments_app"
 	"github.com/imokyou/slshop/product"
+	"github.com/imokyou/slshop/review"
 	saleschannel "github.com/imokyou/slshop/sales_channel"
 	shoplinepay "github.com/imokyou/slshop/shopline_payments"
 	"github.com/imokyou/slshop/store"
@@ -44,6 +49,24 @@ type App struct {
 
 	// Scope defines the access permissions (e.g. "read_products,read_orders").
 	Scope string
+
+	// DomainSuffix is the domain store handles live under, used for OAuth
+	// and API hosts. Empty means DefaultDomainSuffix ("myshopline.com").
+	// Set it for regional domains or partner sandboxes.
+	DomainSuffix string
+}
+
+// DefaultDomainSuffix is the domain under which stores are served by default.
+const DefaultDomainSuffix = "myshopline.com"
+
+// storeHost returns the host of the given store handle, e.g.
+// "open001.myshopline.com".
+func (app App) storeHost(handle string) string {
+	suffix := app.DomainSuffix
+	if suffix == "" {
+		suffix = DefaultDomainSuffix
+	}
+	return handle + "." + strings.TrimPrefix(suffix, ".")
 }
 
 // Client is the Shopline Admin API client.
@@ -56,9 +79,22 @@ type Client struct {
 	httpClient      *http.Client
 	baseURL         *url.URL
 	baseURLOverride string
+	adminDomain     string // custom API host for this store (overrides handle + suffix)
 	maxRetries      int
+	retryPolicy     *RetryPolicy // nil = DefaultRetryPolicy
+	hedge           *hedgeConfig // optional GET hedging (nil = disabled)
 	log             Logger
-	cb              *CircuitBreaker // optional circuit breaker (nil = disabled)
+	metrics         Metrics
+	cb              *CircuitBreaker     // optional circuit breaker (nil = disabled)
+	limiter         *ConcurrencyLimiter // optional in-flight request cap (nil = unlimited)
+	strictDecoding  bool                // fail on response fields unknown to the models
+	onDecodeDrift   DecodeDriftFunc     // optional unknown-field reporter
+	codec           *jsonCodec          // optional JSON implementation (nil = encoding/json)
+	streamDecoding  bool                // decode successful responses from the connection
+	hooks           Hooks               // optional per-attempt callbacks
+	compression     bool                // gzip large request bodies and accept gzipped responses
+	cassette        *cassette           // optional response recording or replay
+	caps            *capabilitiesCache  // shared with WithAPIVersion clones, keyed by version
 
 	// ========================
 	// Sub-package Services
@@ -79,7 +115,8 @@ type Client struct {
 	OrderEdit         order.EditService
 
 	// Customer 大类
-	Customer customer.Service
+	Customer        customer.Service
+	CustomerSegment customer.SegmentService
 
 	// Product 大类
 	Product          product.Service
@@ -87,9 +124,15 @@ type Client struct {
 	SmartCollection  product.SmartCollectionService
 	ManualCollection product.ManualCollectionService
 	Inventory        product.InventoryService
+	Bundle           product.BundleService
+	ProductOption    product.OptionService
+	Review           review.Service
+	Media            media.Service
 
 	// Store 大类
-	Store store.Service
+	Store        store.Service
+	ShippingZone store.ShippingZoneService
+	Events       events.Service
 
 	// Marketing 大类
 	Discount marketing.DiscountService
@@ -122,6 +165,10 @@ type Client struct {
 	MetafieldResource   metafield.ResourceService
 	MetafieldStore      metafield.StoreService
 
+	// Metaobject 大类
+	MetaobjectDefinition metaobject.DefinitionService
+	Metaobject           metaobject.Service
+
 	// Bulk Operations 大类
 	BulkOperation bulk.Service
 
@@ -145,11 +192,6 @@ type Client struct {
 //   - token: Bearer access token
 //   - opts: Optional configuration (WithVersion, WithRetry, etc.)
 func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
-	baseURL, err := url.Parse(fmt.Sprintf("https://%s.myshopline.com", handle))
-	if err != nil {
-		return nil, fmt.Errorf("shopline: invalid handle %q: %w", handle, err)
-	}
-
 	c := &Client{
 		app:        app,
 		handle:     handle,
@@ -163,8 +205,8 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 				IdleConnTimeout:     90 * time.Second,
 			},
 		},
-		baseURL:    baseURL,
 		maxRetries: 0,
+		caps:       newCapabilitiesCache(),
 	}
 
 	// Apply options
@@ -172,6 +214,35 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 		opt(c)
 	}
 
+	// The token manager may have been created before WithDomainSuffix or
+	// WithMetrics ran.
+	if tm := c.tokenManager; tm != nil {
+		if tm.app.DomainSuffix == "" {
+			tm.app.DomainSuffix = c.app.DomainSuffix
+		}
+		if tm.mtr == nil {
+			tm.mtr = c.metrics
+		}
+	}
+
+	// Wrap the transport last so WithHTTPClient cannot drop the recorder,
+	// copying the client so a caller's http.Client is left untouched.
+	if c.cassette != nil {
+		hc := *c.httpClient
+		hc.Transport = c.cassette.transport(hc.Transport)
+		c.httpClient = &hc
+	}
+
+	host := c.app.storeHost(handle)
+	if c.adminDomain != "" {
+		host = c.adminDomain
+	}
+	baseURL, err := url.Parse("https://" + host)
+	if err != nil {
+		return nil, fmt.Errorf("shopline: invalid handle %q: %w", handle, err)
+	}
+	c.baseURL = baseURL
+
 	// Handle base URL override (for testing)
 	if c.baseURLOverride != "" {
 		overrideURL, err := url.Parse(c.baseURLOverride)
@@ -181,7 +252,13 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 		c.baseURL = overrideURL
 	}
 
-	// Initialize all services
+	c.initServices()
+
+	return c, nil
+}
+
+// initServices binds every sub-package service to c.
+func (c *Client) initServices() {
 	c.Order = order.NewService(c)
 	c.DraftOrder = order.NewDraftOrderService(c)
 	c.Fulfillment = order.NewFulfillmentService(c)
@@ -196,14 +273,21 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 	c.OrderEdit = order.NewEditService(c)
 
 	c.Customer = customer.NewService(c)
+	c.CustomerSegment = customer.NewSegmentService(c)
 
 	c.Product = product.NewService(c)
 	c.Collection = product.NewCollectionService(c)
 	c.SmartCollection = product.NewSmartCollectionService(c)
 	c.ManualCollection = product.NewManualCollectionService(c)
 	c.Inventory = product.NewInventoryService(c)
+	c.Bundle = product.NewBundleService(c)
+	c.ProductOption = product.NewOptionService(c)
+	c.Review = review.NewService(c)
+	c.Media = media.NewService(c)
 
 	c.Store = store.NewService(c)
+	c.ShippingZone = store.NewShippingZoneService(c)
+	c.Events = events.NewService(c)
 
 	c.Discount = marketing.NewDiscountService(c)
 
@@ -227,6 +311,8 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 	c.MetafieldDefinition = metafield.NewDefinitionService(c)
 	c.MetafieldResource = metafield.NewResourceService(c)
 	c.MetafieldStore = metafield.NewStoreService(c)
+	c.MetaobjectDefinition = metaobject.NewDefinitionService(c)
+	c.Metaobject = metaobject.NewService(c)
 
 	c.BulkOperation = bulk.NewService(c)
 
@@ -237,8 +323,22 @@ func NewClient(app App, handle, token string, opts ...Option) (*Client, error) {
 	c.SizeChart = appopenapi.NewSizeChartService(c)
 	c.CDP = appopenapi.NewCDPService(c)
 	c.VariantImage = appopenapi.NewVariantImageService(c)
+}
 
-	return c, nil
+// WithAPIVersion returns a client that sends requests to another API
+// version while sharing c's credentials, connection pool and options. Use it
+// for endpoints that only exist on, or changed in, a different version than
+// the rest of the app uses:
+//
+//	v2 := client.WithAPIVersion("v20260301")
+//	products, err := v2.Product.List(ctx, nil)
+//
+// c is unchanged; the returned client is cheap to create.
+func (c *Client) WithAPIVersion(version string) *Client {
+	clone := *c
+	clone.apiVersion = version
+	clone.initServices()
+	return &clone
 }
 
 // GetHandle returns the store handle.
@@ -116,7 +116,7 @@ type transactionsResource struct {
 func (s *serviceOp) GetBalance(ctx context.Context) (*Balance, error) {
 	r := &balanceResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("payments/store/balance.json"), r, nil)
-	return r.Balance, err
+	return core.Found(r.Balance, err)
 }
 
 // GET payments/store/payouts.json
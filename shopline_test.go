@@ -6,18 +6,27 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/events"
+	"github.com/imokyou/slshop/market"
+	onlinestore "github.com/imokyou/slshop/online_store"
 	"github.com/imokyou/slshop/order"
 	"github.com/imokyou/slshop/product"
+	"github.com/imokyou/slshop/scopes"
 	"github.com/imokyou/slshop/store"
 )
 
@@ -68,6 +77,30 @@ func TestNewClientWithVersion(t *testing.T) {
 	}
 }
 
+func TestNewClientWithDomainSuffix(t *testing.T) {
+	app := App{AppKey: "k", AppSecret: "s"}
+	client, err := NewClient(app, "shop", "", WithTokenManager(newMockTokenStore()), WithDomainSuffix("myshopline.cn"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.GetBaseURL().String(); got != "https://shop.myshopline.cn" {
+		t.Errorf("expected regional base URL, got %q", got)
+	}
+	if got := client.tokenManager.app.storeHost("shop"); got != "shop.myshopline.cn" {
+		t.Errorf("expected token refresh host shop.myshopline.cn, got %q", got)
+	}
+
+	client, _ = NewClient(app, "shop", "tok", WithAdminDomain("admin.mybrand.com"))
+	if got := client.GetBaseURL().String(); got != "https://admin.mybrand.com" {
+		t.Errorf("expected custom admin domain, got %q", got)
+	}
+
+	app.DomainSuffix = "sandbox.myshopline.com"
+	if u := app.AuthorizeURL("shop", ""); !strings.HasPrefix(u, "https://shop.sandbox.myshopline.com/") {
+		t.Errorf("expected sandbox authorize URL, got %q", u)
+	}
+}
+
 func TestCreatePath(t *testing.T) {
 	app := App{AppKey: "k", AppSecret: "s"}
 	client, _ := NewClient(app, "shop", "tok")
@@ -158,6 +191,42 @@ func TestDo_ErrorResponse(t *testing.T) {
 	}
 }
 
+func TestDo_ErrorResponseFieldsAndCode(t *testing.T) {
+	bodies := []string{
+		`{"i18nCode":"PRODUCT_INVALID","errors":{"title":["can't be blank"],"price":"must be positive"}}`,
+		`{"code":"PRODUCT_INVALID","errors":[{"field":"title","message":"can't be blank"},{"field":"price","message":"must be positive"}]}`,
+	}
+	for _, body := range bodies {
+		client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, body)
+		})
+		req, _ := client.NewRequest(context.Background(), http.MethodPost, "/test", nil)
+		_, err := client.Do(req, nil)
+		server.Close()
+
+		respErr, ok := err.(*ResponseError)
+		if !ok {
+			t.Fatalf("expected *ResponseError, got %T", err)
+		}
+		if respErr.Code != "PRODUCT_INVALID" {
+			t.Errorf("expected code PRODUCT_INVALID, got %q", respErr.Code)
+		}
+		if got := respErr.Fields["title"]; len(got) != 1 || got[0] != "can't be blank" {
+			t.Errorf("unexpected title errors %v", got)
+		}
+		if got := respErr.Fields["price"]; len(got) != 1 || got[0] != "must be positive" {
+			t.Errorf("unexpected price errors %v", got)
+		}
+		if respErr.IsRetryable() {
+			t.Error("expected 422 not to be retryable")
+		}
+	}
+	if !(&ResponseError{Status: http.StatusBadGateway}).IsRetryable() {
+		t.Error("expected 502 to be retryable")
+	}
+}
+
 func TestDo_RateLimitRetry(t *testing.T) {
 	attempt := 0
 	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
@@ -187,6 +256,326 @@ func TestDo_RateLimitRetry(t *testing.T) {
 	}
 }
 
+func TestDo_WithNoRetry(t *testing.T) {
+	attempt := 0
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		attempt++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":"rate limited"}`)
+	})
+	defer server.Close()
+	client.maxRetries = 3
+
+	req, _ := client.NewRequest(core.WithNoRetry(context.Background()), http.MethodGet, "/test", nil)
+	_, err := client.Do(req, nil)
+	rlErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+	if attempt != 1 {
+		t.Errorf("expected 1 attempt with WithNoRetry, got %d", attempt)
+	}
+	if rlErr.ResponseError.RetryAfter != 2*time.Second {
+		t.Errorf("expected the default RetryAfter on the response error, got %s", rlErr.ResponseError.RetryAfter)
+	}
+}
+
+func TestDo_RetryPolicy(t *testing.T) {
+	attempt := 0
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		attempt++
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, `{"errors":"bad gateway"}`)
+	})
+	defer server.Close()
+	WithRetryPolicy(RetryPolicy{
+		MaxRetries:            2,
+		RetryableStatus:       []int{http.StatusBadGateway},
+		RequireIdempotencyKey: true,
+		BaseBackoff:           time.Millisecond,
+	})(client)
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	client.Do(req, nil)
+	if attempt != 3 {
+		t.Errorf("expected 3 attempts for GET, got %d", attempt)
+	}
+
+	attempt = 0
+	req, _ = client.NewRequest(context.Background(), http.MethodPost, "/test", map[string]string{})
+	client.Do(req, nil)
+	if attempt != 1 {
+		t.Errorf("expected 1 attempt for POST without idempotency key, got %d", attempt)
+	}
+
+	attempt = 0
+	req, _ = client.NewRequest(context.Background(), http.MethodPost, "/test", map[string]string{})
+	req.Header.Set(IdempotencyKeyHeader, "k-1")
+	client.Do(req, nil)
+	if attempt != 3 {
+		t.Errorf("expected 3 attempts for POST with idempotency key, got %d", attempt)
+	}
+}
+
+func TestRetryPolicy_DefaultBackoff(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	for _, def := range []time.Duration{time.Second, 2 * time.Second} {
+		if got := policy.backoff(0, def); got < def*3/4 || got > def*5/4 {
+			t.Errorf("expected first backoff near %s, got %s", def, got)
+		}
+	}
+	policy.BaseBackoff = 10 * time.Millisecond
+	if got := policy.backoff(0, 2*time.Second); got > 20*time.Millisecond {
+		t.Errorf("expected BaseBackoff to override the default, got %s", got)
+	}
+}
+
+func TestRetryPolicy_TinyBackoff(t *testing.T) {
+	for _, policy := range []RetryPolicy{
+		{BaseBackoff: time.Nanosecond},
+		{BaseBackoff: time.Nanosecond, MaxBackoff: 1},
+	} {
+		for attempt := 0; attempt < 3; attempt++ {
+			if got := policy.backoff(attempt, time.Second); got < 0 || got > 4*time.Nanosecond {
+				t.Errorf("%+v attempt %d: unexpected backoff %s", policy, attempt, got)
+			}
+		}
+	}
+}
+
+func TestDo_HedgedRequests(t *testing.T) {
+	var calls atomic.Int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(2 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	defer server.Close()
+	WithHedgedRequests(20*time.Millisecond, 1)(client)
+
+	start := time.Now()
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	var result map[string]string
+	if _, err := client.Do(req, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["status"] != "ok" {
+		t.Errorf("expected hedge response, got %v", result)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("expected hedged GET to return early, took %v", d)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestDo_HedgedRequestsRespectLimiter(t *testing.T) {
+	var calls atomic.Int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	defer server.Close()
+	WithHedgedRequests(10*time.Millisecond, 2)(client)
+	WithMaxConcurrentRequests(1)(client)
+
+	req, _ := client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected no hedges beyond the concurrency limit, got %d requests", n)
+	}
+
+	calls.Store(0)
+	WithMaxConcurrentRequests(2)(client)
+	req, _ = client.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected one hedge within the limit, got %d requests", n)
+	}
+	for i := 0; i < 2; i++ {
+		if _, ok := client.limiter.tryAcquire(client.handle); !ok {
+			t.Fatalf("expected both slots released, slot %d taken", i)
+		}
+	}
+}
+
+func TestDo_ErrorCapturesRateLimitHeaders(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.Header().Set("X-Ratelimit-Limit", "40")
+		w.Header().Set("X-Ratelimit-Remaining", "0")
+		w.Header().Set("X-Ratelimit-Bucket", "orders")
+		w.Header().Set("Traceid", "trace-429")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":"rate limited"}`)
+	})
+	defer server.Close()
+
+	req, _ := client.NewRequest(core.WithNoRetry(context.Background()), http.MethodGet, "/test", nil)
+	_, err := client.Do(req, nil)
+	rlErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.RetryAfter != 3*time.Second || rlErr.ResponseError.RetryAfter != 3*time.Second {
+		t.Errorf("expected RetryAfter 3s, got %s / %s", rlErr.RetryAfter, rlErr.ResponseError.RetryAfter)
+	}
+	rl := rlErr.RateLimit
+	if rl.Limit != 40 || rl.Remaining != 0 || rl.Bucket != "orders" {
+		t.Errorf("unexpected rate limit: %+v", rl)
+	}
+	if rlErr.TraceID != "trace-429" {
+		t.Errorf("expected trace ID from header, got %q", rlErr.TraceID)
+	}
+}
+
+func TestDo_StrictDecoding(t *testing.T) {
+	type model struct {
+		ID int64 `json:"id"`
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":7,"brand_new_field":"x"}`)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	strict, _ := NewClient(App{}, "testshop", "t", WithBaseURL(server.URL), WithStrictDecoding())
+	req, _ := strict.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	var m model
+	if _, err := strict.Do(req, &m); err == nil || !strings.Contains(err.Error(), "brand_new_field") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+
+	var drift []string
+	lenient, _ := NewClient(App{}, "testshop", "t", WithBaseURL(server.URL),
+		WithDecodeDriftHandler(func(_ *http.Request, field string) { drift = append(drift, field) }))
+	req, _ = lenient.NewRequest(context.Background(), http.MethodGet, "/test", nil)
+	m = model{}
+	if _, err := lenient.Do(req, &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.ID != 7 {
+		t.Errorf("expected ID 7, got %d", m.ID)
+	}
+	if len(drift) != 1 || drift[0] != "brand_new_field" {
+		t.Errorf("expected drift report for brand_new_field, got %v", drift)
+	}
+}
+
+func TestRaw(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/admin/openapi/v20251201/new_things.json" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		w.Header().Set("traceId", "tr-1")
+		w.Header().Set("X-Ratelimit-Limit", "40")
+		w.Header().Set("X-Ratelimit-Remaining", "39")
+		w.Header().Set("X-Ratelimit-Reset", "2")
+		fmt.Fprint(w, `{"thing":{"id":5}}`)
+	})
+	defer server.Close()
+
+	var result struct {
+		Thing struct {
+			ID int64 `json:"id"`
+		} `json:"thing"`
+	}
+	resp, err := client.Raw(context.Background(), http.MethodPost, "new_things.json", map[string]string{"a": "b"}, &result)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Thing.ID != 5 {
+		t.Errorf("expected ID 5, got %d", result.Thing.ID)
+	}
+	if resp.TraceID != "tr-1" {
+		t.Errorf("expected trace ID tr-1, got %q", resp.TraceID)
+	}
+	if resp.RateLimit.Limit != 40 || resp.RateLimit.Remaining != 39 || resp.RateLimit.Reset != 2*time.Second {
+		t.Errorf("unexpected rate limit: %+v", resp.RateLimit)
+	}
+}
+
+func TestRaw_ErrorKeepsResponse(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("traceId", "tr-2")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"nope"}`)
+	})
+	defer server.Close()
+
+	resp, err := client.Raw(context.Background(), http.MethodGet, "/custom/path", nil, nil)
+	if _, ok := err.(*ResponseError); !ok {
+		t.Fatalf("expected *ResponseError, got %T", err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound || resp.TraceID != "tr-2" {
+		t.Errorf("expected response with status and trace ID, got %+v", resp)
+	}
+}
+
+func TestErrorReport(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message":"invalid","traceId":"tr-9","customer":{"email":"a@b.com","access_token":"secret"}}`)
+	})
+	defer server.Close()
+
+	_, err := client.Raw(context.Background(), http.MethodPost, "orders.json", nil, nil)
+	report := ErrorReport(err)
+
+	var snap ErrorSnapshot
+	if jsonErr := json.Unmarshal([]byte(report), &snap); jsonErr != nil {
+		t.Fatalf("report is not JSON: %v\n%s", jsonErr, report)
+	}
+	if snap.TraceID != "tr-9" || snap.Status != 422 || snap.Method != http.MethodPost {
+		t.Errorf("unexpected snapshot: %+v", snap)
+	}
+	if snap.APIVersion != client.GetAPIVersion() || !strings.HasSuffix(snap.Path, "/orders.json") {
+		t.Errorf("expected api version and path, got %q %q", snap.APIVersion, snap.Path)
+	}
+	if snap.SDKVersion != LibraryVersion {
+		t.Errorf("expected SDK version %s, got %s", LibraryVersion, snap.SDKVersion)
+	}
+	if strings.Contains(report, "a@b.com") || strings.Contains(report, `"secret"`) {
+		t.Errorf("report leaks sensitive data: %s", report)
+	}
+
+	if snap.Shop != "testshop" {
+		t.Errorf("expected shop testshop, got %q", snap.Shop)
+	}
+
+	if snap := NewErrorSnapshot(fmt.Errorf("dial tcp: timeout")); snap.Message != "dial tcp: timeout" || snap.Status != 0 {
+		t.Errorf("unexpected snapshot for plain error: %+v", snap)
+	}
+	decodeErr := fmt.Errorf("shopline: failed to decode response (traceId: t): bad (body: {\"access_token\":\"tok\"})")
+	if snap := NewErrorSnapshot(decodeErr); strings.Contains(snap.Message, "tok") || !strings.HasSuffix(snap.Message, "(body omitted)") {
+		t.Errorf("decode error message leaks the body: %q", snap.Message)
+	}
+	if s := sanitizeBody([]byte(strings.Repeat("a", maxReportBody-1) + "é")); !utf8.ValidString(s) {
+		t.Errorf("truncation split a rune: %q", s[len(s)-20:])
+	}
+}
+
 // ============== NEW ROBUST TESTS ==============
 
 func TestDo_RetryPreservesBody(t *testing.T) {
@@ -235,9 +624,10 @@ func TestDo_ContextCancellation(t *testing.T) {
 
 	client.maxRetries = 3
 
-	// Context that cancels almost immediately
-	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	// Context without a deadline that is cancelled during the wait
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	time.AfterFunc(100*time.Millisecond, cancel)
 
 	start := time.Now()
 	req, _ := client.NewRequest(ctx, http.MethodGet, "/test", nil)
@@ -247,7 +637,7 @@ func TestDo_ContextCancellation(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error due to context cancellation")
 	}
-	if !strings.Contains(err.Error(), "context deadline exceeded") {
+	if !strings.Contains(err.Error(), "context canceled") {
 		t.Errorf("expected context cancelled error, got: %v", err)
 	}
 	// Should return quickly, not wait 5 seconds
@@ -259,6 +649,44 @@ func TestDo_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestDo_RetryAfterExceedsDeadline(t *testing.T) {
+	attempt := 0
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		attempt++
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":"rate limited","traceId":"rl-dl"}`)
+	})
+	defer server.Close()
+	m := &recordingMetrics{counters: map[string]int{}, gauges: map[string]float64{}}
+	WithMetrics(m)(client)
+	client.maxRetries = 3
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/test", nil)
+	_, err := client.Do(req, nil)
+
+	var dlErr *RetryDeadlineError
+	if !errors.As(err, &dlErr) {
+		t.Fatalf("expected *RetryDeadlineError, got %T: %v", err, err)
+	}
+	if dlErr.Wait != 5*time.Second || dlErr.RetryAt.Before(start.Add(5*time.Second)) {
+		t.Errorf("unexpected suggested retry: %+v", dlErr)
+	}
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) || rlErr.TraceID != "rl-dl" {
+		t.Errorf("expected wrapped *RateLimitError, got %v", dlErr.Err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond || attempt != 1 {
+		t.Errorf("expected immediate return after 1 attempt, took %v with %d attempts", d, attempt)
+	}
+	if m.gauges[MetricRetryWait] != 5 || m.counters[MetricRetryDeadline] != 1 {
+		t.Errorf("unexpected metrics: %v %v", m.gauges, m.counters)
+	}
+}
+
 func TestDo_ExponentialBackoff(t *testing.T) {
 	b0 := backoffDuration(0, time.Second)
 	b1 := backoffDuration(1, time.Second)
@@ -408,12 +836,41 @@ func TestAuthorizeURL(t *testing.T) {
 	}
 }
 
+func TestAuthorizeURLChecked(t *testing.T) {
+	app := App{AppKey: "k", Scope: "read_products,read_ordres"}
+	if _, err := app.AuthorizeURLChecked("shop", ""); err == nil {
+		t.Error("expected error for a misspelled scope")
+	}
+	app.Scope = "read_products,read_orders"
+	if u, err := app.AuthorizeURLChecked("shop", "s"); err != nil || u != app.AuthorizeURL("shop", "s") {
+		t.Errorf("AuthorizeURLChecked = %q, %v", u, err)
+	}
+}
+
+func TestAuthorizeURL_NormalizesScope(t *testing.T) {
+	app := App{AppKey: "k", Scope: " Read_Products, read_orders,read_products "}
+	if u := app.AuthorizeURL("shop", ""); !strings.Contains(u, "scope=read_products%2Cread_orders") {
+		t.Errorf("expected normalized scope in %s", u)
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	app := App{Scope: scopes.Join(scopes.Read(scopes.Products), scopes.Write(scopes.Orders))}
+	if err := app.ValidateScope(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	app.Scope = "read_products,wirte_orders"
+	if err := app.ValidateScope(); err == nil || !strings.Contains(err.Error(), `did you mean "write_orders"`) {
+		t.Errorf("expected typo to be reported, got %v", err)
+	}
+}
+
 func TestBuildQueryString(t *testing.T) {
 	opts := &core.ListOptions{
 		Page:  2,
 		Limit: 50,
 	}
-	qs := buildQueryString(opts)
+	qs, _ := buildQueryString(opts)
 	if qs == "" {
 		t.Fatal("expected non-empty query string")
 	}
@@ -429,14 +886,14 @@ func TestBuildQueryString_OmitEmpty(t *testing.T) {
 		Limit: 25,
 		// Page is 0, should be omitted
 	}
-	qs := buildQueryString(opts)
+	qs, _ := buildQueryString(opts)
 	if qs != "limit=25" {
 		t.Errorf("expected 'limit=25', got %q", qs)
 	}
 }
 
 func TestBuildQueryString_Nil(t *testing.T) {
-	qs := buildQueryString(nil)
+	qs, _ := buildQueryString(nil)
 	if qs != "" {
 		t.Errorf("expected empty string, got %q", qs)
 	}
@@ -586,6 +1043,107 @@ func TestProductDelete(t *testing.T) {
 	}
 }
 
+func TestLocationDelete(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || !strings.HasSuffix(r.URL.Path, "/locations/42.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	defer server.Close()
+
+	if err := client.Location.Delete(context.Background(), 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBundleSetComponents(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "/bundles/5/components.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string][]product.BundleComponent
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]product.Bundle{"bundle": {ID: 5, Components: body["components"]}})
+	})
+	defer server.Close()
+
+	b, err := client.Bundle.SetComponents(context.Background(), 5, []product.BundleComponent{
+		{VariantID: 11, Quantity: 2},
+		{VariantID: 12, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(b.Components) != 2 || b.Components[0].Quantity != 2 {
+		t.Errorf("unexpected bundle: %+v", b)
+	}
+
+	_, err = client.Bundle.SetComponents(context.Background(), 5, []product.BundleComponent{{VariantID: 11, Quantity: 0}})
+	if err == nil {
+		t.Error("expected validation error for zero quantity")
+	}
+}
+
+func TestInventoryCheckAvailability(t *testing.T) {
+	variants := []product.Variant{
+		{ID: 1, InventoryItemID: 11, InventoryManagement: "shopline", InventoryPolicy: "deny"},
+		{ID: 2, InventoryItemID: 22, InventoryManagement: "shopline", InventoryPolicy: "continue"},
+		{ID: 3, InventoryItemID: 33},
+	}
+	levelCalls, variantCalls := 0, 0
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "inventory_levels.json") {
+			levelCalls++
+			if got := r.URL.Query().Get("location_ids"); got != "9" {
+				t.Errorf("expected location_ids=9, got %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string][]product.InventoryLevel{"inventory_levels": {
+				{InventoryItemID: 11, LocationID: 9, Available: 5},
+				{InventoryItemID: 22, LocationID: 9, Available: 0},
+			}})
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/variants.json") {
+			variantCalls++
+			if got := r.URL.Query().Get("ids"); got != "1,2,3" {
+				t.Errorf("expected ids=1,2,3, got %q", got)
+			}
+			json.NewEncoder(w).Encode(map[string][]product.Variant{"variants": variants})
+			return
+		}
+		t.Errorf("unexpected path %s", r.URL.Path)
+	})
+	defer server.Close()
+
+	result, err := client.Inventory.CheckAvailability(context.Background(), []product.VariantQuantity{
+		{VariantID: 1, Quantity: 3},
+		{VariantID: 2, Quantity: 1},
+		{VariantID: 3, Quantity: 100},
+		{VariantID: 1, Quantity: 3},
+	}, 9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if levelCalls != 1 || variantCalls != 1 {
+		t.Errorf("expected single batched variants and inventory_levels calls, got %d and %d", variantCalls, levelCalls)
+	}
+	if len(result) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(result))
+	}
+	if r := result[0]; r.Requested != 6 || r.Available != 5 || r.Sufficient {
+		t.Errorf("variant 1: expected 6 requested, 5 available, insufficient; got %+v", r)
+	}
+	if r := result[1]; !r.Sufficient || !r.Backorder {
+		t.Errorf("variant 2: expected backorder, got %+v", r)
+	}
+	if r := result[2]; !r.Sufficient || r.Tracked {
+		t.Errorf("variant 3: expected untracked and sufficient, got %+v", r)
+	}
+}
+
 func TestOrderList(t *testing.T) {
 	type ordersResource struct {
 		Orders []order.Order `json:"orders"`
@@ -613,6 +1171,25 @@ func TestOrderList(t *testing.T) {
 	}
 }
 
+func TestGiftCardListTransactions(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/gift_cards/5/transactions.json") {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"transactions":[{"id":1,"gift_card_id":5,"order_id":1001,"kind":"debit","amount":"25.00"}]}`))
+	})
+	defer server.Close()
+
+	txns, err := client.GiftCard.ListTransactions(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(txns) != 1 || txns[0].Kind != market.GiftCardTransactionDebit || txns[0].OrderID != 1001 {
+		t.Errorf("unexpected transactions: %+v", txns)
+	}
+}
+
 func TestCustomerGet(t *testing.T) {
 	type customerResource struct {
 		Customer *core.Customer `json:"customer"`
@@ -699,6 +1276,88 @@ func TestVerifyWebhookRequest_BodyPreserved(t *testing.T) {
 	}
 }
 
+func TestVerifyWebhookRequestDetailed(t *testing.T) {
+	app := App{AppKey: "test-key", AppSecret: "test-secret"}
+	body := `{"topic":"orders/create","id":123}`
+	newReq := func(sig string) *http.Request {
+		req := &http.Request{Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+		if sig != "" {
+			req.Header.Set("X-Shopline-Hmac-SHA256", sig)
+		}
+		return req
+	}
+
+	if v := app.VerifyWebhookRequestDetailed(newReq("")); v.Valid || v.Failure != WebhookMissingSignature {
+		t.Errorf("expected missing signature, got %+v", v)
+	}
+
+	wrong := hmacSHA256([]byte("other-secret"), []byte(body))
+	v := app.VerifyWebhookRequestDetailed(newReq(wrong))
+	if v.Valid || v.Failure != WebhookDigestMismatch {
+		t.Fatalf("expected digest mismatch, got %+v", v)
+	}
+	if v.ProvidedPrefix != wrong[:8] || v.BodySize != len(body) {
+		t.Errorf("unexpected diagnostics: %+v", v)
+	}
+	if v.ComputedPrefix != hmacSHA256([]byte(app.AppSecret), []byte(body))[:8] {
+		t.Errorf("unexpected computed prefix %q", v.ComputedPrefix)
+	}
+
+	v = app.VerifyWebhookRequestDetailed(newReq(hmacSHA256([]byte(app.AppSecret), []byte(body))))
+	if !v.Valid || v.Failure != "" {
+		t.Errorf("expected valid signature, got %v", v)
+	}
+}
+
+func TestVerifyAppProxyRequest(t *testing.T) {
+	app := App{AppKey: "test-key", AppSecret: "test-secret"}
+	fixed := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	oldTimeNow := timeNow
+	timeNow = func() time.Time { return fixed }
+	defer func() { timeNow = oldTimeNow }()
+
+	signed := func(ts time.Time) *http.Request {
+		params := map[string]string{
+			"shop":                  "open001.myshopline.com",
+			"logged_in_customer_id": "42",
+			"path_prefix":           "/apps/myapp",
+		}
+		if !ts.IsZero() {
+			params["timestamp"] = fmt.Sprintf("%d", ts.Unix())
+		}
+		q := make([]string, 0, len(params)+1)
+		for k, v := range params {
+			q = append(q, k+"="+v)
+		}
+		q = append(q, "sign="+app.GenerateSignature(params))
+		return httptest.NewRequest(http.MethodGet, "/apps/myapp/page?"+strings.Join(q, "&"), nil)
+	}
+
+	req := signed(fixed.Add(-time.Minute))
+	if !app.VerifyAppProxyRequest(req) {
+		t.Fatal("expected valid app proxy signature")
+	}
+	info := ParseAppProxyRequest(req)
+	if info.Handle != "open001" || info.CustomerID != 42 || info.PathPrefix != "/apps/myapp" {
+		t.Errorf("unexpected proxy info: %+v", info)
+	}
+
+	if app.VerifyAppProxyRequest(signed(fixed.Add(-time.Hour))) {
+		t.Error("expected stale timestamp to be rejected")
+	}
+	if app.VerifyAppProxyRequest(signed(time.Time{})) {
+		t.Error("expected request without timestamp to be rejected")
+	}
+
+	tampered := signed(fixed)
+	q := tampered.URL.Query()
+	q.Set("logged_in_customer_id", "43")
+	tampered.URL.RawQuery = q.Encode()
+	if app.VerifyAppProxyRequest(tampered) {
+		t.Error("expected tampered request to be rejected")
+	}
+}
+
 func TestGetAccessToken_EmptyHandle(t *testing.T) {
 	app := App{AppKey: "k", AppSecret: "s"}
 	_, err := app.GetAccessToken(context.Background(), "", "code123")
@@ -721,6 +1380,31 @@ func TestRefreshAccessToken_EmptyHandle(t *testing.T) {
 	}
 }
 
+func TestGetAccessToken_ReturnsFailedResponse(t *testing.T) {
+	orig := authHTTPClient
+	defer func() { authHTTPClient = orig }()
+	authHTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"code":0,"message":"gateway error","traceId":"t-1"}`)),
+		}, nil
+	})}
+
+	app := App{AppKey: "k", AppSecret: "s"}
+	resp, err := app.GetAccessToken(context.Background(), "shop", "code123")
+	if err == nil {
+		t.Fatal("expected error for code 0")
+	}
+	if resp == nil || resp.TraceID != "t-1" {
+		t.Errorf("expected the decoded response with the error, got %+v", resp)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
 // hmacSHA256 computes HMAC-SHA256 for test use.
 func hmacSHA256(key, data []byte) string {
 	h := hmac.New(sha256.New, key)
@@ -866,7 +1550,7 @@ func TestBuildQueryString_SliceString(t *testing.T) {
 	}{
 		Tags: []string{"new", "featured", "sale"},
 	}
-	qs := buildQueryString(&opts)
+	qs, _ := buildQueryString(&opts)
 	// Should contain all three values as repeated params
 	for _, tag := range []string{"new", "featured", "sale"} {
 		if !strings.Contains(qs, "tags="+tag) {
@@ -881,7 +1565,7 @@ func TestBuildQueryString_SliceInt64(t *testing.T) {
 	}{
 		IDs: []int64{1001, 1002, 1003},
 	}
-	qs := buildQueryString(&opts)
+	qs, _ := buildQueryString(&opts)
 	for _, id := range []string{"1001", "1002", "1003"} {
 		if !strings.Contains(qs, "ids="+id) {
 			t.Errorf("expected 'ids=%s' in query string %q", id, qs)
@@ -895,7 +1579,7 @@ func TestBuildQueryString_EmptySliceOmitted(t *testing.T) {
 	}{
 		IDs: []int64{},
 	}
-	qs := buildQueryString(&opts)
+	qs, _ := buildQueryString(&opts)
 	if qs != "" {
 		t.Errorf("expected empty query string for empty slice, got %q", qs)
 	}
@@ -910,7 +1594,7 @@ func TestBuildQueryString_EmbeddedStruct(t *testing.T) {
 		ListOptions: core.ListOptions{Limit: 10, Page: 2},
 		Status:      "open",
 	}
-	qs := buildQueryString(&opts)
+	qs, _ := buildQueryString(&opts)
 	if !strings.Contains(qs, "limit=10") {
 		t.Errorf("expected 'limit=10' in %q", qs)
 	}
@@ -922,6 +1606,174 @@ func TestBuildQueryString_EmbeddedStruct(t *testing.T) {
 	}
 }
 
+func TestBuildQueryString_Sort(t *testing.T) {
+	opts := struct {
+		core.ListOptions
+		Status string `url:"status,omitempty"`
+	}{
+		ListOptions: core.ListOptions{SortBy: "updated_at", Order: core.SortAsc},
+	}
+	qs, _ := buildQueryString(&opts)
+	if qs != "order=asc&sort_by=updated_at" {
+		t.Errorf("expected sort params, got %q", qs)
+	}
+}
+
+type statusFilter []string
+
+func (f statusFilter) EncodeValues(key string, v *url.Values) error {
+	v.Set(key, "("+strings.Join(f, " OR ")+")")
+	return nil
+}
+
+func TestBuildQueryString_TypedFields(t *testing.T) {
+	published := false
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	opts := struct {
+		*core.ListOptions
+		Limit     int          `url:"limit,omitempty"` // shadows ListOptions.Limit
+		Published *bool        `url:"published,omitempty"`
+		Vendor    *string      `url:"vendor,omitempty"`
+		Since     time.Time    `url:"since,omitempty"`
+		Until     time.Time    `url:"until,omitempty"`
+		SinceUnix time.Time    `url:"since_unix,unix"`
+		Gift      bool         `url:"gift,int"`
+		IDs       []int64      `url:"ids,comma,omitempty"`
+		Tags      []string     `url:"tags,brackets"`
+		Status    statusFilter `url:"status,omitempty"`
+		Price     struct {
+			Min string `url:"min,omitempty"`
+			Max string `url:"max,omitempty"`
+		} `url:"price"`
+		internal string
+	}{
+		ListOptions: &core.ListOptions{Limit: 10, Page: 2},
+		Limit:       50,
+		Published:   &published,
+		Since:       since,
+		SinceUnix:   since,
+		Gift:        true,
+		IDs:         []int64{1, 2},
+		Tags:        []string{"a"},
+		Status:      statusFilter{"open", "closed"},
+		internal:    "x",
+	}
+	opts.Price.Min = "5.00"
+
+	qs, err := buildQueryString(&opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := url.ParseQuery(qs)
+	want := url.Values{
+		"limit":      {"50"},
+		"page":       {"2"},
+		"published":  {"false"},
+		"since":      {"2026-03-01T12:00:00Z"},
+		"since_unix": {"1772366400"},
+		"gift":       {"1"},
+		"ids":        {"1,2"},
+		"tags[]":     {"a"},
+		"status":     {"(open OR closed)"},
+		"price[min]": {"5.00"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected query\ngot:  %v\nwant: %v", got, want)
+	}
+
+	var nilEmbedded struct {
+		*core.ListOptions
+		Status string `url:"status"`
+	}
+	if qs, _ := buildQueryString(&nilEmbedded); qs != "status=" {
+		t.Errorf("expected nil embedded struct to be skipped, got %q", qs)
+	}
+}
+
+// getCalls lists every single-resource Get method reachable from the Client.
+func getCalls(c *Client) map[string]func(ctx context.Context) (any, error) {
+	return map[string]func(ctx context.Context) (any, error){
+		"BulkOperation.GetCurrent":          func(ctx context.Context) (any, error) { return c.BulkOperation.GetCurrent(ctx, "QUERY") },
+		"Customer.Get":                      func(ctx context.Context) (any, error) { return c.Customer.Get(ctx, 1) },
+		"Customer.GetGroup":                 func(ctx context.Context) (any, error) { return c.Customer.GetGroup(ctx, 1) },
+		"Customer.GetAddress":               func(ctx context.Context) (any, error) { return c.Customer.GetAddress(ctx, 1, 2) },
+		"CustomerSegment.Get":               func(ctx context.Context) (any, error) { return c.CustomerSegment.Get(ctx, 1) },
+		"Localizations.GetLanguages":        func(ctx context.Context) (any, error) { return c.Localizations.GetLanguages(ctx) },
+		"Localizations.GetTranslation":      func(ctx context.Context) (any, error) { return c.Localizations.GetTranslation(ctx, nil) },
+		"Market.Get":                        func(ctx context.Context) (any, error) { return c.Market.Get(ctx, 1) },
+		"Location.Get":                      func(ctx context.Context) (any, error) { return c.Location.Get(ctx, 1) },
+		"GiftCard.Get":                      func(ctx context.Context) (any, error) { return c.GiftCard.Get(ctx, 1) },
+		"Discount.GetPriceRule":             func(ctx context.Context) (any, error) { return c.Discount.GetPriceRule(ctx, 1) },
+		"Discount.GetDiscountCode":          func(ctx context.Context) (any, error) { return c.Discount.GetDiscountCode(ctx, 1, 2) },
+		"MetafieldDefinition.Get":           func(ctx context.Context) (any, error) { return c.MetafieldDefinition.Get(ctx, 1) },
+		"MetafieldResource.Get":             func(ctx context.Context) (any, error) { return c.MetafieldResource.Get(ctx, "products", 1, 2) },
+		"MetafieldStore.Get":                func(ctx context.Context) (any, error) { return c.MetafieldStore.Get(ctx, 1) },
+		"MetaobjectDefinition.Get":          func(ctx context.Context) (any, error) { return c.MetaobjectDefinition.Get(ctx, 1) },
+		"Metaobject.Get":                    func(ctx context.Context) (any, error) { return c.Metaobject.Get(ctx, 1) },
+		"Theme.Get":                         func(ctx context.Context) (any, error) { return c.Theme.Get(ctx, 1) },
+		"Page.Get":                          func(ctx context.Context) (any, error) { return c.Page.Get(ctx, 1) },
+		"ScriptTag.Get":                     func(ctx context.Context) (any, error) { return c.ScriptTag.Get(ctx, 1) },
+		"DraftOrder.Get":                    func(ctx context.Context) (any, error) { return c.DraftOrder.Get(ctx, 1) },
+		"Fulfillment.GetByFulfillmentOrder": func(ctx context.Context) (any, error) { return c.Fulfillment.GetByFulfillmentOrder(ctx, 1, 2) },
+		"CarrierService.Get":                func(ctx context.Context) (any, error) { return c.CarrierService.Get(ctx, 1) },
+		"FulfillmentSvcDef.Get":             func(ctx context.Context) (any, error) { return c.FulfillmentSvcDef.Get(ctx, 1) },
+		"Order.Get":                         func(ctx context.Context) (any, error) { return c.Order.Get(ctx, 1) },
+		"Order.GetRefund":                   func(ctx context.Context) (any, error) { return c.Order.GetRefund(ctx, 1, 2) },
+		"Order.GetRisk":                     func(ctx context.Context) (any, error) { return c.Order.GetRisk(ctx, 1, 2) },
+		"Order.GetTransaction":              func(ctx context.Context) (any, error) { return c.Order.GetTransaction(ctx, 1, 2) },
+		"Payment.GetSettings":               func(ctx context.Context) (any, error) { return c.Payment.GetSettings(ctx) },
+		"AbandonedCheckout.Get":             func(ctx context.Context) (any, error) { return c.AbandonedCheckout.Get(ctx, 1) },
+		"Subscription.Get":                  func(ctx context.Context) (any, error) { return c.Subscription.Get(ctx, 1) },
+		"Tax.GetCountry":                    func(ctx context.Context) (any, error) { return c.Tax.GetCountry(ctx, 1) },
+		"Tax.GetProvince":                   func(ctx context.Context) (any, error) { return c.Tax.GetProvince(ctx, 1) },
+		"Bundle.Get":                        func(ctx context.Context) (any, error) { return c.Bundle.Get(ctx, 1) },
+		"Collection.Get":                    func(ctx context.Context) (any, error) { return c.Collection.Get(ctx, 1) },
+		"SmartCollection.Get":               func(ctx context.Context) (any, error) { return c.SmartCollection.Get(ctx, 1) },
+		"ManualCollection.Get":              func(ctx context.Context) (any, error) { return c.ManualCollection.Get(ctx, 1) },
+		"Inventory.GetItem":                 func(ctx context.Context) (any, error) { return c.Inventory.GetItem(ctx, 1) },
+		"Review.Get":                        func(ctx context.Context) (any, error) { return c.Review.Get(ctx, 1) },
+		"Product.Get":                       func(ctx context.Context) (any, error) { return c.Product.Get(ctx, 1) },
+		"SalesChannel.GetProduct":           func(ctx context.Context) (any, error) { return c.SalesChannel.GetProduct(ctx, 1) },
+		"SalesChannel.GetCollection":        func(ctx context.Context) (any, error) { return c.SalesChannel.GetCollection(ctx, 1) },
+		"ShoplinePayments.GetBalance":       func(ctx context.Context) (any, error) { return c.ShoplinePayments.GetBalance(ctx) },
+		"Store.GetInfo":                     func(ctx context.Context) (any, error) { return c.Store.GetInfo(ctx) },
+		"Store.GetStaffMember":              func(ctx context.Context) (any, error) { return c.Store.GetStaffMember(ctx, "u1") },
+		"Store.GetOperationLog":             func(ctx context.Context) (any, error) { return c.Store.GetOperationLog(ctx, 1) },
+		"Store.GetActiveSubscription":       func(ctx context.Context) (any, error) { return c.Store.GetActiveSubscription(ctx) },
+		"Store.GetShop":                     func(ctx context.Context) (any, error) { return c.Store.GetShop(ctx) },
+		"ShippingZone.Get":                  func(ctx context.Context) (any, error) { return c.ShippingZone.Get(ctx, 1) },
+		"Events.Get":                        func(ctx context.Context) (any, error) { return c.Events.Get(ctx, 1) },
+		"Webhook.Get":                       func(ctx context.Context) (any, error) { return c.Webhook.Get(ctx, 1) },
+	}
+}
+
+func TestGetMethods_NotFound(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		body   string
+	}{
+		"404":        {http.StatusNotFound, `{"errors":"Not Found","traceId":"nf1"}`},
+		"empty body": {http.StatusOK, `{}`},
+	}
+	for name, tc := range cases {
+		client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tc.status)
+			fmt.Fprint(w, tc.body)
+		})
+		for method, call := range getCalls(client) {
+			v, err := call(context.Background())
+			if !errors.Is(err, core.ErrNotFound) {
+				t.Errorf("%s %s: expected core.ErrNotFound, got %v", name, method, err)
+			}
+			if rv := reflect.ValueOf(v); !rv.IsNil() {
+				t.Errorf("%s %s: expected nil result, got %+v", name, method, v)
+			}
+		}
+		server.Close()
+	}
+}
+
 // ============== WithTimeout / WithCircuitBreaker option tests ==============
 
 func TestWithTimeout(t *testing.T) {
@@ -987,3 +1839,352 @@ func TestNewClient_AllServicesRegistered(t *testing.T) {
 		}
 	}
 }
+
+func TestDo_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+	WithMaxConcurrentRequests(2)(client)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Get(context.Background(), "/test", nil, nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", peak)
+	}
+
+	// A full slot pool makes a call wait only as long as its context allows.
+	release, _ := client.limiter.acquire(context.Background(), client.handle)
+	release2, _ := client.limiter.acquire(context.Background(), client.handle)
+	defer release()
+	defer release2()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := client.Get(ctx, "/test", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_WithAPIVersion(t *testing.T) {
+	var paths []string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"products":[]}`))
+	})
+	defer server.Close()
+
+	newer := client.WithAPIVersion("v20260301")
+	if _, err := newer.Product.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Product.List(context.Background(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"/admin/openapi/v20260301/products.json",
+		"/admin/openapi/" + DefaultAPIVersion + "/products.json",
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("expected %v, got %v", want, paths)
+	}
+	if client.GetAPIVersion() != DefaultAPIVersion || newer.GetAPIVersion() != "v20260301" {
+		t.Errorf("unexpected versions %q / %q", client.GetAPIVersion(), newer.GetAPIVersion())
+	}
+}
+
+func TestGet_WithFields(t *testing.T) {
+	var queries []string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("fields"))
+		w.Write([]byte(`{"product":{"id":1},"products":[]}`))
+	})
+	defer server.Close()
+
+	ctx := core.WithFields(context.Background(), "id", "title")
+	if _, err := client.Product.Get(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Product.List(ctx, &core.ListOptions{Fields: "id"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"id,title", "id"}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("expected fields %v, got %v", want, queries)
+	}
+
+	if _, err := client.Product.Get(core.WithFields(context.Background(), "id", "titel"), 1); err == nil || !strings.Contains(err.Error(), "titel") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+	if len(queries) != 2 {
+		t.Errorf("expected no request for unknown fields, got %v", queries)
+	}
+}
+
+func TestContextWithHeaders(t *testing.T) {
+	var got http.Header
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"product":{"id":1}}`))
+	})
+	defer server.Close()
+
+	ctx := ContextWithHeaders(context.Background(), http.Header{"X-Request-Id": {"req-1"}, "X-Partner": {"a"}})
+	ctx = ContextWithHeaders(ctx, http.Header{"x-partner": {"b"}, "Authorization": {"Bearer stolen"}})
+	if _, err := client.Product.Get(ctx, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Get("X-Request-Id") != "req-1" || got.Get("X-Partner") != "b" {
+		t.Errorf("expected context headers to be sent, got %v", got)
+	}
+	if got.Get("Authorization") != "Bearer test-token" {
+		t.Errorf("expected client token, got %q", got.Get("Authorization"))
+	}
+}
+
+func TestEventsPublish(t *testing.T) {
+	var body map[string]map[string]interface{}
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/flow/triggers.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{}`))
+	})
+	defer server.Close()
+
+	err := client.Events.Publish(context.Background(), events.AppEvent{
+		Handle:  "loyalty-tier-changed",
+		Payload: map[string]interface{}{"customer_id": 42, "tier": "gold"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["trigger"]["handle"] != "loyalty-tier-changed" || body["trigger"]["payload"].(map[string]interface{})["tier"] != "gold" {
+		t.Errorf("unexpected request body: %v", body)
+	}
+	if err := client.Events.Publish(context.Background(), events.AppEvent{}); err == nil {
+		t.Error("expected error without a handle")
+	}
+}
+
+func TestThemeLifecycle(t *testing.T) {
+	var requests []string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		switch {
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/themes/5.json"):
+			if body["theme"]["role"] != "main" {
+				t.Errorf("unexpected publish body: %v", body)
+			}
+			w.Write([]byte(`{"theme":{"id":5,"role":"main"}}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/themes/5/duplicate.json"):
+			if body["theme"]["name"] != "Staging" {
+				t.Errorf("unexpected duplicate body: %v", body)
+			}
+			w.Write([]byte(`{"theme":{"id":6,"name":"Staging","role":"unpublished","processing":true}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	theme, err := client.Theme.Publish(context.Background(), 5)
+	if err != nil || theme.Role != onlinestore.ThemeRoleMain {
+		t.Fatalf("Publish = %+v, %v", theme, err)
+	}
+	dup, err := client.Theme.Duplicate(context.Background(), 5, "Staging")
+	if err != nil || dup.ID != 6 || !dup.Processing {
+		t.Fatalf("Duplicate = %+v, %v", dup, err)
+	}
+	if _, err := client.Theme.Duplicate(context.Background(), 5, ""); err == nil {
+		t.Error("expected error without a name")
+	}
+	if len(requests) != 2 {
+		t.Errorf("requests = %v", requests)
+	}
+	if got, want := client.Theme.PreviewURL(6), server.URL+"/?preview_theme_id=6"; got != want {
+		t.Errorf("PreviewURL = %q, want %q", got, want)
+	}
+}
+
+func TestUpdateOnConflict_ResponseError(t *testing.T) {
+	var puts int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"product":{"id":1,"title":"Admin title","tags":"a"}}`))
+			return
+		}
+		if atomic.AddInt32(&puts, 1) == 1 {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"errors":"stale updated_at"}`))
+			return
+		}
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(body)
+	})
+	defer server.Close()
+
+	for _, status := range []int{http.StatusConflict, http.StatusPreconditionFailed} {
+		if err := (&ResponseError{Status: status}); !errors.Is(err, core.ErrConflict) {
+			t.Errorf("%d does not match core.ErrConflict", status)
+		}
+	}
+
+	p, err := core.UpdateOnConflict(context.Background(), product.Product{ID: 1, Tags: "synced"}, client.Product.Update, core.ConflictPolicy[product.Product]{
+		Refetch: func(ctx context.Context) (*product.Product, error) { return client.Product.Get(ctx, 1) },
+		Merge: func(cur *product.Product, want product.Product) (product.Product, error) {
+			merged := *cur
+			merged.Tags = want.Tags
+			return merged, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("UpdateOnConflict: %v", err)
+	}
+	if p.Title != "Admin title" || p.Tags != "synced" || atomic.LoadInt32(&puts) != 2 {
+		t.Errorf("got %+v after %d updates", p, atomic.LoadInt32(&puts))
+	}
+}
+
+func TestIdempotencyKeyFromContext(t *testing.T) {
+	var got string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(IdempotencyKeyHeader)
+		w.Write([]byte(`{"draft_order":{"id":1}}`))
+	})
+	defer server.Close()
+
+	ctx := core.WithIdempotencyKey(context.Background(), "key-1")
+	if _, err := client.DraftOrder.Create(ctx, order.DraftOrder{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "key-1" {
+		t.Errorf("expected idempotency key header, got %q", got)
+	}
+}
+
+func TestList_CursorPagination(t *testing.T) {
+	var pageInfos []string
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		pageInfo := r.URL.Query().Get("page_info")
+		pageInfos = append(pageInfos, pageInfo)
+		if pageInfo == "" {
+			w.Header().Set("Link", `<https://testshop.myshopline.com`+r.URL.Path+`?limit=1&page_info=c2>; rel="next"`)
+			w.Write([]byte(`{"orders":[{"id":1}]}`))
+			return
+		}
+		w.Header().Set("Link", `<https://testshop.myshopline.com`+r.URL.Path+`?limit=1&page_info=c1>; rel="previous"`)
+		w.Write([]byte(`{"orders":[{"id":2}]}`))
+	})
+	defer server.Close()
+
+	ctx, page := core.WithPagination(context.Background())
+	opts := &core.ListOptions{Limit: 1}
+	var ids []int64
+	for opts != nil {
+		orders, err := client.Order.List(ctx, &order.ListOptions{ListOptions: *opts})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, o := range orders {
+			ids = append(ids, o.ID)
+		}
+		opts = page.NextOptions(opts)
+	}
+	if !reflect.DeepEqual(ids, []int64{1, 2}) || !reflect.DeepEqual(pageInfos, []string{"", "c2"}) {
+		t.Errorf("unexpected pages: ids %v, page_info %v", ids, pageInfos)
+	}
+	if page.PreviousPageInfo != "c1" {
+		t.Errorf("expected previous cursor c1, got %q", page.PreviousPageInfo)
+	}
+}
+
+// debugLogger records Debugf output.
+type debugLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *debugLogger) Debugf(format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+func (l *debugLogger) Infof(string, ...interface{})  {}
+func (l *debugLogger) Errorf(string, ...interface{}) {}
+
+func TestRequestInfoFromContext(t *testing.T) {
+	var calls int32
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Traceid", fmt.Sprintf("trace-%d", atomic.AddInt32(&calls, 1)))
+		if atomic.LoadInt32(&calls) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"order":{"id":7}}`))
+	})
+	defer server.Close()
+	log := &debugLogger{}
+	WithLogger(log)(client)
+	WithRetry(1)(client)
+
+	ctx, info := WithRequestInfo(context.Background())
+	if RequestInfoFromContext(ctx) != info {
+		t.Fatal("RequestInfoFromContext did not return the recorded info")
+	}
+	if _, err := client.Order.Get(ctx, 7); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if info.TraceID != "trace-2" || info.StatusCode != http.StatusOK || info.Attempt != 2 {
+		t.Errorf("unexpected request info: %+v", info)
+	}
+	if info.Request == nil || !strings.HasSuffix(info.Request.URL.Path, "/orders/7.json") {
+		t.Errorf("unexpected request: %+v", info.Request)
+	}
+	joined := strings.Join(log.lines, "\n")
+	if !strings.Contains(joined, "traceId: trace-1") || !strings.Contains(joined, "traceId: trace-2") {
+		t.Errorf("trace IDs missing from log output:\n%s", joined)
+	}
+}
+
+func TestListWithInfo(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Traceid", "trace-42")
+		w.Header().Set("X-Ratelimit-Remaining", "7")
+		w.Header().Set("Link", `<https://testshop.myshopline.com`+r.URL.Path+`?page_info=n1>; rel="next"`)
+		w.Write([]byte(`{"products":[{"id":1}]}`))
+	})
+	defer server.Close()
+
+	products, info, err := core.ListWithInfo(context.Background(), func(ctx context.Context) ([]product.Product, error) {
+		return client.Product.List(ctx, nil)
+	})
+	if err != nil || len(products) != 1 {
+		t.Fatalf("unexpected result: %v, %v", products, err)
+	}
+	if info.TraceID != "trace-42" || info.RateLimit.Remaining != 7 || info.NextPageInfo != "n1" || info.StatusCode != http.StatusOK {
+		t.Errorf("unexpected page info: %+v", info)
+	}
+}
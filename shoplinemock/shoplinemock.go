@@ -0,0 +1,67 @@
+// Package shoplinemock provides mocks of every service of shopline.Client,
+// so code using the SDK can be unit tested without an HTTP server:
+//
+//	client, mocks := shoplinemock.NewClient()
+//	mocks.Order.GetFunc = func(ctx context.Context, id int64) (*order.Order, error) {
+//	    return &order.Order{ID: id, FinancialStatus: "paid"}, nil
+//	}
+//	err := syncOrder(ctx, client, 42)
+//	if mocks.Order.Calls("Get") != 1 { ... }
+//
+// Each mock has a <Method>Func field per method of its interface; methods
+// whose field is nil return ErrNotMocked. The mocks are plain structs without
+// expectations, so they work with any assertion library. They are generated
+// by cmd/slshop-mockgen.
+package shoplinemock
+
+//go:generate go run ../cmd/slshop-mockgen -o zz_generated.go
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	shopline "github.com/imokyou/slshop"
+)
+
+// ErrNotMocked is returned by mocked methods that have no func set.
+var ErrNotMocked = errors.New("shoplinemock: method not mocked")
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
+
+// NewClient returns a Client whose services are all mocks, and the mocks.
+// Requests made through other Client methods, such as Raw, fail to connect.
+func NewClient() (*shopline.Client, *Mocks) {
+	c, err := shopline.NewClient(shopline.App{AppKey: "mock", AppSecret: "mock"}, "mock", "mock",
+		shopline.WithBaseURL("http://127.0.0.1:0"))
+	if err != nil {
+		panic(fmt.Sprintf("shoplinemock: %v", err))
+	}
+	m := newMocks()
+	m.install(c)
+	return c, m
+}
+
+// recorder counts calls per method. It is safe for concurrent use.
+type recorder struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (r *recorder) record(method string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.calls == nil {
+		r.calls = make(map[string]int)
+	}
+	r.calls[method]++
+}
+
+// Calls returns how many times method was called.
+func (r *recorder) Calls(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[method]
+}
@@ -0,0 +1,34 @@
+package shoplinemock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imokyou/slshop/order"
+)
+
+func TestNewClient(t *testing.T) {
+	client, mocks := NewClient()
+	mocks.Order.GetFunc = func(ctx context.Context, id int64) (*order.Order, error) {
+		return &order.Order{ID: id, FinancialStatus: "paid"}, nil
+	}
+
+	o, err := client.Order.Get(context.Background(), 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ID != 42 || o.FinancialStatus != "paid" {
+		t.Errorf("order = %+v", o)
+	}
+	if n := mocks.Order.Calls("Get"); n != 1 {
+		t.Errorf("Calls(Get) = %d, want 1", n)
+	}
+
+	if _, err := client.Order.Count(context.Background(), nil); !errors.Is(err, ErrNotMocked) {
+		t.Errorf("unmocked Count: err = %v, want ErrNotMocked", err)
+	}
+	if n := mocks.Order.Calls("Count"); n != 1 {
+		t.Errorf("Calls(Count) = %d, want 1", n)
+	}
+}
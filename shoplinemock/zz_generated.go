@@ -0,0 +1,4174 @@
+// Code generated by slshop-mockgen. DO NOT EDIT.
+
+package shoplinemock
+
+import (
+	"context"
+	"time"
+
+	shopline "github.com/imokyou/slshop"
+	"github.com/imokyou/slshop/access"
+	appopenapi "github.com/imokyou/slshop/app_openapi"
+	"github.com/imokyou/slshop/bulk"
+	"github.com/imokyou/slshop/core"
+	"github.com/imokyou/slshop/customer"
+	"github.com/imokyou/slshop/events"
+	"github.com/imokyou/slshop/localizations"
+	"github.com/imokyou/slshop/market"
+	"github.com/imokyou/slshop/marketing"
+	"github.com/imokyou/slshop/media"
+	"github.com/imokyou/slshop/metafield"
+	"github.com/imokyou/slshop/metaobject"
+	onlinestore "github.com/imokyou/slshop/online_store"
+	"github.com/imokyou/slshop/order"
+	paymentsapp "github.com/imokyou/slshop/payments_app"
+	"github.com/imokyou/slshop/product"
+	"github.com/imokyou/slshop/review"
+	saleschannel "github.com/imokyou/slshop/sales_channel"
+	shoplinepay "github.com/imokyou/slshop/shopline_payments"
+	"github.com/imokyou/slshop/store"
+	"github.com/imokyou/slshop/webhook"
+)
+
+// OrderService mocks order.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type OrderService struct {
+	recorder
+	AddTagsFunc          func(ctx context.Context, orderID int64, tags ...string) (*order.Order, error)
+	CalculateRefundFunc  func(ctx context.Context, orderID int64, refund order.Refund) (*order.Refund, error)
+	CancelFunc           func(ctx context.Context, id int64, opts *order.CancelOptions) (*order.Order, error)
+	CloseFunc            func(ctx context.Context, id int64) (*order.Order, error)
+	CountFunc            func(ctx context.Context, opts *order.CountOptions) (int, error)
+	CreateFunc           func(ctx context.Context, order order.Order) (*order.Order, error)
+	CreateRefundFunc     func(ctx context.Context, orderID int64, refund order.Refund) (*order.Refund, error)
+	CreateRiskFunc       func(ctx context.Context, orderID int64, risk order.Risk) (*order.Risk, error)
+	DeleteFunc           func(ctx context.Context, id int64) error
+	DeleteAllRisksFunc   func(ctx context.Context, orderID int64) error
+	DeleteRiskFunc       func(ctx context.Context, orderID int64, riskID int64) error
+	GetFunc              func(ctx context.Context, id int64) (*order.Order, error)
+	GetInvoiceURLFunc    func(ctx context.Context, orderID int64) (string, error)
+	GetRefundFunc        func(ctx context.Context, orderID int64, refundID int64) (*order.Refund, error)
+	GetRiskFunc          func(ctx context.Context, orderID int64, riskID int64) (*order.Risk, error)
+	GetTransactionFunc   func(ctx context.Context, orderID int64, transactionID int64) (*order.Transaction, error)
+	ListFunc             func(ctx context.Context, opts *order.ListOptions) ([]order.Order, error)
+	ListRefundsFunc      func(ctx context.Context, orderID int64) ([]order.Refund, error)
+	ListRisksFunc        func(ctx context.Context, orderID int64) ([]order.Risk, error)
+	ListTransactionsFunc func(ctx context.Context, orderID int64) ([]order.Transaction, error)
+	OpenFunc             func(ctx context.Context, id int64) (*order.Order, error)
+	RefundAllFunc        func(ctx context.Context, orderID int64, opts *order.RefundAllOptions) (*order.Refund, error)
+	RemoveTagsFunc       func(ctx context.Context, orderID int64, tags ...string) (*order.Order, error)
+	SendInvoiceFunc      func(ctx context.Context, orderID int64, to string) error
+	UpdateFunc           func(ctx context.Context, order order.Order) (*order.Order, error)
+	UpdateRiskFunc       func(ctx context.Context, orderID int64, risk order.Risk) (*order.Risk, error)
+}
+
+var _ order.Service = (*OrderService)(nil)
+
+// AddTags implements order.Service.
+func (m *OrderService) AddTags(p0 context.Context, p1 int64, p2 ...string) (*order.Order, error) {
+	m.record("AddTags")
+	if m.AddTagsFunc != nil {
+		return m.AddTagsFunc(p0, p1, p2...)
+	}
+	return nil, notMocked("Order.AddTags")
+}
+
+// CalculateRefund implements order.Service.
+func (m *OrderService) CalculateRefund(p0 context.Context, p1 int64, p2 order.Refund) (*order.Refund, error) {
+	m.record("CalculateRefund")
+	if m.CalculateRefundFunc != nil {
+		return m.CalculateRefundFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.CalculateRefund")
+}
+
+// Cancel implements order.Service.
+func (m *OrderService) Cancel(p0 context.Context, p1 int64, p2 *order.CancelOptions) (*order.Order, error) {
+	m.record("Cancel")
+	if m.CancelFunc != nil {
+		return m.CancelFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.Cancel")
+}
+
+// Close implements order.Service.
+func (m *OrderService) Close(p0 context.Context, p1 int64) (*order.Order, error) {
+	m.record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc(p0, p1)
+	}
+	return nil, notMocked("Order.Close")
+}
+
+// Count implements order.Service.
+func (m *OrderService) Count(p0 context.Context, p1 *order.CountOptions) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1)
+	}
+	return 0, notMocked("Order.Count")
+}
+
+// Create implements order.Service.
+func (m *OrderService) Create(p0 context.Context, p1 order.Order) (*order.Order, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Order.Create")
+}
+
+// CreateRefund implements order.Service.
+func (m *OrderService) CreateRefund(p0 context.Context, p1 int64, p2 order.Refund) (*order.Refund, error) {
+	m.record("CreateRefund")
+	if m.CreateRefundFunc != nil {
+		return m.CreateRefundFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.CreateRefund")
+}
+
+// CreateRisk implements order.Service.
+func (m *OrderService) CreateRisk(p0 context.Context, p1 int64, p2 order.Risk) (*order.Risk, error) {
+	m.record("CreateRisk")
+	if m.CreateRiskFunc != nil {
+		return m.CreateRiskFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.CreateRisk")
+}
+
+// Delete implements order.Service.
+func (m *OrderService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Order.Delete")
+}
+
+// DeleteAllRisks implements order.Service.
+func (m *OrderService) DeleteAllRisks(p0 context.Context, p1 int64) error {
+	m.record("DeleteAllRisks")
+	if m.DeleteAllRisksFunc != nil {
+		return m.DeleteAllRisksFunc(p0, p1)
+	}
+	return notMocked("Order.DeleteAllRisks")
+}
+
+// DeleteRisk implements order.Service.
+func (m *OrderService) DeleteRisk(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("DeleteRisk")
+	if m.DeleteRiskFunc != nil {
+		return m.DeleteRiskFunc(p0, p1, p2)
+	}
+	return notMocked("Order.DeleteRisk")
+}
+
+// Get implements order.Service.
+func (m *OrderService) Get(p0 context.Context, p1 int64) (*order.Order, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Order.Get")
+}
+
+// GetInvoiceURL implements order.Service.
+func (m *OrderService) GetInvoiceURL(p0 context.Context, p1 int64) (string, error) {
+	m.record("GetInvoiceURL")
+	if m.GetInvoiceURLFunc != nil {
+		return m.GetInvoiceURLFunc(p0, p1)
+	}
+	return "", notMocked("Order.GetInvoiceURL")
+}
+
+// GetRefund implements order.Service.
+func (m *OrderService) GetRefund(p0 context.Context, p1 int64, p2 int64) (*order.Refund, error) {
+	m.record("GetRefund")
+	if m.GetRefundFunc != nil {
+		return m.GetRefundFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.GetRefund")
+}
+
+// GetRisk implements order.Service.
+func (m *OrderService) GetRisk(p0 context.Context, p1 int64, p2 int64) (*order.Risk, error) {
+	m.record("GetRisk")
+	if m.GetRiskFunc != nil {
+		return m.GetRiskFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.GetRisk")
+}
+
+// GetTransaction implements order.Service.
+func (m *OrderService) GetTransaction(p0 context.Context, p1 int64, p2 int64) (*order.Transaction, error) {
+	m.record("GetTransaction")
+	if m.GetTransactionFunc != nil {
+		return m.GetTransactionFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.GetTransaction")
+}
+
+// List implements order.Service.
+func (m *OrderService) List(p0 context.Context, p1 *order.ListOptions) ([]order.Order, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Order.List")
+}
+
+// ListRefunds implements order.Service.
+func (m *OrderService) ListRefunds(p0 context.Context, p1 int64) ([]order.Refund, error) {
+	m.record("ListRefunds")
+	if m.ListRefundsFunc != nil {
+		return m.ListRefundsFunc(p0, p1)
+	}
+	return nil, notMocked("Order.ListRefunds")
+}
+
+// ListRisks implements order.Service.
+func (m *OrderService) ListRisks(p0 context.Context, p1 int64) ([]order.Risk, error) {
+	m.record("ListRisks")
+	if m.ListRisksFunc != nil {
+		return m.ListRisksFunc(p0, p1)
+	}
+	return nil, notMocked("Order.ListRisks")
+}
+
+// ListTransactions implements order.Service.
+func (m *OrderService) ListTransactions(p0 context.Context, p1 int64) ([]order.Transaction, error) {
+	m.record("ListTransactions")
+	if m.ListTransactionsFunc != nil {
+		return m.ListTransactionsFunc(p0, p1)
+	}
+	return nil, notMocked("Order.ListTransactions")
+}
+
+// Open implements order.Service.
+func (m *OrderService) Open(p0 context.Context, p1 int64) (*order.Order, error) {
+	m.record("Open")
+	if m.OpenFunc != nil {
+		return m.OpenFunc(p0, p1)
+	}
+	return nil, notMocked("Order.Open")
+}
+
+// RefundAll implements order.Service.
+func (m *OrderService) RefundAll(p0 context.Context, p1 int64, p2 *order.RefundAllOptions) (*order.Refund, error) {
+	m.record("RefundAll")
+	if m.RefundAllFunc != nil {
+		return m.RefundAllFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.RefundAll")
+}
+
+// RemoveTags implements order.Service.
+func (m *OrderService) RemoveTags(p0 context.Context, p1 int64, p2 ...string) (*order.Order, error) {
+	m.record("RemoveTags")
+	if m.RemoveTagsFunc != nil {
+		return m.RemoveTagsFunc(p0, p1, p2...)
+	}
+	return nil, notMocked("Order.RemoveTags")
+}
+
+// SendInvoice implements order.Service.
+func (m *OrderService) SendInvoice(p0 context.Context, p1 int64, p2 string) error {
+	m.record("SendInvoice")
+	if m.SendInvoiceFunc != nil {
+		return m.SendInvoiceFunc(p0, p1, p2)
+	}
+	return notMocked("Order.SendInvoice")
+}
+
+// Update implements order.Service.
+func (m *OrderService) Update(p0 context.Context, p1 order.Order) (*order.Order, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Order.Update")
+}
+
+// UpdateRisk implements order.Service.
+func (m *OrderService) UpdateRisk(p0 context.Context, p1 int64, p2 order.Risk) (*order.Risk, error) {
+	m.record("UpdateRisk")
+	if m.UpdateRiskFunc != nil {
+		return m.UpdateRiskFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Order.UpdateRisk")
+}
+
+// DraftOrderService mocks order.DraftOrderService. Methods whose func field is nil return
+// ErrNotMocked.
+type DraftOrderService struct {
+	recorder
+	BatchCreateFunc       func(ctx context.Context, drafts []order.DraftOrder, workers int) ([]order.DraftOrderResult, error)
+	CompleteFunc          func(ctx context.Context, id int64) (*order.DraftOrder, error)
+	CountFunc             func(ctx context.Context) (int, error)
+	CreateFunc            func(ctx context.Context, order order.DraftOrder) (*order.DraftOrder, error)
+	DeleteFunc            func(ctx context.Context, id int64) error
+	GetFunc               func(ctx context.Context, id int64) (*order.DraftOrder, error)
+	SendInvoiceFunc       func(ctx context.Context, id int64, invoice order.DraftOrderInvoice) (*order.DraftOrderInvoice, error)
+	UpdateFunc            func(ctx context.Context, order order.DraftOrder) (*order.DraftOrder, error)
+	WaitForCompletionFunc func(ctx context.Context, draftID int64, pollInterval time.Duration) (*order.Order, error)
+}
+
+var _ order.DraftOrderService = (*DraftOrderService)(nil)
+
+// BatchCreate implements order.DraftOrderService.
+func (m *DraftOrderService) BatchCreate(p0 context.Context, p1 []order.DraftOrder, p2 int) ([]order.DraftOrderResult, error) {
+	m.record("BatchCreate")
+	if m.BatchCreateFunc != nil {
+		return m.BatchCreateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("DraftOrder.BatchCreate")
+}
+
+// Complete implements order.DraftOrderService.
+func (m *DraftOrderService) Complete(p0 context.Context, p1 int64) (*order.DraftOrder, error) {
+	m.record("Complete")
+	if m.CompleteFunc != nil {
+		return m.CompleteFunc(p0, p1)
+	}
+	return nil, notMocked("DraftOrder.Complete")
+}
+
+// Count implements order.DraftOrderService.
+func (m *DraftOrderService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("DraftOrder.Count")
+}
+
+// Create implements order.DraftOrderService.
+func (m *DraftOrderService) Create(p0 context.Context, p1 order.DraftOrder) (*order.DraftOrder, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("DraftOrder.Create")
+}
+
+// Delete implements order.DraftOrderService.
+func (m *DraftOrderService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("DraftOrder.Delete")
+}
+
+// Get implements order.DraftOrderService.
+func (m *DraftOrderService) Get(p0 context.Context, p1 int64) (*order.DraftOrder, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("DraftOrder.Get")
+}
+
+// SendInvoice implements order.DraftOrderService.
+func (m *DraftOrderService) SendInvoice(p0 context.Context, p1 int64, p2 order.DraftOrderInvoice) (*order.DraftOrderInvoice, error) {
+	m.record("SendInvoice")
+	if m.SendInvoiceFunc != nil {
+		return m.SendInvoiceFunc(p0, p1, p2)
+	}
+	return nil, notMocked("DraftOrder.SendInvoice")
+}
+
+// Update implements order.DraftOrderService.
+func (m *DraftOrderService) Update(p0 context.Context, p1 order.DraftOrder) (*order.DraftOrder, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("DraftOrder.Update")
+}
+
+// WaitForCompletion implements order.DraftOrderService.
+func (m *DraftOrderService) WaitForCompletion(p0 context.Context, p1 int64, p2 time.Duration) (*order.Order, error) {
+	m.record("WaitForCompletion")
+	if m.WaitForCompletionFunc != nil {
+		return m.WaitForCompletionFunc(p0, p1, p2)
+	}
+	return nil, notMocked("DraftOrder.WaitForCompletion")
+}
+
+// FulfillmentService mocks order.FulfillmentService. Methods whose func field is nil return
+// ErrNotMocked.
+type FulfillmentService struct {
+	recorder
+	CancelFunc                      func(ctx context.Context, orderID int64, fulfillmentID int64) (*order.Fulfillment, error)
+	CancelGlobalFunc                func(ctx context.Context, fID int64) (*order.Fulfillment, error)
+	CloseFulfillmentOrderFunc       func(ctx context.Context, foID int64, message string) error
+	CountFunc                       func(ctx context.Context) (int, error)
+	CreateFunc                      func(ctx context.Context, orderID int64, f order.Fulfillment) (*order.Fulfillment, error)
+	CreateByFulfillmentOrderFunc    func(ctx context.Context, foID int64, f order.Fulfillment) (*order.Fulfillment, error)
+	GetByFulfillmentOrderFunc       func(ctx context.Context, foID int64, fID int64) (*order.Fulfillment, error)
+	HoldFulfillmentOrderFunc        func(ctx context.Context, foID int64, hold order.FulfillmentHold) error
+	ListFunc                        func(ctx context.Context, orderID int64, opts *core.ListOptions) ([]order.Fulfillment, error)
+	ListByFulfillmentOrderFunc      func(ctx context.Context, foID int64) ([]order.Fulfillment, error)
+	ListInventoryLocationsFunc      func(ctx context.Context) ([]order.InventoryLocation, error)
+	ListPickupMethodsFunc           func(ctx context.Context) ([]order.PickupMethod, error)
+	ListShippingMethodsFunc         func(ctx context.Context) ([]order.ShippingMethod, error)
+	MoveFulfillmentOrderFunc        func(ctx context.Context, foID int64, locationID int64) error
+	ReleaseHoldFulfillmentOrderFunc func(ctx context.Context, foID int64) error
+	RescheduleFulfillmentOrderFunc  func(ctx context.Context, foID int64, newFulfillAt time.Time) error
+	UpdateTrackingFunc              func(ctx context.Context, orderID int64, fulfillmentID int64, t order.FulfillmentTracking) (*order.Fulfillment, error)
+	UpdateTrackingGlobalFunc        func(ctx context.Context, fID int64, t order.FulfillmentTracking) (*order.Fulfillment, error)
+}
+
+var _ order.FulfillmentService = (*FulfillmentService)(nil)
+
+// Cancel implements order.FulfillmentService.
+func (m *FulfillmentService) Cancel(p0 context.Context, p1 int64, p2 int64) (*order.Fulfillment, error) {
+	m.record("Cancel")
+	if m.CancelFunc != nil {
+		return m.CancelFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.Cancel")
+}
+
+// CancelGlobal implements order.FulfillmentService.
+func (m *FulfillmentService) CancelGlobal(p0 context.Context, p1 int64) (*order.Fulfillment, error) {
+	m.record("CancelGlobal")
+	if m.CancelGlobalFunc != nil {
+		return m.CancelGlobalFunc(p0, p1)
+	}
+	return nil, notMocked("Fulfillment.CancelGlobal")
+}
+
+// CloseFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) CloseFulfillmentOrder(p0 context.Context, p1 int64, p2 string) error {
+	m.record("CloseFulfillmentOrder")
+	if m.CloseFulfillmentOrderFunc != nil {
+		return m.CloseFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return notMocked("Fulfillment.CloseFulfillmentOrder")
+}
+
+// Count implements order.FulfillmentService.
+func (m *FulfillmentService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("Fulfillment.Count")
+}
+
+// Create implements order.FulfillmentService.
+func (m *FulfillmentService) Create(p0 context.Context, p1 int64, p2 order.Fulfillment) (*order.Fulfillment, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.Create")
+}
+
+// CreateByFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) CreateByFulfillmentOrder(p0 context.Context, p1 int64, p2 order.Fulfillment) (*order.Fulfillment, error) {
+	m.record("CreateByFulfillmentOrder")
+	if m.CreateByFulfillmentOrderFunc != nil {
+		return m.CreateByFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.CreateByFulfillmentOrder")
+}
+
+// GetByFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) GetByFulfillmentOrder(p0 context.Context, p1 int64, p2 int64) (*order.Fulfillment, error) {
+	m.record("GetByFulfillmentOrder")
+	if m.GetByFulfillmentOrderFunc != nil {
+		return m.GetByFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.GetByFulfillmentOrder")
+}
+
+// HoldFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) HoldFulfillmentOrder(p0 context.Context, p1 int64, p2 order.FulfillmentHold) error {
+	m.record("HoldFulfillmentOrder")
+	if m.HoldFulfillmentOrderFunc != nil {
+		return m.HoldFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return notMocked("Fulfillment.HoldFulfillmentOrder")
+}
+
+// List implements order.FulfillmentService.
+func (m *FulfillmentService) List(p0 context.Context, p1 int64, p2 *core.ListOptions) ([]order.Fulfillment, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.List")
+}
+
+// ListByFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) ListByFulfillmentOrder(p0 context.Context, p1 int64) ([]order.Fulfillment, error) {
+	m.record("ListByFulfillmentOrder")
+	if m.ListByFulfillmentOrderFunc != nil {
+		return m.ListByFulfillmentOrderFunc(p0, p1)
+	}
+	return nil, notMocked("Fulfillment.ListByFulfillmentOrder")
+}
+
+// ListInventoryLocations implements order.FulfillmentService.
+func (m *FulfillmentService) ListInventoryLocations(p0 context.Context) ([]order.InventoryLocation, error) {
+	m.record("ListInventoryLocations")
+	if m.ListInventoryLocationsFunc != nil {
+		return m.ListInventoryLocationsFunc(p0)
+	}
+	return nil, notMocked("Fulfillment.ListInventoryLocations")
+}
+
+// ListPickupMethods implements order.FulfillmentService.
+func (m *FulfillmentService) ListPickupMethods(p0 context.Context) ([]order.PickupMethod, error) {
+	m.record("ListPickupMethods")
+	if m.ListPickupMethodsFunc != nil {
+		return m.ListPickupMethodsFunc(p0)
+	}
+	return nil, notMocked("Fulfillment.ListPickupMethods")
+}
+
+// ListShippingMethods implements order.FulfillmentService.
+func (m *FulfillmentService) ListShippingMethods(p0 context.Context) ([]order.ShippingMethod, error) {
+	m.record("ListShippingMethods")
+	if m.ListShippingMethodsFunc != nil {
+		return m.ListShippingMethodsFunc(p0)
+	}
+	return nil, notMocked("Fulfillment.ListShippingMethods")
+}
+
+// MoveFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) MoveFulfillmentOrder(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("MoveFulfillmentOrder")
+	if m.MoveFulfillmentOrderFunc != nil {
+		return m.MoveFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return notMocked("Fulfillment.MoveFulfillmentOrder")
+}
+
+// ReleaseHoldFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) ReleaseHoldFulfillmentOrder(p0 context.Context, p1 int64) error {
+	m.record("ReleaseHoldFulfillmentOrder")
+	if m.ReleaseHoldFulfillmentOrderFunc != nil {
+		return m.ReleaseHoldFulfillmentOrderFunc(p0, p1)
+	}
+	return notMocked("Fulfillment.ReleaseHoldFulfillmentOrder")
+}
+
+// RescheduleFulfillmentOrder implements order.FulfillmentService.
+func (m *FulfillmentService) RescheduleFulfillmentOrder(p0 context.Context, p1 int64, p2 time.Time) error {
+	m.record("RescheduleFulfillmentOrder")
+	if m.RescheduleFulfillmentOrderFunc != nil {
+		return m.RescheduleFulfillmentOrderFunc(p0, p1, p2)
+	}
+	return notMocked("Fulfillment.RescheduleFulfillmentOrder")
+}
+
+// UpdateTracking implements order.FulfillmentService.
+func (m *FulfillmentService) UpdateTracking(p0 context.Context, p1 int64, p2 int64, p3 order.FulfillmentTracking) (*order.Fulfillment, error) {
+	m.record("UpdateTracking")
+	if m.UpdateTrackingFunc != nil {
+		return m.UpdateTrackingFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Fulfillment.UpdateTracking")
+}
+
+// UpdateTrackingGlobal implements order.FulfillmentService.
+func (m *FulfillmentService) UpdateTrackingGlobal(p0 context.Context, p1 int64, p2 order.FulfillmentTracking) (*order.Fulfillment, error) {
+	m.record("UpdateTrackingGlobal")
+	if m.UpdateTrackingGlobalFunc != nil {
+		return m.UpdateTrackingGlobalFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Fulfillment.UpdateTrackingGlobal")
+}
+
+// CarrierServiceService mocks order.CarrierServiceService. Methods whose func field is nil return
+// ErrNotMocked.
+type CarrierServiceService struct {
+	recorder
+	CreateFunc func(ctx context.Context, c order.CarrierService) (*order.CarrierService, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*order.CarrierService, error)
+	ListFunc   func(ctx context.Context) ([]order.CarrierService, error)
+	UpdateFunc func(ctx context.Context, c order.CarrierService) (*order.CarrierService, error)
+}
+
+var _ order.CarrierServiceService = (*CarrierServiceService)(nil)
+
+// Create implements order.CarrierServiceService.
+func (m *CarrierServiceService) Create(p0 context.Context, p1 order.CarrierService) (*order.CarrierService, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("CarrierService.Create")
+}
+
+// Delete implements order.CarrierServiceService.
+func (m *CarrierServiceService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("CarrierService.Delete")
+}
+
+// Get implements order.CarrierServiceService.
+func (m *CarrierServiceService) Get(p0 context.Context, p1 int64) (*order.CarrierService, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("CarrierService.Get")
+}
+
+// List implements order.CarrierServiceService.
+func (m *CarrierServiceService) List(p0 context.Context) ([]order.CarrierService, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("CarrierService.List")
+}
+
+// Update implements order.CarrierServiceService.
+func (m *CarrierServiceService) Update(p0 context.Context, p1 order.CarrierService) (*order.CarrierService, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("CarrierService.Update")
+}
+
+// FulfillmentSvcDefService mocks order.FulfillmentServiceDefService. Methods whose func field is nil return
+// ErrNotMocked.
+type FulfillmentSvcDefService struct {
+	recorder
+	CreateFunc         func(ctx context.Context, svc order.FulfillmentServiceDef) (*order.FulfillmentServiceDef, error)
+	CreateLocationFunc func(ctx context.Context, loc order.FulfillmentServiceLocation) (*order.FulfillmentServiceLocation, error)
+	DeleteFunc         func(ctx context.Context, id int64) error
+	GetFunc            func(ctx context.Context, id int64) (*order.FulfillmentServiceDef, error)
+	ListFunc           func(ctx context.Context) ([]order.FulfillmentServiceDef, error)
+	UpdateFunc         func(ctx context.Context, svc order.FulfillmentServiceDef) (*order.FulfillmentServiceDef, error)
+}
+
+var _ order.FulfillmentServiceDefService = (*FulfillmentSvcDefService)(nil)
+
+// Create implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) Create(p0 context.Context, p1 order.FulfillmentServiceDef) (*order.FulfillmentServiceDef, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("FulfillmentSvcDef.Create")
+}
+
+// CreateLocation implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) CreateLocation(p0 context.Context, p1 order.FulfillmentServiceLocation) (*order.FulfillmentServiceLocation, error) {
+	m.record("CreateLocation")
+	if m.CreateLocationFunc != nil {
+		return m.CreateLocationFunc(p0, p1)
+	}
+	return nil, notMocked("FulfillmentSvcDef.CreateLocation")
+}
+
+// Delete implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("FulfillmentSvcDef.Delete")
+}
+
+// Get implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) Get(p0 context.Context, p1 int64) (*order.FulfillmentServiceDef, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("FulfillmentSvcDef.Get")
+}
+
+// List implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) List(p0 context.Context) ([]order.FulfillmentServiceDef, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("FulfillmentSvcDef.List")
+}
+
+// Update implements order.FulfillmentServiceDefService.
+func (m *FulfillmentSvcDefService) Update(p0 context.Context, p1 order.FulfillmentServiceDef) (*order.FulfillmentServiceDef, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("FulfillmentSvcDef.Update")
+}
+
+// PaymentService mocks order.PaymentService. Methods whose func field is nil return
+// ErrNotMocked.
+type PaymentService struct {
+	recorder
+	CreatePaymentSlipFunc func(ctx context.Context, slip order.PaymentSlip) (*order.PaymentSlip, error)
+	GetSettingsFunc       func(ctx context.Context) (*order.PaymentSettings, error)
+	ListChannelsFunc      func(ctx context.Context) ([]order.PaymentChannel, error)
+	ListPaymentsFunc      func(ctx context.Context, orderID int64) ([]order.OrderPayment, error)
+	UpdatePaymentSlipFunc func(ctx context.Context, slip order.PaymentSlip) (*order.PaymentSlip, error)
+}
+
+var _ order.PaymentService = (*PaymentService)(nil)
+
+// CreatePaymentSlip implements order.PaymentService.
+func (m *PaymentService) CreatePaymentSlip(p0 context.Context, p1 order.PaymentSlip) (*order.PaymentSlip, error) {
+	m.record("CreatePaymentSlip")
+	if m.CreatePaymentSlipFunc != nil {
+		return m.CreatePaymentSlipFunc(p0, p1)
+	}
+	return nil, notMocked("Payment.CreatePaymentSlip")
+}
+
+// GetSettings implements order.PaymentService.
+func (m *PaymentService) GetSettings(p0 context.Context) (*order.PaymentSettings, error) {
+	m.record("GetSettings")
+	if m.GetSettingsFunc != nil {
+		return m.GetSettingsFunc(p0)
+	}
+	return nil, notMocked("Payment.GetSettings")
+}
+
+// ListChannels implements order.PaymentService.
+func (m *PaymentService) ListChannels(p0 context.Context) ([]order.PaymentChannel, error) {
+	m.record("ListChannels")
+	if m.ListChannelsFunc != nil {
+		return m.ListChannelsFunc(p0)
+	}
+	return nil, notMocked("Payment.ListChannels")
+}
+
+// ListPayments implements order.PaymentService.
+func (m *PaymentService) ListPayments(p0 context.Context, p1 int64) ([]order.OrderPayment, error) {
+	m.record("ListPayments")
+	if m.ListPaymentsFunc != nil {
+		return m.ListPaymentsFunc(p0, p1)
+	}
+	return nil, notMocked("Payment.ListPayments")
+}
+
+// UpdatePaymentSlip implements order.PaymentService.
+func (m *PaymentService) UpdatePaymentSlip(p0 context.Context, p1 order.PaymentSlip) (*order.PaymentSlip, error) {
+	m.record("UpdatePaymentSlip")
+	if m.UpdatePaymentSlipFunc != nil {
+		return m.UpdatePaymentSlipFunc(p0, p1)
+	}
+	return nil, notMocked("Payment.UpdatePaymentSlip")
+}
+
+// AbandonedCheckoutService mocks order.AbandonedCheckoutService. Methods whose func field is nil return
+// ErrNotMocked.
+type AbandonedCheckoutService struct {
+	recorder
+	ArchiveFunc           func(ctx context.Context, ids []int64) error
+	CountFunc             func(ctx context.Context) (int, error)
+	GetFunc               func(ctx context.Context, checkoutID int64) (*order.AbandonedCheckout, error)
+	ListFunc              func(ctx context.Context, opts *core.ListOptions) ([]order.AbandonedCheckout, error)
+	SendRecoveryEmailFunc func(ctx context.Context, checkoutID int64, template order.RecoveryEmail) error
+}
+
+var _ order.AbandonedCheckoutService = (*AbandonedCheckoutService)(nil)
+
+// Archive implements order.AbandonedCheckoutService.
+func (m *AbandonedCheckoutService) Archive(p0 context.Context, p1 []int64) error {
+	m.record("Archive")
+	if m.ArchiveFunc != nil {
+		return m.ArchiveFunc(p0, p1)
+	}
+	return notMocked("AbandonedCheckout.Archive")
+}
+
+// Count implements order.AbandonedCheckoutService.
+func (m *AbandonedCheckoutService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("AbandonedCheckout.Count")
+}
+
+// Get implements order.AbandonedCheckoutService.
+func (m *AbandonedCheckoutService) Get(p0 context.Context, p1 int64) (*order.AbandonedCheckout, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("AbandonedCheckout.Get")
+}
+
+// List implements order.AbandonedCheckoutService.
+func (m *AbandonedCheckoutService) List(p0 context.Context, p1 *core.ListOptions) ([]order.AbandonedCheckout, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("AbandonedCheckout.List")
+}
+
+// SendRecoveryEmail implements order.AbandonedCheckoutService.
+func (m *AbandonedCheckoutService) SendRecoveryEmail(p0 context.Context, p1 int64, p2 order.RecoveryEmail) error {
+	m.record("SendRecoveryEmail")
+	if m.SendRecoveryEmailFunc != nil {
+		return m.SendRecoveryEmailFunc(p0, p1, p2)
+	}
+	return notMocked("AbandonedCheckout.SendRecoveryEmail")
+}
+
+// SubscriptionService mocks order.SubscriptionService. Methods whose func field is nil return
+// ErrNotMocked.
+type SubscriptionService struct {
+	recorder
+	AddLineItemFunc        func(ctx context.Context, id int64, item order.SubscriptionLineItem) (*order.SubscriptionContract, error)
+	CancelFunc             func(ctx context.Context, id int64) (*order.SubscriptionContract, error)
+	CreateFunc             func(ctx context.Context, c order.SubscriptionContract) (*order.SubscriptionContract, error)
+	CreateOrderFunc        func(ctx context.Context, id int64) (*order.Order, error)
+	GetFunc                func(ctx context.Context, id int64) (*order.SubscriptionContract, error)
+	ListFunc               func(ctx context.Context, opts *core.ListOptions) ([]order.SubscriptionContract, error)
+	PauseFunc              func(ctx context.Context, id int64) (*order.SubscriptionContract, error)
+	RemoveLineItemFunc     func(ctx context.Context, id int64, lineItemID int64) error
+	ResumeFunc             func(ctx context.Context, id int64) (*order.SubscriptionContract, error)
+	ReviseNextBillTimeFunc func(ctx context.Context, id int64, t time.Time) (*order.SubscriptionContract, error)
+	SkipNextBillFunc       func(ctx context.Context, id int64) (*order.SubscriptionContract, error)
+	UpdateFunc             func(ctx context.Context, c order.SubscriptionContract) (*order.SubscriptionContract, error)
+	UpdateLineItemFunc     func(ctx context.Context, id int64, item order.SubscriptionLineItem) (*order.SubscriptionContract, error)
+}
+
+var _ order.SubscriptionService = (*SubscriptionService)(nil)
+
+// AddLineItem implements order.SubscriptionService.
+func (m *SubscriptionService) AddLineItem(p0 context.Context, p1 int64, p2 order.SubscriptionLineItem) (*order.SubscriptionContract, error) {
+	m.record("AddLineItem")
+	if m.AddLineItemFunc != nil {
+		return m.AddLineItemFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Subscription.AddLineItem")
+}
+
+// Cancel implements order.SubscriptionService.
+func (m *SubscriptionService) Cancel(p0 context.Context, p1 int64) (*order.SubscriptionContract, error) {
+	m.record("Cancel")
+	if m.CancelFunc != nil {
+		return m.CancelFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Cancel")
+}
+
+// Create implements order.SubscriptionService.
+func (m *SubscriptionService) Create(p0 context.Context, p1 order.SubscriptionContract) (*order.SubscriptionContract, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Create")
+}
+
+// CreateOrder implements order.SubscriptionService.
+func (m *SubscriptionService) CreateOrder(p0 context.Context, p1 int64) (*order.Order, error) {
+	m.record("CreateOrder")
+	if m.CreateOrderFunc != nil {
+		return m.CreateOrderFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.CreateOrder")
+}
+
+// Get implements order.SubscriptionService.
+func (m *SubscriptionService) Get(p0 context.Context, p1 int64) (*order.SubscriptionContract, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Get")
+}
+
+// List implements order.SubscriptionService.
+func (m *SubscriptionService) List(p0 context.Context, p1 *core.ListOptions) ([]order.SubscriptionContract, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.List")
+}
+
+// Pause implements order.SubscriptionService.
+func (m *SubscriptionService) Pause(p0 context.Context, p1 int64) (*order.SubscriptionContract, error) {
+	m.record("Pause")
+	if m.PauseFunc != nil {
+		return m.PauseFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Pause")
+}
+
+// RemoveLineItem implements order.SubscriptionService.
+func (m *SubscriptionService) RemoveLineItem(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("RemoveLineItem")
+	if m.RemoveLineItemFunc != nil {
+		return m.RemoveLineItemFunc(p0, p1, p2)
+	}
+	return notMocked("Subscription.RemoveLineItem")
+}
+
+// Resume implements order.SubscriptionService.
+func (m *SubscriptionService) Resume(p0 context.Context, p1 int64) (*order.SubscriptionContract, error) {
+	m.record("Resume")
+	if m.ResumeFunc != nil {
+		return m.ResumeFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Resume")
+}
+
+// ReviseNextBillTime implements order.SubscriptionService.
+func (m *SubscriptionService) ReviseNextBillTime(p0 context.Context, p1 int64, p2 time.Time) (*order.SubscriptionContract, error) {
+	m.record("ReviseNextBillTime")
+	if m.ReviseNextBillTimeFunc != nil {
+		return m.ReviseNextBillTimeFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Subscription.ReviseNextBillTime")
+}
+
+// SkipNextBill implements order.SubscriptionService.
+func (m *SubscriptionService) SkipNextBill(p0 context.Context, p1 int64) (*order.SubscriptionContract, error) {
+	m.record("SkipNextBill")
+	if m.SkipNextBillFunc != nil {
+		return m.SkipNextBillFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.SkipNextBill")
+}
+
+// Update implements order.SubscriptionService.
+func (m *SubscriptionService) Update(p0 context.Context, p1 order.SubscriptionContract) (*order.SubscriptionContract, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Subscription.Update")
+}
+
+// UpdateLineItem implements order.SubscriptionService.
+func (m *SubscriptionService) UpdateLineItem(p0 context.Context, p1 int64, p2 order.SubscriptionLineItem) (*order.SubscriptionContract, error) {
+	m.record("UpdateLineItem")
+	if m.UpdateLineItemFunc != nil {
+		return m.UpdateLineItemFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Subscription.UpdateLineItem")
+}
+
+// TaxService mocks order.TaxService. Methods whose func field is nil return
+// ErrNotMocked.
+type TaxService struct {
+	recorder
+	CountCountriesFunc   func(ctx context.Context) (int, error)
+	CountProvincesFunc   func(ctx context.Context, countryID int64) (int, error)
+	DeleteTaxChannelFunc func(ctx context.Context, id int64) error
+	GetCountryFunc       func(ctx context.Context, id int64) (*order.TaxCountry, error)
+	GetProvinceFunc      func(ctx context.Context, id int64) (*order.TaxProvince, error)
+	ListCountriesFunc    func(ctx context.Context) ([]order.TaxCountry, error)
+	ListProvincesFunc    func(ctx context.Context, countryID int64) ([]order.TaxProvince, error)
+	ListTaxChannelsFunc  func(ctx context.Context) ([]order.TaxChannel, error)
+	UpdateTaxChannelFunc func(ctx context.Context, c order.TaxChannel) (*order.TaxChannel, error)
+}
+
+var _ order.TaxService = (*TaxService)(nil)
+
+// CountCountries implements order.TaxService.
+func (m *TaxService) CountCountries(p0 context.Context) (int, error) {
+	m.record("CountCountries")
+	if m.CountCountriesFunc != nil {
+		return m.CountCountriesFunc(p0)
+	}
+	return 0, notMocked("Tax.CountCountries")
+}
+
+// CountProvinces implements order.TaxService.
+func (m *TaxService) CountProvinces(p0 context.Context, p1 int64) (int, error) {
+	m.record("CountProvinces")
+	if m.CountProvincesFunc != nil {
+		return m.CountProvincesFunc(p0, p1)
+	}
+	return 0, notMocked("Tax.CountProvinces")
+}
+
+// DeleteTaxChannel implements order.TaxService.
+func (m *TaxService) DeleteTaxChannel(p0 context.Context, p1 int64) error {
+	m.record("DeleteTaxChannel")
+	if m.DeleteTaxChannelFunc != nil {
+		return m.DeleteTaxChannelFunc(p0, p1)
+	}
+	return notMocked("Tax.DeleteTaxChannel")
+}
+
+// GetCountry implements order.TaxService.
+func (m *TaxService) GetCountry(p0 context.Context, p1 int64) (*order.TaxCountry, error) {
+	m.record("GetCountry")
+	if m.GetCountryFunc != nil {
+		return m.GetCountryFunc(p0, p1)
+	}
+	return nil, notMocked("Tax.GetCountry")
+}
+
+// GetProvince implements order.TaxService.
+func (m *TaxService) GetProvince(p0 context.Context, p1 int64) (*order.TaxProvince, error) {
+	m.record("GetProvince")
+	if m.GetProvinceFunc != nil {
+		return m.GetProvinceFunc(p0, p1)
+	}
+	return nil, notMocked("Tax.GetProvince")
+}
+
+// ListCountries implements order.TaxService.
+func (m *TaxService) ListCountries(p0 context.Context) ([]order.TaxCountry, error) {
+	m.record("ListCountries")
+	if m.ListCountriesFunc != nil {
+		return m.ListCountriesFunc(p0)
+	}
+	return nil, notMocked("Tax.ListCountries")
+}
+
+// ListProvinces implements order.TaxService.
+func (m *TaxService) ListProvinces(p0 context.Context, p1 int64) ([]order.TaxProvince, error) {
+	m.record("ListProvinces")
+	if m.ListProvincesFunc != nil {
+		return m.ListProvincesFunc(p0, p1)
+	}
+	return nil, notMocked("Tax.ListProvinces")
+}
+
+// ListTaxChannels implements order.TaxService.
+func (m *TaxService) ListTaxChannels(p0 context.Context) ([]order.TaxChannel, error) {
+	m.record("ListTaxChannels")
+	if m.ListTaxChannelsFunc != nil {
+		return m.ListTaxChannelsFunc(p0)
+	}
+	return nil, notMocked("Tax.ListTaxChannels")
+}
+
+// UpdateTaxChannel implements order.TaxService.
+func (m *TaxService) UpdateTaxChannel(p0 context.Context, p1 order.TaxChannel) (*order.TaxChannel, error) {
+	m.record("UpdateTaxChannel")
+	if m.UpdateTaxChannelFunc != nil {
+		return m.UpdateTaxChannelFunc(p0, p1)
+	}
+	return nil, notMocked("Tax.UpdateTaxChannel")
+}
+
+// ReturnService mocks order.ReturnService. Methods whose func field is nil return
+// ErrNotMocked.
+type ReturnService struct {
+	recorder
+	CloseFunc                     func(ctx context.Context, returnID int64) (*order.Return, error)
+	CreateFunc                    func(ctx context.Context, orderID int64, ret order.Return) (*order.Return, error)
+	CreateFulfillmentFunc         func(ctx context.Context, returnID int64, f order.ReturnFulfillment) (*order.ReturnFulfillment, error)
+	ListFunc                      func(ctx context.Context, opts *core.ListOptions) ([]order.Return, error)
+	ListFulfillmentOrdersFunc     func(ctx context.Context, opts *core.ListOptions) ([]order.ReturnFulfillmentOrder, error)
+	ListFulfillmentsFunc          func(ctx context.Context, opts *core.ListOptions) ([]order.ReturnFulfillment, error)
+	UpdateFulfillmentTrackingFunc func(ctx context.Context, returnID int64, fID int64, t order.FulfillmentTracking) (*order.ReturnFulfillment, error)
+}
+
+var _ order.ReturnService = (*ReturnService)(nil)
+
+// Close implements order.ReturnService.
+func (m *ReturnService) Close(p0 context.Context, p1 int64) (*order.Return, error) {
+	m.record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc(p0, p1)
+	}
+	return nil, notMocked("Return.Close")
+}
+
+// Create implements order.ReturnService.
+func (m *ReturnService) Create(p0 context.Context, p1 int64, p2 order.Return) (*order.Return, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Return.Create")
+}
+
+// CreateFulfillment implements order.ReturnService.
+func (m *ReturnService) CreateFulfillment(p0 context.Context, p1 int64, p2 order.ReturnFulfillment) (*order.ReturnFulfillment, error) {
+	m.record("CreateFulfillment")
+	if m.CreateFulfillmentFunc != nil {
+		return m.CreateFulfillmentFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Return.CreateFulfillment")
+}
+
+// List implements order.ReturnService.
+func (m *ReturnService) List(p0 context.Context, p1 *core.ListOptions) ([]order.Return, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Return.List")
+}
+
+// ListFulfillmentOrders implements order.ReturnService.
+func (m *ReturnService) ListFulfillmentOrders(p0 context.Context, p1 *core.ListOptions) ([]order.ReturnFulfillmentOrder, error) {
+	m.record("ListFulfillmentOrders")
+	if m.ListFulfillmentOrdersFunc != nil {
+		return m.ListFulfillmentOrdersFunc(p0, p1)
+	}
+	return nil, notMocked("Return.ListFulfillmentOrders")
+}
+
+// ListFulfillments implements order.ReturnService.
+func (m *ReturnService) ListFulfillments(p0 context.Context, p1 *core.ListOptions) ([]order.ReturnFulfillment, error) {
+	m.record("ListFulfillments")
+	if m.ListFulfillmentsFunc != nil {
+		return m.ListFulfillmentsFunc(p0, p1)
+	}
+	return nil, notMocked("Return.ListFulfillments")
+}
+
+// UpdateFulfillmentTracking implements order.ReturnService.
+func (m *ReturnService) UpdateFulfillmentTracking(p0 context.Context, p1 int64, p2 int64, p3 order.FulfillmentTracking) (*order.ReturnFulfillment, error) {
+	m.record("UpdateFulfillmentTracking")
+	if m.UpdateFulfillmentTrackingFunc != nil {
+		return m.UpdateFulfillmentTrackingFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Return.UpdateFulfillmentTracking")
+}
+
+// OrderArchiveService mocks order.ArchiveService. Methods whose func field is nil return
+// ErrNotMocked.
+type OrderArchiveService struct {
+	recorder
+	ArchiveFunc   func(ctx context.Context, orderID int64) error
+	UnarchiveFunc func(ctx context.Context, orderID int64) error
+}
+
+var _ order.ArchiveService = (*OrderArchiveService)(nil)
+
+// Archive implements order.ArchiveService.
+func (m *OrderArchiveService) Archive(p0 context.Context, p1 int64) error {
+	m.record("Archive")
+	if m.ArchiveFunc != nil {
+		return m.ArchiveFunc(p0, p1)
+	}
+	return notMocked("OrderArchive.Archive")
+}
+
+// Unarchive implements order.ArchiveService.
+func (m *OrderArchiveService) Unarchive(p0 context.Context, p1 int64) error {
+	m.record("Unarchive")
+	if m.UnarchiveFunc != nil {
+		return m.UnarchiveFunc(p0, p1)
+	}
+	return notMocked("OrderArchive.Unarchive")
+}
+
+// OrderEditService mocks order.EditService. Methods whose func field is nil return
+// ErrNotMocked.
+type OrderEditService struct {
+	recorder
+	AddCustomItemFunc  func(ctx context.Context, orderID int64, e order.EditAddCustomItem) (*order.EditSession, error)
+	AddDiscountFunc    func(ctx context.Context, orderID int64, e order.EditAddDiscount) (*order.EditSession, error)
+	AddLineItemFunc    func(ctx context.Context, orderID int64, e order.EditAddLineItem) (*order.EditSession, error)
+	CommitFunc         func(ctx context.Context, orderID int64) (*order.Order, error)
+	DiscardFunc        func(ctx context.Context, orderID int64) error
+	RemoveDiscountFunc func(ctx context.Context, orderID int64, e order.EditRemoveDiscount) (*order.EditSession, error)
+	RemoveLineItemFunc func(ctx context.Context, orderID int64, lineItemID int64) (*order.EditSession, error)
+	SetQuantityFunc    func(ctx context.Context, orderID int64, e order.EditSetQuantity) (*order.EditSession, error)
+	StartFunc          func(ctx context.Context, orderID int64) (*order.EditSession, error)
+}
+
+var _ order.EditService = (*OrderEditService)(nil)
+
+// AddCustomItem implements order.EditService.
+func (m *OrderEditService) AddCustomItem(p0 context.Context, p1 int64, p2 order.EditAddCustomItem) (*order.EditSession, error) {
+	m.record("AddCustomItem")
+	if m.AddCustomItemFunc != nil {
+		return m.AddCustomItemFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.AddCustomItem")
+}
+
+// AddDiscount implements order.EditService.
+func (m *OrderEditService) AddDiscount(p0 context.Context, p1 int64, p2 order.EditAddDiscount) (*order.EditSession, error) {
+	m.record("AddDiscount")
+	if m.AddDiscountFunc != nil {
+		return m.AddDiscountFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.AddDiscount")
+}
+
+// AddLineItem implements order.EditService.
+func (m *OrderEditService) AddLineItem(p0 context.Context, p1 int64, p2 order.EditAddLineItem) (*order.EditSession, error) {
+	m.record("AddLineItem")
+	if m.AddLineItemFunc != nil {
+		return m.AddLineItemFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.AddLineItem")
+}
+
+// Commit implements order.EditService.
+func (m *OrderEditService) Commit(p0 context.Context, p1 int64) (*order.Order, error) {
+	m.record("Commit")
+	if m.CommitFunc != nil {
+		return m.CommitFunc(p0, p1)
+	}
+	return nil, notMocked("OrderEdit.Commit")
+}
+
+// Discard implements order.EditService.
+func (m *OrderEditService) Discard(p0 context.Context, p1 int64) error {
+	m.record("Discard")
+	if m.DiscardFunc != nil {
+		return m.DiscardFunc(p0, p1)
+	}
+	return notMocked("OrderEdit.Discard")
+}
+
+// RemoveDiscount implements order.EditService.
+func (m *OrderEditService) RemoveDiscount(p0 context.Context, p1 int64, p2 order.EditRemoveDiscount) (*order.EditSession, error) {
+	m.record("RemoveDiscount")
+	if m.RemoveDiscountFunc != nil {
+		return m.RemoveDiscountFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.RemoveDiscount")
+}
+
+// RemoveLineItem implements order.EditService.
+func (m *OrderEditService) RemoveLineItem(p0 context.Context, p1 int64, p2 int64) (*order.EditSession, error) {
+	m.record("RemoveLineItem")
+	if m.RemoveLineItemFunc != nil {
+		return m.RemoveLineItemFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.RemoveLineItem")
+}
+
+// SetQuantity implements order.EditService.
+func (m *OrderEditService) SetQuantity(p0 context.Context, p1 int64, p2 order.EditSetQuantity) (*order.EditSession, error) {
+	m.record("SetQuantity")
+	if m.SetQuantityFunc != nil {
+		return m.SetQuantityFunc(p0, p1, p2)
+	}
+	return nil, notMocked("OrderEdit.SetQuantity")
+}
+
+// Start implements order.EditService.
+func (m *OrderEditService) Start(p0 context.Context, p1 int64) (*order.EditSession, error) {
+	m.record("Start")
+	if m.StartFunc != nil {
+		return m.StartFunc(p0, p1)
+	}
+	return nil, notMocked("OrderEdit.Start")
+}
+
+// CustomerService mocks customer.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type CustomerService struct {
+	recorder
+	ActivationURLFunc        func(ctx context.Context, id int64) (string, error)
+	AddTagsFunc              func(ctx context.Context, customerID int64, tags []string) (*core.Customer, error)
+	AddToBlacklistFunc       func(ctx context.Context, id int64) error
+	BatchMarketingStatesFunc func(ctx context.Context, opts *customer.MarketingOptions) ([]customer.MarketingState, error)
+	BatchQueryAddressFunc    func(ctx context.Context, customerIDs []int64) ([]customer.AddressResult, error)
+	BatchSetAddressFunc      func(ctx context.Context, customerID int64, addrs []core.Address) ([]core.Address, error)
+	CheckEmailFunc           func(ctx context.Context, email string) (*core.Customer, error)
+	CountFunc                func(ctx context.Context, opts *core.CountOptions) (int, error)
+	CreateFunc               func(ctx context.Context, c core.Customer) (*core.Customer, error)
+	CreateAddressFunc        func(ctx context.Context, customerID int64, addr core.Address) (*core.Address, error)
+	CreateGroupFunc          func(ctx context.Context, g customer.Group) (*customer.Group, error)
+	DeleteFunc               func(ctx context.Context, id int64) error
+	DeleteAddressFunc        func(ctx context.Context, customerID int64, addressID int64) error
+	DeleteGroupFunc          func(ctx context.Context, groupID int64) error
+	DeleteSocialLoginFunc    func(ctx context.Context) error
+	DeleteTagFunc            func(ctx context.Context, customerID int64, tag string) error
+	FindDuplicatesFunc       func(ctx context.Context, by customer.DuplicateField) ([]customer.DuplicateGroup, error)
+	GetFunc                  func(ctx context.Context, id int64) (*core.Customer, error)
+	GetAddressFunc           func(ctx context.Context, customerID int64, addressID int64) (*core.Address, error)
+	GetGroupFunc             func(ctx context.Context, groupID int64) (*customer.Group, error)
+	ListFunc                 func(ctx context.Context, opts *customer.ListOptions) ([]core.Customer, error)
+	ListGroupCustomersFunc   func(ctx context.Context, groupID int64, opts *core.ListOptions) ([]core.Customer, error)
+	ListGroupsFunc           func(ctx context.Context, opts *core.ListOptions) ([]customer.Group, error)
+	ListOrdersFunc           func(ctx context.Context, id int64, opts *core.ListOptions) ([]customer.Order, error)
+	ListSocialLoginFunc      func(ctx context.Context) ([]customer.SocialLoginConfig, error)
+	ListStoreGroupsFunc      func(ctx context.Context) ([]customer.Group, error)
+	MergeFunc                func(ctx context.Context, primaryID int64, duplicateID int64) (*core.Customer, error)
+	RemoveFromBlacklistFunc  func(ctx context.Context, id int64) error
+	SearchFunc               func(ctx context.Context, query string, opts *core.ListOptions) ([]core.Customer, error)
+	SendInviteFunc           func(ctx context.Context, id int64) error
+	SetDefaultAddressFunc    func(ctx context.Context, customerID int64, addressID int64) (*core.Address, error)
+	SetTagsFunc              func(ctx context.Context, customerID int64, tags []string) (*core.Customer, error)
+	UpdateFunc               func(ctx context.Context, c core.Customer) (*core.Customer, error)
+	UpdateAddressFunc        func(ctx context.Context, customerID int64, addr core.Address) (*core.Address, error)
+	UpdateGroupFunc          func(ctx context.Context, g customer.Group) (*customer.Group, error)
+	UpdateSocialLoginFunc    func(ctx context.Context, cfg customer.SocialLoginConfig) (*customer.SocialLoginConfig, error)
+}
+
+var _ customer.Service = (*CustomerService)(nil)
+
+// ActivationURL implements customer.Service.
+func (m *CustomerService) ActivationURL(p0 context.Context, p1 int64) (string, error) {
+	m.record("ActivationURL")
+	if m.ActivationURLFunc != nil {
+		return m.ActivationURLFunc(p0, p1)
+	}
+	return "", notMocked("Customer.ActivationURL")
+}
+
+// AddTags implements customer.Service.
+func (m *CustomerService) AddTags(p0 context.Context, p1 int64, p2 []string) (*core.Customer, error) {
+	m.record("AddTags")
+	if m.AddTagsFunc != nil {
+		return m.AddTagsFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.AddTags")
+}
+
+// AddToBlacklist implements customer.Service.
+func (m *CustomerService) AddToBlacklist(p0 context.Context, p1 int64) error {
+	m.record("AddToBlacklist")
+	if m.AddToBlacklistFunc != nil {
+		return m.AddToBlacklistFunc(p0, p1)
+	}
+	return notMocked("Customer.AddToBlacklist")
+}
+
+// BatchMarketingStates implements customer.Service.
+func (m *CustomerService) BatchMarketingStates(p0 context.Context, p1 *customer.MarketingOptions) ([]customer.MarketingState, error) {
+	m.record("BatchMarketingStates")
+	if m.BatchMarketingStatesFunc != nil {
+		return m.BatchMarketingStatesFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.BatchMarketingStates")
+}
+
+// BatchQueryAddress implements customer.Service.
+func (m *CustomerService) BatchQueryAddress(p0 context.Context, p1 []int64) ([]customer.AddressResult, error) {
+	m.record("BatchQueryAddress")
+	if m.BatchQueryAddressFunc != nil {
+		return m.BatchQueryAddressFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.BatchQueryAddress")
+}
+
+// BatchSetAddress implements customer.Service.
+func (m *CustomerService) BatchSetAddress(p0 context.Context, p1 int64, p2 []core.Address) ([]core.Address, error) {
+	m.record("BatchSetAddress")
+	if m.BatchSetAddressFunc != nil {
+		return m.BatchSetAddressFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.BatchSetAddress")
+}
+
+// CheckEmail implements customer.Service.
+func (m *CustomerService) CheckEmail(p0 context.Context, p1 string) (*core.Customer, error) {
+	m.record("CheckEmail")
+	if m.CheckEmailFunc != nil {
+		return m.CheckEmailFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.CheckEmail")
+}
+
+// Count implements customer.Service.
+func (m *CustomerService) Count(p0 context.Context, p1 *core.CountOptions) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1)
+	}
+	return 0, notMocked("Customer.Count")
+}
+
+// Create implements customer.Service.
+func (m *CustomerService) Create(p0 context.Context, p1 core.Customer) (*core.Customer, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.Create")
+}
+
+// CreateAddress implements customer.Service.
+func (m *CustomerService) CreateAddress(p0 context.Context, p1 int64, p2 core.Address) (*core.Address, error) {
+	m.record("CreateAddress")
+	if m.CreateAddressFunc != nil {
+		return m.CreateAddressFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.CreateAddress")
+}
+
+// CreateGroup implements customer.Service.
+func (m *CustomerService) CreateGroup(p0 context.Context, p1 customer.Group) (*customer.Group, error) {
+	m.record("CreateGroup")
+	if m.CreateGroupFunc != nil {
+		return m.CreateGroupFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.CreateGroup")
+}
+
+// Delete implements customer.Service.
+func (m *CustomerService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Customer.Delete")
+}
+
+// DeleteAddress implements customer.Service.
+func (m *CustomerService) DeleteAddress(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("DeleteAddress")
+	if m.DeleteAddressFunc != nil {
+		return m.DeleteAddressFunc(p0, p1, p2)
+	}
+	return notMocked("Customer.DeleteAddress")
+}
+
+// DeleteGroup implements customer.Service.
+func (m *CustomerService) DeleteGroup(p0 context.Context, p1 int64) error {
+	m.record("DeleteGroup")
+	if m.DeleteGroupFunc != nil {
+		return m.DeleteGroupFunc(p0, p1)
+	}
+	return notMocked("Customer.DeleteGroup")
+}
+
+// DeleteSocialLogin implements customer.Service.
+func (m *CustomerService) DeleteSocialLogin(p0 context.Context) error {
+	m.record("DeleteSocialLogin")
+	if m.DeleteSocialLoginFunc != nil {
+		return m.DeleteSocialLoginFunc(p0)
+	}
+	return notMocked("Customer.DeleteSocialLogin")
+}
+
+// DeleteTag implements customer.Service.
+func (m *CustomerService) DeleteTag(p0 context.Context, p1 int64, p2 string) error {
+	m.record("DeleteTag")
+	if m.DeleteTagFunc != nil {
+		return m.DeleteTagFunc(p0, p1, p2)
+	}
+	return notMocked("Customer.DeleteTag")
+}
+
+// FindDuplicates implements customer.Service.
+func (m *CustomerService) FindDuplicates(p0 context.Context, p1 customer.DuplicateField) ([]customer.DuplicateGroup, error) {
+	m.record("FindDuplicates")
+	if m.FindDuplicatesFunc != nil {
+		return m.FindDuplicatesFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.FindDuplicates")
+}
+
+// Get implements customer.Service.
+func (m *CustomerService) Get(p0 context.Context, p1 int64) (*core.Customer, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.Get")
+}
+
+// GetAddress implements customer.Service.
+func (m *CustomerService) GetAddress(p0 context.Context, p1 int64, p2 int64) (*core.Address, error) {
+	m.record("GetAddress")
+	if m.GetAddressFunc != nil {
+		return m.GetAddressFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.GetAddress")
+}
+
+// GetGroup implements customer.Service.
+func (m *CustomerService) GetGroup(p0 context.Context, p1 int64) (*customer.Group, error) {
+	m.record("GetGroup")
+	if m.GetGroupFunc != nil {
+		return m.GetGroupFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.GetGroup")
+}
+
+// List implements customer.Service.
+func (m *CustomerService) List(p0 context.Context, p1 *customer.ListOptions) ([]core.Customer, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.List")
+}
+
+// ListGroupCustomers implements customer.Service.
+func (m *CustomerService) ListGroupCustomers(p0 context.Context, p1 int64, p2 *core.ListOptions) ([]core.Customer, error) {
+	m.record("ListGroupCustomers")
+	if m.ListGroupCustomersFunc != nil {
+		return m.ListGroupCustomersFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.ListGroupCustomers")
+}
+
+// ListGroups implements customer.Service.
+func (m *CustomerService) ListGroups(p0 context.Context, p1 *core.ListOptions) ([]customer.Group, error) {
+	m.record("ListGroups")
+	if m.ListGroupsFunc != nil {
+		return m.ListGroupsFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.ListGroups")
+}
+
+// ListOrders implements customer.Service.
+func (m *CustomerService) ListOrders(p0 context.Context, p1 int64, p2 *core.ListOptions) ([]customer.Order, error) {
+	m.record("ListOrders")
+	if m.ListOrdersFunc != nil {
+		return m.ListOrdersFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.ListOrders")
+}
+
+// ListSocialLogin implements customer.Service.
+func (m *CustomerService) ListSocialLogin(p0 context.Context) ([]customer.SocialLoginConfig, error) {
+	m.record("ListSocialLogin")
+	if m.ListSocialLoginFunc != nil {
+		return m.ListSocialLoginFunc(p0)
+	}
+	return nil, notMocked("Customer.ListSocialLogin")
+}
+
+// ListStoreGroups implements customer.Service.
+func (m *CustomerService) ListStoreGroups(p0 context.Context) ([]customer.Group, error) {
+	m.record("ListStoreGroups")
+	if m.ListStoreGroupsFunc != nil {
+		return m.ListStoreGroupsFunc(p0)
+	}
+	return nil, notMocked("Customer.ListStoreGroups")
+}
+
+// Merge implements customer.Service.
+func (m *CustomerService) Merge(p0 context.Context, p1 int64, p2 int64) (*core.Customer, error) {
+	m.record("Merge")
+	if m.MergeFunc != nil {
+		return m.MergeFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.Merge")
+}
+
+// RemoveFromBlacklist implements customer.Service.
+func (m *CustomerService) RemoveFromBlacklist(p0 context.Context, p1 int64) error {
+	m.record("RemoveFromBlacklist")
+	if m.RemoveFromBlacklistFunc != nil {
+		return m.RemoveFromBlacklistFunc(p0, p1)
+	}
+	return notMocked("Customer.RemoveFromBlacklist")
+}
+
+// Search implements customer.Service.
+func (m *CustomerService) Search(p0 context.Context, p1 string, p2 *core.ListOptions) ([]core.Customer, error) {
+	m.record("Search")
+	if m.SearchFunc != nil {
+		return m.SearchFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.Search")
+}
+
+// SendInvite implements customer.Service.
+func (m *CustomerService) SendInvite(p0 context.Context, p1 int64) error {
+	m.record("SendInvite")
+	if m.SendInviteFunc != nil {
+		return m.SendInviteFunc(p0, p1)
+	}
+	return notMocked("Customer.SendInvite")
+}
+
+// SetDefaultAddress implements customer.Service.
+func (m *CustomerService) SetDefaultAddress(p0 context.Context, p1 int64, p2 int64) (*core.Address, error) {
+	m.record("SetDefaultAddress")
+	if m.SetDefaultAddressFunc != nil {
+		return m.SetDefaultAddressFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.SetDefaultAddress")
+}
+
+// SetTags implements customer.Service.
+func (m *CustomerService) SetTags(p0 context.Context, p1 int64, p2 []string) (*core.Customer, error) {
+	m.record("SetTags")
+	if m.SetTagsFunc != nil {
+		return m.SetTagsFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.SetTags")
+}
+
+// Update implements customer.Service.
+func (m *CustomerService) Update(p0 context.Context, p1 core.Customer) (*core.Customer, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.Update")
+}
+
+// UpdateAddress implements customer.Service.
+func (m *CustomerService) UpdateAddress(p0 context.Context, p1 int64, p2 core.Address) (*core.Address, error) {
+	m.record("UpdateAddress")
+	if m.UpdateAddressFunc != nil {
+		return m.UpdateAddressFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Customer.UpdateAddress")
+}
+
+// UpdateGroup implements customer.Service.
+func (m *CustomerService) UpdateGroup(p0 context.Context, p1 customer.Group) (*customer.Group, error) {
+	m.record("UpdateGroup")
+	if m.UpdateGroupFunc != nil {
+		return m.UpdateGroupFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.UpdateGroup")
+}
+
+// UpdateSocialLogin implements customer.Service.
+func (m *CustomerService) UpdateSocialLogin(p0 context.Context, p1 customer.SocialLoginConfig) (*customer.SocialLoginConfig, error) {
+	m.record("UpdateSocialLogin")
+	if m.UpdateSocialLoginFunc != nil {
+		return m.UpdateSocialLoginFunc(p0, p1)
+	}
+	return nil, notMocked("Customer.UpdateSocialLogin")
+}
+
+// CustomerSegmentService mocks customer.SegmentService. Methods whose func field is nil return
+// ErrNotMocked.
+type CustomerSegmentService struct {
+	recorder
+	CountFunc              func(ctx context.Context) (int, error)
+	CreateFunc             func(ctx context.Context, s customer.Segment) (*customer.Segment, error)
+	DeleteFunc             func(ctx context.Context, id int64) error
+	GetFunc                func(ctx context.Context, id int64) (*customer.Segment, error)
+	ListFunc               func(ctx context.Context, opts *core.ListOptions) ([]customer.Segment, error)
+	ListSegmentMembersFunc func(ctx context.Context, segmentID int64, opts *core.ListOptions) ([]core.Customer, error)
+	UpdateFunc             func(ctx context.Context, s customer.Segment) (*customer.Segment, error)
+}
+
+var _ customer.SegmentService = (*CustomerSegmentService)(nil)
+
+// Count implements customer.SegmentService.
+func (m *CustomerSegmentService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("CustomerSegment.Count")
+}
+
+// Create implements customer.SegmentService.
+func (m *CustomerSegmentService) Create(p0 context.Context, p1 customer.Segment) (*customer.Segment, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("CustomerSegment.Create")
+}
+
+// Delete implements customer.SegmentService.
+func (m *CustomerSegmentService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("CustomerSegment.Delete")
+}
+
+// Get implements customer.SegmentService.
+func (m *CustomerSegmentService) Get(p0 context.Context, p1 int64) (*customer.Segment, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("CustomerSegment.Get")
+}
+
+// List implements customer.SegmentService.
+func (m *CustomerSegmentService) List(p0 context.Context, p1 *core.ListOptions) ([]customer.Segment, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("CustomerSegment.List")
+}
+
+// ListSegmentMembers implements customer.SegmentService.
+func (m *CustomerSegmentService) ListSegmentMembers(p0 context.Context, p1 int64, p2 *core.ListOptions) ([]core.Customer, error) {
+	m.record("ListSegmentMembers")
+	if m.ListSegmentMembersFunc != nil {
+		return m.ListSegmentMembersFunc(p0, p1, p2)
+	}
+	return nil, notMocked("CustomerSegment.ListSegmentMembers")
+}
+
+// Update implements customer.SegmentService.
+func (m *CustomerSegmentService) Update(p0 context.Context, p1 customer.Segment) (*customer.Segment, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("CustomerSegment.Update")
+}
+
+// ProductService mocks product.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type ProductService struct {
+	recorder
+	BatchUpsertFunc       func(ctx context.Context, products []product.Product, opts product.BatchOpts) ([]product.UpsertResult, error)
+	CountFunc             func(ctx context.Context, opts *core.CountOptions) (int, error)
+	CreateFunc            func(ctx context.Context, p product.Product) (*product.Product, error)
+	DeleteFunc            func(ctx context.Context, id int64) error
+	DuplicateFunc         func(ctx context.Context, productID int64, opts *product.DuplicateOptions) (*product.Product, error)
+	GetFunc               func(ctx context.Context, id int64) (*product.Product, error)
+	IsHandleAvailableFunc func(ctx context.Context, handle string) (bool, error)
+	ListFunc              func(ctx context.Context, opts *core.ListOptions) ([]product.Product, error)
+	SetPublishedAtFunc    func(ctx context.Context, id int64, t time.Time) (*product.Product, error)
+	SetStatusFunc         func(ctx context.Context, id int64, status string) (*product.Product, error)
+	SetStatusesFunc       func(ctx context.Context, ids []int64, status string) ([]product.StatusResult, error)
+	UpdateFunc            func(ctx context.Context, p product.Product) (*product.Product, error)
+}
+
+var _ product.Service = (*ProductService)(nil)
+
+// BatchUpsert implements product.Service.
+func (m *ProductService) BatchUpsert(p0 context.Context, p1 []product.Product, p2 product.BatchOpts) ([]product.UpsertResult, error) {
+	m.record("BatchUpsert")
+	if m.BatchUpsertFunc != nil {
+		return m.BatchUpsertFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Product.BatchUpsert")
+}
+
+// Count implements product.Service.
+func (m *ProductService) Count(p0 context.Context, p1 *core.CountOptions) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1)
+	}
+	return 0, notMocked("Product.Count")
+}
+
+// Create implements product.Service.
+func (m *ProductService) Create(p0 context.Context, p1 product.Product) (*product.Product, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Product.Create")
+}
+
+// Delete implements product.Service.
+func (m *ProductService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Product.Delete")
+}
+
+// Duplicate implements product.Service.
+func (m *ProductService) Duplicate(p0 context.Context, p1 int64, p2 *product.DuplicateOptions) (*product.Product, error) {
+	m.record("Duplicate")
+	if m.DuplicateFunc != nil {
+		return m.DuplicateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Product.Duplicate")
+}
+
+// Get implements product.Service.
+func (m *ProductService) Get(p0 context.Context, p1 int64) (*product.Product, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Product.Get")
+}
+
+// IsHandleAvailable implements product.Service.
+func (m *ProductService) IsHandleAvailable(p0 context.Context, p1 string) (bool, error) {
+	m.record("IsHandleAvailable")
+	if m.IsHandleAvailableFunc != nil {
+		return m.IsHandleAvailableFunc(p0, p1)
+	}
+	return false, notMocked("Product.IsHandleAvailable")
+}
+
+// List implements product.Service.
+func (m *ProductService) List(p0 context.Context, p1 *core.ListOptions) ([]product.Product, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Product.List")
+}
+
+// SetPublishedAt implements product.Service.
+func (m *ProductService) SetPublishedAt(p0 context.Context, p1 int64, p2 time.Time) (*product.Product, error) {
+	m.record("SetPublishedAt")
+	if m.SetPublishedAtFunc != nil {
+		return m.SetPublishedAtFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Product.SetPublishedAt")
+}
+
+// SetStatus implements product.Service.
+func (m *ProductService) SetStatus(p0 context.Context, p1 int64, p2 string) (*product.Product, error) {
+	m.record("SetStatus")
+	if m.SetStatusFunc != nil {
+		return m.SetStatusFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Product.SetStatus")
+}
+
+// SetStatuses implements product.Service.
+func (m *ProductService) SetStatuses(p0 context.Context, p1 []int64, p2 string) ([]product.StatusResult, error) {
+	m.record("SetStatuses")
+	if m.SetStatusesFunc != nil {
+		return m.SetStatusesFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Product.SetStatuses")
+}
+
+// Update implements product.Service.
+func (m *ProductService) Update(p0 context.Context, p1 product.Product) (*product.Product, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Product.Update")
+}
+
+// CollectionService mocks product.CollectionService. Methods whose func field is nil return
+// ErrNotMocked.
+type CollectionService struct {
+	recorder
+	CountFunc  func(ctx context.Context) (int, error)
+	CreateFunc func(ctx context.Context, c product.Collection) (*product.Collection, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*product.Collection, error)
+	ListFunc   func(ctx context.Context, opts *core.ListOptions) ([]product.Collection, error)
+	UpdateFunc func(ctx context.Context, c product.Collection) (*product.Collection, error)
+}
+
+var _ product.CollectionService = (*CollectionService)(nil)
+
+// Count implements product.CollectionService.
+func (m *CollectionService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("Collection.Count")
+}
+
+// Create implements product.CollectionService.
+func (m *CollectionService) Create(p0 context.Context, p1 product.Collection) (*product.Collection, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Collection.Create")
+}
+
+// Delete implements product.CollectionService.
+func (m *CollectionService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Collection.Delete")
+}
+
+// Get implements product.CollectionService.
+func (m *CollectionService) Get(p0 context.Context, p1 int64) (*product.Collection, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Collection.Get")
+}
+
+// List implements product.CollectionService.
+func (m *CollectionService) List(p0 context.Context, p1 *core.ListOptions) ([]product.Collection, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Collection.List")
+}
+
+// Update implements product.CollectionService.
+func (m *CollectionService) Update(p0 context.Context, p1 product.Collection) (*product.Collection, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Collection.Update")
+}
+
+// SmartCollectionService mocks product.SmartCollectionService. Methods whose func field is nil return
+// ErrNotMocked.
+type SmartCollectionService struct {
+	recorder
+	CreateFunc         func(ctx context.Context, c product.SmartCollection) (*product.SmartCollection, error)
+	DeleteFunc         func(ctx context.Context, id int64) error
+	GetFunc            func(ctx context.Context, id int64) (*product.SmartCollection, error)
+	ListFunc           func(ctx context.Context, opts *core.ListOptions) ([]product.SmartCollection, error)
+	SetPublishedAtFunc func(ctx context.Context, id int64, t time.Time) (*product.SmartCollection, error)
+	UpdateFunc         func(ctx context.Context, c product.SmartCollection) (*product.SmartCollection, error)
+}
+
+var _ product.SmartCollectionService = (*SmartCollectionService)(nil)
+
+// Create implements product.SmartCollectionService.
+func (m *SmartCollectionService) Create(p0 context.Context, p1 product.SmartCollection) (*product.SmartCollection, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("SmartCollection.Create")
+}
+
+// Delete implements product.SmartCollectionService.
+func (m *SmartCollectionService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("SmartCollection.Delete")
+}
+
+// Get implements product.SmartCollectionService.
+func (m *SmartCollectionService) Get(p0 context.Context, p1 int64) (*product.SmartCollection, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("SmartCollection.Get")
+}
+
+// List implements product.SmartCollectionService.
+func (m *SmartCollectionService) List(p0 context.Context, p1 *core.ListOptions) ([]product.SmartCollection, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("SmartCollection.List")
+}
+
+// SetPublishedAt implements product.SmartCollectionService.
+func (m *SmartCollectionService) SetPublishedAt(p0 context.Context, p1 int64, p2 time.Time) (*product.SmartCollection, error) {
+	m.record("SetPublishedAt")
+	if m.SetPublishedAtFunc != nil {
+		return m.SetPublishedAtFunc(p0, p1, p2)
+	}
+	return nil, notMocked("SmartCollection.SetPublishedAt")
+}
+
+// Update implements product.SmartCollectionService.
+func (m *SmartCollectionService) Update(p0 context.Context, p1 product.SmartCollection) (*product.SmartCollection, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("SmartCollection.Update")
+}
+
+// ManualCollectionService mocks product.ManualCollectionService. Methods whose func field is nil return
+// ErrNotMocked.
+type ManualCollectionService struct {
+	recorder
+	CreateFunc         func(ctx context.Context, c product.ManualCollection) (*product.ManualCollection, error)
+	DeleteFunc         func(ctx context.Context, id int64) error
+	GetFunc            func(ctx context.Context, id int64) (*product.ManualCollection, error)
+	ListFunc           func(ctx context.Context, opts *core.ListOptions) ([]product.ManualCollection, error)
+	SetPublishedAtFunc func(ctx context.Context, id int64, t time.Time) (*product.ManualCollection, error)
+	UpdateFunc         func(ctx context.Context, c product.ManualCollection) (*product.ManualCollection, error)
+}
+
+var _ product.ManualCollectionService = (*ManualCollectionService)(nil)
+
+// Create implements product.ManualCollectionService.
+func (m *ManualCollectionService) Create(p0 context.Context, p1 product.ManualCollection) (*product.ManualCollection, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("ManualCollection.Create")
+}
+
+// Delete implements product.ManualCollectionService.
+func (m *ManualCollectionService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("ManualCollection.Delete")
+}
+
+// Get implements product.ManualCollectionService.
+func (m *ManualCollectionService) Get(p0 context.Context, p1 int64) (*product.ManualCollection, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("ManualCollection.Get")
+}
+
+// List implements product.ManualCollectionService.
+func (m *ManualCollectionService) List(p0 context.Context, p1 *core.ListOptions) ([]product.ManualCollection, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("ManualCollection.List")
+}
+
+// SetPublishedAt implements product.ManualCollectionService.
+func (m *ManualCollectionService) SetPublishedAt(p0 context.Context, p1 int64, p2 time.Time) (*product.ManualCollection, error) {
+	m.record("SetPublishedAt")
+	if m.SetPublishedAtFunc != nil {
+		return m.SetPublishedAtFunc(p0, p1, p2)
+	}
+	return nil, notMocked("ManualCollection.SetPublishedAt")
+}
+
+// Update implements product.ManualCollectionService.
+func (m *ManualCollectionService) Update(p0 context.Context, p1 product.ManualCollection) (*product.ManualCollection, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("ManualCollection.Update")
+}
+
+// InventoryService mocks product.InventoryService. Methods whose func field is nil return
+// ErrNotMocked.
+type InventoryService struct {
+	recorder
+	AdjustLevelFunc       func(ctx context.Context, inventoryItemID int64, locationID int64, adjustment int) (*product.InventoryLevel, error)
+	CheckAvailabilityFunc func(ctx context.Context, items []product.VariantQuantity, locationID int64) ([]product.VariantAvailability, error)
+	GetItemFunc           func(ctx context.Context, id int64) (*product.InventoryItem, error)
+	ListItemsFunc         func(ctx context.Context, opts *core.ListOptions) ([]product.InventoryItem, error)
+	ListLevelsFunc        func(ctx context.Context, opts *product.InventoryLevelListOptions) ([]product.InventoryLevel, error)
+	SetLevelFunc          func(ctx context.Context, level product.InventoryLevel) (*product.InventoryLevel, error)
+	UpdateItemFunc        func(ctx context.Context, item product.InventoryItem) (*product.InventoryItem, error)
+}
+
+var _ product.InventoryService = (*InventoryService)(nil)
+
+// AdjustLevel implements product.InventoryService.
+func (m *InventoryService) AdjustLevel(p0 context.Context, p1 int64, p2 int64, p3 int) (*product.InventoryLevel, error) {
+	m.record("AdjustLevel")
+	if m.AdjustLevelFunc != nil {
+		return m.AdjustLevelFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Inventory.AdjustLevel")
+}
+
+// CheckAvailability implements product.InventoryService.
+func (m *InventoryService) CheckAvailability(p0 context.Context, p1 []product.VariantQuantity, p2 int64) ([]product.VariantAvailability, error) {
+	m.record("CheckAvailability")
+	if m.CheckAvailabilityFunc != nil {
+		return m.CheckAvailabilityFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Inventory.CheckAvailability")
+}
+
+// GetItem implements product.InventoryService.
+func (m *InventoryService) GetItem(p0 context.Context, p1 int64) (*product.InventoryItem, error) {
+	m.record("GetItem")
+	if m.GetItemFunc != nil {
+		return m.GetItemFunc(p0, p1)
+	}
+	return nil, notMocked("Inventory.GetItem")
+}
+
+// ListItems implements product.InventoryService.
+func (m *InventoryService) ListItems(p0 context.Context, p1 *core.ListOptions) ([]product.InventoryItem, error) {
+	m.record("ListItems")
+	if m.ListItemsFunc != nil {
+		return m.ListItemsFunc(p0, p1)
+	}
+	return nil, notMocked("Inventory.ListItems")
+}
+
+// ListLevels implements product.InventoryService.
+func (m *InventoryService) ListLevels(p0 context.Context, p1 *product.InventoryLevelListOptions) ([]product.InventoryLevel, error) {
+	m.record("ListLevels")
+	if m.ListLevelsFunc != nil {
+		return m.ListLevelsFunc(p0, p1)
+	}
+	return nil, notMocked("Inventory.ListLevels")
+}
+
+// SetLevel implements product.InventoryService.
+func (m *InventoryService) SetLevel(p0 context.Context, p1 product.InventoryLevel) (*product.InventoryLevel, error) {
+	m.record("SetLevel")
+	if m.SetLevelFunc != nil {
+		return m.SetLevelFunc(p0, p1)
+	}
+	return nil, notMocked("Inventory.SetLevel")
+}
+
+// UpdateItem implements product.InventoryService.
+func (m *InventoryService) UpdateItem(p0 context.Context, p1 product.InventoryItem) (*product.InventoryItem, error) {
+	m.record("UpdateItem")
+	if m.UpdateItemFunc != nil {
+		return m.UpdateItemFunc(p0, p1)
+	}
+	return nil, notMocked("Inventory.UpdateItem")
+}
+
+// BundleService mocks product.BundleService. Methods whose func field is nil return
+// ErrNotMocked.
+type BundleService struct {
+	recorder
+	CreateFunc        func(ctx context.Context, b product.Bundle) (*product.Bundle, error)
+	DeleteFunc        func(ctx context.Context, id int64) error
+	GetFunc           func(ctx context.Context, id int64) (*product.Bundle, error)
+	ListFunc          func(ctx context.Context, opts *core.ListOptions) ([]product.Bundle, error)
+	ListByProductFunc func(ctx context.Context, productID int64) ([]product.Bundle, error)
+	SetComponentsFunc func(ctx context.Context, bundleID int64, components []product.BundleComponent) (*product.Bundle, error)
+	UpdateFunc        func(ctx context.Context, b product.Bundle) (*product.Bundle, error)
+}
+
+var _ product.BundleService = (*BundleService)(nil)
+
+// Create implements product.BundleService.
+func (m *BundleService) Create(p0 context.Context, p1 product.Bundle) (*product.Bundle, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Bundle.Create")
+}
+
+// Delete implements product.BundleService.
+func (m *BundleService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Bundle.Delete")
+}
+
+// Get implements product.BundleService.
+func (m *BundleService) Get(p0 context.Context, p1 int64) (*product.Bundle, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Bundle.Get")
+}
+
+// List implements product.BundleService.
+func (m *BundleService) List(p0 context.Context, p1 *core.ListOptions) ([]product.Bundle, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Bundle.List")
+}
+
+// ListByProduct implements product.BundleService.
+func (m *BundleService) ListByProduct(p0 context.Context, p1 int64) ([]product.Bundle, error) {
+	m.record("ListByProduct")
+	if m.ListByProductFunc != nil {
+		return m.ListByProductFunc(p0, p1)
+	}
+	return nil, notMocked("Bundle.ListByProduct")
+}
+
+// SetComponents implements product.BundleService.
+func (m *BundleService) SetComponents(p0 context.Context, p1 int64, p2 []product.BundleComponent) (*product.Bundle, error) {
+	m.record("SetComponents")
+	if m.SetComponentsFunc != nil {
+		return m.SetComponentsFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Bundle.SetComponents")
+}
+
+// Update implements product.BundleService.
+func (m *BundleService) Update(p0 context.Context, p1 product.Bundle) (*product.Bundle, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Bundle.Update")
+}
+
+// ProductOptionService mocks product.OptionService. Methods whose func field is nil return
+// ErrNotMocked.
+type ProductOptionService struct {
+	recorder
+	AddFunc           func(ctx context.Context, productID int64, name string, value string) (*product.Product, error)
+	ListFunc          func(ctx context.Context, productID int64) ([]product.Option, error)
+	RemoveFunc        func(ctx context.Context, productID int64, name string) (*product.Product, error)
+	RenameFunc        func(ctx context.Context, productID int64, name string, newName string) (*product.Product, error)
+	RenameValueFunc   func(ctx context.Context, productID int64, name string, value string, newValue string) (*product.Product, error)
+	ReorderValuesFunc func(ctx context.Context, productID int64, name string, values []string) (*product.Product, error)
+}
+
+var _ product.OptionService = (*ProductOptionService)(nil)
+
+// Add implements product.OptionService.
+func (m *ProductOptionService) Add(p0 context.Context, p1 int64, p2 string, p3 string) (*product.Product, error) {
+	m.record("Add")
+	if m.AddFunc != nil {
+		return m.AddFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("ProductOption.Add")
+}
+
+// List implements product.OptionService.
+func (m *ProductOptionService) List(p0 context.Context, p1 int64) ([]product.Option, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("ProductOption.List")
+}
+
+// Remove implements product.OptionService.
+func (m *ProductOptionService) Remove(p0 context.Context, p1 int64, p2 string) (*product.Product, error) {
+	m.record("Remove")
+	if m.RemoveFunc != nil {
+		return m.RemoveFunc(p0, p1, p2)
+	}
+	return nil, notMocked("ProductOption.Remove")
+}
+
+// Rename implements product.OptionService.
+func (m *ProductOptionService) Rename(p0 context.Context, p1 int64, p2 string, p3 string) (*product.Product, error) {
+	m.record("Rename")
+	if m.RenameFunc != nil {
+		return m.RenameFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("ProductOption.Rename")
+}
+
+// RenameValue implements product.OptionService.
+func (m *ProductOptionService) RenameValue(p0 context.Context, p1 int64, p2 string, p3 string, p4 string) (*product.Product, error) {
+	m.record("RenameValue")
+	if m.RenameValueFunc != nil {
+		return m.RenameValueFunc(p0, p1, p2, p3, p4)
+	}
+	return nil, notMocked("ProductOption.RenameValue")
+}
+
+// ReorderValues implements product.OptionService.
+func (m *ProductOptionService) ReorderValues(p0 context.Context, p1 int64, p2 string, p3 []string) (*product.Product, error) {
+	m.record("ReorderValues")
+	if m.ReorderValuesFunc != nil {
+		return m.ReorderValuesFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("ProductOption.ReorderValues")
+}
+
+// ReviewService mocks review.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type ReviewService struct {
+	recorder
+	ApproveFunc func(ctx context.Context, id int64) (*review.Review, error)
+	DeleteFunc  func(ctx context.Context, id int64) error
+	GetFunc     func(ctx context.Context, id int64) (*review.Review, error)
+	ListFunc    func(ctx context.Context, opts *review.ListOptions) ([]review.Review, error)
+	ReplyFunc   func(ctx context.Context, id int64, body string) (*review.Review, error)
+}
+
+var _ review.Service = (*ReviewService)(nil)
+
+// Approve implements review.Service.
+func (m *ReviewService) Approve(p0 context.Context, p1 int64) (*review.Review, error) {
+	m.record("Approve")
+	if m.ApproveFunc != nil {
+		return m.ApproveFunc(p0, p1)
+	}
+	return nil, notMocked("Review.Approve")
+}
+
+// Delete implements review.Service.
+func (m *ReviewService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Review.Delete")
+}
+
+// Get implements review.Service.
+func (m *ReviewService) Get(p0 context.Context, p1 int64) (*review.Review, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Review.Get")
+}
+
+// List implements review.Service.
+func (m *ReviewService) List(p0 context.Context, p1 *review.ListOptions) ([]review.Review, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Review.List")
+}
+
+// Reply implements review.Service.
+func (m *ReviewService) Reply(p0 context.Context, p1 int64, p2 string) (*review.Review, error) {
+	m.record("Reply")
+	if m.ReplyFunc != nil {
+		return m.ReplyFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Review.Reply")
+}
+
+// MediaService mocks media.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type MediaService struct {
+	recorder
+	AttachToProductFunc func(ctx context.Context, productID int64, target *media.StagedTarget, image product.Image) (*product.Image, error)
+	StageUploadFunc     func(ctx context.Context, filename string, size int64, mimeType string) (*media.StagedTarget, error)
+}
+
+var _ media.Service = (*MediaService)(nil)
+
+// AttachToProduct implements media.Service.
+func (m *MediaService) AttachToProduct(p0 context.Context, p1 int64, p2 *media.StagedTarget, p3 product.Image) (*product.Image, error) {
+	m.record("AttachToProduct")
+	if m.AttachToProductFunc != nil {
+		return m.AttachToProductFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Media.AttachToProduct")
+}
+
+// StageUpload implements media.Service.
+func (m *MediaService) StageUpload(p0 context.Context, p1 string, p2 int64, p3 string) (*media.StagedTarget, error) {
+	m.record("StageUpload")
+	if m.StageUploadFunc != nil {
+		return m.StageUploadFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Media.StageUpload")
+}
+
+// StoreService mocks store.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type StoreService struct {
+	recorder
+	CountOperationLogsFunc     func(ctx context.Context) (int, error)
+	GetActiveSubscriptionFunc  func(ctx context.Context) (*store.Subscription, error)
+	GetInfoFunc                func(ctx context.Context) (*store.Info, error)
+	GetOperationLogFunc        func(ctx context.Context, id int64) (*store.OperationLog, error)
+	GetSettlementCurrencyFunc  func(ctx context.Context) ([]store.Currency, error)
+	GetShopFunc                func(ctx context.Context) (*store.Shop, error)
+	GetStaffMemberFunc         func(ctx context.Context, uid string) (*store.StaffMember, error)
+	InviteStaffMemberFunc      func(ctx context.Context, email string, permissions []string) (*store.StaffMember, error)
+	ListOperationLogsFunc      func(ctx context.Context, opts *core.ListOptions) ([]store.OperationLog, error)
+	ListStaffMembersFunc       func(ctx context.Context) ([]store.StaffMember, error)
+	RemoveStaffMemberFunc      func(ctx context.Context, uid string) error
+	UpdateStaffPermissionsFunc func(ctx context.Context, uid string, permissions []string) (*store.StaffMember, error)
+}
+
+var _ store.Service = (*StoreService)(nil)
+
+// CountOperationLogs implements store.Service.
+func (m *StoreService) CountOperationLogs(p0 context.Context) (int, error) {
+	m.record("CountOperationLogs")
+	if m.CountOperationLogsFunc != nil {
+		return m.CountOperationLogsFunc(p0)
+	}
+	return 0, notMocked("Store.CountOperationLogs")
+}
+
+// GetActiveSubscription implements store.Service.
+func (m *StoreService) GetActiveSubscription(p0 context.Context) (*store.Subscription, error) {
+	m.record("GetActiveSubscription")
+	if m.GetActiveSubscriptionFunc != nil {
+		return m.GetActiveSubscriptionFunc(p0)
+	}
+	return nil, notMocked("Store.GetActiveSubscription")
+}
+
+// GetInfo implements store.Service.
+func (m *StoreService) GetInfo(p0 context.Context) (*store.Info, error) {
+	m.record("GetInfo")
+	if m.GetInfoFunc != nil {
+		return m.GetInfoFunc(p0)
+	}
+	return nil, notMocked("Store.GetInfo")
+}
+
+// GetOperationLog implements store.Service.
+func (m *StoreService) GetOperationLog(p0 context.Context, p1 int64) (*store.OperationLog, error) {
+	m.record("GetOperationLog")
+	if m.GetOperationLogFunc != nil {
+		return m.GetOperationLogFunc(p0, p1)
+	}
+	return nil, notMocked("Store.GetOperationLog")
+}
+
+// GetSettlementCurrency implements store.Service.
+func (m *StoreService) GetSettlementCurrency(p0 context.Context) ([]store.Currency, error) {
+	m.record("GetSettlementCurrency")
+	if m.GetSettlementCurrencyFunc != nil {
+		return m.GetSettlementCurrencyFunc(p0)
+	}
+	return nil, notMocked("Store.GetSettlementCurrency")
+}
+
+// GetShop implements store.Service.
+func (m *StoreService) GetShop(p0 context.Context) (*store.Shop, error) {
+	m.record("GetShop")
+	if m.GetShopFunc != nil {
+		return m.GetShopFunc(p0)
+	}
+	return nil, notMocked("Store.GetShop")
+}
+
+// GetStaffMember implements store.Service.
+func (m *StoreService) GetStaffMember(p0 context.Context, p1 string) (*store.StaffMember, error) {
+	m.record("GetStaffMember")
+	if m.GetStaffMemberFunc != nil {
+		return m.GetStaffMemberFunc(p0, p1)
+	}
+	return nil, notMocked("Store.GetStaffMember")
+}
+
+// InviteStaffMember implements store.Service.
+func (m *StoreService) InviteStaffMember(p0 context.Context, p1 string, p2 []string) (*store.StaffMember, error) {
+	m.record("InviteStaffMember")
+	if m.InviteStaffMemberFunc != nil {
+		return m.InviteStaffMemberFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Store.InviteStaffMember")
+}
+
+// ListOperationLogs implements store.Service.
+func (m *StoreService) ListOperationLogs(p0 context.Context, p1 *core.ListOptions) ([]store.OperationLog, error) {
+	m.record("ListOperationLogs")
+	if m.ListOperationLogsFunc != nil {
+		return m.ListOperationLogsFunc(p0, p1)
+	}
+	return nil, notMocked("Store.ListOperationLogs")
+}
+
+// ListStaffMembers implements store.Service.
+func (m *StoreService) ListStaffMembers(p0 context.Context) ([]store.StaffMember, error) {
+	m.record("ListStaffMembers")
+	if m.ListStaffMembersFunc != nil {
+		return m.ListStaffMembersFunc(p0)
+	}
+	return nil, notMocked("Store.ListStaffMembers")
+}
+
+// RemoveStaffMember implements store.Service.
+func (m *StoreService) RemoveStaffMember(p0 context.Context, p1 string) error {
+	m.record("RemoveStaffMember")
+	if m.RemoveStaffMemberFunc != nil {
+		return m.RemoveStaffMemberFunc(p0, p1)
+	}
+	return notMocked("Store.RemoveStaffMember")
+}
+
+// UpdateStaffPermissions implements store.Service.
+func (m *StoreService) UpdateStaffPermissions(p0 context.Context, p1 string, p2 []string) (*store.StaffMember, error) {
+	m.record("UpdateStaffPermissions")
+	if m.UpdateStaffPermissionsFunc != nil {
+		return m.UpdateStaffPermissionsFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Store.UpdateStaffPermissions")
+}
+
+// ShippingZoneService mocks store.ShippingZoneService. Methods whose func field is nil return
+// ErrNotMocked.
+type ShippingZoneService struct {
+	recorder
+	AddCountryFunc            func(ctx context.Context, zoneID int64, country store.ZoneCountry) (*store.ZoneCountry, error)
+	CreatePriceBasedRateFunc  func(ctx context.Context, zoneID int64, rate store.PriceBasedRate) (*store.PriceBasedRate, error)
+	CreateWeightBasedRateFunc func(ctx context.Context, zoneID int64, rate store.WeightBasedRate) (*store.WeightBasedRate, error)
+	GetFunc                   func(ctx context.Context, id int64) (*store.ShippingZone, error)
+	ListFunc                  func(ctx context.Context) ([]store.ShippingZone, error)
+	RemoveCountryFunc         func(ctx context.Context, zoneID int64, countryID int64) error
+	SetProvincesFunc          func(ctx context.Context, zoneID int64, countryID int64, provinces []store.ZoneProvince) (*store.ZoneCountry, error)
+}
+
+var _ store.ShippingZoneService = (*ShippingZoneService)(nil)
+
+// AddCountry implements store.ShippingZoneService.
+func (m *ShippingZoneService) AddCountry(p0 context.Context, p1 int64, p2 store.ZoneCountry) (*store.ZoneCountry, error) {
+	m.record("AddCountry")
+	if m.AddCountryFunc != nil {
+		return m.AddCountryFunc(p0, p1, p2)
+	}
+	return nil, notMocked("ShippingZone.AddCountry")
+}
+
+// CreatePriceBasedRate implements store.ShippingZoneService.
+func (m *ShippingZoneService) CreatePriceBasedRate(p0 context.Context, p1 int64, p2 store.PriceBasedRate) (*store.PriceBasedRate, error) {
+	m.record("CreatePriceBasedRate")
+	if m.CreatePriceBasedRateFunc != nil {
+		return m.CreatePriceBasedRateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("ShippingZone.CreatePriceBasedRate")
+}
+
+// CreateWeightBasedRate implements store.ShippingZoneService.
+func (m *ShippingZoneService) CreateWeightBasedRate(p0 context.Context, p1 int64, p2 store.WeightBasedRate) (*store.WeightBasedRate, error) {
+	m.record("CreateWeightBasedRate")
+	if m.CreateWeightBasedRateFunc != nil {
+		return m.CreateWeightBasedRateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("ShippingZone.CreateWeightBasedRate")
+}
+
+// Get implements store.ShippingZoneService.
+func (m *ShippingZoneService) Get(p0 context.Context, p1 int64) (*store.ShippingZone, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("ShippingZone.Get")
+}
+
+// List implements store.ShippingZoneService.
+func (m *ShippingZoneService) List(p0 context.Context) ([]store.ShippingZone, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("ShippingZone.List")
+}
+
+// RemoveCountry implements store.ShippingZoneService.
+func (m *ShippingZoneService) RemoveCountry(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("RemoveCountry")
+	if m.RemoveCountryFunc != nil {
+		return m.RemoveCountryFunc(p0, p1, p2)
+	}
+	return notMocked("ShippingZone.RemoveCountry")
+}
+
+// SetProvinces implements store.ShippingZoneService.
+func (m *ShippingZoneService) SetProvinces(p0 context.Context, p1 int64, p2 int64, p3 []store.ZoneProvince) (*store.ZoneCountry, error) {
+	m.record("SetProvinces")
+	if m.SetProvincesFunc != nil {
+		return m.SetProvincesFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("ShippingZone.SetProvinces")
+}
+
+// EventsService mocks events.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type EventsService struct {
+	recorder
+	CountFunc   func(ctx context.Context, opts *events.CountOptions) (int, error)
+	GetFunc     func(ctx context.Context, id int64) (*events.Event, error)
+	ListFunc    func(ctx context.Context, opts *events.ListOptions) ([]events.Event, error)
+	PublishFunc func(ctx context.Context, event events.AppEvent) error
+}
+
+var _ events.Service = (*EventsService)(nil)
+
+// Count implements events.Service.
+func (m *EventsService) Count(p0 context.Context, p1 *events.CountOptions) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1)
+	}
+	return 0, notMocked("Events.Count")
+}
+
+// Get implements events.Service.
+func (m *EventsService) Get(p0 context.Context, p1 int64) (*events.Event, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Events.Get")
+}
+
+// List implements events.Service.
+func (m *EventsService) List(p0 context.Context, p1 *events.ListOptions) ([]events.Event, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Events.List")
+}
+
+// Publish implements events.Service.
+func (m *EventsService) Publish(p0 context.Context, p1 events.AppEvent) error {
+	m.record("Publish")
+	if m.PublishFunc != nil {
+		return m.PublishFunc(p0, p1)
+	}
+	return notMocked("Events.Publish")
+}
+
+// DiscountService mocks marketing.DiscountService. Methods whose func field is nil return
+// ErrNotMocked.
+type DiscountService struct {
+	recorder
+	CreateDiscountCodeFunc func(ctx context.Context, priceRuleID int64, c marketing.DiscountCode) (*marketing.DiscountCode, error)
+	CreatePriceRuleFunc    func(ctx context.Context, r marketing.PriceRule) (*marketing.PriceRule, error)
+	DeleteDiscountCodeFunc func(ctx context.Context, priceRuleID int64, codeID int64) error
+	DeletePriceRuleFunc    func(ctx context.Context, id int64) error
+	GetDiscountCodeFunc    func(ctx context.Context, priceRuleID int64, codeID int64) (*marketing.DiscountCode, error)
+	GetPriceRuleFunc       func(ctx context.Context, id int64) (*marketing.PriceRule, error)
+	ListCodeUsagesFunc     func(ctx context.Context, priceRuleID int64, codeID int64, opts *core.ListOptions) ([]marketing.DiscountCodeUsage, error)
+	ListDiscountCodesFunc  func(ctx context.Context, priceRuleID int64) ([]marketing.DiscountCode, error)
+	ListPriceRulesFunc     func(ctx context.Context, opts *core.ListOptions) ([]marketing.PriceRule, error)
+	UpdateDiscountCodeFunc func(ctx context.Context, priceRuleID int64, c marketing.DiscountCode) (*marketing.DiscountCode, error)
+	UpdatePriceRuleFunc    func(ctx context.Context, r marketing.PriceRule) (*marketing.PriceRule, error)
+}
+
+var _ marketing.DiscountService = (*DiscountService)(nil)
+
+// CreateDiscountCode implements marketing.DiscountService.
+func (m *DiscountService) CreateDiscountCode(p0 context.Context, p1 int64, p2 marketing.DiscountCode) (*marketing.DiscountCode, error) {
+	m.record("CreateDiscountCode")
+	if m.CreateDiscountCodeFunc != nil {
+		return m.CreateDiscountCodeFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Discount.CreateDiscountCode")
+}
+
+// CreatePriceRule implements marketing.DiscountService.
+func (m *DiscountService) CreatePriceRule(p0 context.Context, p1 marketing.PriceRule) (*marketing.PriceRule, error) {
+	m.record("CreatePriceRule")
+	if m.CreatePriceRuleFunc != nil {
+		return m.CreatePriceRuleFunc(p0, p1)
+	}
+	return nil, notMocked("Discount.CreatePriceRule")
+}
+
+// DeleteDiscountCode implements marketing.DiscountService.
+func (m *DiscountService) DeleteDiscountCode(p0 context.Context, p1 int64, p2 int64) error {
+	m.record("DeleteDiscountCode")
+	if m.DeleteDiscountCodeFunc != nil {
+		return m.DeleteDiscountCodeFunc(p0, p1, p2)
+	}
+	return notMocked("Discount.DeleteDiscountCode")
+}
+
+// DeletePriceRule implements marketing.DiscountService.
+func (m *DiscountService) DeletePriceRule(p0 context.Context, p1 int64) error {
+	m.record("DeletePriceRule")
+	if m.DeletePriceRuleFunc != nil {
+		return m.DeletePriceRuleFunc(p0, p1)
+	}
+	return notMocked("Discount.DeletePriceRule")
+}
+
+// GetDiscountCode implements marketing.DiscountService.
+func (m *DiscountService) GetDiscountCode(p0 context.Context, p1 int64, p2 int64) (*marketing.DiscountCode, error) {
+	m.record("GetDiscountCode")
+	if m.GetDiscountCodeFunc != nil {
+		return m.GetDiscountCodeFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Discount.GetDiscountCode")
+}
+
+// GetPriceRule implements marketing.DiscountService.
+func (m *DiscountService) GetPriceRule(p0 context.Context, p1 int64) (*marketing.PriceRule, error) {
+	m.record("GetPriceRule")
+	if m.GetPriceRuleFunc != nil {
+		return m.GetPriceRuleFunc(p0, p1)
+	}
+	return nil, notMocked("Discount.GetPriceRule")
+}
+
+// ListCodeUsages implements marketing.DiscountService.
+func (m *DiscountService) ListCodeUsages(p0 context.Context, p1 int64, p2 int64, p3 *core.ListOptions) ([]marketing.DiscountCodeUsage, error) {
+	m.record("ListCodeUsages")
+	if m.ListCodeUsagesFunc != nil {
+		return m.ListCodeUsagesFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("Discount.ListCodeUsages")
+}
+
+// ListDiscountCodes implements marketing.DiscountService.
+func (m *DiscountService) ListDiscountCodes(p0 context.Context, p1 int64) ([]marketing.DiscountCode, error) {
+	m.record("ListDiscountCodes")
+	if m.ListDiscountCodesFunc != nil {
+		return m.ListDiscountCodesFunc(p0, p1)
+	}
+	return nil, notMocked("Discount.ListDiscountCodes")
+}
+
+// ListPriceRules implements marketing.DiscountService.
+func (m *DiscountService) ListPriceRules(p0 context.Context, p1 *core.ListOptions) ([]marketing.PriceRule, error) {
+	m.record("ListPriceRules")
+	if m.ListPriceRulesFunc != nil {
+		return m.ListPriceRulesFunc(p0, p1)
+	}
+	return nil, notMocked("Discount.ListPriceRules")
+}
+
+// UpdateDiscountCode implements marketing.DiscountService.
+func (m *DiscountService) UpdateDiscountCode(p0 context.Context, p1 int64, p2 marketing.DiscountCode) (*marketing.DiscountCode, error) {
+	m.record("UpdateDiscountCode")
+	if m.UpdateDiscountCodeFunc != nil {
+		return m.UpdateDiscountCodeFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Discount.UpdateDiscountCode")
+}
+
+// UpdatePriceRule implements marketing.DiscountService.
+func (m *DiscountService) UpdatePriceRule(p0 context.Context, p1 marketing.PriceRule) (*marketing.PriceRule, error) {
+	m.record("UpdatePriceRule")
+	if m.UpdatePriceRuleFunc != nil {
+		return m.UpdatePriceRuleFunc(p0, p1)
+	}
+	return nil, notMocked("Discount.UpdatePriceRule")
+}
+
+// ThemeService mocks onlinestore.ThemeService. Methods whose func field is nil return
+// ErrNotMocked.
+type ThemeService struct {
+	recorder
+	DuplicateFunc  func(ctx context.Context, id int64, name string) (*onlinestore.Theme, error)
+	GetFunc        func(ctx context.Context, id int64) (*onlinestore.Theme, error)
+	ListFunc       func(ctx context.Context) ([]onlinestore.Theme, error)
+	PreviewURLFunc func(id int64) string
+	PublishFunc    func(ctx context.Context, id int64) (*onlinestore.Theme, error)
+}
+
+var _ onlinestore.ThemeService = (*ThemeService)(nil)
+
+// Duplicate implements onlinestore.ThemeService.
+func (m *ThemeService) Duplicate(p0 context.Context, p1 int64, p2 string) (*onlinestore.Theme, error) {
+	m.record("Duplicate")
+	if m.DuplicateFunc != nil {
+		return m.DuplicateFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Theme.Duplicate")
+}
+
+// Get implements onlinestore.ThemeService.
+func (m *ThemeService) Get(p0 context.Context, p1 int64) (*onlinestore.Theme, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Theme.Get")
+}
+
+// List implements onlinestore.ThemeService.
+func (m *ThemeService) List(p0 context.Context) ([]onlinestore.Theme, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("Theme.List")
+}
+
+// PreviewURL implements onlinestore.ThemeService.
+func (m *ThemeService) PreviewURL(p0 int64) string {
+	m.record("PreviewURL")
+	if m.PreviewURLFunc != nil {
+		return m.PreviewURLFunc(p0)
+	}
+	return ""
+}
+
+// Publish implements onlinestore.ThemeService.
+func (m *ThemeService) Publish(p0 context.Context, p1 int64) (*onlinestore.Theme, error) {
+	m.record("Publish")
+	if m.PublishFunc != nil {
+		return m.PublishFunc(p0, p1)
+	}
+	return nil, notMocked("Theme.Publish")
+}
+
+// PageService mocks onlinestore.PageService. Methods whose func field is nil return
+// ErrNotMocked.
+type PageService struct {
+	recorder
+	CreateFunc func(ctx context.Context, p onlinestore.Page) (*onlinestore.Page, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*onlinestore.Page, error)
+	ListFunc   func(ctx context.Context, opts *core.ListOptions) ([]onlinestore.Page, error)
+	UpdateFunc func(ctx context.Context, p onlinestore.Page) (*onlinestore.Page, error)
+}
+
+var _ onlinestore.PageService = (*PageService)(nil)
+
+// Create implements onlinestore.PageService.
+func (m *PageService) Create(p0 context.Context, p1 onlinestore.Page) (*onlinestore.Page, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Page.Create")
+}
+
+// Delete implements onlinestore.PageService.
+func (m *PageService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Page.Delete")
+}
+
+// Get implements onlinestore.PageService.
+func (m *PageService) Get(p0 context.Context, p1 int64) (*onlinestore.Page, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Page.Get")
+}
+
+// List implements onlinestore.PageService.
+func (m *PageService) List(p0 context.Context, p1 *core.ListOptions) ([]onlinestore.Page, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Page.List")
+}
+
+// Update implements onlinestore.PageService.
+func (m *PageService) Update(p0 context.Context, p1 onlinestore.Page) (*onlinestore.Page, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Page.Update")
+}
+
+// ScriptTagService mocks onlinestore.ScriptTagService. Methods whose func field is nil return
+// ErrNotMocked.
+type ScriptTagService struct {
+	recorder
+	CreateFunc func(ctx context.Context, t onlinestore.ScriptTag) (*onlinestore.ScriptTag, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*onlinestore.ScriptTag, error)
+	ListFunc   func(ctx context.Context, opts *core.ListOptions) ([]onlinestore.ScriptTag, error)
+}
+
+var _ onlinestore.ScriptTagService = (*ScriptTagService)(nil)
+
+// Create implements onlinestore.ScriptTagService.
+func (m *ScriptTagService) Create(p0 context.Context, p1 onlinestore.ScriptTag) (*onlinestore.ScriptTag, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("ScriptTag.Create")
+}
+
+// Delete implements onlinestore.ScriptTagService.
+func (m *ScriptTagService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("ScriptTag.Delete")
+}
+
+// Get implements onlinestore.ScriptTagService.
+func (m *ScriptTagService) Get(p0 context.Context, p1 int64) (*onlinestore.ScriptTag, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("ScriptTag.Get")
+}
+
+// List implements onlinestore.ScriptTagService.
+func (m *ScriptTagService) List(p0 context.Context, p1 *core.ListOptions) ([]onlinestore.ScriptTag, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("ScriptTag.List")
+}
+
+// WebhookService mocks webhook.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type WebhookService struct {
+	recorder
+	CreateFunc       func(ctx context.Context, w webhook.Subscription) (*webhook.Subscription, error)
+	DeleteFunc       func(ctx context.Context, id int64) error
+	GetFunc          func(ctx context.Context, id int64) (*webhook.Subscription, error)
+	ListFunc         func(ctx context.Context, opts *core.ListOptions) ([]webhook.Subscription, error)
+	TestDeliveryFunc func(ctx context.Context, id int64) error
+	UpdateFunc       func(ctx context.Context, w webhook.Subscription) (*webhook.Subscription, error)
+}
+
+var _ webhook.Service = (*WebhookService)(nil)
+
+// Create implements webhook.Service.
+func (m *WebhookService) Create(p0 context.Context, p1 webhook.Subscription) (*webhook.Subscription, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Webhook.Create")
+}
+
+// Delete implements webhook.Service.
+func (m *WebhookService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Webhook.Delete")
+}
+
+// Get implements webhook.Service.
+func (m *WebhookService) Get(p0 context.Context, p1 int64) (*webhook.Subscription, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Webhook.Get")
+}
+
+// List implements webhook.Service.
+func (m *WebhookService) List(p0 context.Context, p1 *core.ListOptions) ([]webhook.Subscription, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Webhook.List")
+}
+
+// TestDelivery implements webhook.Service.
+func (m *WebhookService) TestDelivery(p0 context.Context, p1 int64) error {
+	m.record("TestDelivery")
+	if m.TestDeliveryFunc != nil {
+		return m.TestDeliveryFunc(p0, p1)
+	}
+	return notMocked("Webhook.TestDelivery")
+}
+
+// Update implements webhook.Service.
+func (m *WebhookService) Update(p0 context.Context, p1 webhook.Subscription) (*webhook.Subscription, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Webhook.Update")
+}
+
+// StorefrontAccessTokenService mocks access.StorefrontAccessTokenService. Methods whose func field is nil return
+// ErrNotMocked.
+type StorefrontAccessTokenService struct {
+	recorder
+	CreateFunc func(ctx context.Context, title string) (*access.StorefrontAccessToken, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	ListFunc   func(ctx context.Context) ([]access.StorefrontAccessToken, error)
+}
+
+var _ access.StorefrontAccessTokenService = (*StorefrontAccessTokenService)(nil)
+
+// Create implements access.StorefrontAccessTokenService.
+func (m *StorefrontAccessTokenService) Create(p0 context.Context, p1 string) (*access.StorefrontAccessToken, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("StorefrontAccessToken.Create")
+}
+
+// Delete implements access.StorefrontAccessTokenService.
+func (m *StorefrontAccessTokenService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("StorefrontAccessToken.Delete")
+}
+
+// List implements access.StorefrontAccessTokenService.
+func (m *StorefrontAccessTokenService) List(p0 context.Context) ([]access.StorefrontAccessToken, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("StorefrontAccessToken.List")
+}
+
+// MarketService mocks market.MarketService. Methods whose func field is nil return
+// ErrNotMocked.
+type MarketService struct {
+	recorder
+	GetFunc  func(ctx context.Context, id int64) (*market.Market, error)
+	ListFunc func(ctx context.Context, opts *core.ListOptions) ([]market.Market, error)
+}
+
+var _ market.MarketService = (*MarketService)(nil)
+
+// Get implements market.MarketService.
+func (m *MarketService) Get(p0 context.Context, p1 int64) (*market.Market, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Market.Get")
+}
+
+// List implements market.MarketService.
+func (m *MarketService) List(p0 context.Context, p1 *core.ListOptions) ([]market.Market, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Market.List")
+}
+
+// LocationService mocks market.LocationService. Methods whose func field is nil return
+// ErrNotMocked.
+type LocationService struct {
+	recorder
+	ActivateFunc   func(ctx context.Context, id int64) (*market.Location, error)
+	CreateFunc     func(ctx context.Context, l market.Location) (*market.Location, error)
+	DeactivateFunc func(ctx context.Context, id int64) (*market.Location, error)
+	DeleteFunc     func(ctx context.Context, id int64) error
+	GetFunc        func(ctx context.Context, id int64) (*market.Location, error)
+	ListFunc       func(ctx context.Context) ([]market.Location, error)
+	UpdateFunc     func(ctx context.Context, l market.Location) (*market.Location, error)
+}
+
+var _ market.LocationService = (*LocationService)(nil)
+
+// Activate implements market.LocationService.
+func (m *LocationService) Activate(p0 context.Context, p1 int64) (*market.Location, error) {
+	m.record("Activate")
+	if m.ActivateFunc != nil {
+		return m.ActivateFunc(p0, p1)
+	}
+	return nil, notMocked("Location.Activate")
+}
+
+// Create implements market.LocationService.
+func (m *LocationService) Create(p0 context.Context, p1 market.Location) (*market.Location, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Location.Create")
+}
+
+// Deactivate implements market.LocationService.
+func (m *LocationService) Deactivate(p0 context.Context, p1 int64) (*market.Location, error) {
+	m.record("Deactivate")
+	if m.DeactivateFunc != nil {
+		return m.DeactivateFunc(p0, p1)
+	}
+	return nil, notMocked("Location.Deactivate")
+}
+
+// Delete implements market.LocationService.
+func (m *LocationService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Location.Delete")
+}
+
+// Get implements market.LocationService.
+func (m *LocationService) Get(p0 context.Context, p1 int64) (*market.Location, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Location.Get")
+}
+
+// List implements market.LocationService.
+func (m *LocationService) List(p0 context.Context) ([]market.Location, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0)
+	}
+	return nil, notMocked("Location.List")
+}
+
+// Update implements market.LocationService.
+func (m *LocationService) Update(p0 context.Context, p1 market.Location) (*market.Location, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Location.Update")
+}
+
+// PublicationService mocks market.PublicationService. Methods whose func field is nil return
+// ErrNotMocked.
+type PublicationService struct {
+	recorder
+	ListFunc func(ctx context.Context, opts *core.ListOptions) ([]market.Publication, error)
+}
+
+var _ market.PublicationService = (*PublicationService)(nil)
+
+// List implements market.PublicationService.
+func (m *PublicationService) List(p0 context.Context, p1 *core.ListOptions) ([]market.Publication, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Publication.List")
+}
+
+// GiftCardService mocks market.GiftCardService. Methods whose func field is nil return
+// ErrNotMocked.
+type GiftCardService struct {
+	recorder
+	CreateFunc           func(ctx context.Context, c market.GiftCard) (*market.GiftCard, error)
+	GetFunc              func(ctx context.Context, id int64) (*market.GiftCard, error)
+	ListFunc             func(ctx context.Context, opts *core.ListOptions) ([]market.GiftCard, error)
+	ListTransactionsFunc func(ctx context.Context, giftCardID int64) ([]market.GiftCardTransaction, error)
+}
+
+var _ market.GiftCardService = (*GiftCardService)(nil)
+
+// Create implements market.GiftCardService.
+func (m *GiftCardService) Create(p0 context.Context, p1 market.GiftCard) (*market.GiftCard, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("GiftCard.Create")
+}
+
+// Get implements market.GiftCardService.
+func (m *GiftCardService) Get(p0 context.Context, p1 int64) (*market.GiftCard, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("GiftCard.Get")
+}
+
+// List implements market.GiftCardService.
+func (m *GiftCardService) List(p0 context.Context, p1 *core.ListOptions) ([]market.GiftCard, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("GiftCard.List")
+}
+
+// ListTransactions implements market.GiftCardService.
+func (m *GiftCardService) ListTransactions(p0 context.Context, p1 int64) ([]market.GiftCardTransaction, error) {
+	m.record("ListTransactions")
+	if m.ListTransactionsFunc != nil {
+		return m.ListTransactionsFunc(p0, p1)
+	}
+	return nil, notMocked("GiftCard.ListTransactions")
+}
+
+// LocalizationsService mocks localizations.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type LocalizationsService struct {
+	recorder
+	AddLanguagesFunc              func(ctx context.Context, languages []string) (*localizations.LanguageData, error)
+	BatchQueryTranslationFunc     func(ctx context.Context, opts *localizations.TranslationBatchQuery) ([]localizations.TranslationData, error)
+	BatchUpsertTranslationsFunc   func(ctx context.Context, resourceType string, resourceID string, translations []localizations.TranslationEntry) error
+	DeleteLanguagesFunc           func(ctx context.Context, languages []string) (*localizations.LanguageData, error)
+	DeleteTranslationFunc         func(ctx context.Context, data localizations.TranslationDeleteRequest) error
+	GetAvailableLanguagesFunc     func(ctx context.Context) ([]localizations.AvailableLanguage, error)
+	GetLanguagesFunc              func(ctx context.Context) (*localizations.LanguageData, error)
+	GetTranslationFunc            func(ctx context.Context, opts *localizations.TranslationQuery) (*localizations.TranslationData, error)
+	ListLocalesFunc               func(ctx context.Context) ([]localizations.Locale, error)
+	ListTranslatableResourcesFunc func(ctx context.Context, resourceType string, opts *core.ListOptions) ([]localizations.TranslatableResource, error)
+	UpdateTranslationFunc         func(ctx context.Context, data localizations.TranslationUpdateRequest) error
+}
+
+var _ localizations.Service = (*LocalizationsService)(nil)
+
+// AddLanguages implements localizations.Service.
+func (m *LocalizationsService) AddLanguages(p0 context.Context, p1 []string) (*localizations.LanguageData, error) {
+	m.record("AddLanguages")
+	if m.AddLanguagesFunc != nil {
+		return m.AddLanguagesFunc(p0, p1)
+	}
+	return nil, notMocked("Localizations.AddLanguages")
+}
+
+// BatchQueryTranslation implements localizations.Service.
+func (m *LocalizationsService) BatchQueryTranslation(p0 context.Context, p1 *localizations.TranslationBatchQuery) ([]localizations.TranslationData, error) {
+	m.record("BatchQueryTranslation")
+	if m.BatchQueryTranslationFunc != nil {
+		return m.BatchQueryTranslationFunc(p0, p1)
+	}
+	return nil, notMocked("Localizations.BatchQueryTranslation")
+}
+
+// BatchUpsertTranslations implements localizations.Service.
+func (m *LocalizationsService) BatchUpsertTranslations(p0 context.Context, p1 string, p2 string, p3 []localizations.TranslationEntry) error {
+	m.record("BatchUpsertTranslations")
+	if m.BatchUpsertTranslationsFunc != nil {
+		return m.BatchUpsertTranslationsFunc(p0, p1, p2, p3)
+	}
+	return notMocked("Localizations.BatchUpsertTranslations")
+}
+
+// DeleteLanguages implements localizations.Service.
+func (m *LocalizationsService) DeleteLanguages(p0 context.Context, p1 []string) (*localizations.LanguageData, error) {
+	m.record("DeleteLanguages")
+	if m.DeleteLanguagesFunc != nil {
+		return m.DeleteLanguagesFunc(p0, p1)
+	}
+	return nil, notMocked("Localizations.DeleteLanguages")
+}
+
+// DeleteTranslation implements localizations.Service.
+func (m *LocalizationsService) DeleteTranslation(p0 context.Context, p1 localizations.TranslationDeleteRequest) error {
+	m.record("DeleteTranslation")
+	if m.DeleteTranslationFunc != nil {
+		return m.DeleteTranslationFunc(p0, p1)
+	}
+	return notMocked("Localizations.DeleteTranslation")
+}
+
+// GetAvailableLanguages implements localizations.Service.
+func (m *LocalizationsService) GetAvailableLanguages(p0 context.Context) ([]localizations.AvailableLanguage, error) {
+	m.record("GetAvailableLanguages")
+	if m.GetAvailableLanguagesFunc != nil {
+		return m.GetAvailableLanguagesFunc(p0)
+	}
+	return nil, notMocked("Localizations.GetAvailableLanguages")
+}
+
+// GetLanguages implements localizations.Service.
+func (m *LocalizationsService) GetLanguages(p0 context.Context) (*localizations.LanguageData, error) {
+	m.record("GetLanguages")
+	if m.GetLanguagesFunc != nil {
+		return m.GetLanguagesFunc(p0)
+	}
+	return nil, notMocked("Localizations.GetLanguages")
+}
+
+// GetTranslation implements localizations.Service.
+func (m *LocalizationsService) GetTranslation(p0 context.Context, p1 *localizations.TranslationQuery) (*localizations.TranslationData, error) {
+	m.record("GetTranslation")
+	if m.GetTranslationFunc != nil {
+		return m.GetTranslationFunc(p0, p1)
+	}
+	return nil, notMocked("Localizations.GetTranslation")
+}
+
+// ListLocales implements localizations.Service.
+func (m *LocalizationsService) ListLocales(p0 context.Context) ([]localizations.Locale, error) {
+	m.record("ListLocales")
+	if m.ListLocalesFunc != nil {
+		return m.ListLocalesFunc(p0)
+	}
+	return nil, notMocked("Localizations.ListLocales")
+}
+
+// ListTranslatableResources implements localizations.Service.
+func (m *LocalizationsService) ListTranslatableResources(p0 context.Context, p1 string, p2 *core.ListOptions) ([]localizations.TranslatableResource, error) {
+	m.record("ListTranslatableResources")
+	if m.ListTranslatableResourcesFunc != nil {
+		return m.ListTranslatableResourcesFunc(p0, p1, p2)
+	}
+	return nil, notMocked("Localizations.ListTranslatableResources")
+}
+
+// UpdateTranslation implements localizations.Service.
+func (m *LocalizationsService) UpdateTranslation(p0 context.Context, p1 localizations.TranslationUpdateRequest) error {
+	m.record("UpdateTranslation")
+	if m.UpdateTranslationFunc != nil {
+		return m.UpdateTranslationFunc(p0, p1)
+	}
+	return notMocked("Localizations.UpdateTranslation")
+}
+
+// SalesChannelService mocks saleschannel.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type SalesChannelService struct {
+	recorder
+	AddCollectionFunc            func(ctx context.Context, collectionID int64) (*saleschannel.CollectionListing, error)
+	AddProductFunc               func(ctx context.Context, productID int64) (*saleschannel.ProductListing, error)
+	CountProductsFunc            func(ctx context.Context) (int, error)
+	GetCollectionFunc            func(ctx context.Context, collectionID int64) (*saleschannel.CollectionListing, error)
+	GetProductFunc               func(ctx context.Context, productID int64) (*saleschannel.ProductListing, error)
+	ListCollectionProductIDsFunc func(ctx context.Context, collectionID int64, opts *core.ListOptions) ([]int64, error)
+	ListCollectionsFunc          func(ctx context.Context, opts *core.ListOptions) ([]saleschannel.CollectionListing, error)
+	ListProductIDsFunc           func(ctx context.Context, opts *core.ListOptions) ([]int64, error)
+	ListProductsFunc             func(ctx context.Context, opts *core.ListOptions) ([]saleschannel.ProductListing, error)
+	RemoveCollectionFunc         func(ctx context.Context, collectionID int64) error
+	RemoveProductFunc            func(ctx context.Context, productID int64) error
+}
+
+var _ saleschannel.Service = (*SalesChannelService)(nil)
+
+// AddCollection implements saleschannel.Service.
+func (m *SalesChannelService) AddCollection(p0 context.Context, p1 int64) (*saleschannel.CollectionListing, error) {
+	m.record("AddCollection")
+	if m.AddCollectionFunc != nil {
+		return m.AddCollectionFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.AddCollection")
+}
+
+// AddProduct implements saleschannel.Service.
+func (m *SalesChannelService) AddProduct(p0 context.Context, p1 int64) (*saleschannel.ProductListing, error) {
+	m.record("AddProduct")
+	if m.AddProductFunc != nil {
+		return m.AddProductFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.AddProduct")
+}
+
+// CountProducts implements saleschannel.Service.
+func (m *SalesChannelService) CountProducts(p0 context.Context) (int, error) {
+	m.record("CountProducts")
+	if m.CountProductsFunc != nil {
+		return m.CountProductsFunc(p0)
+	}
+	return 0, notMocked("SalesChannel.CountProducts")
+}
+
+// GetCollection implements saleschannel.Service.
+func (m *SalesChannelService) GetCollection(p0 context.Context, p1 int64) (*saleschannel.CollectionListing, error) {
+	m.record("GetCollection")
+	if m.GetCollectionFunc != nil {
+		return m.GetCollectionFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.GetCollection")
+}
+
+// GetProduct implements saleschannel.Service.
+func (m *SalesChannelService) GetProduct(p0 context.Context, p1 int64) (*saleschannel.ProductListing, error) {
+	m.record("GetProduct")
+	if m.GetProductFunc != nil {
+		return m.GetProductFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.GetProduct")
+}
+
+// ListCollectionProductIDs implements saleschannel.Service.
+func (m *SalesChannelService) ListCollectionProductIDs(p0 context.Context, p1 int64, p2 *core.ListOptions) ([]int64, error) {
+	m.record("ListCollectionProductIDs")
+	if m.ListCollectionProductIDsFunc != nil {
+		return m.ListCollectionProductIDsFunc(p0, p1, p2)
+	}
+	return nil, notMocked("SalesChannel.ListCollectionProductIDs")
+}
+
+// ListCollections implements saleschannel.Service.
+func (m *SalesChannelService) ListCollections(p0 context.Context, p1 *core.ListOptions) ([]saleschannel.CollectionListing, error) {
+	m.record("ListCollections")
+	if m.ListCollectionsFunc != nil {
+		return m.ListCollectionsFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.ListCollections")
+}
+
+// ListProductIDs implements saleschannel.Service.
+func (m *SalesChannelService) ListProductIDs(p0 context.Context, p1 *core.ListOptions) ([]int64, error) {
+	m.record("ListProductIDs")
+	if m.ListProductIDsFunc != nil {
+		return m.ListProductIDsFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.ListProductIDs")
+}
+
+// ListProducts implements saleschannel.Service.
+func (m *SalesChannelService) ListProducts(p0 context.Context, p1 *core.ListOptions) ([]saleschannel.ProductListing, error) {
+	m.record("ListProducts")
+	if m.ListProductsFunc != nil {
+		return m.ListProductsFunc(p0, p1)
+	}
+	return nil, notMocked("SalesChannel.ListProducts")
+}
+
+// RemoveCollection implements saleschannel.Service.
+func (m *SalesChannelService) RemoveCollection(p0 context.Context, p1 int64) error {
+	m.record("RemoveCollection")
+	if m.RemoveCollectionFunc != nil {
+		return m.RemoveCollectionFunc(p0, p1)
+	}
+	return notMocked("SalesChannel.RemoveCollection")
+}
+
+// RemoveProduct implements saleschannel.Service.
+func (m *SalesChannelService) RemoveProduct(p0 context.Context, p1 int64) error {
+	m.record("RemoveProduct")
+	if m.RemoveProductFunc != nil {
+		return m.RemoveProductFunc(p0, p1)
+	}
+	return notMocked("SalesChannel.RemoveProduct")
+}
+
+// MetafieldDefinitionService mocks metafield.DefinitionService. Methods whose func field is nil return
+// ErrNotMocked.
+type MetafieldDefinitionService struct {
+	recorder
+	CountFunc  func(ctx context.Context, opts *metafield.DefinitionCountOptions) (int, error)
+	CreateFunc func(ctx context.Context, def metafield.MetafieldDefinition) (*metafield.MetafieldDefinition, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*metafield.MetafieldDefinition, error)
+	ListFunc   func(ctx context.Context, opts *metafield.DefinitionListOptions) ([]metafield.MetafieldDefinition, error)
+	UpdateFunc func(ctx context.Context, def metafield.MetafieldDefinition) (*metafield.MetafieldDefinition, error)
+}
+
+var _ metafield.DefinitionService = (*MetafieldDefinitionService)(nil)
+
+// Count implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) Count(p0 context.Context, p1 *metafield.DefinitionCountOptions) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1)
+	}
+	return 0, notMocked("MetafieldDefinition.Count")
+}
+
+// Create implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) Create(p0 context.Context, p1 metafield.MetafieldDefinition) (*metafield.MetafieldDefinition, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldDefinition.Create")
+}
+
+// Delete implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("MetafieldDefinition.Delete")
+}
+
+// Get implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) Get(p0 context.Context, p1 int64) (*metafield.MetafieldDefinition, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldDefinition.Get")
+}
+
+// List implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) List(p0 context.Context, p1 *metafield.DefinitionListOptions) ([]metafield.MetafieldDefinition, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldDefinition.List")
+}
+
+// Update implements metafield.DefinitionService.
+func (m *MetafieldDefinitionService) Update(p0 context.Context, p1 metafield.MetafieldDefinition) (*metafield.MetafieldDefinition, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldDefinition.Update")
+}
+
+// MetafieldResourceService mocks metafield.ResourceService. Methods whose func field is nil return
+// ErrNotMocked.
+type MetafieldResourceService struct {
+	recorder
+	CountFunc  func(ctx context.Context, ownerResource string, ownerID int64) (int, error)
+	CreateFunc func(ctx context.Context, ownerResource string, ownerID int64, m metafield.Metafield) (*metafield.Metafield, error)
+	DeleteFunc func(ctx context.Context, ownerResource string, ownerID int64, metafieldID int64) error
+	GetFunc    func(ctx context.Context, ownerResource string, ownerID int64, metafieldID int64) (*metafield.Metafield, error)
+	ListFunc   func(ctx context.Context, ownerResource string, ownerID int64, opts *core.ListOptions) ([]metafield.Metafield, error)
+	UpdateFunc func(ctx context.Context, ownerResource string, ownerID int64, m metafield.Metafield) (*metafield.Metafield, error)
+}
+
+var _ metafield.ResourceService = (*MetafieldResourceService)(nil)
+
+// Count implements metafield.ResourceService.
+func (m *MetafieldResourceService) Count(p0 context.Context, p1 string, p2 int64) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0, p1, p2)
+	}
+	return 0, notMocked("MetafieldResource.Count")
+}
+
+// Create implements metafield.ResourceService.
+func (m *MetafieldResourceService) Create(p0 context.Context, p1 string, p2 int64, p3 metafield.Metafield) (*metafield.Metafield, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("MetafieldResource.Create")
+}
+
+// Delete implements metafield.ResourceService.
+func (m *MetafieldResourceService) Delete(p0 context.Context, p1 string, p2 int64, p3 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1, p2, p3)
+	}
+	return notMocked("MetafieldResource.Delete")
+}
+
+// Get implements metafield.ResourceService.
+func (m *MetafieldResourceService) Get(p0 context.Context, p1 string, p2 int64, p3 int64) (*metafield.Metafield, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("MetafieldResource.Get")
+}
+
+// List implements metafield.ResourceService.
+func (m *MetafieldResourceService) List(p0 context.Context, p1 string, p2 int64, p3 *core.ListOptions) ([]metafield.Metafield, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("MetafieldResource.List")
+}
+
+// Update implements metafield.ResourceService.
+func (m *MetafieldResourceService) Update(p0 context.Context, p1 string, p2 int64, p3 metafield.Metafield) (*metafield.Metafield, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1, p2, p3)
+	}
+	return nil, notMocked("MetafieldResource.Update")
+}
+
+// MetafieldStoreService mocks metafield.StoreService. Methods whose func field is nil return
+// ErrNotMocked.
+type MetafieldStoreService struct {
+	recorder
+	CountFunc  func(ctx context.Context) (int, error)
+	CreateFunc func(ctx context.Context, m metafield.Metafield) (*metafield.Metafield, error)
+	DeleteFunc func(ctx context.Context, metafieldID int64) error
+	GetFunc    func(ctx context.Context, metafieldID int64) (*metafield.Metafield, error)
+	ListFunc   func(ctx context.Context, opts *core.ListOptions) ([]metafield.Metafield, error)
+	UpdateFunc func(ctx context.Context, m metafield.Metafield) (*metafield.Metafield, error)
+}
+
+var _ metafield.StoreService = (*MetafieldStoreService)(nil)
+
+// Count implements metafield.StoreService.
+func (m *MetafieldStoreService) Count(p0 context.Context) (int, error) {
+	m.record("Count")
+	if m.CountFunc != nil {
+		return m.CountFunc(p0)
+	}
+	return 0, notMocked("MetafieldStore.Count")
+}
+
+// Create implements metafield.StoreService.
+func (m *MetafieldStoreService) Create(p0 context.Context, p1 metafield.Metafield) (*metafield.Metafield, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldStore.Create")
+}
+
+// Delete implements metafield.StoreService.
+func (m *MetafieldStoreService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("MetafieldStore.Delete")
+}
+
+// Get implements metafield.StoreService.
+func (m *MetafieldStoreService) Get(p0 context.Context, p1 int64) (*metafield.Metafield, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldStore.Get")
+}
+
+// List implements metafield.StoreService.
+func (m *MetafieldStoreService) List(p0 context.Context, p1 *core.ListOptions) ([]metafield.Metafield, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldStore.List")
+}
+
+// Update implements metafield.StoreService.
+func (m *MetafieldStoreService) Update(p0 context.Context, p1 metafield.Metafield) (*metafield.Metafield, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("MetafieldStore.Update")
+}
+
+// MetaobjectDefinitionService mocks metaobject.DefinitionService. Methods whose func field is nil return
+// ErrNotMocked.
+type MetaobjectDefinitionService struct {
+	recorder
+	CreateFunc func(ctx context.Context, def metaobject.Definition) (*metaobject.Definition, error)
+	DeleteFunc func(ctx context.Context, id int64) error
+	GetFunc    func(ctx context.Context, id int64) (*metaobject.Definition, error)
+	ListFunc   func(ctx context.Context, opts *core.ListOptions) ([]metaobject.Definition, error)
+	UpdateFunc func(ctx context.Context, def metaobject.Definition) (*metaobject.Definition, error)
+}
+
+var _ metaobject.DefinitionService = (*MetaobjectDefinitionService)(nil)
+
+// Create implements metaobject.DefinitionService.
+func (m *MetaobjectDefinitionService) Create(p0 context.Context, p1 metaobject.Definition) (*metaobject.Definition, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("MetaobjectDefinition.Create")
+}
+
+// Delete implements metaobject.DefinitionService.
+func (m *MetaobjectDefinitionService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("MetaobjectDefinition.Delete")
+}
+
+// Get implements metaobject.DefinitionService.
+func (m *MetaobjectDefinitionService) Get(p0 context.Context, p1 int64) (*metaobject.Definition, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("MetaobjectDefinition.Get")
+}
+
+// List implements metaobject.DefinitionService.
+func (m *MetaobjectDefinitionService) List(p0 context.Context, p1 *core.ListOptions) ([]metaobject.Definition, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("MetaobjectDefinition.List")
+}
+
+// Update implements metaobject.DefinitionService.
+func (m *MetaobjectDefinitionService) Update(p0 context.Context, p1 metaobject.Definition) (*metaobject.Definition, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("MetaobjectDefinition.Update")
+}
+
+// MetaobjectService mocks metaobject.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type MetaobjectService struct {
+	recorder
+	CreateFunc    func(ctx context.Context, m metaobject.Metaobject) (*metaobject.Metaobject, error)
+	DeleteFunc    func(ctx context.Context, id int64) error
+	GetFunc       func(ctx context.Context, id int64) (*metaobject.Metaobject, error)
+	ListFunc      func(ctx context.Context, opts *metaobject.ListOptions) ([]metaobject.Metaobject, error)
+	PublishFunc   func(ctx context.Context, id int64) (*metaobject.Metaobject, error)
+	UnpublishFunc func(ctx context.Context, id int64) (*metaobject.Metaobject, error)
+	UpdateFunc    func(ctx context.Context, m metaobject.Metaobject) (*metaobject.Metaobject, error)
+}
+
+var _ metaobject.Service = (*MetaobjectService)(nil)
+
+// Create implements metaobject.Service.
+func (m *MetaobjectService) Create(p0 context.Context, p1 metaobject.Metaobject) (*metaobject.Metaobject, error) {
+	m.record("Create")
+	if m.CreateFunc != nil {
+		return m.CreateFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.Create")
+}
+
+// Delete implements metaobject.Service.
+func (m *MetaobjectService) Delete(p0 context.Context, p1 int64) error {
+	m.record("Delete")
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(p0, p1)
+	}
+	return notMocked("Metaobject.Delete")
+}
+
+// Get implements metaobject.Service.
+func (m *MetaobjectService) Get(p0 context.Context, p1 int64) (*metaobject.Metaobject, error) {
+	m.record("Get")
+	if m.GetFunc != nil {
+		return m.GetFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.Get")
+}
+
+// List implements metaobject.Service.
+func (m *MetaobjectService) List(p0 context.Context, p1 *metaobject.ListOptions) ([]metaobject.Metaobject, error) {
+	m.record("List")
+	if m.ListFunc != nil {
+		return m.ListFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.List")
+}
+
+// Publish implements metaobject.Service.
+func (m *MetaobjectService) Publish(p0 context.Context, p1 int64) (*metaobject.Metaobject, error) {
+	m.record("Publish")
+	if m.PublishFunc != nil {
+		return m.PublishFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.Publish")
+}
+
+// Unpublish implements metaobject.Service.
+func (m *MetaobjectService) Unpublish(p0 context.Context, p1 int64) (*metaobject.Metaobject, error) {
+	m.record("Unpublish")
+	if m.UnpublishFunc != nil {
+		return m.UnpublishFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.Unpublish")
+}
+
+// Update implements metaobject.Service.
+func (m *MetaobjectService) Update(p0 context.Context, p1 metaobject.Metaobject) (*metaobject.Metaobject, error) {
+	m.record("Update")
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(p0, p1)
+	}
+	return nil, notMocked("Metaobject.Update")
+}
+
+// BulkOperationService mocks bulk.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type BulkOperationService struct {
+	recorder
+	CancelFunc         func(ctx context.Context, id string) (*bulk.BulkOperation, error)
+	CreateMutationFunc func(ctx context.Context, mutation bulk.BulkMutationRequest) (*bulk.BulkOperation, error)
+	CreateQueryFunc    func(ctx context.Context, query bulk.BulkQueryRequest) (*bulk.BulkOperation, error)
+	GetCurrentFunc     func(ctx context.Context, opType string) (*bulk.BulkOperation, error)
+}
+
+var _ bulk.Service = (*BulkOperationService)(nil)
+
+// Cancel implements bulk.Service.
+func (m *BulkOperationService) Cancel(p0 context.Context, p1 string) (*bulk.BulkOperation, error) {
+	m.record("Cancel")
+	if m.CancelFunc != nil {
+		return m.CancelFunc(p0, p1)
+	}
+	return nil, notMocked("BulkOperation.Cancel")
+}
+
+// CreateMutation implements bulk.Service.
+func (m *BulkOperationService) CreateMutation(p0 context.Context, p1 bulk.BulkMutationRequest) (*bulk.BulkOperation, error) {
+	m.record("CreateMutation")
+	if m.CreateMutationFunc != nil {
+		return m.CreateMutationFunc(p0, p1)
+	}
+	return nil, notMocked("BulkOperation.CreateMutation")
+}
+
+// CreateQuery implements bulk.Service.
+func (m *BulkOperationService) CreateQuery(p0 context.Context, p1 bulk.BulkQueryRequest) (*bulk.BulkOperation, error) {
+	m.record("CreateQuery")
+	if m.CreateQueryFunc != nil {
+		return m.CreateQueryFunc(p0, p1)
+	}
+	return nil, notMocked("BulkOperation.CreateQuery")
+}
+
+// GetCurrent implements bulk.Service.
+func (m *BulkOperationService) GetCurrent(p0 context.Context, p1 string) (*bulk.BulkOperation, error) {
+	m.record("GetCurrent")
+	if m.GetCurrentFunc != nil {
+		return m.GetCurrentFunc(p0, p1)
+	}
+	return nil, notMocked("BulkOperation.GetCurrent")
+}
+
+// ShoplinePaymentsService mocks shoplinepay.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type ShoplinePaymentsService struct {
+	recorder
+	CreatePayoutFunc       func(ctx context.Context, payout shoplinepay.PayoutRequest) (*shoplinepay.Payout, error)
+	GetBalanceFunc         func(ctx context.Context) (*shoplinepay.Balance, error)
+	ListBillingRecordsFunc func(ctx context.Context, opts *shoplinepay.BillingListOptions) ([]shoplinepay.BillingRecord, error)
+	ListPayoutsFunc        func(ctx context.Context, opts *shoplinepay.PayoutListOptions) ([]shoplinepay.Payout, error)
+	ListTransactionsFunc   func(ctx context.Context, opts *shoplinepay.TransactionListOptions) ([]shoplinepay.Transaction, error)
+}
+
+var _ shoplinepay.Service = (*ShoplinePaymentsService)(nil)
+
+// CreatePayout implements shoplinepay.Service.
+func (m *ShoplinePaymentsService) CreatePayout(p0 context.Context, p1 shoplinepay.PayoutRequest) (*shoplinepay.Payout, error) {
+	m.record("CreatePayout")
+	if m.CreatePayoutFunc != nil {
+		return m.CreatePayoutFunc(p0, p1)
+	}
+	return nil, notMocked("ShoplinePayments.CreatePayout")
+}
+
+// GetBalance implements shoplinepay.Service.
+func (m *ShoplinePaymentsService) GetBalance(p0 context.Context) (*shoplinepay.Balance, error) {
+	m.record("GetBalance")
+	if m.GetBalanceFunc != nil {
+		return m.GetBalanceFunc(p0)
+	}
+	return nil, notMocked("ShoplinePayments.GetBalance")
+}
+
+// ListBillingRecords implements shoplinepay.Service.
+func (m *ShoplinePaymentsService) ListBillingRecords(p0 context.Context, p1 *shoplinepay.BillingListOptions) ([]shoplinepay.BillingRecord, error) {
+	m.record("ListBillingRecords")
+	if m.ListBillingRecordsFunc != nil {
+		return m.ListBillingRecordsFunc(p0, p1)
+	}
+	return nil, notMocked("ShoplinePayments.ListBillingRecords")
+}
+
+// ListPayouts implements shoplinepay.Service.
+func (m *ShoplinePaymentsService) ListPayouts(p0 context.Context, p1 *shoplinepay.PayoutListOptions) ([]shoplinepay.Payout, error) {
+	m.record("ListPayouts")
+	if m.ListPayoutsFunc != nil {
+		return m.ListPayoutsFunc(p0, p1)
+	}
+	return nil, notMocked("ShoplinePayments.ListPayouts")
+}
+
+// ListTransactions implements shoplinepay.Service.
+func (m *ShoplinePaymentsService) ListTransactions(p0 context.Context, p1 *shoplinepay.TransactionListOptions) ([]shoplinepay.Transaction, error) {
+	m.record("ListTransactions")
+	if m.ListTransactionsFunc != nil {
+		return m.ListTransactionsFunc(p0, p1)
+	}
+	return nil, notMocked("ShoplinePayments.ListTransactions")
+}
+
+// PaymentsAppService mocks paymentsapp.Service. Methods whose func field is nil return
+// ErrNotMocked.
+type PaymentsAppService struct {
+	recorder
+	NotifyActivationFunc     func(ctx context.Context, req paymentsapp.ActivationNotification) error
+	NotifyDeviceBindingFunc  func(ctx context.Context, req paymentsapp.DeviceBindingNotification) error
+	NotifyPaymentSuccessFunc func(ctx context.Context, req paymentsapp.PaymentNotification) error
+	NotifyRefundSuccessFunc  func(ctx context.Context, req paymentsapp.RefundNotification) error
+}
+
+var _ paymentsapp.Service = (*PaymentsAppService)(nil)
+
+// NotifyActivation implements paymentsapp.Service.
+func (m *PaymentsAppService) NotifyActivation(p0 context.Context, p1 paymentsapp.ActivationNotification) error {
+	m.record("NotifyActivation")
+	if m.NotifyActivationFunc != nil {
+		return m.NotifyActivationFunc(p0, p1)
+	}
+	return notMocked("PaymentsApp.NotifyActivation")
+}
+
+// NotifyDeviceBinding implements paymentsapp.Service.
+func (m *PaymentsAppService) NotifyDeviceBinding(p0 context.Context, p1 paymentsapp.DeviceBindingNotification) error {
+	m.record("NotifyDeviceBinding")
+	if m.NotifyDeviceBindingFunc != nil {
+		return m.NotifyDeviceBindingFunc(p0, p1)
+	}
+	return notMocked("PaymentsApp.NotifyDeviceBinding")
+}
+
+// NotifyPaymentSuccess implements paymentsapp.Service.
+func (m *PaymentsAppService) NotifyPaymentSuccess(p0 context.Context, p1 paymentsapp.PaymentNotification) error {
+	m.record("NotifyPaymentSuccess")
+	if m.NotifyPaymentSuccessFunc != nil {
+		return m.NotifyPaymentSuccessFunc(p0, p1)
+	}
+	return notMocked("PaymentsApp.NotifyPaymentSuccess")
+}
+
+// NotifyRefundSuccess implements paymentsapp.Service.
+func (m *PaymentsAppService) NotifyRefundSuccess(p0 context.Context, p1 paymentsapp.RefundNotification) error {
+	m.record("NotifyRefundSuccess")
+	if m.NotifyRefundSuccessFunc != nil {
+		return m.NotifyRefundSuccessFunc(p0, p1)
+	}
+	return notMocked("PaymentsApp.NotifyRefundSuccess")
+}
+
+// SizeChartService mocks appopenapi.SizeChartService. Methods whose func field is nil return
+// ErrNotMocked.
+type SizeChartService struct {
+	recorder
+	BatchCreateOrUpdateProductSizesFunc func(ctx context.Context, data []appopenapi.ProductSizeData) ([]appopenapi.ProductSizeData, error)
+	BatchDeleteProductSizesFunc         func(ctx context.Context, productIDs []int64) error
+	BatchQueryCategoryTemplatesFunc     func(ctx context.Context, categoryIDs []int64) ([]appopenapi.SizeTemplate, error)
+	BatchQueryProductSizesFunc          func(ctx context.Context, productIDs []int64) ([]appopenapi.ProductSizeData, error)
+	BatchQueryStoreTemplatesFunc        func(ctx context.Context, opts *core.ListOptions) ([]appopenapi.SizeTemplate, error)
+}
+
+var _ appopenapi.SizeChartService = (*SizeChartService)(nil)
+
+// BatchCreateOrUpdateProductSizes implements appopenapi.SizeChartService.
+func (m *SizeChartService) BatchCreateOrUpdateProductSizes(p0 context.Context, p1 []appopenapi.ProductSizeData) ([]appopenapi.ProductSizeData, error) {
+	m.record("BatchCreateOrUpdateProductSizes")
+	if m.BatchCreateOrUpdateProductSizesFunc != nil {
+		return m.BatchCreateOrUpdateProductSizesFunc(p0, p1)
+	}
+	return nil, notMocked("SizeChart.BatchCreateOrUpdateProductSizes")
+}
+
+// BatchDeleteProductSizes implements appopenapi.SizeChartService.
+func (m *SizeChartService) BatchDeleteProductSizes(p0 context.Context, p1 []int64) error {
+	m.record("BatchDeleteProductSizes")
+	if m.BatchDeleteProductSizesFunc != nil {
+		return m.BatchDeleteProductSizesFunc(p0, p1)
+	}
+	return notMocked("SizeChart.BatchDeleteProductSizes")
+}
+
+// BatchQueryCategoryTemplates implements appopenapi.SizeChartService.
+func (m *SizeChartService) BatchQueryCategoryTemplates(p0 context.Context, p1 []int64) ([]appopenapi.SizeTemplate, error) {
+	m.record("BatchQueryCategoryTemplates")
+	if m.BatchQueryCategoryTemplatesFunc != nil {
+		return m.BatchQueryCategoryTemplatesFunc(p0, p1)
+	}
+	return nil, notMocked("SizeChart.BatchQueryCategoryTemplates")
+}
+
+// BatchQueryProductSizes implements appopenapi.SizeChartService.
+func (m *SizeChartService) BatchQueryProductSizes(p0 context.Context, p1 []int64) ([]appopenapi.ProductSizeData, error) {
+	m.record("BatchQueryProductSizes")
+	if m.BatchQueryProductSizesFunc != nil {
+		return m.BatchQueryProductSizesFunc(p0, p1)
+	}
+	return nil, notMocked("SizeChart.BatchQueryProductSizes")
+}
+
+// BatchQueryStoreTemplates implements appopenapi.SizeChartService.
+func (m *SizeChartService) BatchQueryStoreTemplates(p0 context.Context, p1 *core.ListOptions) ([]appopenapi.SizeTemplate, error) {
+	m.record("BatchQueryStoreTemplates")
+	if m.BatchQueryStoreTemplatesFunc != nil {
+		return m.BatchQueryStoreTemplatesFunc(p0, p1)
+	}
+	return nil, notMocked("SizeChart.BatchQueryStoreTemplates")
+}
+
+// CDPService mocks appopenapi.CDPService. Methods whose func field is nil return
+// ErrNotMocked.
+type CDPService struct {
+	recorder
+	ReportBehaviorEventsFunc func(ctx context.Context, events []appopenapi.BehaviorEvent) error
+	ReportIdentityFunc       func(ctx context.Context, identity appopenapi.IdentityReport) error
+}
+
+var _ appopenapi.CDPService = (*CDPService)(nil)
+
+// ReportBehaviorEvents implements appopenapi.CDPService.
+func (m *CDPService) ReportBehaviorEvents(p0 context.Context, p1 []appopenapi.BehaviorEvent) error {
+	m.record("ReportBehaviorEvents")
+	if m.ReportBehaviorEventsFunc != nil {
+		return m.ReportBehaviorEventsFunc(p0, p1)
+	}
+	return notMocked("CDP.ReportBehaviorEvents")
+}
+
+// ReportIdentity implements appopenapi.CDPService.
+func (m *CDPService) ReportIdentity(p0 context.Context, p1 appopenapi.IdentityReport) error {
+	m.record("ReportIdentity")
+	if m.ReportIdentityFunc != nil {
+		return m.ReportIdentityFunc(p0, p1)
+	}
+	return notMocked("CDP.ReportIdentity")
+}
+
+// VariantImageService mocks appopenapi.VariantImageService. Methods whose func field is nil return
+// ErrNotMocked.
+type VariantImageService struct {
+	recorder
+	BatchUpdateVariantImagesFunc func(ctx context.Context, updates []appopenapi.VariantImageUpdate) error
+	QueryVariantImagesFunc       func(ctx context.Context, variantID int64) ([]appopenapi.VariantImage, error)
+}
+
+var _ appopenapi.VariantImageService = (*VariantImageService)(nil)
+
+// BatchUpdateVariantImages implements appopenapi.VariantImageService.
+func (m *VariantImageService) BatchUpdateVariantImages(p0 context.Context, p1 []appopenapi.VariantImageUpdate) error {
+	m.record("BatchUpdateVariantImages")
+	if m.BatchUpdateVariantImagesFunc != nil {
+		return m.BatchUpdateVariantImagesFunc(p0, p1)
+	}
+	return notMocked("VariantImage.BatchUpdateVariantImages")
+}
+
+// QueryVariantImages implements appopenapi.VariantImageService.
+func (m *VariantImageService) QueryVariantImages(p0 context.Context, p1 int64) ([]appopenapi.VariantImage, error) {
+	m.record("QueryVariantImages")
+	if m.QueryVariantImagesFunc != nil {
+		return m.QueryVariantImagesFunc(p0, p1)
+	}
+	return nil, notMocked("VariantImage.QueryVariantImages")
+}
+
+// Mocks holds a mock for every service of a shopline.Client.
+type Mocks struct {
+	Order                 *OrderService
+	DraftOrder            *DraftOrderService
+	Fulfillment           *FulfillmentService
+	CarrierService        *CarrierServiceService
+	FulfillmentSvcDef     *FulfillmentSvcDefService
+	Payment               *PaymentService
+	AbandonedCheckout     *AbandonedCheckoutService
+	Subscription          *SubscriptionService
+	Tax                   *TaxService
+	Return                *ReturnService
+	OrderArchive          *OrderArchiveService
+	OrderEdit             *OrderEditService
+	Customer              *CustomerService
+	CustomerSegment       *CustomerSegmentService
+	Product               *ProductService
+	Collection            *CollectionService
+	SmartCollection       *SmartCollectionService
+	ManualCollection      *ManualCollectionService
+	Inventory             *InventoryService
+	Bundle                *BundleService
+	ProductOption         *ProductOptionService
+	Review                *ReviewService
+	Media                 *MediaService
+	Store                 *StoreService
+	ShippingZone          *ShippingZoneService
+	Events                *EventsService
+	Discount              *DiscountService
+	Theme                 *ThemeService
+	Page                  *PageService
+	ScriptTag             *ScriptTagService
+	Webhook               *WebhookService
+	StorefrontAccessToken *StorefrontAccessTokenService
+	Market                *MarketService
+	Location              *LocationService
+	Publication           *PublicationService
+	GiftCard              *GiftCardService
+	Localizations         *LocalizationsService
+	SalesChannel          *SalesChannelService
+	MetafieldDefinition   *MetafieldDefinitionService
+	MetafieldResource     *MetafieldResourceService
+	MetafieldStore        *MetafieldStoreService
+	MetaobjectDefinition  *MetaobjectDefinitionService
+	Metaobject            *MetaobjectService
+	BulkOperation         *BulkOperationService
+	ShoplinePayments      *ShoplinePaymentsService
+	PaymentsApp           *PaymentsAppService
+	SizeChart             *SizeChartService
+	CDP                   *CDPService
+	VariantImage          *VariantImageService
+}
+
+// newMocks creates empty mocks.
+func newMocks() *Mocks {
+	return &Mocks{
+		Order:                 &OrderService{},
+		DraftOrder:            &DraftOrderService{},
+		Fulfillment:           &FulfillmentService{},
+		CarrierService:        &CarrierServiceService{},
+		FulfillmentSvcDef:     &FulfillmentSvcDefService{},
+		Payment:               &PaymentService{},
+		AbandonedCheckout:     &AbandonedCheckoutService{},
+		Subscription:          &SubscriptionService{},
+		Tax:                   &TaxService{},
+		Return:                &ReturnService{},
+		OrderArchive:          &OrderArchiveService{},
+		OrderEdit:             &OrderEditService{},
+		Customer:              &CustomerService{},
+		CustomerSegment:       &CustomerSegmentService{},
+		Product:               &ProductService{},
+		Collection:            &CollectionService{},
+		SmartCollection:       &SmartCollectionService{},
+		ManualCollection:      &ManualCollectionService{},
+		Inventory:             &InventoryService{},
+		Bundle:                &BundleService{},
+		ProductOption:         &ProductOptionService{},
+		Review:                &ReviewService{},
+		Media:                 &MediaService{},
+		Store:                 &StoreService{},
+		ShippingZone:          &ShippingZoneService{},
+		Events:                &EventsService{},
+		Discount:              &DiscountService{},
+		Theme:                 &ThemeService{},
+		Page:                  &PageService{},
+		ScriptTag:             &ScriptTagService{},
+		Webhook:               &WebhookService{},
+		StorefrontAccessToken: &StorefrontAccessTokenService{},
+		Market:                &MarketService{},
+		Location:              &LocationService{},
+		Publication:           &PublicationService{},
+		GiftCard:              &GiftCardService{},
+		Localizations:         &LocalizationsService{},
+		SalesChannel:          &SalesChannelService{},
+		MetafieldDefinition:   &MetafieldDefinitionService{},
+		MetafieldResource:     &MetafieldResourceService{},
+		MetafieldStore:        &MetafieldStoreService{},
+		MetaobjectDefinition:  &MetaobjectDefinitionService{},
+		Metaobject:            &MetaobjectService{},
+		BulkOperation:         &BulkOperationService{},
+		ShoplinePayments:      &ShoplinePaymentsService{},
+		PaymentsApp:           &PaymentsAppService{},
+		SizeChart:             &SizeChartService{},
+		CDP:                   &CDPService{},
+		VariantImage:          &VariantImageService{},
+	}
+}
+
+// install replaces every service of c with its mock.
+func (m *Mocks) install(c *shopline.Client) {
+	c.Order = m.Order
+	c.DraftOrder = m.DraftOrder
+	c.Fulfillment = m.Fulfillment
+	c.CarrierService = m.CarrierService
+	c.FulfillmentSvcDef = m.FulfillmentSvcDef
+	c.Payment = m.Payment
+	c.AbandonedCheckout = m.AbandonedCheckout
+	c.Subscription = m.Subscription
+	c.Tax = m.Tax
+	c.Return = m.Return
+	c.OrderArchive = m.OrderArchive
+	c.OrderEdit = m.OrderEdit
+	c.Customer = m.Customer
+	c.CustomerSegment = m.CustomerSegment
+	c.Product = m.Product
+	c.Collection = m.Collection
+	c.SmartCollection = m.SmartCollection
+	c.ManualCollection = m.ManualCollection
+	c.Inventory = m.Inventory
+	c.Bundle = m.Bundle
+	c.ProductOption = m.ProductOption
+	c.Review = m.Review
+	c.Media = m.Media
+	c.Store = m.Store
+	c.ShippingZone = m.ShippingZone
+	c.Events = m.Events
+	c.Discount = m.Discount
+	c.Theme = m.Theme
+	c.Page = m.Page
+	c.ScriptTag = m.ScriptTag
+	c.Webhook = m.Webhook
+	c.StorefrontAccessToken = m.StorefrontAccessToken
+	c.Market = m.Market
+	c.Location = m.Location
+	c.Publication = m.Publication
+	c.GiftCard = m.GiftCard
+	c.Localizations = m.Localizations
+	c.SalesChannel = m.SalesChannel
+	c.MetafieldDefinition = m.MetafieldDefinition
+	c.MetafieldResource = m.MetafieldResource
+	c.MetafieldStore = m.MetafieldStore
+	c.MetaobjectDefinition = m.MetaobjectDefinition
+	c.Metaobject = m.Metaobject
+	c.BulkOperation = m.BulkOperation
+	c.ShoplinePayments = m.ShoplinePayments
+	c.PaymentsApp = m.PaymentsApp
+	c.SizeChart = m.SizeChart
+	c.CDP = m.CDP
+	c.VariantImage = m.VariantImage
+}
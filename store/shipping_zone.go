@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imokyou/slshop/core"
+)
+
+// =====================================================================
+// Shipping Zone Service
+// =====================================================================
+
+// ShippingZoneService manages the shop's shipping zones, their rates and the
+// countries and provinces they cover.
+type ShippingZoneService interface {
+	List(ctx context.Context) ([]ShippingZone, error)
+	Get(ctx context.Context, id int64) (*ShippingZone, error)
+
+	// Rates
+	CreatePriceBasedRate(ctx context.Context, zoneID int64, rate PriceBasedRate) (*PriceBasedRate, error)
+	CreateWeightBasedRate(ctx context.Context, zoneID int64, rate WeightBasedRate) (*WeightBasedRate, error)
+
+	// Countries & provinces
+	AddCountry(ctx context.Context, zoneID int64, country ZoneCountry) (*ZoneCountry, error)
+	RemoveCountry(ctx context.Context, zoneID, countryID int64) error
+	SetProvinces(ctx context.Context, zoneID, countryID int64, provinces []ZoneProvince) (*ZoneCountry, error)
+}
+
+func NewShippingZoneService(client core.Requester) ShippingZoneService {
+	return &shippingZoneOp{client: client}
+}
+
+type shippingZoneOp struct{ client core.Requester }
+
+// =====================================================================
+// Models
+// =====================================================================
+
+type ShippingZone struct {
+	ID                       int64             `json:"id,omitempty"`
+	Name                     string            `json:"name,omitempty"`
+	Countries                []ZoneCountry     `json:"countries,omitempty"`
+	PriceBasedShippingRates  []PriceBasedRate  `json:"price_based_shipping_rates,omitempty"`
+	WeightBasedShippingRates []WeightBasedRate `json:"weight_based_shipping_rates,omitempty"`
+	CreatedAt                *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt                *time.Time        `json:"updated_at,omitempty"`
+}
+
+// ZoneCountry is a country covered by a shipping zone. An empty Provinces
+// list means the whole country is covered.
+type ZoneCountry struct {
+	ID        int64          `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Code      string         `json:"code,omitempty"` // ISO 3166-1 alpha-2, e.g. "US"
+	Tax       string         `json:"tax,omitempty"`
+	Provinces []ZoneProvince `json:"provinces,omitempty"`
+}
+
+type ZoneProvince struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Code string `json:"code,omitempty"` // e.g. "CA"
+	Tax  string `json:"tax,omitempty"`
+}
+
+// PriceBasedRate applies when the order subtotal falls within
+// [MinOrderSubtotal, MaxOrderSubtotal]; an empty max means no upper bound.
+type PriceBasedRate struct {
+	ID               int64  `json:"id,omitempty"`
+	Name             string `json:"name,omitempty"`
+	Price            string `json:"price,omitempty"`
+	MinOrderSubtotal string `json:"min_order_subtotal,omitempty"`
+	MaxOrderSubtotal string `json:"max_order_subtotal,omitempty"`
+}
+
+// WeightBasedRate applies when the order weight (in kilograms) falls within
+// [WeightLow, WeightHigh].
+type WeightBasedRate struct {
+	ID         int64   `json:"id,omitempty"`
+	Name       string  `json:"name,omitempty"`
+	Price      string  `json:"price,omitempty"`
+	WeightLow  float64 `json:"weight_low,omitempty"`
+	WeightHigh float64 `json:"weight_high,omitempty"`
+}
+
+type shippingZoneResource struct {
+	ShippingZone *ShippingZone `json:"shipping_zone"`
+}
+type shippingZonesResource struct {
+	ShippingZones []ShippingZone `json:"shipping_zones"`
+}
+type priceBasedRateResource struct {
+	Rate *PriceBasedRate `json:"price_based_shipping_rate"`
+}
+type weightBasedRateResource struct {
+	Rate *WeightBasedRate `json:"weight_based_shipping_rate"`
+}
+type zoneCountryResource struct {
+	Country *ZoneCountry `json:"country"`
+}
+
+// =====================================================================
+// Implementation
+// =====================================================================
+
+func (s *shippingZoneOp) List(ctx context.Context) ([]ShippingZone, error) {
+	r := &shippingZonesResource{}
+	err := s.client.Get(ctx, s.client.CreatePath("shipping_zones.json"), r, nil)
+	return r.ShippingZones, err
+}
+
+func (s *shippingZoneOp) Get(ctx context.Context, id int64) (*ShippingZone, error) {
+	r := &shippingZoneResource{}
+	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("shipping_zones/%d.json", id)), r, nil)
+	return core.Found(r.ShippingZone, err)
+}
+
+func (s *shippingZoneOp) CreatePriceBasedRate(ctx context.Context, zoneID int64, rate PriceBasedRate) (*PriceBasedRate, error) {
+	r := &priceBasedRateResource{}
+	path := s.client.CreatePath(fmt.Sprintf("shipping_zones/%d/price_based_shipping_rates.json", zoneID))
+	err := s.client.Post(ctx, path, priceBasedRateResource{Rate: &rate}, r)
+	return r.Rate, err
+}
+
+func (s *shippingZoneOp) CreateWeightBasedRate(ctx context.Context, zoneID int64, rate WeightBasedRate) (*WeightBasedRate, error) {
+	if rate.WeightHigh != 0 && rate.WeightHigh < rate.WeightLow {
+		return nil, fmt.Errorf("store: weight_high %v is below weight_low %v", rate.WeightHigh, rate.WeightLow)
+	}
+	r := &weightBasedRateResource{}
+	path := s.client.CreatePath(fmt.Sprintf("shipping_zones/%d/weight_based_shipping_rates.json", zoneID))
+	err := s.client.Post(ctx, path, weightBasedRateResource{Rate: &rate}, r)
+	return r.Rate, err
+}
+
+func (s *shippingZoneOp) AddCountry(ctx context.Context, zoneID int64, country ZoneCountry) (*ZoneCountry, error) {
+	r := &zoneCountryResource{}
+	path := s.client.CreatePath(fmt.Sprintf("shipping_zones/%d/countries.json", zoneID))
+	err := s.client.Post(ctx, path, zoneCountryResource{Country: &country}, r)
+	return r.Country, err
+}
+
+func (s *shippingZoneOp) RemoveCountry(ctx context.Context, zoneID, countryID int64) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("shipping_zones/%d/countries/%d.json", zoneID, countryID)))
+}
+
+// SetProvinces replaces the provinces of a country in the zone. Passing no
+// provinces makes the zone cover the whole country.
+func (s *shippingZoneOp) SetProvinces(ctx context.Context, zoneID, countryID int64, provinces []ZoneProvince) (*ZoneCountry, error) {
+	if provinces == nil {
+		provinces = []ZoneProvince{}
+	}
+	body := map[string]interface{}{"country": map[string]interface{}{"id": countryID, "provinces": provinces}}
+	r := &zoneCountryResource{}
+	path := s.client.CreatePath(fmt.Sprintf("shipping_zones/%d/countries/%d.json", zoneID, countryID))
+	err := s.client.Put(ctx, path, body, r)
+	return r.Country, err
+}
@@ -17,6 +17,9 @@ type Service interface {
 	GetSettlementCurrency(ctx context.Context) ([]Currency, error)
 	GetStaffMember(ctx context.Context, uid string) (*StaffMember, error)
 	ListStaffMembers(ctx context.Context) ([]StaffMember, error)
+	InviteStaffMember(ctx context.Context, email string, permissions []string) (*StaffMember, error)
+	UpdateStaffPermissions(ctx context.Context, uid string, permissions []string) (*StaffMember, error)
+	RemoveStaffMember(ctx context.Context, uid string) error
 	ListOperationLogs(ctx context.Context, opts *core.ListOptions) ([]OperationLog, error)
 	GetOperationLog(ctx context.Context, id int64) (*OperationLog, error)
 	CountOperationLogs(ctx context.Context) (int, error)
@@ -54,6 +57,19 @@ type Info struct {
 	UpdatedAt           string         `json:"updated_at,omitempty"`
 }
 
+// Location loads the shop's IANA timezone (e.g. "Asia/Shanghai").
+// Use it with core.Today, core.LastDays, etc. for shop-local date ranges.
+func (i *Info) Location() (*time.Location, error) {
+	if i.IanaTimezone == "" {
+		return nil, fmt.Errorf("store: shop has no iana_timezone")
+	}
+	loc, err := time.LoadLocation(i.IanaTimezone)
+	if err != nil {
+		return nil, fmt.Errorf("store: invalid iana_timezone %q: %w", i.IanaTimezone, err)
+	}
+	return loc, nil
+}
+
 type SalesChannel struct {
 	ChannelHandle string `json:"channel_handle,omitempty"`
 }
@@ -81,6 +97,20 @@ type StaffMember struct {
 	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
 }
 
+// HasPermission reports whether the staff member holds perm. The account
+// owner implicitly holds every permission.
+func (m *StaffMember) HasPermission(perm string) bool {
+	if m.AccountOwner {
+		return true
+	}
+	for _, p := range m.Permissions {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
 type OperationLog struct {
 	ID          int64      `json:"id,omitempty"`
 	Action      string     `json:"action,omitempty"`
@@ -148,6 +178,16 @@ type staffResource struct {
 type staffListResource struct {
 	Staff []StaffMember `json:"staff"`
 }
+
+// staffPermissions is the write payload for invitations and permission
+// updates; Permissions is always sent so an empty list revokes everything.
+type staffPermissions struct {
+	Email       string   `json:"email,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+type staffWriteResource struct {
+	Staff staffPermissions `json:"staff"`
+}
 type opLogResource struct {
 	OperationLog *OperationLog `json:"operation_log"`
 }
@@ -171,7 +211,7 @@ type shopResource struct {
 func (s *serviceOp) GetInfo(ctx context.Context) (*Info, error) {
 	r := &infoResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("merchants/shop.json"), r, nil)
-	return r.Data, err
+	return core.Found(r.Data, err)
 }
 func (s *serviceOp) GetSettlementCurrency(ctx context.Context) ([]Currency, error) {
 	r := &currenciesResource{}
@@ -181,13 +221,38 @@ func (s *serviceOp) GetSettlementCurrency(ctx context.Context) ([]Currency, erro
 func (s *serviceOp) GetStaffMember(ctx context.Context, uid string) (*StaffMember, error) {
 	r := &staffResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("store/staff/%s.json", uid)), r, nil)
-	return r.Staff, err
+	return core.Found(r.Staff, err)
 }
 func (s *serviceOp) ListStaffMembers(ctx context.Context) ([]StaffMember, error) {
 	r := &staffListResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("store/list/staff.json"), r, nil)
 	return r.Staff, err
 }
+func (s *serviceOp) InviteStaffMember(ctx context.Context, email string, permissions []string) (*StaffMember, error) {
+	if email == "" {
+		return nil, fmt.Errorf("store: staff invitation requires an email")
+	}
+	r := &staffResource{}
+	body := staffWriteResource{Staff: staffPermissions{Email: email, Permissions: nonNil(permissions)}}
+	err := s.client.Post(ctx, s.client.CreatePath("store/staff.json"), body, r)
+	return r.Staff, err
+}
+func (s *serviceOp) UpdateStaffPermissions(ctx context.Context, uid string, permissions []string) (*StaffMember, error) {
+	r := &staffResource{}
+	body := staffWriteResource{Staff: staffPermissions{Permissions: nonNil(permissions)}}
+	err := s.client.Put(ctx, s.client.CreatePath(fmt.Sprintf("store/staff/%s.json", uid)), body, r)
+	return r.Staff, err
+}
+func (s *serviceOp) RemoveStaffMember(ctx context.Context, uid string) error {
+	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("store/staff/%s.json", uid)))
+}
+
+func nonNil(perms []string) []string {
+	if perms == nil {
+		return []string{}
+	}
+	return perms
+}
 func (s *serviceOp) ListOperationLogs(ctx context.Context, opts *core.ListOptions) ([]OperationLog, error) {
 	r := &opLogsResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("store/operation_logs.json"), r, opts)
@@ -196,7 +261,7 @@ func (s *serviceOp) ListOperationLogs(ctx context.Context, opts *core.ListOption
 func (s *serviceOp) GetOperationLog(ctx context.Context, id int64) (*OperationLog, error) {
 	r := &opLogResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("store/operation_logs/%d.json", id)), r, nil)
-	return r.OperationLog, err
+	return core.Found(r.OperationLog, err)
 }
 func (s *serviceOp) CountOperationLogs(ctx context.Context) (int, error) {
 	r := &countResource{}
@@ -206,10 +271,10 @@ func (s *serviceOp) CountOperationLogs(ctx context.Context) (int, error) {
 func (s *serviceOp) GetActiveSubscription(ctx context.Context) (*Subscription, error) {
 	r := &subscriptionResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("store/subscription"), r, nil)
-	return r.Subscription, err
+	return core.Found(r.Subscription, err)
 }
 func (s *serviceOp) GetShop(ctx context.Context) (*Shop, error) {
 	r := &shopResource{}
 	err := s.client.Get(ctx, s.client.CreatePath("shop.json"), r, nil)
-	return r.Shop, err
+	return core.Found(r.Shop, err)
 }
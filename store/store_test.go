@@ -207,3 +207,87 @@ func TestGetInfo(t *testing.T) {
 		t.Errorf("expected 'USD', got %q", info.Currency)
 	}
 }
+
+func TestCreateWeightBasedRate(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "shipping_zones/7/weight_based_shipping_rates.json") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body weightBasedRateResource
+		json.NewDecoder(r.Body).Decode(&body)
+		body.Rate.ID = 11
+		json.NewEncoder(w).Encode(body)
+	})
+	defer close()
+
+	svc := NewShippingZoneService(mock)
+	rate, err := svc.CreateWeightBasedRate(context.Background(), 7, WeightBasedRate{Name: "Heavy", Price: "20.00", WeightLow: 5, WeightHigh: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate.ID != 11 || rate.WeightHigh != 30 {
+		t.Errorf("unexpected rate: %+v", rate)
+	}
+
+	if _, err := svc.CreateWeightBasedRate(context.Background(), 7, WeightBasedRate{WeightLow: 10, WeightHigh: 5}); err == nil {
+		t.Error("expected error for inverted weight range")
+	}
+}
+
+func TestSetProvinces(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "shipping_zones/7/countries/3.json") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Country struct {
+				Provinces []ZoneProvince `json:"provinces"`
+			} `json:"country"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Country.Provinces == nil {
+			t.Error("expected provinces to be sent even when empty")
+		}
+		json.NewEncoder(w).Encode(zoneCountryResource{Country: &ZoneCountry{ID: 3, Code: "US"}})
+	})
+	defer close()
+
+	svc := NewShippingZoneService(mock)
+	country, err := svc.SetProvinces(context.Background(), 7, 3, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if country.Code != "US" {
+		t.Errorf("unexpected country: %+v", country)
+	}
+}
+
+func TestUpdateStaffPermissions(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasSuffix(r.URL.Path, "store/staff/uid-002.json") {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		var body staffWriteResource
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Staff.Permissions == nil {
+			t.Error("expected permissions to be sent even when empty")
+		}
+		json.NewEncoder(w).Encode(staffResource{Staff: &StaffMember{UID: "uid-002", Permissions: body.Staff.Permissions}})
+	})
+	defer close()
+
+	svc := NewService(mock)
+	member, err := svc.UpdateStaffPermissions(context.Background(), "uid-002", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if member.HasPermission("orders") {
+		t.Error("expected permissions to be revoked")
+	}
+	if !(&StaffMember{AccountOwner: true}).HasPermission("orders") {
+		t.Error("expected account owner to hold every permission")
+	}
+	if _, err := svc.InviteStaffMember(context.Background(), "", nil); err == nil {
+		t.Error("expected error for invitation without email")
+	}
+}
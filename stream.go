@@ -0,0 +1,80 @@
+package shopline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WithStreamingDecode makes Do decode successful responses directly from
+// the connection with a json.Decoder instead of buffering the whole body
+// first. Peak memory for large pages (e.g. orders with hundreds of line
+// items) drops to roughly the size of the decoded models. The 10MB response
+// limit still applies and exceeding it fails the request.
+//
+// Streaming always uses encoding/json. It is skipped when a codec is set
+// with WithJSONCodec or a drift handler with WithDecodeDriftHandler, since
+// both need the buffered body; WithStrictDecoding works as usual. Decode
+// errors no longer include the response body.
+func WithStreamingDecode() Option {
+	return func(c *Client) {
+		c.streamDecoding = true
+	}
+}
+
+// streams reports whether resp should be decoded into result by
+// decodeStream.
+func (c *Client) streams(resp *http.Response, result interface{}) bool {
+	return c.streamDecoding && result != nil &&
+		resp.StatusCode >= 200 && resp.StatusCode < 300 &&
+		c.codec == nil && c.onDecodeDrift == nil
+}
+
+// decodeStream decodes body into result, then drains and closes body so the
+// connection can be reused. An empty body leaves result untouched.
+func (c *Client) decodeStream(body io.ReadCloser, result interface{}) error {
+	defer body.Close()
+	limited := &limitedReader{r: body, remaining: maxResponseBodySize}
+	dec := json.NewDecoder(limited)
+	if c.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	err := dec.Decode(result)
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if _, drainErr := io.Copy(io.Discard, limited); err == nil && errors.Is(drainErr, errResponseTooLarge) {
+		err = drainErr
+	}
+	return err
+}
+
+var errResponseTooLarge = fmt.Errorf("shopline: response body exceeds %d bytes", maxResponseBodySize)
+
+// limitedReader is io.LimitReader that fails instead of reporting EOF when
+// the limit is exceeded, so an oversized response is an error rather than
+// truncated JSON.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// At the limit: only a clean EOF is acceptable.
+		var probe [1]byte
+		n, err := l.r.Read(probe[:])
+		if n > 0 {
+			return 0, errResponseTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
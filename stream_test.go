@@ -0,0 +1,75 @@
+package shopline
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ============================================================
+// Streaming Decode Tests
+// ============================================================
+
+func TestWithStreamingDecode(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(productPage(3))
+	})
+	defer server.Close()
+	WithStreamingDecode()(client)
+
+	products, err := client.Product.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(products) != 3 || len(products[2].Variants) != 5 {
+		t.Errorf("unexpected products: %+v", products)
+	}
+}
+
+func TestWithStreamingDecode_Strict(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"product":{"id":1,"brand_new_field":true}}`))
+	})
+	defer server.Close()
+	WithStreamingDecode()(client)
+	WithStrictDecoding()(client)
+
+	if _, err := client.Product.Get(context.Background(), 1); err == nil || !strings.Contains(err.Error(), "brand_new_field") {
+		t.Errorf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestWithStreamingDecode_ErrorResponse(t *testing.T) {
+	client, server := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	})
+	defer server.Close()
+	WithStreamingDecode()(client)
+
+	_, err := client.Product.Get(context.Background(), 1)
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) || respErr.Status != http.StatusNotFound {
+		t.Errorf("expected 404 ResponseError, got %v", err)
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	exact := &limitedReader{r: strings.NewReader("12345"), remaining: 5}
+	if b, err := io.ReadAll(exact); err != nil || string(b) != "12345" {
+		t.Errorf("expected body at the limit to be read, got %q, %v", b, err)
+	}
+	over := &limitedReader{r: strings.NewReader("123456"), remaining: 5}
+	if _, err := io.ReadAll(over); !errors.Is(err, errResponseTooLarge) {
+		t.Errorf("expected errResponseTooLarge, got %v", err)
+	}
+}
+
+// BenchmarkProductList_Streaming decodes the same page as
+// BenchmarkProductList without buffering the body.
+func BenchmarkProductList_Streaming(b *testing.B) {
+	benchmarkProductList(b, WithStreamingDecode())
+}
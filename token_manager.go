@@ -2,6 +2,9 @@ package shopline
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,6 +15,14 @@ const (
 	// Refreshing 5 minutes early avoids edge cases where requests fail because
 	// the token expires mid-flight.
 	defaultRefreshBuffer = 5 * time.Minute
+
+	// defaultRefreshLockTTL bounds how long a RefreshLocker lock is held,
+	// so a crashed instance cannot block refreshes forever.
+	defaultRefreshLockTTL = 30 * time.Second
+
+	// refreshLockPollInterval is how often an instance that lost the
+	// refresh lock checks the store for the winner's token.
+	refreshLockPollInterval = 250 * time.Millisecond
 )
 
 // TokenManager handles automatic token lifecycle management with:
@@ -30,13 +41,37 @@ type TokenManager struct {
 	app    App
 	handle string
 	store  TokenStore
+	notify TokenStoreNotifier // store as a notifier, if it is one
+	id     string             // TokenChange.Source of this manager
 	log    Logger
+	mtr    Metrics
+
+	// refresh obtains a new token; doRefresh unless replaced in tests.
+	refresh func(ctx context.Context) (*ManagedToken, error)
+
+	locker   RefreshLocker // optional cross-process refresh lock
+	lockTTL  time.Duration
+	lockPoll time.Duration
+
+	// revoke revokes a token on InvalidateToken; nil unless
+	// WithRevokeOnInvalidate is set.
+	revoke func(ctx context.Context, token string) error
 
 	mu            sync.Mutex
 	token         *ManagedToken
 	refreshCh     chan struct{} // non-nil while a refresh is in progress; closed when done
 	refreshBuffer time.Duration
 	initialized   bool // true after first load from store
+	stats         TokenStats
+}
+
+// TokenStats is a point-in-time view of a TokenManager's activity.
+type TokenStats struct {
+	Refreshes      int64     // successful refreshes
+	Failures       int64     // failed refreshes
+	CoalescedWaits int64     // GetToken calls that waited on another goroutine's refresh
+	ExpireAt       time.Time // expiry of the current token; zero if none
+	LastError      string    // most recent refresh error, cleared on success
 }
 
 // NewTokenManager creates a TokenManager for the given app and store handle.
@@ -51,8 +86,12 @@ func NewTokenManager(app App, handle string, store TokenStore, opts ...TokenMana
 		app:           app,
 		handle:        handle,
 		store:         store,
+		id:            newTokenManagerID(),
 		refreshBuffer: defaultRefreshBuffer,
+		lockPoll:      refreshLockPollInterval,
 	}
+	tm.notify, _ = store.(TokenStoreNotifier)
+	tm.refresh = tm.doRefresh
 	for _, opt := range opts {
 		opt(tm)
 	}
@@ -77,6 +116,87 @@ func WithTokenManagerLogger(log Logger) TokenManagerOption {
 	}
 }
 
+// WithTokenMetrics reports refreshes, refresh failures, coalesced waits and
+// the current token expiry to m. See the MetricToken* names.
+func WithTokenMetrics(m Metrics) TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.mtr = m
+	}
+}
+
+// WithRefreshLocker makes refreshes mutually exclusive across processes
+// sharing the store, so N replicas do not issue N refresh calls that
+// invalidate each other's tokens. The lock expires after ttl (default 30s if
+// not positive) in case its holder dies. Instances that do not get the lock
+// wait for the holder's token to appear in the store, and refresh themselves
+// only if it has not after ttl. Requires a TokenStore.
+func WithRefreshLocker(l RefreshLocker, ttl time.Duration) TokenManagerOption {
+	return func(tm *TokenManager) {
+		if ttl <= 0 {
+			ttl = defaultRefreshLockTTL
+		}
+		tm.locker = l
+		tm.lockTTL = ttl
+	}
+}
+
+// WithRevokeOnInvalidate makes InvalidateToken also revoke the token with
+// App.RevokeAccessToken, so it cannot be used again anywhere.
+func WithRevokeOnInvalidate() TokenManagerOption {
+	return func(tm *TokenManager) {
+		tm.revoke = func(ctx context.Context, token string) error {
+			return tm.app.RevokeAccessToken(ctx, tm.handle, token)
+		}
+	}
+}
+
+// Snapshot returns the manager's counters and current token expiry. It is
+// safe to call concurrently with GetToken.
+func (tm *TokenManager) Snapshot() TokenStats {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	stats := tm.stats
+	if tm.token != nil {
+		stats.ExpireAt = tm.token.ExpireAt
+	}
+	return stats
+}
+
+// Watch subscribes to token changes published by other instances sharing a
+// store that implements TokenStoreNotifier, until ctx is done. When another
+// instance refreshes or invalidates the token, the next GetToken reloads it
+// from the store. Watch is a no-op for other stores.
+func (tm *TokenManager) Watch(ctx context.Context) error {
+	if tm.notify == nil {
+		return nil
+	}
+	key := tm.storeKey()
+	err := tm.notify.Subscribe(ctx, func(change TokenChange) {
+		if change.Key != key || change.Source == tm.id {
+			return
+		}
+		tm.mu.Lock()
+		tm.token = nil
+		tm.initialized = false
+		tm.mu.Unlock()
+		tm.logDebugf("Token for %s changed by another instance, will reload from store", tm.handle)
+	})
+	if err != nil {
+		return fmt.Errorf("shopline: failed to subscribe to token changes: %w", err)
+	}
+	return nil
+}
+
+// publishChange announces a change of the stored token to other instances.
+func (tm *TokenManager) publishChange(ctx context.Context) {
+	if tm.notify == nil {
+		return
+	}
+	if err := tm.notify.Publish(ctx, TokenChange{Key: tm.storeKey(), Source: tm.id}); err != nil {
+		tm.logDebugf("Failed to publish token change: %v", err)
+	}
+}
+
 // storeKey returns the persistence key for this manager's token.
 func (tm *TokenManager) storeKey() string {
 	return fmt.Sprintf("%s:%s", tm.handle, tm.app.AppKey)
@@ -112,7 +232,9 @@ func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
 	if tm.refreshCh != nil {
 		// Another goroutine is already refreshing — wait for it
 		ch := tm.refreshCh
+		tm.stats.CoalescedWaits++
 		tm.mu.Unlock()
+		tm.incCounter(MetricTokenWaits)
 		select {
 		case <-ch:
 			// Refresh completed (successfully or not), retry
@@ -128,11 +250,16 @@ func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
 
 	// Perform the refresh outside the lock
 	tm.logDebugf("Refreshing access token for %s", tm.handle)
-	newToken, err := tm.doRefresh(ctx)
+	newToken, err := tm.lockedRefresh(ctx)
 
 	tm.mu.Lock()
 	if err == nil {
 		tm.token = newToken
+		tm.stats.Refreshes++
+		tm.stats.LastError = ""
+	} else {
+		tm.stats.Failures++
+		tm.stats.LastError = err.Error()
 	}
 	ch := tm.refreshCh
 	tm.refreshCh = nil
@@ -142,8 +269,11 @@ func (tm *TokenManager) GetToken(ctx context.Context) (string, error) {
 	close(ch)
 
 	if err != nil {
+		tm.incCounter(MetricTokenRefreshErrors)
 		return "", fmt.Errorf("shopline: token refresh failed: %w", err)
 	}
+	tm.incCounter(MetricTokenRefreshes)
+	tm.setExpiryGauge(newToken.ExpireAt)
 	return newToken.AccessToken, nil
 }
 
@@ -160,27 +290,104 @@ func (tm *TokenManager) SetInitialToken(ctx context.Context, accessToken string,
 	tm.token = token
 	tm.initialized = true
 	tm.mu.Unlock()
+	tm.setExpiryGauge(expireAt)
 
 	// Persist to store
 	if tm.store != nil {
 		if err := tm.store.Set(ctx, tm.storeKey(), token); err != nil {
 			return fmt.Errorf("shopline: failed to persist initial token: %w", err)
 		}
+		tm.publishChange(ctx)
 	}
 	return nil
 }
 
+// grantedScope returns the scope recorded with the current token, if any.
+func (tm *TokenManager) grantedScope() string {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	if tm.token == nil {
+		return ""
+	}
+	return tm.token.Scope
+}
+
 // InvalidateToken clears the cached token and removes it from the store.
-// Call this when you know the token is revoked or invalid.
+// Call this when you know the token is revoked or invalid. With
+// WithRevokeOnInvalidate the token is revoked first; it is cleared locally
+// even if revocation fails, and the revocation error is returned.
 func (tm *TokenManager) InvalidateToken(ctx context.Context) error {
 	tm.mu.Lock()
+	token := tm.token
 	tm.token = nil
 	tm.mu.Unlock()
 
+	var revokeErr error
+	if tm.revoke != nil && token != nil && !token.IsExpired() {
+		if err := tm.revoke(ctx, token.AccessToken); err != nil {
+			revokeErr = fmt.Errorf("shopline: failed to revoke token: %w", err)
+		}
+	}
+
 	if tm.store != nil {
-		return tm.store.Delete(ctx, tm.storeKey())
+		if err := tm.store.Delete(ctx, tm.storeKey()); err != nil {
+			return errors.Join(revokeErr, err)
+		}
+		tm.publishChange(ctx)
 	}
-	return nil
+	return revokeErr
+}
+
+// lockedRefresh runs refresh under the RefreshLocker, if any. A token that
+// another instance stored while this one waited for the lock is used
+// instead of refreshing again. Locker errors fall back to refreshing
+// without the lock.
+func (tm *TokenManager) lockedRefresh(ctx context.Context) (*ManagedToken, error) {
+	if tm.locker == nil || tm.store == nil {
+		return tm.refresh(ctx)
+	}
+	key := tm.storeKey()
+	deadline := time.Now().Add(tm.lockTTL)
+	for {
+		owner, locked, err := tm.locker.TryLock(ctx, key, tm.lockTTL)
+		if err != nil {
+			tm.logDebugf("Refresh lock unavailable, refreshing without it: %v", err)
+			return tm.refresh(ctx)
+		}
+		if locked {
+			defer func() {
+				if err := tm.locker.Unlock(context.WithoutCancel(ctx), key, owner); err != nil {
+					tm.logDebugf("Failed to release refresh lock: %v", err)
+				}
+			}()
+			if token := tm.freshStoredToken(ctx); token != nil {
+				return token, nil
+			}
+			return tm.refresh(ctx)
+		}
+
+		// Another instance holds the lock — wait for its token.
+		if token := tm.freshStoredToken(ctx); token != nil {
+			return token, nil
+		}
+		if !time.Now().Before(deadline) {
+			tm.logDebugf("Refresh lock for %s held past its TTL, refreshing without it", tm.handle)
+			return tm.refresh(ctx)
+		}
+		if err := sleepWithContext(ctx, tm.lockPoll); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// freshStoredToken returns the stored token if it is not expiring, e.g.
+// because another instance just refreshed it.
+func (tm *TokenManager) freshStoredToken(ctx context.Context) *ManagedToken {
+	token, err := tm.store.Get(ctx, tm.storeKey())
+	if err != nil || token.IsExpiring(tm.refreshBuffer) {
+		return nil
+	}
+	return token
 }
 
 // doRefresh calls the Shopline refresh API and persists the new token.
@@ -210,6 +417,8 @@ func (tm *TokenManager) doRefresh(ctx context.Context) (*ManagedToken, error) {
 		if err := tm.store.Set(ctx, tm.storeKey(), token); err != nil {
 			tm.logDebugf("Failed to persist refreshed token: %v", err)
 			// Don't fail the refresh — the token is still valid in memory
+		} else {
+			tm.publishChange(ctx)
 		}
 	}
 
@@ -234,14 +443,37 @@ func (tm *TokenManager) loadFromStore(ctx context.Context) error {
 	tm.mu.Lock()
 	tm.token = token
 	tm.mu.Unlock()
+	tm.setExpiryGauge(token.ExpireAt)
 
 	tm.logDebugf("Loaded token from store, expires at %s", token.ExpireAt.Format(time.RFC3339))
 	return nil
 }
 
+// incCounter reports a counter increment if metrics are set.
+func (tm *TokenManager) incCounter(name string) {
+	if tm.mtr != nil {
+		tm.mtr.IncCounter(name, map[string]string{"shop": tm.handle})
+	}
+}
+
+// setExpiryGauge reports the token expiry as a Unix timestamp.
+func (tm *TokenManager) setExpiryGauge(expireAt time.Time) {
+	if tm.mtr != nil {
+		tm.mtr.SetGauge(MetricTokenExpiry, float64(expireAt.Unix()), map[string]string{"shop": tm.handle})
+	}
+}
+
 // logDebugf logs a debug message if a logger is set.
 func (tm *TokenManager) logDebugf(format string, args ...interface{}) {
 	if tm.log != nil {
 		tm.log.Debugf(format, args...)
 	}
 }
+
+// newTokenManagerID returns a random ID identifying a TokenManager in
+// TokenChange notifications.
+func newTokenManagerID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
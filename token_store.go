@@ -50,6 +50,58 @@ type TokenStore interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// TokenStoreNotifier is optionally implemented by a TokenStore shared by
+// several processes, e.g. with Redis pub/sub or Postgres LISTEN/NOTIFY. A
+// TokenManager on such a store publishes a TokenChange whenever it saves or
+// deletes a token, and after Watch, drops its in-memory copy when another
+// instance changes the token, so replicas reload the refreshed token from the
+// store instead of racing to refresh it themselves.
+type TokenStoreNotifier interface {
+	// Publish announces a change to all subscribers, including the
+	// publisher's own.
+	Publish(ctx context.Context, change TokenChange) error
+
+	// Subscribe calls fn for every published change until ctx is done. It
+	// must not block.
+	Subscribe(ctx context.Context, fn func(TokenChange)) error
+}
+
+// TokenChange announces that the token stored under Key was replaced or
+// deleted.
+type TokenChange struct {
+	Key    string `json:"key"`
+	Source string `json:"source"` // ID of the publishing TokenManager
+}
+
+// RefreshLocker is a lock shared by the processes using one TokenStore, e.g.
+// Redis SET NX PX or a database advisory lock. See WithRefreshLocker.
+//
+// Each acquisition is identified by an owner token, so a holder whose lock
+// expired cannot release the lock another process has since acquired.
+// With Redis:
+//
+//	func (l *RedisLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+//	    owner := uuid.NewString()
+//	    ok, err := l.client.SetNX(ctx, "lock:"+key, owner, ttl).Result()
+//	    return owner, ok, err
+//	}
+//
+//	var unlockScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+//
+//	func (l *RedisLocker) Unlock(ctx context.Context, key, owner string) error {
+//	    return unlockScript.Run(ctx, l.client, []string{"lock:" + key}, owner).Err()
+//	}
+type RefreshLocker interface {
+	// TryLock acquires the lock for key without waiting, reporting whether
+	// it was acquired and the owner token identifying this acquisition.
+	// The lock must expire by itself after ttl.
+	TryLock(ctx context.Context, key string, ttl time.Duration) (owner string, acquired bool, err error)
+
+	// Unlock releases the lock for key if it is still held by owner, and
+	// does nothing otherwise (compare-and-delete).
+	Unlock(ctx context.Context, key, owner string) error
+}
+
 // ============================================================
 // FileTokenStore — built-in file-based implementation
 // ============================================================
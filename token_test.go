@@ -2,8 +2,10 @@ package shopline
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -466,3 +468,310 @@ func TestManagedToken_IsExpiring(t *testing.T) {
 		t.Error("token with 3m left should be expiring with 5m buffer")
 	}
 }
+
+// recordingMetrics counts IncCounter calls and keeps the last gauge values.
+type recordingMetrics struct {
+	mu       sync.Mutex
+	counters map[string]int
+	gauges   map[string]float64
+}
+
+func (m *recordingMetrics) IncCounter(name string, _ map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counters[name]++
+}
+
+func (m *recordingMetrics) SetGauge(name string, value float64, _ map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges[name] = value
+}
+
+func TestTokenManager_MetricsAndSnapshot(t *testing.T) {
+	m := &recordingMetrics{counters: map[string]int{}, gauges: map[string]float64{}}
+	tm := NewTokenManager(App{AppKey: "k"}, "shop", nil, WithRefreshBuffer(0), WithTokenMetrics(m))
+	expireAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	release := make(chan struct{})
+	fail := true
+	tm.refresh = func(ctx context.Context) (*ManagedToken, error) {
+		<-release
+		if fail {
+			return nil, fmt.Errorf("upstream down")
+		}
+		return &ManagedToken{AccessToken: "fresh", ExpireAt: expireAt}, nil
+	}
+
+	close(release)
+	if _, err := tm.GetToken(context.Background()); err == nil {
+		t.Fatal("expected refresh failure")
+	}
+	if s := tm.Snapshot(); s.Failures != 1 || s.LastError != "upstream down" {
+		t.Errorf("unexpected stats after failure: %+v", s)
+	}
+
+	fail = false
+	release = make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tm.GetToken(context.Background())
+		}()
+	}
+	for tm.Snapshot().CoalescedWaits < 4 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	s := tm.Snapshot()
+	if s.Refreshes != 1 || s.CoalescedWaits != 4 || !s.ExpireAt.Equal(expireAt) || s.LastError != "" {
+		t.Errorf("unexpected stats: %+v", s)
+	}
+	if m.counters[MetricTokenRefreshes] != 1 || m.counters[MetricTokenRefreshErrors] != 1 || m.counters[MetricTokenWaits] != 4 {
+		t.Errorf("unexpected counters: %v", m.counters)
+	}
+	if m.gauges[MetricTokenExpiry] != float64(expireAt.Unix()) {
+		t.Errorf("unexpected expiry gauge: %v", m.gauges)
+	}
+}
+
+// ============================================================
+// UserTokenManager Tests
+// ============================================================
+
+func TestUserTokenManager_Exchange(t *testing.T) {
+	store := newMockTokenStore()
+	ctx := context.Background()
+	m := NewUserTokenManager(App{AppKey: "k", AppSecret: "s"}, store)
+	m.exchange = func(_ context.Context, handle, code string) (*OnlineTokenResponse, error) {
+		resp := &OnlineTokenResponse{Code: 200}
+		resp.Data.AccessToken = "user-token-" + code
+		resp.Data.ExpireTime = time.Now().Add(time.Hour).Format(time.RFC3339)
+		resp.Data.AssociatedUserScope = "read_orders"
+		resp.Data.AssociatedUser = OnlineUser{ID: 42, Email: "staff@example.com"}
+		return resp, nil
+	}
+
+	user, err := m.Exchange(ctx, "shop", "abc")
+	if err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+	if user.ID != 42 {
+		t.Errorf("expected user 42, got %d", user.ID)
+	}
+	stored, _ := store.Get(ctx, "shop:k:user:42")
+	if stored == nil || stored.AccessToken != "user-token-abc" || stored.Scope != "read_orders" {
+		t.Fatalf("expected user token in store, got %+v", stored)
+	}
+
+	// A new manager loads the token from the store.
+	client, err := NewUserTokenManager(App{AppKey: "k"}, store).NewClient(ctx, "shop", 42)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.token != "user-token-abc" {
+		t.Errorf("expected client to use the user token, got %q", client.token)
+	}
+	if _, err := m.GetToken(ctx, "shop", 7); !errors.Is(err, ErrUserTokenRequired) {
+		t.Errorf("expected ErrUserTokenRequired for unknown user, got %v", err)
+	}
+}
+
+func TestUserTokenManager_ExchangeInvalidExpiry(t *testing.T) {
+	store := newMockTokenStore()
+	ctx := context.Background()
+	m := NewUserTokenManager(App{AppKey: "k"}, store)
+	m.exchange = func(_ context.Context, handle, code string) (*OnlineTokenResponse, error) {
+		resp := &OnlineTokenResponse{Code: 200}
+		resp.Data.AccessToken = "user-token"
+		resp.Data.ExpireTime = "soon"
+		resp.Data.AssociatedUser = OnlineUser{ID: 42}
+		return resp, nil
+	}
+
+	if _, err := m.Exchange(ctx, "shop", "abc"); err == nil || !strings.Contains(err.Error(), "soon") {
+		t.Fatalf("expected expiry parse error, got %v", err)
+	}
+	if stored, _ := store.Get(ctx, "shop:k:user:42"); stored != nil {
+		t.Errorf("expected no token saved, got %+v", stored)
+	}
+}
+
+func TestUserTokenManager_Expired(t *testing.T) {
+	ctx := context.Background()
+	m := NewUserTokenManager(App{AppKey: "k"}, nil)
+	m.SetToken(ctx, "shop", 1, &ManagedToken{AccessToken: "old", ExpireAt: time.Now().Add(-time.Minute)})
+
+	if _, err := m.GetToken(ctx, "shop", 1); !errors.Is(err, ErrUserTokenRequired) {
+		t.Errorf("expected ErrUserTokenRequired for expired token, got %v", err)
+	}
+	m.SetToken(ctx, "shop", 1, &ManagedToken{AccessToken: "new", ExpireAt: time.Now().Add(time.Hour)})
+	if tok, err := m.GetToken(ctx, "shop", 1); err != nil || tok != "new" {
+		t.Errorf("expected new token, got %q (%v)", tok, err)
+	}
+	m.InvalidateToken(ctx, "shop", 1)
+	if _, err := m.GetToken(ctx, "shop", 1); !errors.Is(err, ErrUserTokenRequired) {
+		t.Errorf("expected ErrUserTokenRequired after invalidation, got %v", err)
+	}
+}
+
+func TestTokenManager_RevokeOnInvalidate(t *testing.T) {
+	store := newMockTokenStore()
+	ctx := context.Background()
+	tm := NewTokenManager(App{AppKey: "k", AppSecret: "s"}, "shop", store, WithRevokeOnInvalidate())
+	var revoked []string
+	tm.revoke = func(_ context.Context, token string) error {
+		revoked = append(revoked, token)
+		return errors.New("revoke endpoint down")
+	}
+	tm.SetInitialToken(ctx, "to-revoke", time.Now().Add(10*time.Hour), "")
+
+	if err := tm.InvalidateToken(ctx); err == nil {
+		t.Error("expected revocation error")
+	}
+	if len(revoked) != 1 || revoked[0] != "to-revoke" {
+		t.Errorf("expected token to be revoked, got %v", revoked)
+	}
+	if stored, _ := store.Get(ctx, "shop:k"); stored != nil {
+		t.Error("expected token to be removed from store despite revocation error")
+	}
+}
+
+// notifyingTokenStore is a mockTokenStore with in-process change
+// notifications.
+type notifyingTokenStore struct {
+	*mockTokenStore
+	mu   sync.Mutex
+	subs []func(TokenChange)
+}
+
+func (s *notifyingTokenStore) Publish(_ context.Context, change TokenChange) error {
+	s.mu.Lock()
+	subs := append([]func(TokenChange){}, s.subs...)
+	s.mu.Unlock()
+	for _, fn := range subs {
+		fn(change)
+	}
+	return nil
+}
+
+func (s *notifyingTokenStore) Subscribe(_ context.Context, fn func(TokenChange)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subs = append(s.subs, fn)
+	return nil
+}
+
+func TestTokenManager_WatchReloadsChangedToken(t *testing.T) {
+	store := &notifyingTokenStore{mockTokenStore: newMockTokenStore()}
+	ctx := context.Background()
+	app := App{AppKey: "k", AppSecret: "s"}
+
+	a := NewTokenManager(app, "shop", store)
+	b := NewTokenManager(app, "shop", store)
+	var bRefreshes int32
+	b.refresh = func(context.Context) (*ManagedToken, error) {
+		atomic.AddInt32(&bRefreshes, 1)
+		return &ManagedToken{AccessToken: "b-refreshed", ExpireAt: time.Now().Add(time.Hour)}, nil
+	}
+	for _, tm := range []*TokenManager{a, b} {
+		if err := tm.Watch(ctx); err != nil {
+			t.Fatalf("Watch failed: %v", err)
+		}
+	}
+
+	a.SetInitialToken(ctx, "old", time.Now().Add(10*time.Hour), "")
+	if tok, _ := b.GetToken(ctx); tok != "old" {
+		t.Fatalf("expected b to load 'old', got %q", tok)
+	}
+
+	a.SetInitialToken(ctx, "new", time.Now().Add(10*time.Hour), "")
+	if tok, _ := b.GetToken(ctx); tok != "new" {
+		t.Errorf("expected b to reload 'new', got %q", tok)
+	}
+	if tok, _ := a.GetToken(ctx); tok != "new" {
+		t.Errorf("expected a to keep 'new', got %q", tok)
+	}
+	if n := atomic.LoadInt32(&bRefreshes); n != 0 {
+		t.Errorf("expected b not to refresh, refreshed %d times", n)
+	}
+}
+
+// memoryLocker is an in-process RefreshLocker. It counts Unlock calls by
+// a stale owner, which must not release the lock.
+type memoryLocker struct {
+	mu      sync.Mutex
+	owners  map[string]string
+	next    int
+	foreign int
+}
+
+func (l *memoryLocker) TryLock(_ context.Context, key string, _ time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, held := l.owners[key]; held {
+		return "", false, nil
+	}
+	l.next++
+	owner := fmt.Sprint(l.next)
+	l.owners[key] = owner
+	return owner, true, nil
+}
+
+func (l *memoryLocker) Unlock(_ context.Context, key, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.owners[key] != owner {
+		l.foreign++
+		return nil
+	}
+	delete(l.owners, key)
+	return nil
+}
+
+func TestTokenManager_RefreshLocker(t *testing.T) {
+	store := newMockTokenStore()
+	locker := &memoryLocker{owners: make(map[string]string)}
+	app := App{AppKey: "k", AppSecret: "s"}
+
+	var refreshes int32
+	refresh := func(tm *TokenManager) func(context.Context) (*ManagedToken, error) {
+		return func(ctx context.Context) (*ManagedToken, error) {
+			atomic.AddInt32(&refreshes, 1)
+			time.Sleep(20 * time.Millisecond)
+			token := &ManagedToken{AccessToken: "refreshed", ExpireAt: time.Now().Add(10 * time.Hour)}
+			return token, store.Set(ctx, tm.storeKey(), token)
+		}
+	}
+
+	const replicas = 5
+	var wg sync.WaitGroup
+	tokens := make([]string, replicas)
+	for i := 0; i < replicas; i++ {
+		tm := NewTokenManager(app, "shop", store, WithRefreshLocker(locker, time.Second))
+		tm.lockPoll = 5 * time.Millisecond
+		tm.refresh = refresh(tm)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], _ = tm.GetToken(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("expected 1 refresh across replicas, got %d", n)
+	}
+	if locker.foreign != 0 || len(locker.owners) != 0 {
+		t.Errorf("lock released by a non-owner %d times, still held: %v", locker.foreign, locker.owners)
+	}
+	for i, tok := range tokens {
+		if tok != "refreshed" {
+			t.Errorf("replica %d got %q", i, tok)
+		}
+	}
+}
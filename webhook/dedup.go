@@ -0,0 +1,165 @@
+package webhook
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDedupWindow   = 24 * time.Hour
+	defaultDedupCapacity = 100000
+)
+
+// =====================================================================
+// Deduplication
+// =====================================================================
+
+// DedupStore records which deliveries are being or have been handled.
+// MemoryDedupStore serves a single process; implement it on a shared
+// backend when several replicas receive webhooks, e.g. with Redis:
+//
+//	func (s *RedisDedupStore) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+//	    return s.client.SetNX(ctx, "webhook:"+key, 1, ttl).Result()
+//	}
+//	func (s *RedisDedupStore) Release(ctx context.Context, key string) error {
+//	    return s.client.Del(ctx, "webhook:"+key).Err()
+//	}
+type DedupStore interface {
+	// Claim atomically records key for ttl and reports whether it was new.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+
+	// Release forgets key, so the next delivery with it is handled again.
+	Release(ctx context.Context, key string) error
+}
+
+// Deduplicator drops redelivered webhooks: a delivery whose webhook ID was
+// already handled, or is being handled, within the window is acknowledged
+// without running the handler. When a handler fails its claim is released,
+// so Shopline's redelivery is processed. Deliveries without a webhook ID are
+// always handled.
+//
+// Deduplication is exactly-once only if the handler's effects are complete
+// when it returns; a crash between the handler finishing and the response
+// being sent still leads to a redelivery that is dropped. For effects that
+// must commit atomically with the dedup record use outbox.Handler instead.
+type Deduplicator struct {
+	store  DedupStore
+	window time.Duration
+}
+
+// NewDeduplicator creates a Deduplicator remembering deliveries in store for
+// window (default 24h, covering Shopline's redelivery period).
+func NewDeduplicator(store DedupStore, window time.Duration) *Deduplicator {
+	if window <= 0 {
+		window = defaultDedupWindow
+	}
+	return &Deduplicator{store: store, window: window}
+}
+
+// Wrap returns h with deduplication. A Dispatcher created WithDeduplicator
+// wraps every handler already.
+func (dd *Deduplicator) Wrap(h HandlerFunc) HandlerFunc {
+	return func(ctx context.Context, d Delivery) error {
+		if d.WebhookID == "" {
+			return h(ctx, d)
+		}
+		key := d.ShopDomain + ":" + d.WebhookID
+		first, err := dd.store.Claim(ctx, key, dd.window)
+		if err != nil {
+			return fmt.Errorf("webhook: failed to claim delivery %s: %w", d.WebhookID, err)
+		}
+		if !first {
+			return nil
+		}
+		if err := h(ctx, d); err != nil {
+			if relErr := dd.store.Release(context.WithoutCancel(ctx), key); relErr != nil {
+				return fmt.Errorf("%w (and failed to release delivery %s: %v)", err, d.WebhookID, relErr)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// WithDeduplicator makes the Dispatcher drop redelivered webhooks before
+// they reach any handler, including the fallback.
+//
+//	dd := webhook.NewDeduplicator(webhook.NewMemoryDedupStore(0), time.Hour)
+//	d := webhook.NewDispatcher(webhook.WithDeduplicator(dd))
+func WithDeduplicator(dd *Deduplicator) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.dedup = dd
+	}
+}
+
+// =====================================================================
+// Memory Store
+// =====================================================================
+
+// MemoryDedupStore is an in-memory DedupStore that keeps at most capacity
+// keys, evicting the least recently claimed first. It is safe for
+// concurrent use.
+type MemoryDedupStore struct {
+	capacity int
+	now      func() time.Time
+
+	mu    sync.Mutex
+	order *list.List // of *dedupEntry, most recent first
+	keys  map[string]*list.Element
+}
+
+type dedupEntry struct {
+	key     string
+	expires time.Time
+}
+
+// NewMemoryDedupStore creates a MemoryDedupStore holding up to capacity
+// keys (default 100000 when capacity is not positive).
+func NewMemoryDedupStore(capacity int) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+	return &MemoryDedupStore{
+		capacity: capacity,
+		now:      time.Now,
+		order:    list.New(),
+		keys:     make(map[string]*list.Element),
+	}
+}
+
+// Claim implements DedupStore.
+func (s *MemoryDedupStore) Claim(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if el, ok := s.keys[key]; ok {
+		e := el.Value.(*dedupEntry)
+		if now.Before(e.expires) {
+			return false, nil
+		}
+		e.expires = now.Add(ttl)
+		s.order.MoveToFront(el)
+		return true, nil
+	}
+	s.keys[key] = s.order.PushFront(&dedupEntry{key: key, expires: now.Add(ttl)})
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.keys, oldest.Value.(*dedupEntry).key)
+	}
+	return true, nil
+}
+
+// Release implements DedupStore.
+func (s *MemoryDedupStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.keys[key]; ok {
+		s.order.Remove(el)
+		delete(s.keys, key)
+	}
+	return nil
+}
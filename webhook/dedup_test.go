@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDispatcher_Deduplicates(t *testing.T) {
+	calls := 0
+	fail := true
+	d := NewDispatcher(WithDeduplicator(NewDeduplicator(NewMemoryDedupStore(0), time.Hour)))
+	d.Handle("orders/paid", func(_ context.Context, del Delivery) error {
+		calls++
+		if fail {
+			fail = false
+			return errors.New("temporary")
+		}
+		return nil
+	})
+
+	send := func(id string) int {
+		req := newDelivery("orders/paid", `{"id":1}`)
+		req.Header.Set(HeaderWebhookID, id)
+		rec := httptest.NewRecorder()
+		d.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send("wh-1"); code != http.StatusInternalServerError {
+		t.Fatalf("expected first attempt to fail, got %d", code)
+	}
+	for i := 0; i < 3; i++ {
+		if code := send("wh-1"); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected handler to run for the failed attempt and one redelivery, got %d", calls)
+	}
+	send("wh-2")
+	send("")
+	send("")
+	if calls != 5 {
+		t.Errorf("expected new and ID-less deliveries to be handled, got %d calls", calls)
+	}
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewMemoryDedupStore(2)
+	s.now = func() time.Time { return now }
+
+	claim := func(key string) bool {
+		ok, _ := s.Claim(ctx, key, time.Minute)
+		return ok
+	}
+	if !claim("a") || claim("a") {
+		t.Fatal("expected only the first claim to succeed")
+	}
+	now = now.Add(time.Minute)
+	if !claim("a") {
+		t.Error("expected claim to succeed after the window")
+	}
+
+	claim("b")
+	claim("c") // evicts "a"
+	if !claim("a") {
+		t.Error("expected evicted key to be claimable")
+	}
+	if len(s.keys) != 2 {
+		t.Errorf("expected capacity to be enforced, got %d keys", len(s.keys))
+	}
+}
@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// =====================================================================
+// Destinations
+// =====================================================================
+
+// DestinationKind is the kind of endpoint a subscription delivers to, as
+// encoded in its address.
+type DestinationKind int
+
+const (
+	// DestinationHTTPS delivers with an HTTPS POST to the address.
+	DestinationHTTPS DestinationKind = iota
+	// DestinationEventBridge delivers to an Amazon EventBridge partner
+	// event source; the address is its ARN.
+	DestinationEventBridge
+	// DestinationPubSub delivers to a Google Cloud Pub/Sub topic; the
+	// address is "pubsub://{project}:{topic}".
+	DestinationPubSub
+)
+
+// String returns the kind's name.
+func (k DestinationKind) String() string {
+	switch k {
+	case DestinationEventBridge:
+		return "eventbridge"
+	case DestinationPubSub:
+		return "pubsub"
+	}
+	return "https"
+}
+
+const (
+	eventBridgePrefix = "arn:aws:events:"
+	pubSubPrefix      = "pubsub://"
+)
+
+// EventBridgeAddress returns the subscription address for an Amazon
+// EventBridge partner event source ARN. Use it only on stores whose API
+// version supports EventBridge destinations; others reject the address.
+func EventBridgeAddress(arn string) (string, error) {
+	if !strings.HasPrefix(arn, eventBridgePrefix) {
+		return "", fmt.Errorf("webhook: %q is not an EventBridge ARN", arn)
+	}
+	return arn, nil
+}
+
+// PubSubAddress returns the subscription address for a Google Cloud Pub/Sub
+// topic. Use it only on stores whose API version supports Pub/Sub
+// destinations; others reject the address.
+func PubSubAddress(project, topic string) (string, error) {
+	if project == "" || topic == "" || strings.Contains(project, ":") {
+		return "", fmt.Errorf("webhook: invalid Pub/Sub project %q or topic %q", project, topic)
+	}
+	return pubSubPrefix + project + ":" + topic, nil
+}
+
+// Destination returns the kind of endpoint s delivers to. Only HTTPS
+// subscriptions reach a Dispatcher; events of the others are read from the
+// cloud service.
+func (s Subscription) Destination() DestinationKind {
+	switch {
+	case strings.HasPrefix(s.Address, eventBridgePrefix):
+		return DestinationEventBridge
+	case strings.HasPrefix(s.Address, pubSubPrefix):
+		return DestinationPubSub
+	}
+	return DestinationHTTPS
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Webhook delivery headers set by Shopline.
+const (
+	HeaderTopic      = "X-Shopline-Topic"
+	HeaderShopDomain = "X-Shopline-Shop-Domain"
+	HeaderWebhookID  = "X-Shopline-Webhook-Id"
+	HeaderHmac       = "X-Shopline-Hmac-SHA256"
+)
+
+// maxDeliveryBodySize limits webhook body reads to 10MB, matching the client's
+// response body limit.
+const maxDeliveryBodySize = 10 * 1024 * 1024
+
+// =====================================================================
+// Dispatcher
+// =====================================================================
+
+// Delivery is a single webhook request received from Shopline.
+type Delivery struct {
+	Topic      string
+	ShopDomain string
+	WebhookID  string
+	Header     http.Header
+	Body       []byte
+}
+
+// HandlerFunc processes a verified webhook delivery. Returning an error
+// responds with HTTP 500 so Shopline redelivers the webhook later.
+type HandlerFunc func(ctx context.Context, d Delivery) error
+
+// DispatcherOption configures a Dispatcher.
+type DispatcherOption func(*Dispatcher)
+
+// WithVerifier sets the signature check run before any handler.
+// Pass App.VerifyWebhookRequest from the root package:
+//
+//	d := webhook.NewDispatcher(webhook.WithVerifier(app.VerifyWebhookRequest))
+//
+// Requests failing verification are rejected with HTTP 401.
+func WithVerifier(verify func(r *http.Request) bool) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.verify = verify
+	}
+}
+
+// WithFallback sets the handler used for topics with no registered handler.
+// By default unknown topics are acknowledged with HTTP 200 and dropped.
+func WithFallback(h HandlerFunc) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.fallback = h
+	}
+}
+
+// Dispatcher is an http.Handler that routes webhook deliveries to handlers
+// by topic. It is safe for concurrent use.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+	verify   func(r *http.Request) bool
+	fallback HandlerFunc
+	dedup    *Deduplicator
+}
+
+// NewDispatcher creates an empty Dispatcher.
+func NewDispatcher(opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{handlers: make(map[string]HandlerFunc)}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Handle registers h for topic (e.g. "orders/create"), replacing any
+// previously registered handler.
+func (d *Dispatcher) Handle(topic string, h HandlerFunc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[topic] = h
+}
+
+// handler returns the handler registered for topic, or the fallback.
+func (d *Dispatcher) handler(topic string) HandlerFunc {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if h, ok := d.handlers[topic]; ok {
+		return h
+	}
+	return d.fallback
+}
+
+// ServeHTTP implements http.Handler.
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if d.verify != nil && !d.verify(r) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDeliveryBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	delivery := Delivery{
+		Topic:      r.Header.Get(HeaderTopic),
+		ShopDomain: r.Header.Get(HeaderShopDomain),
+		WebhookID:  r.Header.Get(HeaderWebhookID),
+		Header:     r.Header,
+		Body:       body,
+	}
+
+	h := d.handler(delivery.Topic)
+	if h == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if d.dedup != nil {
+		h = d.dedup.Wrap(h)
+	}
+	if err := h(r.Context(), delivery); err != nil {
+		http.Error(w, fmt.Sprintf("webhook %s failed", delivery.Topic), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newDelivery(topic, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set(HeaderTopic, topic)
+	req.Header.Set(HeaderShopDomain, "open001.myshopline.com")
+	return req
+}
+
+func TestDispatcher_RoutesByTopic(t *testing.T) {
+	var got Delivery
+	d := NewDispatcher()
+	d.Handle("orders/create", func(_ context.Context, del Delivery) error {
+		got = del
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, newDelivery("orders/create", `{"id":1}`))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if got.Topic != "orders/create" || string(got.Body) != `{"id":1}` {
+		t.Errorf("unexpected delivery: %+v", got)
+	}
+	if got.ShopDomain != "open001.myshopline.com" {
+		t.Errorf("expected shop domain, got %q", got.ShopDomain)
+	}
+}
+
+func TestDispatcher_UnknownTopicAcknowledged(t *testing.T) {
+	d := NewDispatcher()
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, newDelivery("products/update", `{}`))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 for unhandled topic, got %d", rec.Code)
+	}
+}
+
+func TestDispatcher_HandlerErrorReturns500(t *testing.T) {
+	d := NewDispatcher()
+	d.Handle("orders/create", func(context.Context, Delivery) error {
+		return errors.New("db down")
+	})
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, newDelivery("orders/create", `{}`))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestDispatcher_VerifierRejects(t *testing.T) {
+	called := false
+	d := NewDispatcher(WithVerifier(func(*http.Request) bool { return false }))
+	d.Handle("orders/create", func(context.Context, Delivery) error {
+		called = true
+		return nil
+	})
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, newDelivery("orders/create", `{}`))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+	if called {
+		t.Error("handler must not run when verification fails")
+	}
+}
+
+func TestDispatcher_RejectsNonPost(t *testing.T) {
+	d := NewDispatcher()
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
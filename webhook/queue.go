@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultQueueSize    = 1000
+	defaultQueueWorkers = 4
+)
+
+// ErrQueueFull is returned by QueueHandler.Handle when the queue has no room
+// under OverflowReject, and ErrQueueClosed after Shutdown. The Dispatcher
+// answers both with HTTP 500, so Shopline redelivers the webhook later.
+var (
+	ErrQueueFull   = errors.New("webhook: queue full")
+	ErrQueueClosed = errors.New("webhook: queue shut down")
+)
+
+// =====================================================================
+// Queue Handler
+// =====================================================================
+
+// OverflowPolicy decides what QueueHandler.Handle does when the queue is
+// full.
+type OverflowPolicy int
+
+const (
+	// OverflowReject fails the delivery with ErrQueueFull so Shopline
+	// retries it later. This is the default.
+	OverflowReject OverflowPolicy = iota
+	// OverflowBlock waits for room until the request is cancelled. Keep
+	// the wait below Shopline's delivery timeout with a handler deadline.
+	OverflowBlock
+)
+
+// QueueOption configures a QueueHandler.
+type QueueOption func(*QueueHandler)
+
+// WithQueueSize sets how many deliveries may wait for a worker (default
+// 1000).
+func WithQueueSize(n int) QueueOption {
+	return func(q *QueueHandler) { q.size = n }
+}
+
+// WithQueueWorkers sets how many deliveries are processed concurrently
+// (default 4).
+func WithQueueWorkers(n int) QueueOption {
+	return func(q *QueueHandler) { q.workers = n }
+}
+
+// WithOverflow sets the policy for a full queue (default OverflowReject).
+func WithOverflow(p OverflowPolicy) QueueOption {
+	return func(q *QueueHandler) { q.overflow = p }
+}
+
+// WithQueueErrorHandler sets a callback for deliveries whose handler failed
+// or panicked. They have already been acknowledged, so this is the place to
+// log them or hand them to a retry mechanism.
+func WithQueueErrorHandler(fn func(d Delivery, err error)) QueueOption {
+	return func(q *QueueHandler) { q.onError = fn }
+}
+
+// QueueHandler acknowledges deliveries as soon as they are queued and runs
+// the wrapped handler on a bounded pool of workers. Slow handlers such as ERP
+// syncs then no longer exceed Shopline's delivery timeout, which would cause
+// redeliveries and duplicate processing. Register Handle on a Dispatcher,
+// which verifies signatures before anything is queued:
+//
+//	q := webhook.NewQueueHandler(syncOrder, webhook.WithQueueWorkers(8))
+//	d.Handle("orders/paid", q.Handle)
+//	...
+//	q.Shutdown(ctx) // after http.Server.Shutdown
+//
+// The queue is in memory: deliveries still queued when the process dies are
+// lost even though they were acknowledged. Use outbox.Handler when that is
+// not acceptable.
+type QueueHandler struct {
+	next     HandlerFunc
+	size     int
+	workers  int
+	overflow OverflowPolicy
+	onError  func(d Delivery, err error)
+
+	mu     sync.RWMutex // guards closed and sends on queue
+	closed bool
+	queue  chan queuedDelivery
+	wg     sync.WaitGroup
+	done   chan struct{}
+}
+
+type queuedDelivery struct {
+	ctx context.Context
+	d   Delivery
+}
+
+// NewQueueHandler creates a QueueHandler running next and starts its
+// workers.
+func NewQueueHandler(next HandlerFunc, opts ...QueueOption) *QueueHandler {
+	q := &QueueHandler{next: next, size: defaultQueueSize, workers: defaultQueueWorkers}
+	for _, opt := range opts {
+		opt(q)
+	}
+	if q.size < 0 {
+		q.size = 0
+	}
+	if q.workers < 1 {
+		q.workers = 1
+	}
+	q.queue = make(chan queuedDelivery, q.size)
+	q.done = make(chan struct{})
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	go func() {
+		q.wg.Wait()
+		close(q.done)
+	}()
+	return q
+}
+
+// Handle queues d and returns without waiting for it to be processed. It is
+// a HandlerFunc. The handler later runs with ctx's values but not its
+// cancellation, since the request is over by then.
+func (q *QueueHandler) Handle(ctx context.Context, d Delivery) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	item := queuedDelivery{ctx: context.WithoutCancel(ctx), d: d}
+	if q.overflow == OverflowBlock {
+		select {
+		case q.queue <- item:
+			return nil
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrQueueFull, ctx.Err())
+		}
+	}
+	select {
+	case q.queue <- item:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Len returns the number of deliveries waiting for a worker.
+func (q *QueueHandler) Len() int {
+	return len(q.queue)
+}
+
+// Shutdown stops accepting deliveries and waits until the queued ones have
+// been processed, or until ctx is done, in which case it returns ctx's error
+// while the workers keep draining the queue in the background.
+func (q *QueueHandler) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *QueueHandler) work() {
+	defer q.wg.Done()
+	for item := range q.queue {
+		if err := q.run(item); err != nil && q.onError != nil {
+			q.onError(item.d, err)
+		}
+	}
+}
+
+// run calls the handler, turning a panic into an error so one bad delivery
+// does not take the worker down.
+func (q *QueueHandler) run(item queuedDelivery) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("webhook: handler for %s panicked: %v", item.d.Topic, r)
+		}
+	}()
+	return q.next(item.ctx, item.d)
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQueueHandler_AcksBeforeProcessing(t *testing.T) {
+	release := make(chan struct{})
+	var processed int32
+	q := NewQueueHandler(func(ctx context.Context, d Delivery) error {
+		<-release
+		atomic.AddInt32(&processed, 1)
+		return nil
+	}, WithQueueWorkers(1))
+
+	d := NewDispatcher()
+	d.Handle("orders/paid", q.Handle)
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		d.ServeHTTP(rec, newDelivery("orders/paid", `{"id":1}`))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected immediate 200, got %d", rec.Code)
+		}
+	}
+	if n := atomic.LoadInt32(&processed); n != 0 {
+		t.Fatalf("expected nothing processed yet, got %d", n)
+	}
+
+	close(release)
+	if err := q.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+	if n := atomic.LoadInt32(&processed); n != 3 {
+		t.Errorf("expected shutdown to drain 3 deliveries, got %d", n)
+	}
+	if err := q.Handle(context.Background(), Delivery{}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("expected ErrQueueClosed after shutdown, got %v", err)
+	}
+}
+
+func TestQueueHandler_Overflow(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	q := NewQueueHandler(func(ctx context.Context, d Delivery) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, WithQueueWorkers(1), WithQueueSize(1))
+	defer q.Shutdown(context.Background())
+	defer close(release)
+
+	ctx := context.Background()
+	q.Handle(ctx, Delivery{}) // taken by the worker
+	<-started
+	if err := q.Handle(ctx, Delivery{}); err != nil { // fills the queue
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.Handle(ctx, Delivery{}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected ErrQueueFull, got %v", err)
+	}
+
+	q.overflow = OverflowBlock
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := q.Handle(ctx, Delivery{}); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("expected blocked enqueue to give up with the request, got %v", err)
+	}
+}
+
+func TestQueueHandler_ReportsFailures(t *testing.T) {
+	var mu sync.Mutex
+	var errs []error
+	q := NewQueueHandler(func(ctx context.Context, d Delivery) error {
+		if d.Topic == "panic" {
+			panic("boom")
+		}
+		return errors.New("sync failed")
+	}, WithQueueErrorHandler(func(d Delivery, err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}))
+
+	q.Handle(context.Background(), Delivery{Topic: "orders/paid"})
+	q.Handle(context.Background(), Delivery{Topic: "panic"})
+	q.Shutdown(context.Background())
+	if len(errs) != 2 {
+		t.Errorf("expected 2 reported failures, got %v", errs)
+	}
+}
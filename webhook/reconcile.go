@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+
+	"github.com/imokyou/slshop/core"
+)
+
+const reconcilePageSize = 250
+
+// =====================================================================
+// Subscription Reconciliation
+// =====================================================================
+
+// SubscriptionPlan lists the changes that turn the current webhooks into
+// the desired set.
+type SubscriptionPlan struct {
+	Create []Subscription
+	Update []Subscription // with the ID of the webhook being changed
+	Delete []Subscription
+}
+
+// Empty reports whether the current webhooks already match.
+func (p SubscriptionPlan) Empty() bool {
+	return len(p.Create) == 0 && len(p.Update) == 0 && len(p.Delete) == 0
+}
+
+// PlanSubscriptions diffs current against desired. Subscriptions are
+// identified by topic and address; a match whose format, fields or headers
+// differ is updated. Headers are only compared when current has them, as
+// API versions without header support do not return them. A current
+// webhook whose topic is still wanted but whose address is not is moved to
+// the new address instead of being deleted and re-created, so deliveries
+// do not stop in between. Everything else not in desired is deleted.
+func PlanSubscriptions(current, desired []Subscription) SubscriptionPlan {
+	var plan SubscriptionPlan
+	used := make([]bool, len(current))
+	var unmatched []Subscription
+
+	for _, want := range desired {
+		i := indexOf(current, used, func(s Subscription) bool {
+			return s.Topic == want.Topic && s.Address == want.Address
+		})
+		if i < 0 {
+			unmatched = append(unmatched, want)
+			continue
+		}
+		used[i] = true
+		if !sameSettings(current[i], want) {
+			want.ID = current[i].ID
+			plan.Update = append(plan.Update, want)
+		}
+	}
+	for _, want := range unmatched {
+		i := indexOf(current, used, func(s Subscription) bool { return s.Topic == want.Topic })
+		if i < 0 {
+			plan.Create = append(plan.Create, want)
+			continue
+		}
+		used[i] = true
+		want.ID = current[i].ID
+		plan.Update = append(plan.Update, want)
+	}
+	for i, s := range current {
+		if !used[i] {
+			plan.Delete = append(plan.Delete, s)
+		}
+	}
+	return plan
+}
+
+// EnsureSubscriptions makes the store's webhooks match desired, typically
+// at app start-up. It is idempotent: once applied, running it again changes
+// nothing. Creates run before updates and deletes so no wanted topic is
+// left without a subscription. On error, the changes applied so far are
+// returned along with it.
+//
+//	_, err := webhook.EnsureSubscriptions(ctx, client.Webhook, []webhook.Subscription{
+//	    {Topic: "orders/create", Address: "https://app.example.com/webhooks"},
+//	    {Topic: "app/uninstalled", Address: "https://app.example.com/webhooks"},
+//	})
+func EnsureSubscriptions(ctx context.Context, svc Service, desired []Subscription) (SubscriptionPlan, error) {
+	current, err := listAll(ctx, svc)
+	if err != nil {
+		return SubscriptionPlan{}, err
+	}
+	plan := PlanSubscriptions(current, desired)
+
+	var done SubscriptionPlan
+	for _, s := range plan.Create {
+		created, err := svc.Create(ctx, s)
+		if err != nil {
+			return done, fmt.Errorf("webhook: failed to create %s subscription: %w", s.Topic, err)
+		}
+		done.Create = append(done.Create, orSent(created, s))
+	}
+	for _, s := range plan.Update {
+		updated, err := svc.Update(ctx, s)
+		if err != nil {
+			return done, fmt.Errorf("webhook: failed to update subscription %d (%s): %w", s.ID, s.Topic, err)
+		}
+		done.Update = append(done.Update, orSent(updated, s))
+	}
+	for _, s := range plan.Delete {
+		if err := svc.Delete(ctx, s.ID); err != nil {
+			return done, fmt.Errorf("webhook: failed to delete subscription %d (%s): %w", s.ID, s.Topic, err)
+		}
+		done.Delete = append(done.Delete, s)
+	}
+	return done, nil
+}
+
+// orSent returns the API's copy of s, or s itself if the response had none.
+func orSent(got *Subscription, s Subscription) Subscription {
+	if got == nil {
+		return s
+	}
+	return *got
+}
+
+func listAll(ctx context.Context, svc Service) ([]Subscription, error) {
+	var all []Subscription
+	opts := &core.ListOptions{Limit: reconcilePageSize}
+	for {
+		page, err := svc.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("webhook: failed to list subscriptions: %w", err)
+		}
+		all = append(all, page...)
+		if len(page) < reconcilePageSize {
+			return all, nil
+		}
+		opts.SinceID = page[len(page)-1].ID
+	}
+}
+
+func indexOf(subs []Subscription, used []bool, match func(Subscription) bool) int {
+	for i, s := range subs {
+		if !used[i] && match(s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sameSettings compares format (empty meaning json), fields as a set, and
+// headers if the API returned any for current.
+func sameSettings(current, want Subscription) bool {
+	if formatOf(current) != formatOf(want) || len(current.Fields) != len(want.Fields) {
+		return false
+	}
+	if current.Headers != nil && !maps.Equal(current.Headers, want.Headers) {
+		return false
+	}
+	fa := append([]string(nil), current.Fields...)
+	fb := append([]string(nil), want.Fields...)
+	sort.Strings(fa)
+	sort.Strings(fb)
+	for i := range fa {
+		if fa[i] != fb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatOf(s Subscription) string {
+	if s.Format == "" {
+		return FormatJSON
+	}
+	return s.Format
+}
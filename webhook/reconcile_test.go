@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPlanSubscriptions(t *testing.T) {
+	const addr = "https://app.example.com/webhooks"
+	current := []Subscription{
+		{ID: 1, Topic: "orders/create", Address: addr, Format: "json"},
+		{ID: 2, Topic: "orders/paid", Address: addr, Fields: []string{"id"}},
+		{ID: 3, Topic: "products/update", Address: "https://old.example.com/hooks"},
+		{ID: 4, Topic: "customers/create", Address: addr},
+	}
+	desired := []Subscription{
+		{Topic: "orders/create", Address: addr},
+		{Topic: "orders/paid", Address: addr, Fields: []string{"id", "total_price"}},
+		{Topic: "products/update", Address: addr},
+		{Topic: "app/uninstalled", Address: addr},
+	}
+
+	plan := PlanSubscriptions(current, desired)
+	if len(plan.Create) != 1 || plan.Create[0].Topic != "app/uninstalled" {
+		t.Errorf("unexpected creates: %+v", plan.Create)
+	}
+	if len(plan.Update) != 2 || plan.Update[0].ID != 2 || plan.Update[1].ID != 3 || plan.Update[1].Address != addr {
+		t.Errorf("unexpected updates: %+v", plan.Update)
+	}
+	if len(plan.Delete) != 1 || plan.Delete[0].ID != 4 {
+		t.Errorf("unexpected deletes: %+v", plan.Delete)
+	}
+
+	if p := PlanSubscriptions(desired, desired); !p.Empty() {
+		t.Errorf("expected no changes for matching sets, got %+v", p)
+	}
+}
+
+func TestPlanSubscriptions_Headers(t *testing.T) {
+	const addr = "https://app.example.com/webhooks"
+	current := []Subscription{{ID: 1, Topic: "orders/create", Address: addr, Headers: map[string]string{"X-Route": "a"}}}
+	desired := []Subscription{{Topic: "orders/create", Address: addr, Headers: map[string]string{"X-Route": "b"}}}
+	if plan := PlanSubscriptions(current, desired); len(plan.Update) != 1 || plan.Update[0].Headers["X-Route"] != "b" {
+		t.Errorf("unexpected plan: %+v", plan)
+	}
+	if plan := PlanSubscriptions(current, current); !plan.Empty() {
+		t.Errorf("expected no changes, got %+v", plan)
+	}
+	// Without header support the API does not echo them back.
+	current[0].Headers = nil
+	if plan := PlanSubscriptions(current, desired); !plan.Empty() {
+		t.Errorf("expected no changes when headers are not returned, got %+v", plan)
+	}
+}
+
+func TestEnsureSubscriptions(t *testing.T) {
+	var calls []string
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path[strings.Index(r.URL.Path, "webhooks"):])
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(webhooksResource{Webhooks: []Subscription{
+				{ID: 7, Topic: "orders/create", Address: "https://a.example.com"},
+				{ID: 8, Topic: "carts/update", Address: "https://a.example.com"},
+			}})
+		case http.MethodPost:
+			var body webhookResource
+			json.NewDecoder(r.Body).Decode(&body)
+			body.Webhook.ID = 9
+			json.NewEncoder(w).Encode(body)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+	defer close()
+
+	done, err := EnsureSubscriptions(context.Background(), NewService(mock), []Subscription{
+		{Topic: "orders/create", Address: "https://a.example.com"},
+		{Topic: "app/uninstalled", Address: "https://a.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"GET webhooks.json", "POST webhooks.json", "DELETE webhooks/8.json"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("expected calls %v, got %v", want, calls)
+	}
+	if len(done.Create) != 1 || done.Create[0].ID != 9 || len(done.Delete) != 1 {
+		t.Errorf("unexpected result: %+v", done)
+	}
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// =====================================================================
+// Replay
+// =====================================================================
+
+// Sign returns the X-Shopline-Hmac-SHA256 value Shopline would send for body.
+func Sign(appSecret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ReplayServer re-signs recorded deliveries with the app secret and POSTs
+// them to a webhook consumer, for integration tests without a live store.
+//
+// Replay sends a Delivery directly. ReplayServer is also an http.Handler:
+// POST a raw payload to it with an X-Shopline-Topic header and it is
+// forwarded, signed, to the target, which makes captured payloads easy to
+// replay with curl:
+//
+//	rs := webhook.NewReplayServer(app.AppSecret, "http://localhost:8080/webhooks")
+//	http.ListenAndServe("localhost:9090", rs)
+//
+//	curl -X POST localhost:9090 -H "X-Shopline-Topic: orders/create" -d @order.json
+type ReplayServer struct {
+	appSecret string
+	targetURL string
+	client    *http.Client
+}
+
+// NewReplayServer creates a ReplayServer forwarding to targetURL.
+func NewReplayServer(appSecret, targetURL string) *ReplayServer {
+	return &ReplayServer{
+		appSecret: appSecret,
+		targetURL: targetURL,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Replay POSTs d to the target with a fresh signature and returns the
+// consumer's HTTP status code. Headers recorded in d.Header are sent as
+// well, except the signature, which is always recomputed.
+func (s *ReplayServer) Replay(ctx context.Context, d Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.targetURL, bytes.NewReader(d.Body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: failed to build replay request: %w", err)
+	}
+	for k, vs := range d.Header {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	setHeader(req.Header, HeaderTopic, d.Topic)
+	setHeader(req.Header, HeaderShopDomain, d.ShopDomain)
+	setHeader(req.Header, HeaderWebhookID, d.WebhookID)
+	req.Header.Set(HeaderHmac, Sign(s.appSecret, d.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: replay of %s failed: %w", d.Topic, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// ServeHTTP replays the incoming request body and relays the consumer's
+// status code.
+func (s *ReplayServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxDeliveryBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	code, err := s.Replay(r.Context(), Delivery{
+		Topic:      r.Header.Get(HeaderTopic),
+		ShopDomain: r.Header.Get(HeaderShopDomain),
+		WebhookID:  r.Header.Get(HeaderWebhookID),
+		Body:       body,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(code)
+}
+
+func setHeader(h http.Header, key, value string) {
+	if value != "" {
+		h.Set(key, value)
+	}
+}
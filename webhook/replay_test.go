@@ -0,0 +1,46 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReplayServer(t *testing.T) {
+	const secret = "test-secret"
+	var got Delivery
+	d := NewDispatcher()
+	d.Handle("orders/create", func(_ context.Context, del Delivery) error {
+		got = del
+		return nil
+	})
+	consumer := httptest.NewServer(d)
+	defer consumer.Close()
+
+	rs := NewReplayServer(secret, consumer.URL)
+	code, err := rs.Replay(context.Background(), Delivery{
+		Topic:      "orders/create",
+		ShopDomain: "open001.myshopline.com",
+		Header:     http.Header{HeaderHmac: {"stale-signature"}},
+		Body:       []byte(`{"id":1}`),
+	})
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("expected 200, got %d (%v)", code, err)
+	}
+	if string(got.Body) != `{"id":1}` || got.ShopDomain != "open001.myshopline.com" {
+		t.Errorf("unexpected delivery: %+v", got)
+	}
+	if sig := got.Header.Get(HeaderHmac); sig != Sign(secret, got.Body) {
+		t.Errorf("expected re-signed delivery, got signature %q", sig)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"id":2}`))
+	req.Header.Set(HeaderTopic, "orders/create")
+	rs.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || string(got.Body) != `{"id":2}` {
+		t.Errorf("expected relayed delivery, got %d %s", rec.Code, got.Body)
+	}
+}
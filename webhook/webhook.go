@@ -14,6 +14,10 @@ type Service interface {
 	Create(ctx context.Context, w Subscription) (*Subscription, error)
 	Update(ctx context.Context, w Subscription) (*Subscription, error)
 	Delete(ctx context.Context, id int64) error
+
+	// TestDelivery asks Shopline to send a sample payload to the webhook's
+	// address.
+	TestDelivery(ctx context.Context, id int64) error
 }
 
 func NewService(client core.Requester) Service {
@@ -22,14 +26,22 @@ func NewService(client core.Requester) Service {
 
 type serviceOp struct{ client core.Requester }
 
+// FormatJSON is the delivery format Shopline uses by default. Format is
+// sent as given, so other formats can be requested as soon as the store's
+// API version accepts them.
+const FormatJSON = "json"
+
 type Subscription struct {
-	ID        int64      `json:"id,omitempty"`
-	Address   string     `json:"address,omitempty"`
-	Topic     string     `json:"topic,omitempty"`
-	Format    string     `json:"format,omitempty"`
-	Fields    []string   `json:"fields,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID      int64    `json:"id,omitempty"`
+	Address string   `json:"address,omitempty"`
+	Topic   string   `json:"topic,omitempty"`
+	Format  string   `json:"format,omitempty"`
+	Fields  []string `json:"fields,omitempty"`
+	// Headers are extra HTTP headers Shopline adds to each delivery, where
+	// the API version supports them, e.g. a routing key for a gateway.
+	Headers   map[string]string `json:"headers,omitempty"`
+	CreatedAt *time.Time        `json:"created_at,omitempty"`
+	UpdatedAt *time.Time        `json:"updated_at,omitempty"`
 }
 
 type webhookResource struct {
@@ -47,7 +59,7 @@ func (s *serviceOp) List(ctx context.Context, opts *core.ListOptions) ([]Subscri
 func (s *serviceOp) Get(ctx context.Context, id int64) (*Subscription, error) {
 	r := &webhookResource{}
 	err := s.client.Get(ctx, s.client.CreatePath(fmt.Sprintf("webhooks/%d.json", id)), r, nil)
-	return r.Webhook, err
+	return core.Found(r.Webhook, err)
 }
 func (s *serviceOp) Create(ctx context.Context, w Subscription) (*Subscription, error) {
 	r := &webhookResource{}
@@ -62,3 +74,6 @@ func (s *serviceOp) Update(ctx context.Context, w Subscription) (*Subscription,
 func (s *serviceOp) Delete(ctx context.Context, id int64) error {
 	return s.client.Delete(ctx, s.client.CreatePath(fmt.Sprintf("webhooks/%d.json", id)))
 }
+func (s *serviceOp) TestDelivery(ctx context.Context, id int64) error {
+	return s.client.Post(ctx, s.client.CreatePath(fmt.Sprintf("webhooks/%d/test.json", id)), nil, nil)
+}
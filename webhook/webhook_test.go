@@ -199,3 +199,73 @@ func TestWebhookDelete(t *testing.T) {
 		t.Error("DELETE handler was not called")
 	}
 }
+
+func TestWebhookTestDelivery(t *testing.T) {
+	called := false
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/webhooks/42/test.json") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	defer close()
+
+	if err := NewService(mock).TestDelivery(context.Background(), 42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("test delivery handler was not called")
+	}
+}
+
+func TestSubscriptionDestination(t *testing.T) {
+	arn := "arn:aws:events:us-east-1::event-source/aws.partner/shopline.com/123/source"
+	eb, err := EventBridgeAddress(arn)
+	if err != nil || eb != arn {
+		t.Fatalf("EventBridgeAddress = %q, %v", eb, err)
+	}
+	if _, err := EventBridgeAddress("https://example.com"); err == nil {
+		t.Error("expected error for a non-ARN address")
+	}
+	ps, err := PubSubAddress("my-project", "orders")
+	if err != nil || ps != "pubsub://my-project:orders" {
+		t.Fatalf("PubSubAddress = %q, %v", ps, err)
+	}
+	if _, err := PubSubAddress("", "orders"); err == nil {
+		t.Error("expected error for an empty project")
+	}
+
+	tests := map[string]DestinationKind{
+		"https://example.com/webhooks": DestinationHTTPS,
+		eb:                             DestinationEventBridge,
+		ps:                             DestinationPubSub,
+	}
+	for addr, want := range tests {
+		if got := (Subscription{Address: addr}).Destination(); got != want {
+			t.Errorf("Destination(%q) = %s, want %s", addr, got, want)
+		}
+	}
+}
+
+func TestWebhookCreate_Headers(t *testing.T) {
+	mock, close := newMockRequester(func(w http.ResponseWriter, r *http.Request) {
+		var body webhookResource
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Webhook == nil || body.Webhook.Headers["X-Tenant"] != "eu" {
+			t.Errorf("headers not sent: %+v", body.Webhook)
+		}
+		json.NewEncoder(w).Encode(body)
+	})
+	defer close()
+
+	_, err := NewService(mock).Create(context.Background(), Subscription{
+		Topic:   "orders/create",
+		Address: "https://example.com/webhook",
+		Format:  FormatJSON,
+		Headers: map[string]string{"X-Tenant": "eu"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}